@@ -32,8 +32,11 @@ import (
 
 const (
 	sendgridAPIKeyEnvKey = "SENDGRID_API_KEY"
+	smtpUsernameEnvKey   = "SMTP_USERNAME"
+	smtpPasswordEnvKey   = "SMTP_PASSWORD"
 	nameFlag             = "name"
 	emailFlag            = "email"
+	smtpServerFlag       = "smtp-server"
 )
 
 // announceCmd represents the subcommand for `krel announce`
@@ -58,6 +61,10 @@ Beside this, if the flags for a valid sender name (--%s,-n) and sender email
 address (--%s,-e) are not set, then it tries to retrieve those values directly
 from the Sendgrid API.
 
+Alternatively, the mail can be sent via an SMTP server by setting --%s to
+its host:port. The credentials are read from the $%s and $%s
+environment variables, and the sender name and email address are required.
+
 Setting a valid Kubernetes tag (--%s,-t) is always necessary.
 
 If --%s,-p is given, then krel announce will only print the email content
@@ -68,6 +75,9 @@ without doing anything else.`,
 		sendgridAPIKeyEnvKey,
 		nameFlag,
 		emailFlag,
+		smtpServerFlag,
+		smtpUsernameEnvKey,
+		smtpPasswordEnvKey,
 		tagFlag,
 		printOnlyFlag,
 	),
@@ -82,12 +92,17 @@ type sendAnnounceOptions struct {
 	sendgridAPIKey string
 	name           string
 	email          string
+	smtpServer     string
+	smtpUsername   string
+	smtpPassword   string
 }
 
 var sendAnnounceOpts = &sendAnnounceOptions{}
 
 func init() {
 	sendAnnounceOpts.sendgridAPIKey = env.Default(sendgridAPIKeyEnvKey, "")
+	sendAnnounceOpts.smtpUsername = env.Default(smtpUsernameEnvKey, "")
+	sendAnnounceOpts.smtpPassword = env.Default(smtpPasswordEnvKey, "")
 
 	sendAnnounceCmd.PersistentFlags().StringVarP(
 		&sendAnnounceOpts.name,
@@ -105,6 +120,13 @@ func init() {
 		"email address",
 	)
 
+	sendAnnounceCmd.PersistentFlags().StringVar(
+		&sendAnnounceOpts.smtpServer,
+		smtpServerFlag,
+		"",
+		"send the mail via the SMTP server at host:port instead of the Sendgrid API",
+	)
+
 	announceCmd.AddCommand(sendAnnounceCmd)
 }
 
@@ -134,15 +156,11 @@ func runAnnounce(opts *sendAnnounceOptions, announceRootOpts *announceOptions, r
 		return nil
 	}
 
-	if opts.sendgridAPIKey == "" {
-		return errors.Errorf(
-			"$%s is not set", sendgridAPIKeyEnvKey,
-		)
+	m, err := opts.newSender()
+	if err != nil {
+		return errors.Wrap(err, "preparing mail sender")
 	}
 
-	logrus.Info("Preparing mail sender")
-	m := mail.NewSender(opts.sendgridAPIKey)
-
 	if opts.name != "" && opts.email != "" {
 		if err := m.SetSender(opts.name, opts.email); err != nil {
 			return errors.Wrap(err, "unable to set mail sender")
@@ -176,6 +194,36 @@ func runAnnounce(opts *sendAnnounceOptions, announceRootOpts *announceOptions, r
 	return nil
 }
 
+// newSender creates the mail sender, which uses either the SMTP server or
+// the Sendgrid API.
+func (o *sendAnnounceOptions) newSender() (*mail.Sender, error) {
+	if o.smtpServer == "" {
+		if o.sendgridAPIKey == "" {
+			return nil, errors.Errorf(
+				"$%s is not set", sendgridAPIKeyEnvKey,
+			)
+		}
+		logrus.Info("Preparing Sendgrid mail sender")
+		return mail.NewSender(o.sendgridAPIKey), nil
+	}
+
+	if o.name == "" || o.email == "" {
+		return nil, errors.Errorf(
+			"--%s and --%s are required for sending via SMTP", nameFlag, emailFlag,
+		)
+	}
+	logrus.Infof("Preparing SMTP mail sender for %s", o.smtpServer)
+	client, err := mail.NewSMTPSendClient(
+		o.smtpServer, o.smtpUsername, o.smtpPassword,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating SMTP client")
+	}
+	m := mail.NewSender("")
+	m.SetSendClient(client)
+	return m, nil
+}
+
 func (o *announceOptions) Validate() error {
 	if o.tag == "" {
 		return errors.New("need to specify a tag value")
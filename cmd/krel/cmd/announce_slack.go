@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/announce"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// slackAnnounceCmd represents the subcommand for `krel announce slack`
+var slackAnnounceCmd = &cobra.Command{
+	Use:   "slack",
+	Short: "Announce Kubernetes releases on Slack",
+	Long: fmt.Sprintf(`krel announce slack
+
+krel announce slack can be used to post the announcement of an already
+released Kubernetes version to Slack channels. The message links the GitHub
+release page and the changelog of the release.
+
+The channels are configured by exporting the $%s environment
+variable, which contains a comma separated list of Slack incoming webhook URLs.
+
+By default the message will be only logged, ie: the announcement run will only
+be a mock run. To post the message, use the --nomock flag.
+
+Setting a valid Kubernetes tag (--%s,-t) is always necessary.
+
+If --%s,-p is given, then krel announce will only print the message content
+without doing anything else.`,
+		announce.SlackWebhooksEnvKey,
+		tagFlag,
+		printOnlyFlag,
+	),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAnnounceSlack(announceOpts, rootOpts)
+	},
+}
+
+func init() {
+	announceCmd.AddCommand(slackAnnounceCmd)
+}
+
+func runAnnounceSlack(announceRootOpts *announceOptions, rootOpts *rootOptions) error {
+	if err := announceRootOpts.Validate(); err != nil {
+		return errors.Wrap(err, "validating annoucement slack options")
+	}
+
+	tag := util.AddTagPrefix(announceRootOpts.tag)
+	tagSemver, err := util.TagStringToSemver(tag)
+	if err != nil {
+		return errors.Wrapf(err, "parsing tag %s", tag)
+	}
+	text := announce.SlackReleaseText(tag, fmt.Sprintf(
+		"CHANGELOG/CHANGELOG-%d.%d.md", tagSemver.Major, tagSemver.Minor,
+	))
+
+	if announceRootOpts.printOnly {
+		fmt.Println(text)
+		return nil
+	}
+
+	webhooks := announce.SlackWebhooksFromEnv()
+	if len(webhooks) == 0 {
+		return errors.Errorf("$%s is not set", announce.SlackWebhooksEnvKey)
+	}
+
+	if err := announce.PostSlackMessage(&announce.SlackOptions{
+		Webhooks: webhooks,
+		NoMock:   rootOpts.nomock,
+	}, text); err != nil {
+		return errors.Wrap(err, "posting release announcement to Slack")
+	}
+	return nil
+}
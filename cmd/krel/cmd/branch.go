@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/branchcut"
+	kgit "k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/release"
+)
+
+const revisionFlag = "revision"
+
+var branchCreateOpts = &branchcut.Options{}
+
+// branchCmd represents the subcommand for `krel branch`
+var branchCmd = &cobra.Command{
+	Use:           "branch",
+	Short:         "Manage Kubernetes release branches",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+// branchCreateCmd represents the subcommand for `krel branch create`
+var branchCreateCmd = &cobra.Command{
+	Use:   "create --branch <release-branch> [--revision <rev>] [--nomock] [--state-file <path>]",
+	Short: "Cut a new Kubernetes release branch",
+	Long: fmt.Sprintf(`krel branch create cuts a new minor release branch.
+
+The command verifies that the release branch does not exist yet on the remote
+and that the revision (defaults to %s) is part of the %s branch. The
+latest tag of the revision has to be a pre-release of the minor version of the
+branch, for example v1.23.0-beta.2 when creating release-1.23.
+
+After that, the release branch gets created at the revision and pushed. The
+%s branch gets an empty commit tagged as the first alpha of the next minor
+version, for example v1.24.0-alpha.0, which keeps 'git describe' on both
+branches unambiguous. Pushes are only simulated unless '--nomock' is specified.
+
+The completed steps can be recorded in a state file, which allows resuming a
+failed branch cut with the same options. Manual follow-up tasks are printed at
+the end.
+`, kgit.Remotify(kgit.DefaultBranch), kgit.DefaultBranch, kgit.DefaultBranch),
+	Example:       "krel branch create --branch release-1.23 --state-file /tmp/branch-cut.json",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		branchCreateOpts.Mode = release.ModeFromNoMock(rootOpts.nomock)
+		return runBranchCreate(branchCreateOpts)
+	},
+}
+
+func init() {
+	branchCreateCmd.PersistentFlags().StringVar(
+		&branchCreateOpts.Branch,
+		branchFlag,
+		"",
+		"The release branch to be created, like release-1.23",
+	)
+
+	branchCreateCmd.PersistentFlags().StringVar(
+		&branchCreateOpts.Revision,
+		revisionFlag,
+		"",
+		fmt.Sprintf(
+			"The revision to create the branch from (default %s)",
+			kgit.Remotify(kgit.DefaultBranch),
+		),
+	)
+
+	branchCreateCmd.PersistentFlags().StringVar(
+		&branchCreateOpts.RepoPath,
+		"repo",
+		filepath.Join(os.TempDir(), "k8s"),
+		"The local path to the repository to be used",
+	)
+
+	branchCreateCmd.PersistentFlags().StringVar(
+		&branchCreateOpts.StateFile,
+		stateFileFlag,
+		"",
+		"Path to a file recording the completed steps, which allows resuming the branch cut after a failure",
+	)
+
+	branchCmd.AddCommand(branchCreateCmd)
+	rootCmd.AddCommand(branchCmd)
+}
+
+func runBranchCreate(opts *branchcut.Options) error {
+	res, err := branchcut.New(opts).Run()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"Created branch %s at %s and tagged %s on the %s branch\n\nFollow-up tasks:\n",
+		res.Branch, res.Revision, res.Tag, kgit.DefaultBranch,
+	)
+	for _, task := range res.FollowUps {
+		fmt.Printf("  - %s\n", task)
+	}
+	return nil
+}
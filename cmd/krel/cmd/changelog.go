@@ -46,9 +46,10 @@ the golang based 'release-notes' tool:
 1. Generate the release notes for either a patch or a new minor release. Minor
    releases can be alpha, beta or rc’s, too.
    a) Create a new CHANGELOG-x.y.md file if not existing.
-   b) Correctly prepend the generated notes to the existing CHANGELOG-x.y.md
-      file if already existing. This also includes the modification of the
-	  table of contents.
+   b) Correctly insert the generated notes into the existing CHANGELOG-x.y.md
+      file if already existing. The releases are ordered by descending
+      version and the section of the same release gets replaced. This also
+      includes the regeneration of the table of contents.
 
 2. Convert the markdown release notes into a HTML equivalent on purpose of
    sending it by mail to the announce list. The HTML file will be dropped into
@@ -59,10 +60,16 @@ the golang based 'release-notes' tool:
    corresponding release-branch of kubernetes/kubernetes. The release branch
    will be pruned from all other CHANGELOG-*.md files which do not belong to
    this release branch.
+
+   If '--fork' is set, the changes of the main branch are pushed to a new
+   branch of the fork instead, and a labeled pull request against the main
+   branch of kubernetes/kubernetes gets created. Pushing and creating the pull
+   request only happens if '--nomock' is set.
 `, github.TokenEnvKey),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		changelogOptions.DryRun = !rootOpts.nomock
 		return changelog.New(changelogOptions).Run()
 	},
 }
@@ -79,6 +86,7 @@ func init() {
 	changelogCmd.PersistentFlags().StringVar(&changelogOptions.RecordDir, "record", "", "Record the API into a directory")
 	changelogCmd.PersistentFlags().StringVar(&changelogOptions.ReplayDir, "replay", "", "Replay a previously recorded API from a directory")
 	changelogCmd.PersistentFlags().BoolVar(&changelogOptions.Dependencies, "dependencies", true, "Add dependency report")
+	changelogCmd.PersistentFlags().StringVar(&changelogOptions.Fork, "fork", "", "The user's fork in the form org/repo. If set, a pull request gets created instead of committing to the main branch directly")
 
 	if err := changelogCmd.MarkPersistentFlagRequired("tag"); err != nil {
 		logrus.Fatalf("Unable to %v", err)
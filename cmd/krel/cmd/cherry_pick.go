@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/cherrypick"
+	kgit "k8s.io/release/pkg/git"
+)
+
+var cherryPickOpts = &cherrypick.Options{}
+
+// cherryPickCmd represents the subcommand for `krel cherry-pick`
+var cherryPickCmd = &cobra.Command{
+	Use:   "cherry-pick --pr <number> --branches <release-branch>,... --fork <owner> [--nomock] [--cleanup]",
+	Short: "Cherry pick a merged pull request into release branches",
+	Long: `cherry-pick picks the merge commit of a pull request into release branches.
+
+For every release branch, krel creates the branch
+'automated-cherry-pick-of-#<number>-upstream-<release-branch>' on top of the
+remote release branch, cherry picks the merge commit onto it and pushes it to
+the fork. Failed cherry picks, for example because of conflicts, get aborted.
+
+If the '--nomock' flag is specified, the branches get pushed for real and krel
+opens a pull request for each of them, which references the original pull
+request and takes over its release note as well as its area, kind, priority
+and sig labels. The milestone of the release branch gets set if it exists.
+`,
+	Example:       "krel cherry-pick --pr 104000 --branches release-1.22,release-1.21 --fork my-user --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cherryPickOpts.NoMock = rootOpts.nomock
+		return cherrypick.Run(cherryPickOpts)
+	},
+}
+
+func init() {
+	cherryPickCmd.PersistentFlags().IntVar(&cherryPickOpts.PullRequest, "pr", 0, "number of the merged pull request to be picked")
+	cherryPickCmd.PersistentFlags().StringSliceVar(&cherryPickOpts.Branches, "branches", []string{}, "release branches to pick the pull request into")
+	cherryPickCmd.PersistentFlags().StringVar(&cherryPickOpts.ForkOwner, "fork", "", "GitHub user or organization owning the fork, where the branches get pushed to")
+	cherryPickCmd.PersistentFlags().StringVar(&cherryPickOpts.Org, "org", kgit.DefaultGithubOrg, "GitHub organization of the repository")
+	cherryPickCmd.PersistentFlags().StringVar(&cherryPickOpts.Repo, "repo-name", kgit.DefaultGithubRepo, "GitHub repository of the pull request")
+	cherryPickCmd.PersistentFlags().StringVar(&cherryPickOpts.RepoPath, "repo", filepath.Join(os.TempDir(), "k8s"), "the local path to the repository to be used")
+	cherryPickCmd.PersistentFlags().BoolVar(&cherryPickOpts.Cleanup, "cleanup", false, "cleanup the repository after the run")
+
+	rootCmd.AddCommand(cherryPickCmd)
+}
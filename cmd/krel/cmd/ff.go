@@ -44,6 +44,11 @@ verifies that the latest merge base tag is the same for the main and the
 release branch. This means that only the latest release branch can be fast
 forwarded.
 
+krel also refuses to fast forward a release branch which diverged from its
+merge base with the main branch. This is the case if the release branch
+contains commits other than previous fast forward merges, which will be
+printed instead of being buried by a merge.
+
 krel merges the provided ref into the release branch and asks for a final
 confirmation if the push should really happen. The push will only be executed
 as real push if the '--nomock' flag is specified.
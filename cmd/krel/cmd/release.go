@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/announce"
 	"k8s.io/release/pkg/github"
 	"k8s.io/release/pkg/release"
 )
@@ -62,7 +63,11 @@ Build (GCB) job, which does:
 
 7. Archive: Copies the release process logs to a bucket and sets private
    permissions on it.
-`, github.TokenEnvKey),
+
+If the $%s environment variable contains a comma separated list of
+Slack incoming webhook URLs, then the release gets announced in those channels
+when running with --nomock.
+`, github.TokenEnvKey, announce.SlackWebhooksEnvKey),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -119,6 +124,14 @@ func init() {
 			"Run the Google Cloud Build job synchronously",
 		)
 
+	releaseCmd.PersistentFlags().
+		StringVar(
+			&releaseOptions.StateFile,
+			stateFileFlag,
+			"",
+			"Path to a file recording the completed steps of a local run, which allows resuming it after a failure",
+		)
+
 	if err := releaseCmd.PersistentFlags().MarkHidden(submitJobFlag); err != nil {
 		logrus.Fatal(err)
 	}
@@ -131,7 +144,8 @@ func init() {
 }
 
 func runRelease(options *anago.ReleaseOptions) error {
-	options.NoMock = rootOpts.nomock
+	options.Mode = release.ModeFromNoMock(rootOpts.nomock)
+	options.SlackWebhooks = announce.SlackWebhooksFromEnv()
 	rel := anago.NewRelease(options)
 
 	if submitJob {
@@ -96,7 +96,8 @@ The 'release-notes' subcommand of krel has been developed to:
    https://github.com/kubernetes/sig-release/blob/master/releases/release-1.xx/release-notes-draft.md
 
 3. Put the generated notes into a JSON file and create a GitHub pull request
-   to update the website https://relnotes.k8s.io.
+   to update the website https://relnotes.k8s.io. The JSON file can also be
+   written to a local path only, by using --website-file.
 
 To use the tool, please set the %v environment variable which needs write
 permissions to your fork of k/sig-release and k-sigs/release-notes.`,
@@ -106,7 +107,8 @@ permissions to your fork of k/sig-release and k-sigs/release-notes.`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// If none of the operation modes is defined, show the usage help and exit
 		if !releaseNotesOpts.createDraftPR &&
-			!releaseNotesOpts.createWebsitePR {
+			!releaseNotesOpts.createWebsitePR &&
+			releaseNotesOpts.websiteFile == "" {
 			if err := cmd.Help(); err != nil {
 				return err
 			}
@@ -125,8 +127,11 @@ type releaseNotesOptions struct {
 	userFork           string
 	createDraftPR      bool
 	createWebsitePR    bool
+	websiteFile        string
 	fixNotes           bool
 	listReleaseNotesV2 bool
+	graphQL            bool
+	cacheDir           string
 	websiteRepo        string
 	mapProviders       []string
 	githubOrg          string
@@ -183,6 +188,13 @@ func init() {
 		"patch the relnotes.k8s.io sources and generate a PR with the changes",
 	)
 
+	releaseNotesCmd.PersistentFlags().StringVar(
+		&releaseNotesOpts.websiteFile,
+		"website-file",
+		"",
+		"write the notes in the JSON format of relnotes.k8s.io to this file instead of creating a PR",
+	)
+
 	releaseNotesCmd.PersistentFlags().StringSliceVarP(
 		&releaseNotesOpts.mapProviders,
 		"maps-from",
@@ -212,6 +224,20 @@ func init() {
 		"enable experimental implementation to list commits (ListReleaseNotesV2)",
 	)
 
+	releaseNotesCmd.PersistentFlags().BoolVar(
+		&releaseNotesOpts.graphQL,
+		"graphql",
+		false,
+		"retrieve the commits and pull requests in bulk via the GitHub GraphQL API, which is much faster for big releases",
+	)
+
+	releaseNotesCmd.PersistentFlags().StringVar(
+		&releaseNotesOpts.cacheDir,
+		"cache-dir",
+		"",
+		"cache the pull requests of commits in a directory, which speeds up subsequent runs",
+	)
+
 	releaseNotesCmd.PersistentFlags().BoolVar(
 		&releaseNotesOpts.interactiveMode,
 		"interactiveMode",
@@ -282,6 +308,15 @@ func runReleaseNotes() (err error) {
 		}
 	}
 
+	// Write the relnotes.k8s.io JSON to a local file
+	if releaseNotesOpts.websiteFile != "" {
+		if err := writeWebsiteFile(
+			releaseNotesOpts.repoPath, tag, releaseNotesOpts.websiteFile,
+		); err != nil {
+			return errors.Wrap(err, "writing website file")
+		}
+	}
+
 	// Create the PR for the Release Notes Draft in k/sig-release
 	if releaseNotesOpts.createDraftPR && confirmWithUser(releaseNotesOpts, "Create draft pull request?") {
 		// Create the Draft PR Process
@@ -290,7 +325,8 @@ func runReleaseNotes() (err error) {
 		}
 	}
 
-	if releaseNotesOpts.createDraftPR || releaseNotesOpts.createWebsitePR {
+	if releaseNotesOpts.createDraftPR || releaseNotesOpts.createWebsitePR ||
+		releaseNotesOpts.websiteFile != "" {
 		logrus.Info("Release notes generation complete!")
 	}
 
@@ -344,13 +380,7 @@ func createDraftPR(repoPath, tag string) (err error) {
 		return errors.Wrapf(err, "reading tag: %s", tag)
 	}
 
-	// From v1.20.0 on we use the previous minor as a starting tag
-	// for the Release Notes draft because the branch is fast-rowarded now:
-	start := util.SemverToTagString(semver.Version{
-		Major: tagVersion.Major,
-		Minor: tagVersion.Minor - 1,
-		Patch: 0,
-	})
+	start := draftStartTag(tagVersion)
 
 	gh := github.New()
 
@@ -809,6 +839,40 @@ func createWebsitePR(repoPath, tag string) (err error) {
 	return err
 }
 
+// writeWebsiteFile generates the release notes for the tag and writes them
+// in the JSON format consumed by relnotes.k8s.io to path. The file can be
+// added to the src/assets directory of kubernetes-sigs/release-notes.
+func writeWebsiteFile(repoPath, tag, path string) error {
+	jsonStr, err := releaseNotesJSON(repoPath, tag)
+	if err != nil {
+		return errors.Wrapf(err, "generating release notes in JSON format")
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(jsonStr), "", "  "); err != nil {
+		return errors.Wrap(err, "indenting release notes JSON")
+	}
+	indented.WriteString("\n")
+
+	logrus.Infof("Writing release notes JSON for %s to %s", tag, path)
+	if err := os.WriteFile(path, indented.Bytes(), os.FileMode(0o644)); err != nil {
+		return errors.Wrapf(err, "writing release notes json file")
+	}
+	return nil
+}
+
+// draftStartTag returns the tag to start gathering the release notes draft
+// of tagVersion from.
+func draftStartTag(tagVersion semver.Version) string {
+	// From v1.20.0 on we use the previous minor as a starting tag
+	// for the Release Notes draft because the branch is fast-rowarded now:
+	return util.SemverToTagString(semver.Version{
+		Major: tagVersion.Major,
+		Minor: tagVersion.Minor - 1,
+		Patch: 0,
+	})
+}
+
 // tryToFindLatestMinorTag looks-up the default k/k remote to find the latest
 // non final version
 func tryToFindLatestMinorTag() (string, error) {
@@ -914,6 +978,7 @@ func releaseNotesJSON(repoPath, tag string) (jsonString string, err error) {
 	notesOptions.EndRev = tag
 	notesOptions.Debug = logrus.StandardLogger().Level >= logrus.DebugLevel
 	notesOptions.MapProviderStrings = releaseNotesOpts.mapProviders
+	notesOptions.CacheDir = releaseNotesOpts.cacheDir
 
 	// If the the release for the tag we are using has a mapping directory,
 	// add it to the mapProviders array to read the edits from the release team:
@@ -966,7 +1031,9 @@ func gatherNotesFrom(repoPath, startTag string) (*notes.ReleaseNotes, error) {
 	notesOptions.EndRev = releaseNotesOpts.tag
 	notesOptions.Debug = logrus.StandardLogger().Level >= logrus.DebugLevel
 	notesOptions.MapProviderStrings = releaseNotesOpts.mapProviders
+	notesOptions.CacheDir = releaseNotesOpts.cacheDir
 	notesOptions.ListReleaseNotesV2 = releaseNotesOpts.listReleaseNotesV2
+	notesOptions.GraphQL = releaseNotesOpts.graphQL
 
 	if err := notesOptions.ValidateAndFinish(); err != nil {
 		return nil, err
@@ -1034,6 +1101,10 @@ func (o *releaseNotesOptions) Validate() error {
 		}
 	}
 
+	if o.createWebsitePR && o.websiteFile != "" {
+		return errors.New("--create-website-pr and --website-file are mutually exclusive")
+	}
+
 	return nil
 }
 
@@ -1157,10 +1228,6 @@ func fixReleaseNotes(workDir string, releaseNotes *notes.ReleaseNotes) error {
 		return errors.Wrap(err, "while getting map provider for current notes")
 	}
 
-	const (
-		spacer = "    │ "
-	)
-
 	// Cycle all gathered release notes
 	for pr, note := range releaseNotes.ByPR() {
 		contentHash, err := note.ContentHash()
@@ -1187,17 +1254,7 @@ func fixReleaseNotes(workDir string, releaseNotes *notes.ReleaseNotes) error {
 		}
 
 		// Capture the original note values to compare
-		originalNote := &notes.ReleaseNote{
-			Text:           note.Text,
-			Author:         note.Author,
-			Areas:          note.Areas,
-			Kinds:          note.Kinds,
-			SIGs:           note.SIGs,
-			Feature:        note.Feature,
-			ActionRequired: note.ActionRequired,
-			Documentation:  note.Documentation,
-			DoNotPublish:   note.DoNotPublish,
-		}
+		originalNote := copyNoteFields(note)
 
 		if noteMaps != nil {
 			fmt.Println("✨ Note contents was previously modified with a map")
@@ -1208,19 +1265,7 @@ func fixReleaseNotes(workDir string, releaseNotes *notes.ReleaseNotes) error {
 			}
 		}
 
-		fmt.Println(pointIfChanged("Author", note.Author, originalNote.Author), "@"+note.Author)
-		fmt.Println(pointIfChanged("SIGs", note.SIGs, originalNote.SIGs), note.SIGs)
-		fmt.Println(pointIfChanged("Kinds", note.Kinds, originalNote.Kinds), note.Kinds)
-		fmt.Println(pointIfChanged("Areas", note.Areas, originalNote.Areas), note.Areas)
-		fmt.Println(pointIfChanged("Feature", note.Feature, originalNote.Feature), note.Feature)
-		fmt.Println(pointIfChanged("ActionRequired", note.ActionRequired, originalNote.ActionRequired), note.ActionRequired)
-		fmt.Println(pointIfChanged("DoNotPublish", note.DoNotPublish, originalNote.DoNotPublish), note.DoNotPublish)
-		// TODO: Implement note.Documentation
-
-		// Wrap the note for better readability on the terminal
-		fmt.Println(pointIfChanged("Text", note.Text, originalNote.Text))
-		text := util.WrapText(note.Text, 80)
-		fmt.Println(spacer + strings.ReplaceAll(text, nl, nl+spacer))
+		printReleaseNote(originalNote, note)
 
 		_, choice, err := util.Ask(fmt.Sprintf("\n- Fix note for PR #%d? (y/N)", note.PrNumber), "y:Y:yes|n:N:no|n", 10)
 		if err != nil {
@@ -1234,29 +1279,11 @@ func fixReleaseNotes(workDir string, releaseNotes *notes.ReleaseNotes) error {
 
 		noteReviewed = true
 		if choice {
-			for {
-				retry, err := editReleaseNote(pr, workDir, originalNote, note)
-				if err == nil {
-					break
-				}
-				// If it's a user error (like yaml error) we can try again
-				if retry {
-					logrus.Error(err)
-					_, retryEditingChoice, err := util.Ask(
-						fmt.Sprintf("\n- An error occurred while editing PR #%d. Try again?", note.PrNumber),
-						"y:yes|n:no", 10,
-					)
-					if err != nil {
-						return errors.Wrap(err, "while asking to re-edit release note")
-					}
-					// If user chooses not to fix the faulty yaml, do not mark as fixed
-					if !retryEditingChoice {
-						noteReviewed = false
-						break
-					}
-				} else {
-					return errors.Wrap(err, "while editing release note")
-				}
+			noteReviewed, err = editReleaseNoteWithRetry(
+				pr, filepath.Join(workDir, mapsMainDirectory), originalNote, note,
+			)
+			if err != nil {
+				return err
 			}
 		}
 		// If the note was reviewed, add the PR to the session file:
@@ -1307,10 +1334,76 @@ func pointIfChanged(label string, var1, var2 interface{}) string {
 	return fmt.Sprintf("    %s:", label)
 }
 
+// copyNoteFields returns a copy of the note fields which can be changed by
+// a map, to compare them with the note after applying maps
+func copyNoteFields(note *notes.ReleaseNote) *notes.ReleaseNote {
+	return &notes.ReleaseNote{
+		Text:           note.Text,
+		Author:         note.Author,
+		Areas:          note.Areas,
+		Kinds:          note.Kinds,
+		SIGs:           note.SIGs,
+		Feature:        note.Feature,
+		ActionRequired: note.ActionRequired,
+		Documentation:  note.Documentation,
+		DoNotPublish:   note.DoNotPublish,
+	}
+}
+
+// printReleaseNote prints the note fields, pointing to those modified by maps
+func printReleaseNote(originalNote, note *notes.ReleaseNote) {
+	const (
+		spacer = "    │ "
+	)
+
+	fmt.Println(pointIfChanged("Author", note.Author, originalNote.Author), "@"+note.Author)
+	fmt.Println(pointIfChanged("SIGs", note.SIGs, originalNote.SIGs), note.SIGs)
+	fmt.Println(pointIfChanged("Kinds", note.Kinds, originalNote.Kinds), note.Kinds)
+	fmt.Println(pointIfChanged("Areas", note.Areas, originalNote.Areas), note.Areas)
+	fmt.Println(pointIfChanged("Feature", note.Feature, originalNote.Feature), note.Feature)
+	fmt.Println(pointIfChanged("ActionRequired", note.ActionRequired, originalNote.ActionRequired), note.ActionRequired)
+	fmt.Println(pointIfChanged("DoNotPublish", note.DoNotPublish, originalNote.DoNotPublish), note.DoNotPublish)
+	// TODO: Implement note.Documentation
+
+	// Wrap the note for better readability on the terminal
+	fmt.Println(pointIfChanged("Text", note.Text, originalNote.Text))
+	text := util.WrapText(note.Text, 80)
+	fmt.Println(spacer + strings.ReplaceAll(text, nl, nl+spacer))
+}
+
+// editReleaseNoteWithRetry edits the note until the user provides a valid
+// map or chooses to stop. It returns if the note has been reviewed.
+func editReleaseNoteWithRetry(
+	pr int, mapsDir string, originalNote, modifiedNote *notes.ReleaseNote,
+) (reviewed bool, err error) {
+	for {
+		retry, err := editReleaseNote(pr, mapsDir, originalNote, modifiedNote)
+		if err == nil {
+			return true, nil
+		}
+		// If it's a user error (like yaml error) we can try again
+		if !retry {
+			return false, errors.Wrap(err, "while editing release note")
+		}
+		logrus.Error(err)
+		_, retryEditingChoice, err := util.Ask(
+			fmt.Sprintf("\n- An error occurred while editing PR #%d. Try again?", pr),
+			"y:yes|n:no", 10,
+		)
+		if err != nil {
+			return false, errors.Wrap(err, "while asking to re-edit release note")
+		}
+		// If user chooses not to fix the faulty yaml, do not mark as fixed
+		if !retryEditingChoice {
+			return false, nil
+		}
+	}
+}
+
 // editReleaseNote opens the user's editor for them to update the note.
-//   In case of an editing error by the user, it returns shouldRetryEditing
-//   set to true to retry editing.
-func editReleaseNote(pr int, workDir string, originalNote, modifiedNote *notes.ReleaseNote) (shouldRetryEditing bool, err error) {
+//   The resulting map is written to mapsDir. In case of an editing error by
+//   the user, it returns shouldRetryEditing set to true to retry editing.
+func editReleaseNote(pr int, mapsDir string, originalNote, modifiedNote *notes.ReleaseNote) (shouldRetryEditing bool, err error) {
 	// To edit the note, we will create a yaml file, with the changed fields
 	// active and we'll add the unaltered fields commented for the user to review
 
@@ -1460,7 +1553,7 @@ func editReleaseNote(pr int, workDir string, originalNote, modifiedNote *notes.R
 	}
 
 	// Write the new map, removing the instructions
-	mapPath := filepath.Join(workDir, mapsMainDirectory, fmt.Sprintf("pr-%d-map.yaml", pr))
+	mapPath := filepath.Join(mapsDir, fmt.Sprintf("pr-%d-map.yaml", pr))
 	err = os.WriteFile(mapPath, newYAML, os.FileMode(0o644))
 	if err != nil {
 		logrus.Errorf("Error writing map to %s: %s", mapPath, err)
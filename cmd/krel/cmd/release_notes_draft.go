@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/github"
+	"k8s.io/release/pkg/notes"
+	"sigs.k8s.io/release-utils/util"
+)
+
+const mapsDirFlag = "maps-dir"
+
+// releaseNotesDraftCmd represents the subcommand for `krel release-notes draft`
+var releaseNotesDraftCmd = &cobra.Command{
+	Use:   "draft",
+	Short: "Curate the unresolved release notes of the upcoming release",
+	Long: fmt.Sprintf(`krel release-notes draft
+
+The 'draft' subcommand gathers the release notes for the provided tag (or the
+latest minor tag if not set) and opens every unresolved note in $KUBE_EDITOR
+or $EDITOR, one by one.
+
+A note is unresolved if it has no text, no kind or no SIG, or if it has
+multiple kinds. Maps which already exist in the directory provided by
+--%s are applied before checking the notes, which means that notes fixed in
+previous runs are skipped.
+
+The edited notes are written back into the --%s directory as override maps,
+which can be used by 'krel release-notes --maps-from' and 'release-notes
+--maps-from' afterwards.
+
+To use the tool, please set the %v environment variable.`,
+		mapsDirFlag, mapsDirFlag, github.TokenEnvKey,
+	),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReleaseNotesDraft(releaseNotesDraftOpts)
+	},
+}
+
+type releaseNotesDraftOptions struct {
+	mapsDir string
+}
+
+var releaseNotesDraftOpts = &releaseNotesDraftOptions{}
+
+func init() {
+	releaseNotesDraftCmd.PersistentFlags().StringVar(
+		&releaseNotesDraftOpts.mapsDir,
+		mapsDirFlag,
+		"",
+		"directory to read the existing release notes maps from and write the edited ones to",
+	)
+
+	if err := releaseNotesDraftCmd.MarkPersistentFlagRequired(mapsDirFlag); err != nil {
+		logrus.Fatal(err)
+	}
+
+	releaseNotesCmd.AddCommand(releaseNotesDraftCmd)
+}
+
+func runReleaseNotesDraft(opts *releaseNotesDraftOptions) (err error) {
+	tag := releaseNotesOpts.tag
+	if tag == "" {
+		tag, err = tryToFindLatestMinorTag()
+		if err != nil {
+			return errors.Wrapf(err, "unable to find latest minor tag")
+		}
+		releaseNotesOpts.tag = tag
+	}
+
+	if err := releaseNotesOpts.Validate(); err != nil {
+		return errors.Wrap(err, "validating command line options")
+	}
+
+	tagVersion, err := util.TagStringToSemver(tag)
+	if err != nil {
+		return errors.Wrapf(err, "reading tag: %s", tag)
+	}
+
+	if err := os.MkdirAll(opts.mapsDir, os.FileMode(0o755)); err != nil {
+		return errors.Wrap(err, "creating maps directory")
+	}
+
+	start := draftStartTag(tagVersion)
+	releaseNotes, err := gatherNotesFrom(releaseNotesOpts.repoPath, start)
+	if err != nil {
+		return errors.Wrapf(err, "while generating the release notes for tag %s", start)
+	}
+
+	if err := draftReleaseNotes(opts.mapsDir, releaseNotes); err != nil {
+		return errors.Wrap(err, "while running release notes draft flow")
+	}
+	return nil
+}
+
+// draftReleaseNotes opens all unresolved release notes in the editor and
+// writes the results as maps into mapsDir
+func draftReleaseNotes(mapsDir string, releaseNotes *notes.ReleaseNotes) error {
+	provider, err := notes.NewProviderFromInitString(mapsDir)
+	if err != nil {
+		return errors.Wrap(err, "while getting map provider for current notes")
+	}
+
+	notesByPR := releaseNotes.ByPR()
+	prs := make([]int, 0, len(notesByPR))
+	for pr := range notesByPR {
+		prs = append(prs, pr)
+	}
+	sort.Ints(prs)
+
+	unresolved, edited := 0, 0
+	for _, pr := range prs {
+		note := notesByPR[pr]
+		originalNote := copyNoteFields(note)
+
+		noteMaps, err := provider.GetMapsForPR(pr)
+		if err != nil {
+			return errors.Wrapf(err, "while getting map for PR #%d", pr)
+		}
+		for _, noteMap := range noteMaps {
+			if err := note.ApplyMap(noteMap); err != nil {
+				return errors.Wrapf(err, "applying notemap for PR #%d", pr)
+			}
+		}
+
+		reasons := note.UnresolvedReasons()
+		if len(reasons) == 0 {
+			logrus.Debugf("Release note of PR #%d is resolved", pr)
+			continue
+		}
+		unresolved++
+
+		title := fmt.Sprintf("Unresolved Release Note for PR %d:", pr)
+		fmt.Println(nl + title)
+		fmt.Println(strings.Repeat("=", len(title)))
+		fmt.Printf("Pull Request URL: %skubernetes/kubernetes/pull/%d%s", github.GitHubURL, pr, nl)
+		for _, reason := range reasons {
+			fmt.Println("  ⚠ " + reason)
+		}
+		printReleaseNote(originalNote, note)
+
+		_, choice, err := util.Ask(
+			fmt.Sprintf("\n- Edit note for PR #%d? (Y/n)", pr), "y:Y:yes|n:N:no|y", 10,
+		)
+		if err != nil {
+			// If the user cancelled with ctr+c exit the draft flow
+			if userErr, ok := err.(util.UserInputError); ok && userErr.IsCtrlC() {
+				logrus.Info("Input cancelled, exiting draft flow")
+				break
+			}
+			return errors.Wrap(err, "while asking to edit release note")
+		}
+		if !choice {
+			continue
+		}
+
+		reviewed, err := editReleaseNoteWithRetry(pr, mapsDir, originalNote, note)
+		if err != nil {
+			return err
+		}
+		if reviewed {
+			edited++
+		}
+	}
+
+	logrus.Infof(
+		"Found %d unresolved release notes, %d reviewed in %s",
+		unresolved, edited, mapsDir,
+	)
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/announce"
 	"k8s.io/release/pkg/github"
 	"k8s.io/release/pkg/release"
 )
@@ -57,7 +58,17 @@ Build (GCB) job which does:
    into the local working repository.
 
 6. Stage: Copies the build artifacts to a Google Cloud Bucket.
-`, github.TokenEnvKey, release.BuildDir),
+
+If '--health-gate' is set, the release blocking testgrid dashboard of the
+release branch gets checked before tagging the repository. Any failing,
+broken, stale or pending job results in a no-go and aborts the stage, as well
+as flaky jobs unless '--allow-flaky' is set. Tabs listed in '--ignored-tabs'
+never result in a no-go.
+
+If the $%s environment variable contains a comma separated list of
+Slack incoming webhook URLs, then the staged versions get posted to those
+channels when running with --nomock.
+`, github.TokenEnvKey, release.BuildDir, announce.SlackWebhooksEnvKey),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -75,6 +86,7 @@ const (
 	buildVersionFlag = "build-version"
 	submitJobFlag    = "submit"
 	streamFlag       = "stream"
+	stateFileFlag    = "state-file"
 )
 
 func init() {
@@ -124,6 +136,38 @@ func init() {
 			"Run the Google Cloud Build job synchronously",
 		)
 
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.StateFile,
+			stateFileFlag,
+			"",
+			"Path to a file recording the completed steps of a local run, which allows resuming it after a failure",
+		)
+
+	stageCmd.PersistentFlags().
+		BoolVar(
+			&stageOptions.HealthGate,
+			"health-gate",
+			false,
+			"Refuse to stage if the release blocking testgrid dashboard of the branch contains failing jobs",
+		)
+
+	stageCmd.PersistentFlags().
+		BoolVar(
+			&stageOptions.AllowFlaky,
+			"allow-flaky",
+			false,
+			"Do not let flaky jobs fail the health gate",
+		)
+
+	stageCmd.PersistentFlags().
+		StringSliceVar(
+			&stageOptions.IgnoredTabs,
+			"ignored-tabs",
+			[]string{},
+			"Comma separated list of testgrid dashboard tabs which never fail the health gate",
+		)
+
 	for _, flag := range []string{buildVersionFlag, submitJobFlag} {
 		if err := stageCmd.PersistentFlags().MarkHidden(flag); err != nil {
 			logrus.Fatal(err)
@@ -134,7 +178,8 @@ func init() {
 }
 
 func runStage(options *anago.StageOptions) error {
-	options.NoMock = rootOpts.nomock
+	options.Mode = release.ModeFromNoMock(rootOpts.nomock)
+	options.SlackWebhooks = announce.SlackWebhooksFromEnv()
 	stage := anago.NewStage(options)
 	if submitJob {
 		return stage.Submit(stream)
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-utils/util"
+)
+
+type verifyOptions struct {
+	version      string
+	buildVersion string
+	bucket       string
+}
+
+var verifyOpts = &verifyOptions{}
+
+// verifyCmd is a krel subcommand which verifies the artifacts of a published
+// Kubernetes release.
+var verifyCmd = &cobra.Command{
+	Use:   "verify --version <version> [--build-version <build-version>] [--bucket <bucket>]",
+	Short: "Verify the published artifacts of a Kubernetes release",
+	Long: fmt.Sprintf(`krel verify
+
+Downloads the published tarballs and binaries of a Kubernetes version from the
+release bucket (defaults to %s with --nomock and to %s otherwise) and verifies
+that all expected artifacts exist.
+
+The checksums of all artifacts get recomputed and compared with their
+published .sha256 and .sha512 files as well as with the published SHA256SUMS
+and SHA512SUMS manifests. If the build version of the release is provided, the
+checksums are additionally compared with the manifests of the staged build.
+
+All mismatches are reported at the end, which makes the command suitable as a
+sanity check after a release.
+`, release.ProductionBucket, release.TestBucket),
+	Example:       "krel verify --version v1.22.0 --build-version v1.22.0-rc.0.2+c4d752765b3bbac --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVerify(verifyOpts)
+	},
+}
+
+func init() {
+	verifyCmd.PersistentFlags().StringVar(
+		&verifyOpts.version,
+		"version",
+		"",
+		"The published Kubernetes version to be verified, like v1.22.0",
+	)
+
+	verifyCmd.PersistentFlags().StringVar(
+		&verifyOpts.buildVersion,
+		buildVersionFlag,
+		"",
+		"The build version of the release to compare the staged checksum manifests with",
+	)
+
+	verifyCmd.PersistentFlags().StringVar(
+		&verifyOpts.bucket,
+		"bucket",
+		"",
+		"The bucket to verify the release in (default depends on --nomock)",
+	)
+
+	if err := verifyCmd.MarkPersistentFlagRequired("version"); err != nil {
+		logrus.Fatal(err)
+	}
+
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify(opts *verifyOptions) error {
+	if _, err := util.TagStringToSemver(opts.version); err != nil {
+		return errors.Wrapf(err, "invalid version %s", opts.version)
+	}
+	if opts.buildVersion != "" {
+		valid, err := release.IsValidReleaseBuild(opts.buildVersion)
+		if err != nil || !valid {
+			return errors.Errorf("invalid build version %s", opts.buildVersion)
+		}
+	}
+
+	bucket := opts.bucket
+	if bucket == "" {
+		bucket = release.ModeFromNoMock(rootOpts.nomock).Bucket()
+	}
+
+	return release.NewArtifactValidator(
+		release.PublishedArtifactLayout(),
+	).ValidatePublished(bucket, opts.version, opts.buildVersion)
+}
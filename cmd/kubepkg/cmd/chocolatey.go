@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// chocolateyCmd represents the base command when called without any subcommands
+var chocolateyCmd = &cobra.Command{
+	Use:           "chocolatey [--arch <architectures>] [--channels <channels>]",
+	Short:         "chocolatey creates Chocolatey packages for kubectl and kubeadm",
+	Example:       "kubepkg chocolatey --arch amd64,arm64 --channels release",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(*cobra.Command, []string) error {
+		return setOptions()
+	},
+	RunE: func(*cobra.Command, []string) error {
+		return run(options.BuildChocolatey)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(chocolateyCmd)
+}
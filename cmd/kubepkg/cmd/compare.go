@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+var failOnFileChanges bool
+
+// compareCmd represents the command to compare two versions of a package
+var compareCmd = &cobra.Command{
+	Use:   "compare <old-package> <new-package>",
+	Short: "compare reports the file and metadata differences of two versions of a deb or rpm",
+	Long: `compare reports the file and metadata differences of two versions of a deb or rpm.
+
+The file lists as well as the name, version, architecture, maintainer and
+dependencies of both packages get compared, for example to catch unexpected
+file additions or removals before publishing a new patch release. The packages
+are inspected via dpkg-deb for debs and rpm for rpms, which have to be
+available on the host. Use --output-format json for a machine readable report.`,
+	Example:       "kubepkg compare kubeadm_1.22.0-0_amd64.deb kubeadm_1.22.1-0_amd64.deb --fail-on-file-changes",
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(*cobra.Command, []string) error {
+		return setOptions()
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runCompare(args[0], args[1])
+	},
+}
+
+func init() {
+	compareCmd.PersistentFlags().BoolVar(
+		&failOnFileChanges,
+		"fail-on-file-changes",
+		false,
+		"exit with an error if files have been added or removed",
+	)
+
+	rootCmd.AddCommand(compareCmd)
+}
+
+func runCompare(oldPath, newPath string) error {
+	diff, err := kubepkg.New(opts).ComparePackages(oldPath, newPath)
+	if err != nil {
+		return errors.Wrap(err, "comparing packages")
+	}
+
+	if opts.OutputFormat() == options.OutputFormatJSON {
+		res, err := diff.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(res))
+	} else if err := diff.Write(os.Stdout); err != nil {
+		return err
+	}
+
+	if failOnFileChanges && diff.FilesChanged() {
+		return kubepkg.WithCategory(errors.Errorf(
+			"%d files have been added and %d removed", len(diff.Added), len(diff.Removed),
+		), kubepkg.ErrorCategoryValidation)
+	}
+	return nil
+}
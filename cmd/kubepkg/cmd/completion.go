@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// completionFunc completes the value of a flag.
+type completionFunc func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+
+// registerCompletions adds the dynamic completion of the flag values to the
+// root command. The shell completion scripts are generated by the completion
+// command, which gets added by cobra.
+func registerCompletions() {
+	packages := append(options.SupportedPackages(), options.PackagePresets()...)
+	for flag, completion := range map[string]completionFunc{
+		"packages":    completeList(packages),
+		"channels":    completeList(options.SupportedChannels()),
+		"arch":        completeList(options.SupportedArchitectures()),
+		"cni-plugins": completeList(options.SupportedCNIPlugins()),
+		"distros":     completeList(options.SupportedDistros()),
+	} {
+		if err := rootCmd.RegisterFlagCompletionFunc(flag, completion); err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	if err := rootCmd.MarkPersistentFlagFilename("config", "yaml", "yml", "json"); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+// completeList completes the last element of a comma separated list of the
+// provided values. Values which are already part of the list are omitted.
+func completeList(values []string) completionFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		prefix := ""
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			prefix = toComplete[:i+1]
+		}
+		selected := map[string]bool{}
+		for _, value := range strings.Split(prefix, ",") {
+			selected[value] = true
+		}
+
+		res := []string{}
+		for _, value := range values {
+			if !selected[value] {
+				res = append(res, prefix+value)
+			}
+		}
+		return res, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// completeValues completes a flag accepting a single of the provided values.
+func completeValues(values []string) completionFunc {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// configCmd represents the command to inspect the kubepkg configuration
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "config inspects the kubepkg configuration",
+}
+
+// configViewCmd represents the command to print the effective options
+var configViewCmd = &cobra.Command{
+	Use:   "view [--config <file>]",
+	Short: "view prints the effective options after applying defaults, environment variables, the config file and flags",
+	Long: `view prints the effective options after applying defaults, environment variables, the config file and flags.
+
+The output uses the format of the kubepkg config files, which means that it
+can be passed to --config to reproduce a build. The notification URL gets
+redacted, because webhook URLs usually contain a secret.`,
+	Example:       "kubepkg config view --config kubepkg.yaml --arch arm64",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(*cobra.Command, []string) error {
+		if err := setOptions(); err != nil {
+			return err
+		}
+		return opts.Validate()
+	},
+	RunE: func(*cobra.Command, []string) error {
+		return runConfigView()
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configViewCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigView() error {
+	config := opts.Config()
+	if config.NotifyURL != "" {
+		config.NotifyURL = "<redacted>"
+	}
+
+	if opts.OutputFormat() == options.OutputFormatJSON {
+		return printJSON(config)
+	}
+
+	res, err := config.YAML()
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(res))
+	return nil
+}
@@ -30,7 +30,7 @@ var debsCmd = &cobra.Command{
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PreRunE: func(*cobra.Command, []string) error {
-		return opts.Validate()
+		return setOptions()
 	},
 	RunE: func(*cobra.Command, []string) error {
 		return run(options.BuildDeb)
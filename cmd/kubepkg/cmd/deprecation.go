@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// markDeprecatedFlags adds the deprecation notices to the usage of the
+// deprecated flags.
+func markDeprecatedFlags() {
+	for _, d := range options.Deprecations() {
+		flag := rootCmd.PersistentFlags().Lookup(d.Flag)
+		if flag == nil {
+			logrus.Fatalf("deprecated flag --%s does not exist", d.Flag)
+		}
+		flag.Usage += fmt.Sprintf(" (%s)", d.Message())
+	}
+}
+
+// warnDeprecatedFlags logs a warning for every deprecated flag of the root
+// command which has been set, including the ones set via environment
+// variables.
+func warnDeprecatedFlags(cmd *cobra.Command) {
+	for _, d := range options.Deprecations() {
+		if cmd.Root().PersistentFlags().Changed(d.Flag) {
+			d.Warn()
+		}
+	}
+}
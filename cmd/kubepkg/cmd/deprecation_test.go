@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestDeprecatedFlags(t *testing.T) {
+	for _, d := range options.Deprecations() {
+		flag := rootCmd.PersistentFlags().Lookup(d.Flag)
+		require.NotNil(t, flag, d.Flag)
+		require.Contains(t, flag.Usage, d.Message())
+		if d.Replacement != "" {
+			require.NotNil(t, rootCmd.PersistentFlags().Lookup(d.Replacement), d.Replacement)
+		}
+	}
+}
+
+func TestWarnDeprecatedFlags(t *testing.T) {
+	deprecations := options.Deprecations()
+	require.NotEmpty(t, deprecations)
+	d := deprecations[0]
+
+	flag := rootCmd.PersistentFlags().Lookup(d.Flag)
+	prevValue, prevChanged := flag.Value.String(), flag.Changed
+	defer func() {
+		require.Nil(t, flag.Value.Set(prevValue))
+		flag.Changed = prevChanged
+	}()
+
+	hook := test.NewGlobal()
+	defer logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+
+	warnDeprecatedFlags(rootCmd)
+	require.Empty(t, hook.AllEntries())
+
+	require.Nil(t, rootCmd.PersistentFlags().Set(d.Flag, "value"))
+	warnDeprecatedFlags(rootCmd)
+	require.Len(t, hook.AllEntries(), 1)
+	entry := hook.LastEntry()
+	require.Equal(t, logrus.WarnLevel, entry.Level)
+	require.Equal(t, d.Message(), entry.Message)
+	require.Equal(t, d.Flag, entry.Data["deprecatedFlag"])
+	require.Equal(t, d.Replacement, entry.Data["replacement"])
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+var (
+	diffType string
+	diffOpts = &kubepkg.RepoDiffOptions{}
+)
+
+// diffCmd represents the command to compare the planned packages with a
+// published repository
+var diffCmd = &cobra.Command{
+	Use:   "diff --repo-url <url> [--type <type>] [--suite <suite>] [--component <component>]",
+	Short: "diff compares the planned package versions with a published APT or yum repository",
+	Long: `diff compares the planned package versions with a published APT or yum repository.
+
+The builds get planned like in "kubepkg plan" and every resulting deb or rpm is
+compared with the newest version of the same package and architecture served
+by the repository. Packages are reported as missing if the repository does not
+serve them at all, as outdated if it only serves older versions and as newer if
+it serves a version newer than the planned one.
+
+For debs, --repo-url is the root of the APT repository containing the dists
+directory. For rpms, it is the parent of the yum repositories per
+architecture, like created by "kubepkg repo rpm". Use --output-format json for
+a machine readable report.`,
+	Example:       "kubepkg diff --type deb --channels release --repo-url https://apt.kubernetes.io --suite kubernetes-xenial",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(*cobra.Command, []string) error {
+		if diffOpts.RepoURL == "" {
+			return kubepkg.WithCategory(
+				errors.New("a repository URL is required"), kubepkg.ErrorCategoryValidation,
+			)
+		}
+		if err := setOptions(); err != nil {
+			return err
+		}
+		return opts.WithBuildType(options.BuildType(diffType)).Validate()
+	},
+	RunE: func(*cobra.Command, []string) error {
+		return runDiff()
+	},
+}
+
+func init() {
+	diffCmd.PersistentFlags().StringVar(
+		&diffType,
+		"type",
+		string(options.BuildDeb),
+		"package type to compare, either deb or rpm",
+	)
+
+	diffCmd.PersistentFlags().StringVar(
+		&diffOpts.RepoURL,
+		"repo-url",
+		"",
+		"base URL of the APT repository or the yum repositories per architecture",
+	)
+
+	diffCmd.PersistentFlags().StringVar(
+		&diffOpts.Suite,
+		"suite",
+		kubepkg.DefaultAptSuite,
+		"suite (distribution) of the APT repository",
+	)
+
+	diffCmd.PersistentFlags().StringVar(
+		&diffOpts.Component,
+		"component",
+		kubepkg.DefaultAptComponent,
+		"component of the APT repository",
+	)
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff() error {
+	client := kubepkg.New(opts)
+	builds, err := client.ConstructBuilds()
+	if err != nil {
+		return errors.Wrap(err, "constructing builds")
+	}
+
+	diff, err := client.DiffRepo(builds, diffOpts)
+	if err != nil {
+		return errors.Wrap(err, "comparing with repository")
+	}
+
+	if diff.Differs() {
+		logrus.Warnf("Repository %s differs from the planned packages", diffOpts.RepoURL)
+	} else {
+		logrus.Infof("Repository %s serves all planned packages", diffOpts.RepoURL)
+	}
+
+	if opts.OutputFormat() == options.OutputFormatJSON {
+		res, err := diff.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(res))
+		return nil
+	}
+	return diff.Write(os.Stdout)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// envPrefix is the prefix of the environment variables setting the flags.
+const envPrefix = "KUBEPKG_"
+
+// envName returns the environment variable of the flag, for example
+// KUBEPKG_KUBE_VERSION for --kube-version.
+func envName(flag string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// bindEnv sets all flags of the command which have not been set on the
+// command line from their environment variables. Flags set that way count
+// as explicitly set, which means that the precedence is flag > environment
+// variable > config file.
+func bindEnv(cmd *cobra.Command) error {
+	var err error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flag.Name == "help" {
+			return
+		}
+		value, ok := os.LookupEnv(envName(flag.Name))
+		if !ok {
+			return
+		}
+		logrus.Debugf("Setting --%s from %s", flag.Name, envName(flag.Name))
+		if setErr := cmd.Flags().Set(flag.Name, value); setErr != nil {
+			err = errors.Wrapf(setErr, "setting --%s from %s", flag.Name, envName(flag.Name))
+		}
+	})
+	return err
+}
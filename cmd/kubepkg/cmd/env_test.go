@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvName(t *testing.T) {
+	require.Equal(t, "KUBEPKG_KUBE_VERSION", envName("kube-version"))
+	require.Equal(t, "KUBEPKG_ARCH", envName("arch"))
+}
+
+func TestBindEnv(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		args        []string
+		env         map[string]string
+		shouldErr   bool
+		expected    string
+		expectedSet bool
+		archs       []string
+		dryRun      bool
+	}{
+		{
+			name:     "defaults without flags and environment",
+			expected: "default",
+			archs:    []string{"amd64"},
+		},
+		{
+			name:        "environment sets the flag",
+			env:         map[string]string{"KUBEPKG_KUBE_VERSION": "v1.22.0"},
+			expected:    "v1.22.0",
+			expectedSet: true,
+			archs:       []string{"amd64"},
+		},
+		{
+			name:        "flag takes precedence over environment",
+			args:        []string{"--kube-version", "v1.23.0"},
+			env:         map[string]string{"KUBEPKG_KUBE_VERSION": "v1.22.0"},
+			expected:    "v1.23.0",
+			expectedSet: true,
+			archs:       []string{"amd64"},
+		},
+		{
+			name:     "slice and bool flags from environment",
+			env:      map[string]string{"KUBEPKG_ARCH": "arm64,s390x", "KUBEPKG_DRY_RUN": "true"},
+			expected: "default",
+			archs:    []string{"arm64", "s390x"},
+			dryRun:   true,
+		},
+		{
+			name:      "invalid environment value",
+			env:       map[string]string{"KUBEPKG_DRY_RUN": "wrong"},
+			shouldErr: true,
+		},
+		{
+			name:     "help is never set from environment",
+			env:      map[string]string{"KUBEPKG_HELP": "true"},
+			expected: "default",
+			archs:    []string{"amd64"},
+		},
+	} {
+		var (
+			kubeVersion string
+			archs       []string
+			dryRun      bool
+		)
+		cmd := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+		cmd.Flags().StringVar(&kubeVersion, "kube-version", "default", "")
+		cmd.Flags().StringSliceVar(&archs, "arch", []string{"amd64"}, "")
+		cmd.Flags().BoolVar(&dryRun, "dry-run", false, "")
+		cmd.Flags().Bool("help", false, "")
+		require.Nil(t, cmd.ParseFlags(tc.args), tc.name)
+
+		for k, v := range tc.env {
+			require.Nil(t, os.Setenv(k, v))
+		}
+		err := bindEnv(cmd)
+		for k := range tc.env {
+			require.Nil(t, os.Unsetenv(k))
+		}
+
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.name)
+			continue
+		}
+		require.Nil(t, err, tc.name)
+		require.Equal(t, tc.expected, kubeVersion, tc.name)
+		require.Equal(t, tc.expectedSet, cmd.Flags().Changed("kube-version"), tc.name)
+		require.Equal(t, tc.archs, archs, tc.name)
+		require.Equal(t, tc.dryRun, dryRun, tc.name)
+		require.False(t, cmd.Flags().Changed("help"), tc.name)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// sourceDateEpochEnvKey is the environment variable containing the Unix
+// timestamp used as date of the man pages.
+const sourceDateEpochEnvKey = "SOURCE_DATE_EPOCH"
+
+var gendocsDir string
+
+// gendocsCmd represents the command to generate the reference docs
+var gendocsCmd = &cobra.Command{
+	Use:   "gendocs [--docs-dir <dir>]",
+	Short: "gendocs generates the man pages and markdown reference docs of kubepkg",
+	Long: `gendocs generates the man pages and markdown reference docs of kubepkg.
+
+The man pages get written to <docs-dir>/man and the markdown docs to
+<docs-dir>/markdown, both containing one file per command. The docs do not
+contain a generation date, which keeps them reproducible. The date of the man
+pages is taken from $SOURCE_DATE_EPOCH, which defaults to the Unix epoch.`,
+	Example:       "kubepkg gendocs --docs-dir docs",
+	Hidden:        true,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(*cobra.Command, []string) error {
+		return runGendocs()
+	},
+}
+
+func init() {
+	gendocsCmd.PersistentFlags().StringVar(
+		&gendocsDir,
+		"docs-dir",
+		"docs",
+		"directory to write the man pages and markdown docs to",
+	)
+
+	rootCmd.AddCommand(gendocsCmd)
+}
+
+func runGendocs() error {
+	rootCmd.DisableAutoGenTag = true
+
+	date, err := manDate()
+	if err != nil {
+		return err
+	}
+
+	manDir := filepath.Join(gendocsDir, "man")
+	if err := os.MkdirAll(manDir, os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "creating %s", manDir)
+	}
+	if err := doc.GenManTree(rootCmd, &doc.GenManHeader{
+		Title:   "KUBEPKG",
+		Section: "1",
+		Source:  "Kubernetes",
+		Manual:  "kubepkg Manual",
+		Date:    &date,
+	}, manDir); err != nil {
+		return errors.Wrap(err, "generating man pages")
+	}
+
+	markdownDir := filepath.Join(gendocsDir, "markdown")
+	if err := os.MkdirAll(markdownDir, os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "creating %s", markdownDir)
+	}
+	if err := doc.GenMarkdownTree(rootCmd, markdownDir); err != nil {
+		return errors.Wrap(err, "generating markdown docs")
+	}
+
+	logrus.Infof("Generated the kubepkg docs in %s", gendocsDir)
+	return nil
+}
+
+// manDate returns the date of the man pages, which is the Unix epoch unless
+// $SOURCE_DATE_EPOCH is set.
+func manDate() (time.Time, error) {
+	epoch, ok := os.LookupEnv(sourceDateEpochEnvKey)
+	if !ok || epoch == "" {
+		return time.Unix(0, 0).UTC(), nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "parsing %s", sourceDateEpochEnvKey)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
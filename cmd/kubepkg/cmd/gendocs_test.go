@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// generateDocs runs gendocs into a new temporary directory and returns the
+// content of all generated files by their relative path.
+func generateDocs(t *testing.T) map[string]string {
+	dir, err := os.MkdirTemp("", "kubepkg-gendocs-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	prevDir := gendocsDir
+	defer func() { gendocsDir = prevDir }()
+	gendocsDir = dir
+	require.Nil(t, runGendocs())
+
+	res := map[string]string{}
+	require.Nil(t, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		res[rel] = string(content)
+		return nil
+	}))
+	return res
+}
+
+func TestGendocsReproducible(t *testing.T) {
+	require.Nil(t, os.Unsetenv(sourceDateEpochEnvKey))
+
+	first := generateDocs(t)
+	require.Contains(t, first, filepath.Join("man", "kubepkg.1"))
+	require.Contains(t, first, filepath.Join("markdown", "kubepkg.md"))
+	require.Contains(t, first[filepath.Join("man", "kubepkg.1")], `"Jan 1970"`)
+	require.Equal(t, first, generateDocs(t))
+}
+
+func TestGendocsSourceDateEpoch(t *testing.T) {
+	require.Nil(t, os.Setenv(sourceDateEpochEnvKey, "1633046400"))
+	defer os.Unsetenv(sourceDateEpochEnvKey)
+
+	docs := generateDocs(t)
+	require.Contains(t, docs[filepath.Join("man", "kubepkg.1")], `"Oct 2021"`)
+}
+
+func TestManDate(t *testing.T) {
+	defer os.Unsetenv(sourceDateEpochEnvKey)
+
+	require.Nil(t, os.Unsetenv(sourceDateEpochEnvKey))
+	date, err := manDate()
+	require.Nil(t, err)
+	require.Equal(t, time.Unix(0, 0).UTC(), date)
+
+	require.Nil(t, os.Setenv(sourceDateEpochEnvKey, "1633046400"))
+	date, err = manDate()
+	require.Nil(t, err)
+	require.Equal(t, time.Date(2021, time.October, 1, 0, 0, 0, 0, time.UTC), date)
+
+	require.Nil(t, os.Setenv(sourceDateEpochEnvKey, "wrong"))
+	_, err = manDate()
+	require.NotNil(t, err)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// promptRetries is the number of times an invalid answer can be corrected.
+const promptRetries = 3
+
+// prompter asks for the values of missing options.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter() *prompter {
+	return &prompter{in: bufio.NewReader(os.Stdin), out: os.Stderr}
+}
+
+// ask prints the question and returns the answer, which is the default value
+// if the answer is empty. Answers failing the validation are asked again.
+func (p *prompter) ask(
+	question, defaultValue string, validate func(string) error,
+) (string, error) {
+	for try := 0; ; try++ {
+		if defaultValue != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+
+		line, err := p.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", errors.Wrap(err, "reading answer")
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = defaultValue
+		}
+
+		err = validate(answer)
+		if err == nil {
+			return answer, nil
+		}
+		if try >= promptRetries {
+			return "", err
+		}
+		fmt.Fprintf(p.out, "Invalid answer: %v\n", err)
+	}
+}
+
+// promptOptions asks for the values of options which have not been set via
+// flags, environment variables or the config file, but are typically
+// required for a release build.
+func promptOptions(p *prompter) error {
+	if len(opts.KubeVersions()) == 0 {
+		answer, err := p.ask(
+			"Kubernetes versions to build, comma separated (empty resolves the version per channel)",
+			"",
+			func(answer string) error {
+				return options.New().WithKubeVersions(splitAnswer(answer)...).Validate()
+			},
+		)
+		if err != nil {
+			return err
+		}
+		opts.WithKubeVersions(splitAnswer(answer)...)
+	}
+
+	if opts.SignKey() == "" && !opts.SpecOnly() {
+		answer, err := p.ask(
+			`GPG key for signing, either "file:<path>", "keyring:<id>" or "kms:<id>" (empty does not sign)`,
+			"",
+			func(answer string) error {
+				return options.New().WithSignKey(answer).Validate()
+			},
+		)
+		if err != nil {
+			return err
+		}
+		opts.WithSignKey(answer)
+	}
+
+	if opts.UploadBucket() != "" && opts.UploadPath() == "" && len(opts.KubeVersions()) != 1 {
+		answer, err := p.ask(
+			"Path within the upload bucket", "",
+			func(answer string) error {
+				if answer == "" {
+					return errors.New("an upload path is required unless a single Kubernetes version gets built")
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+		opts.WithUploadPath(answer)
+	}
+	return nil
+}
+
+// splitAnswer returns the non-empty elements of a comma separated answer.
+func splitAnswer(answer string) []string {
+	res := []string{}
+	for _, value := range strings.Split(answer, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			res = append(res, value)
+		}
+	}
+	return res
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func newTestPrompter(input string) (*prompter, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &prompter{in: bufio.NewReader(strings.NewReader(input)), out: out}, out
+}
+
+func TestPrompterAsk(t *testing.T) {
+	notEmpty := func(answer string) error {
+		if answer == "" {
+			return errors.New("empty")
+		}
+		return nil
+	}
+
+	for _, tc := range []struct {
+		name           string
+		input          string
+		defaultValue   string
+		shouldErr      bool
+		expected       string
+		expectedOutput string
+	}{
+		{
+			name:           "answer",
+			input:          " answer \n",
+			expected:       "answer",
+			expectedOutput: "question: ",
+		},
+		{
+			name:           "empty answer uses the default",
+			input:          "\n",
+			defaultValue:   "default",
+			expected:       "default",
+			expectedOutput: "question [default]: ",
+		},
+		{
+			name:           "answer overrides the default",
+			input:          "answer\n",
+			defaultValue:   "default",
+			expected:       "answer",
+			expectedOutput: "question [default]: ",
+		},
+		{
+			name:     "invalid answer gets corrected",
+			input:    "\nanswer\n",
+			expected: "answer",
+			expectedOutput: "question: Invalid answer: empty\n" +
+				"question: ",
+		},
+		{
+			name:      "retries exceeded",
+			input:     "\n\n\n\nanswer\n",
+			shouldErr: true,
+			expectedOutput: strings.Repeat("question: Invalid answer: empty\n", promptRetries) +
+				"question: ",
+		},
+		{
+			name:           "EOF after the last answer",
+			input:          "answer",
+			expected:       "answer",
+			expectedOutput: "question: ",
+		},
+		{
+			name:           "EOF without answer",
+			input:          "",
+			defaultValue:   "default",
+			shouldErr:      true,
+			expectedOutput: "question [default]: ",
+		},
+	} {
+		sut, out := newTestPrompter(tc.input)
+		res, err := sut.ask("question", tc.defaultValue, notEmpty)
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.name)
+		} else {
+			require.Nil(t, err, tc.name)
+			require.Equal(t, tc.expected, res, tc.name)
+		}
+		require.Equal(t, tc.expectedOutput, out.String(), tc.name)
+	}
+}
+
+func TestPromptOptions(t *testing.T) {
+	prevOpts := opts
+	defer func() { opts = prevOpts }()
+
+	for _, tc := range []struct {
+		name                 string
+		opts                 *options.Options
+		input                string
+		shouldErr            bool
+		expectedKubeVersions []string
+		expectedSignKey      string
+		expectedUploadPath   string
+	}{
+		{
+			name:                 "all options answered",
+			opts:                 options.New().WithUploadBucket("bucket"),
+			input:                "v1.22.0, v1.23.0\nkeyring:ABCD\npath\n",
+			expectedKubeVersions: []string{"v1.22.0", "v1.23.0"},
+			expectedSignKey:      "keyring:ABCD",
+			expectedUploadPath:   "path",
+		},
+		{
+			name:                 "empty answers keep the defaults",
+			opts:                 options.New(),
+			input:                "\n\n",
+			expectedKubeVersions: []string{},
+		},
+		{
+			name:                 "set options are not asked for",
+			opts:                 options.New().WithKubeVersions("v1.22.0").WithSignKey("keyring:ABCD"),
+			expectedKubeVersions: []string{"v1.22.0"},
+			expectedSignKey:      "keyring:ABCD",
+		},
+		{
+			name:                 "no sign key for specs",
+			opts:                 options.New().WithSpecOnly(true),
+			input:                "v1.22.0\n",
+			expectedKubeVersions: []string{"v1.22.0"},
+		},
+		{
+			name:      "invalid sign key",
+			opts:      options.New().WithKubeVersions("v1.22.0"),
+			input:     strings.Repeat("wrong:key\n", promptRetries+1),
+			shouldErr: true,
+		},
+		{
+			name:      "upload path required",
+			opts:      options.New().WithUploadBucket("bucket"),
+			input:     "\n\n" + strings.Repeat("\n", promptRetries+1),
+			shouldErr: true,
+		},
+	} {
+		opts = tc.opts
+		sut, _ := newTestPrompter(tc.input)
+		err := promptOptions(sut)
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.name)
+			continue
+		}
+		require.Nil(t, err, tc.name)
+		require.Equal(t, tc.expectedKubeVersions, opts.KubeVersions(), tc.name)
+		require.Equal(t, tc.expectedSignKey, opts.SignKey(), tc.name)
+		require.Equal(t, tc.expectedUploadPath, opts.UploadPath(), tc.name)
+	}
+}
+
+func TestSplitAnswer(t *testing.T) {
+	require.Equal(t, []string{}, splitAnswer(""))
+	require.Equal(t, []string{"a", "b"}, splitAnswer(" a, ,b ,"))
+}
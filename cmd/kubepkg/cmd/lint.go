@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+var lintType string
+
+// lintResult is the JSON representation of a lint issue.
+type lintResult struct {
+	Type options.BuildType `json:"type"`
+	kubepkg.LintIssue
+}
+
+// lintCmd represents the command to lint the templates
+var lintCmd = &cobra.Command{
+	Use:   "lint [--type <type>] [--arch <architectures>] [--channels <channels>]",
+	Short: "lint validates the templates by rendering the specs for every build",
+	Long: `lint validates the templates by rendering the specs for every build.
+
+The specs get rendered for every package, channel and architecture
+combination and are checked for the required metadata fields of the package
+type. Nothing gets built, which allows catching template regressions before a
+real build run.`,
+	Example:       "kubepkg lint --type all --kube-version v1.22.1 --cri-tools-version 1.22.0",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(*cobra.Command, []string) error {
+		return setOptions()
+	},
+	RunE: func(*cobra.Command, []string) error {
+		return runLint()
+	},
+}
+
+func init() {
+	lintCmd.PersistentFlags().StringVar(
+		&lintType,
+		"type",
+		string(options.BuildAll),
+		fmt.Sprintf("package type to lint, either %q or one of %v", options.BuildAll, options.SupportedBuildTypes()),
+	)
+
+	rootCmd.AddCommand(lintCmd)
+}
+
+func runLint() error {
+	buildTypes := []options.BuildType{options.BuildType(lintType)}
+	if lintType == string(options.BuildAll) {
+		buildTypes = options.SupportedBuildTypes()
+	}
+
+	distros := opts.Distros()
+	issues := []lintResult{}
+	for _, buildType := range buildTypes {
+		// Distro-specific templates only exist for the build type of the
+		// distribution
+		if lintType == string(options.BuildAll) && len(distros) > 0 {
+			typeDistros := []string{}
+			for _, distro := range distros {
+				if options.DistroBuildType(distro) == buildType {
+					typeDistros = append(typeDistros, distro)
+				}
+			}
+			if len(typeDistros) == 0 {
+				continue
+			}
+			opts.WithDistros(typeDistros...)
+		}
+
+		if err := opts.WithBuildType(buildType).Validate(); err != nil {
+			return err
+		}
+
+		client := kubepkg.New(opts)
+		builds, err := client.ConstructBuilds()
+		if err != nil {
+			return errors.Wrapf(err, "constructing %s builds", buildType)
+		}
+
+		typeIssues, err := client.Lint(builds)
+		if err != nil {
+			return errors.Wrapf(err, "linting %s builds", buildType)
+		}
+		for i := range typeIssues {
+			logrus.Errorf("%s: %s", buildType, typeIssues[i].String())
+			issues = append(issues, lintResult{Type: buildType, LintIssue: typeIssues[i]})
+		}
+	}
+
+	if opts.OutputFormat() == options.OutputFormatJSON {
+		if err := printJSON(issues); err != nil {
+			return err
+		}
+	}
+
+	if len(issues) > 0 {
+		return kubepkg.WithCategory(
+			errors.Errorf("found %d lint issues", len(issues)), kubepkg.ErrorCategoryValidation,
+		)
+	}
+	logrus.Info("No lint issues found")
+	return nil
+}
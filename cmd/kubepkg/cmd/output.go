@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// exitCodes are the process exit codes by error category. Errors which have
+// not been classified exit with 1.
+var exitCodes = map[kubepkg.ErrorCategory]int{
+	kubepkg.ErrorCategoryValidation: 2,
+	kubepkg.ErrorCategoryNetwork:    3,
+	kubepkg.ErrorCategoryAuth:       4,
+	kubepkg.ErrorCategoryBuild:      5,
+	kubepkg.ErrorCategoryPublish:    6,
+	kubepkg.ErrorCategorySign:       7,
+}
+
+// errorOutput is the JSON representation of a failed command.
+type errorOutput struct {
+	Error    string                `json:"error"`
+	Category kubepkg.ErrorCategory `json:"category"`
+	ExitCode int                   `json:"exitCode"`
+}
+
+// printJSON prints the indented JSON representation of v to stdout.
+func printJSON(v interface{}) error {
+	res, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshaling output")
+	}
+	fmt.Println(string(res))
+	return nil
+}
+
+// handleError logs the error of a failed command, which also gets printed as
+// JSON if enabled, and returns the exit code of its category.
+func handleError(err error) int {
+	category := kubepkg.ErrorCategoryOf(err)
+	exitCode, ok := exitCodes[category]
+	if !ok {
+		exitCode = 1
+	}
+
+	if jsonOutput || opts.OutputFormat() == options.OutputFormatJSON {
+		if err := printJSON(errorOutput{
+			Error: err.Error(), Category: category, ExitCode: exitCode,
+		}); err != nil {
+			logrus.Error(err)
+		}
+	}
+	logrus.WithField("category", category).Error(err)
+	return exitCode
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+var planType string
+
+// planCmd represents the command to print the build plan
+var planCmd = &cobra.Command{
+	Use:   "plan [--type <type>] [--arch <architectures>] [--channels <channels>]",
+	Short: "plan resolves all versions and prints the build matrix without building anything",
+	Long: `plan resolves all versions and prints the build matrix without building anything.
+
+The plan contains every package, channel and architecture combination
+including the resulting package file and the download URLs used for it. Use
+--output-format json for a machine readable plan.`,
+	Example:       "kubepkg plan --type deb --packages kubeadm --channels release",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(*cobra.Command, []string) error {
+		if err := setOptions(); err != nil {
+			return err
+		}
+		return opts.WithBuildType(options.BuildType(planType)).Validate()
+	},
+	RunE: func(*cobra.Command, []string) error {
+		return runPlan()
+	},
+}
+
+func init() {
+	planCmd.PersistentFlags().StringVar(
+		&planType,
+		"type",
+		string(options.BuildDeb),
+		"package type to plan the builds for, like deb, rpm or msi",
+	)
+
+	buildTypes := []string{}
+	for _, buildType := range options.SupportedBuildTypes() {
+		buildTypes = append(buildTypes, string(buildType))
+	}
+	if err := planCmd.RegisterFlagCompletionFunc("type", completeValues(buildTypes)); err != nil {
+		logrus.Fatal(err)
+	}
+
+	rootCmd.AddCommand(planCmd)
+}
+
+func runPlan() error {
+	client := kubepkg.New(opts)
+	builds, err := client.ConstructBuilds()
+	if err != nil {
+		return errors.Wrap(err, "constructing builds")
+	}
+
+	plan, err := client.Plan(builds)
+	if err != nil {
+		return errors.Wrap(err, "planning builds")
+	}
+
+	if opts.OutputFormat() == options.OutputFormatJSON {
+		res, err := plan.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(res))
+		return nil
+	}
+	return plan.Write(os.Stdout)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg"
+)
+
+var publishOpts = &kubepkg.PublishOptions{}
+
+// publishCmd represents the command for publishing package repositories
+var publishCmd = &cobra.Command{
+	Use:   "publish [--repo-dir <dir>] [--staging-bucket <bucket>] [--promote --production-bucket <bucket>]",
+	Short: "publish uploads package repositories to the staging bucket and promotes them",
+	Long: `publish uploads package repositories to the staging bucket and promotes them.
+
+The repository directory, for example created by "kubepkg repo apt" and
+"kubepkg repo rpm", gets synced to the staging bucket. If --promote is set,
+then the staged repositories get synced to the production bucket afterwards.
+The repository metadata has to be signed unless --allow-unsigned is set.
+
+publish runs in dry-run mode by default and only logs what would be synced.
+Use --dry-run=false to upload the repositories. gsutil has to be available
+in $PATH.`,
+	Example:       "kubepkg publish --repo-dir repo --staging-path packages/v1.22.1 --dry-run=false",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		// Publishing defaults to a dry run unless --dry-run is set explicitly
+		if !cmd.Flags().Changed("dry-run") {
+			dryRun = true
+		}
+		return setOptions()
+	},
+	RunE: func(*cobra.Command, []string) error {
+		return kubepkg.New(opts).Publish(publishOpts)
+	},
+}
+
+func init() {
+	publishCmd.PersistentFlags().StringVar(
+		&publishOpts.RepoDir,
+		"repo-dir",
+		"repo",
+		"local root directory of the package repositories",
+	)
+
+	publishCmd.PersistentFlags().StringVar(
+		&publishOpts.StagingBucket,
+		"staging-bucket",
+		kubepkg.DefaultPublishStagingBucket,
+		"GCS bucket to stage the package repositories in",
+	)
+
+	publishCmd.PersistentFlags().StringVar(
+		&publishOpts.StagingPath,
+		"staging-path",
+		kubepkg.DefaultPublishStagingPath,
+		"path within the staging bucket",
+	)
+
+	publishCmd.PersistentFlags().BoolVar(
+		&publishOpts.Promote,
+		"promote",
+		false,
+		"promote the staged package repositories to the production bucket",
+	)
+
+	publishCmd.PersistentFlags().StringVar(
+		&publishOpts.ProductionBucket,
+		"production-bucket",
+		"",
+		"GCS bucket to promote the package repositories to, required for --promote",
+	)
+
+	publishCmd.PersistentFlags().StringVar(
+		&publishOpts.ProductionPath,
+		"production-path",
+		"",
+		"path within the production bucket",
+	)
+
+	publishCmd.PersistentFlags().BoolVar(
+		&publishOpts.AllowUnsigned,
+		"allow-unsigned",
+		false,
+		"allow publishing repositories with unsigned metadata",
+	)
+
+	rootCmd.AddCommand(publishCmd)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg"
+)
+
+var aptRepoOpts = &kubepkg.AptRepoOptions{}
+
+// repoAptCmd represents the command for creating APT repositories
+var repoAptCmd = &cobra.Command{
+	Use:   "apt [--package-dir <dir>] [--repo-dir <dir>] [--suite <suite>] [--component <component>]",
+	Short: "apt creates an APT repository from built debs",
+	Long: `apt creates an APT repository from built debs.
+
+The debs get assembled into the pool and dists layout known from reprepro,
+including the Packages indexes for every architecture and the Release file.
+If --sign-key is provided, then the Release file gets signed into Release.gpg
+and InRelease. apt-ftparchive has to be available in $PATH.`,
+	Example:       "kubepkg repo apt --package-dir bin/release --repo-dir repo/apt --sign-key file:release-key.asc",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(*cobra.Command, []string) error {
+		return setOptions()
+	},
+	RunE: func(*cobra.Command, []string) error {
+		return kubepkg.New(opts).CreateAptRepo(aptRepoOpts)
+	},
+}
+
+func init() {
+	repoAptCmd.PersistentFlags().StringVar(
+		&aptRepoOpts.PackageDir,
+		"package-dir",
+		filepath.Join("bin", string(kubepkg.ChannelRelease)),
+		"directory containing the built debs",
+	)
+
+	repoAptCmd.PersistentFlags().StringVar(
+		&aptRepoOpts.RepoDir,
+		"repo-dir",
+		filepath.Join("repo", "apt"),
+		"root directory of the APT repository",
+	)
+
+	repoAptCmd.PersistentFlags().StringVar(
+		&aptRepoOpts.Suite,
+		"suite",
+		kubepkg.DefaultAptSuite,
+		"suite (distribution) of the APT repository",
+	)
+
+	repoAptCmd.PersistentFlags().StringVar(
+		&aptRepoOpts.Component,
+		"component",
+		kubepkg.DefaultAptComponent,
+		"component of the APT repository",
+	)
+
+	repoCmd.AddCommand(repoAptCmd)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg"
+)
+
+var rpmRepoOpts = &kubepkg.RpmRepoOptions{}
+
+// repoRpmCmd represents the command for creating yum repositories
+var repoRpmCmd = &cobra.Command{
+	Use:   "rpm [--package-dir <dir>] [--repo-dir <dir>]",
+	Short: "rpm creates yum repositories from built rpms",
+	Long: `rpm creates yum repositories from built rpms.
+
+Every architecture gets its own repository below --repo-dir, for example
+repo/rpm/x86_64, which contains the rpms and the repodata generated by
+createrepo_c (or createrepo as fallback). noarch rpms become part of every
+repository. If --sign-key is provided, then the repomd.xml of every
+repository gets signed into repomd.xml.asc. Run the command once per channel
+to get one set of repositories per channel.`,
+	Example:       "kubepkg repo rpm --package-dir bin/release --repo-dir repo/rpm/release --sign-key file:release-key.asc",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(*cobra.Command, []string) error {
+		return setOptions()
+	},
+	RunE: func(*cobra.Command, []string) error {
+		return kubepkg.New(opts).CreateRpmRepo(rpmRepoOpts)
+	},
+}
+
+func init() {
+	repoRpmCmd.PersistentFlags().StringVar(
+		&rpmRepoOpts.PackageDir,
+		"package-dir",
+		filepath.Join("bin", string(kubepkg.ChannelRelease)),
+		"directory containing the built rpms",
+	)
+
+	repoRpmCmd.PersistentFlags().StringVar(
+		&rpmRepoOpts.RepoDir,
+		"repo-dir",
+		filepath.Join("repo", "rpm"),
+		"root directory of the yum repositories",
+	)
+
+	repoCmd.AddCommand(repoRpmCmd)
+}
@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -25,36 +27,86 @@ import (
 
 	"k8s.io/release/pkg/kubepkg"
 	"k8s.io/release/pkg/kubepkg/options"
+	"sigs.k8s.io/release-utils/command"
 	"sigs.k8s.io/release-utils/log"
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:               "kubepkg",
-	Short:             "kubepkg",
-	PersistentPreRunE: initLogging,
+	Use:   "kubepkg",
+	Short: "kubepkg",
+	Long: `kubepkg builds packages for Kubernetes components.
+
+Every flag can also be set via an environment variable, which is the flag name
+in upper case prefixed by KUBEPKG_ and with dashes replaced by underscores,
+for example KUBEPKG_KUBE_VERSION for --kube-version. Flags take precedence
+over environment variables, which take precedence over the config file.
+
+Failures exit with a code depending on their category: 2 for invalid options
+or packages, 3 for network, 4 for authentication, 5 for build and 6 for
+publishing errors. All other errors exit with 1.`,
+	PersistentPreRunE: initCommand,
 }
 
 var (
 	opts                    *options.Options = options.New()
 	logLevel                string
-	kubeVersion             string
+	quiet                   bool
+	verbosity               int
+	kubeVersions            []string
 	packages                []string
 	channels                []string
+	channelNames            map[string]string
+	distros                 []string
 	architectures           []string
+	cniPlugins              []string
 	revision                string
 	cniVersion              string
 	criToolsVersion         string
 	releaseDownloadLinkBase string
+	kubeletCgroupDriver     string
+	kubeletExtraArgs        []string
+	kubeletEnvironmentFiles []string
 	templateDir             string
+	extraTemplateDirs       []string
 	specOnly                bool
+	releaseNotes            bool
+	outputDir               string
+	force                   bool
+	binaryDir               string
+	cacheDir                string
+	sourceDir               string
+	configFile              string
+	backend                 string
+	buildInContainer        bool
+	containerRuntime        string
+	containerImage          string
+	containerImages         map[string]string
+	concurrency             int
+	downloadConcurrency     int
+	outputFormat            string
+	jsonOutput              bool
+	interactive             bool
+	signKey                 string
+	provenance              bool
+	validatePackages        bool
+	uploadBucket            string
+	uploadPath              string
+	lintPackages            bool
+	lintFailSeverity        string
+	notifyURL               string
+	notifyFormat            string
+	logsURL                 string
+	issueRepo               string
+	issueLabels             []string
+	dryRun                  bool
 )
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		logrus.Fatal(err)
+		os.Exit(handleError(err))
 	}
 }
 
@@ -63,49 +115,76 @@ func init() {
 		&packages,
 		"packages",
 		opts.Packages(),
-		"packages to build",
+		fmt.Sprintf(
+			"packages to build, can also be a preset like %s",
+			strings.Join(options.PackagePresets(), " or "),
+		),
 	)
 
 	rootCmd.PersistentFlags().StringSliceVar(
 		&channels,
 		"channels",
 		opts.Channels(),
-		"channels to build for",
+		"channels to build for, which can also be their names set via --channel-names",
+	)
+
+	rootCmd.PersistentFlags().StringToStringVar(
+		&channelNames,
+		"channel-names",
+		opts.ChannelNames(),
+		"published names of the channels, like release=stable,nightly=unstable, where release gets built from release versions, testing from pre-releases and nightly from CI builds",
 	)
 
 	rootCmd.PersistentFlags().StringSliceVar(
 		&architectures,
 		"arch",
 		opts.Architectures(),
-		"architectures to build for",
+		"architectures to build for, riscv64 is supported but not built by default",
 	)
 
-	rootCmd.PersistentFlags().StringVar(
-		&kubeVersion,
+	rootCmd.PersistentFlags().StringSliceVar(
+		&cniPlugins,
+		"cni-plugins",
+		[]string{},
+		"CNI plugins to build individual kubernetes-cni-<plugin> packages for, in addition to kubernetes-cni",
+	)
+
+	rootCmd.PersistentFlags().StringSliceVar(
+		&distros,
+		"distros",
+		[]string{},
+		fmt.Sprintf(
+			"distributions to build distro-specific debs or rpms for instead of the generic ones, any of: %s",
+			strings.Join(options.SupportedDistros(), ", "),
+		),
+	)
+
+	rootCmd.PersistentFlags().StringSliceVar(
+		&kubeVersions,
 		"kube-version",
-		"",
-		"Kubernetes version to build",
+		[]string{},
+		"Kubernetes versions to build, can be repeated, a patch version range like 1.22.0-1.22.3 or a version marker like stable, stable-1.28, latest or ci/latest",
 	)
 
 	rootCmd.PersistentFlags().StringVar(
 		&revision,
 		"revision",
 		opts.Revision(),
-		"deb package revision.",
+		"package revision, derived from the git state of --source-dir if not set",
 	)
 
 	rootCmd.PersistentFlags().StringVar(
 		&cniVersion,
 		"cni-version",
 		opts.CNIVersion(),
-		"CNI version to build",
+		"CNI version to build, or 'latest' for the newest release (resolved from the build dependencies of the Kubernetes version if empty)",
 	)
 
 	rootCmd.PersistentFlags().StringVar(
 		&criToolsVersion,
 		"cri-tools-version",
 		opts.CRIToolsVersion(),
-		"CRI tools version to build",
+		"CRI tools version to build, or 'latest' for the newest release (resolved from the build dependencies of the Kubernetes version if empty)",
 	)
 
 	rootCmd.PersistentFlags().StringVar(
@@ -122,6 +201,13 @@ func init() {
 		"template directory",
 	)
 
+	rootCmd.PersistentFlags().StringSliceVar(
+		&extraTemplateDirs,
+		"extra-template-dirs",
+		opts.ExtraTemplateDirs(),
+		"additional template directories, whose files override the ones of the template directory (later directories take precedence)",
+	)
+
 	rootCmd.PersistentFlags().BoolVar(
 		&specOnly,
 		"spec-only",
@@ -129,30 +215,493 @@ func init() {
 		"only create specs instead of building packages",
 	)
 
+	rootCmd.PersistentFlags().StringVar(
+		&kubeletCgroupDriver,
+		"kubelet-cgroup-driver",
+		opts.Systemd().CgroupDriver,
+		"cgroup driver set in the kubelet systemd unit, either 'systemd' or 'cgroupfs' (the kubelet default is used if empty)",
+	)
+
+	rootCmd.PersistentFlags().StringArrayVar(
+		&kubeletExtraArgs,
+		"kubelet-extra-args",
+		opts.Systemd().ExtraArgs,
+		"additional kubelet arguments set in the kubelet systemd unit, can be repeated",
+	)
+
+	rootCmd.PersistentFlags().StringSliceVar(
+		&kubeletEnvironmentFiles,
+		"kubelet-environment-files",
+		opts.Systemd().EnvironmentFiles,
+		"additional optional environment files of the kubelet systemd unit",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&releaseNotes,
+		"release-notes",
+		opts.ReleaseNotes(),
+		"use the published release notes of the Kubernetes version as changelog of the kubelet, kubectl and kubeadm debs and rpms",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&outputDir,
+		"output-dir",
+		opts.OutputDir(),
+		"directory for the built packages (one subdirectory per channel) and the specs if --spec-only is set",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&force,
+		"force",
+		opts.Force(),
+		"rebuild packages which already exist in the output directory instead of skipping them",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&backend,
+		"backend",
+		string(opts.Backend()),
+		fmt.Sprintf(
+			"backend used to build the packages, either %q (dpkg-buildpackage/rpmbuild) or %q",
+			options.BackendNative, options.BackendNfpm,
+		),
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&binaryDir,
+		"binary-dir",
+		opts.BinaryDir(),
+		"local Kubernetes _output directory containing the kubelet, kubectl and kubeadm binaries to be packaged instead of downloading them (requires --kube-version)",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&cacheDir,
+		"cache-dir",
+		opts.CacheDir(),
+		"directory for caching downloads, which get verified against their published SHA256 checksums (caching is disabled if empty)",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&sourceDir,
+		"source-dir",
+		opts.SourceDir(),
+		"git repository whose tag distance and commit determine the package revision if --revision is not set, like 42.g0123456789ab",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&buildInContainer,
+		"build-in-container",
+		opts.BuildInContainer(),
+		"build the packages inside a container image for the distribution or build type",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&containerRuntime,
+		"container-runtime",
+		opts.ContainerRuntime(),
+		"container runtime used for --build-in-container, either docker or podman (detected automatically if empty)",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&containerImage,
+		"container-image",
+		opts.ContainerImage(),
+		"container image used for --build-in-container for all packages",
+	)
+
+	rootCmd.PersistentFlags().StringToStringVar(
+		&containerImages,
+		"container-images",
+		opts.ContainerImages(),
+		fmt.Sprintf(
+			"container images used for --build-in-container by distro or build type, like el9=example.com/el9-builder,deb=example.com/deb-builder (defaults to an image per distro and %s for generic debs and %s for generic rpms)",
+			kubepkg.DefaultDebBuildImage, kubepkg.DefaultRpmBuildImage,
+		),
+	)
+
+	rootCmd.PersistentFlags().IntVar(
+		&concurrency,
+		"concurrency",
+		opts.Concurrency(),
+		"maximum number of packages to be built in parallel",
+	)
+
+	rootCmd.PersistentFlags().IntVar(
+		&downloadConcurrency,
+		"download-concurrency",
+		opts.DownloadConcurrency(),
+		"maximum number of files to be downloaded in parallel across all builds, where identical files get downloaded once",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&outputFormat,
+		"output-format",
+		string(opts.OutputFormat()),
+		`format of the command output like the build summary, either "text" or "json" (printed to stdout)`,
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&jsonOutput,
+		"json",
+		false,
+		"print the output of all commands as JSON to stdout while logging to stderr, same as --output-format json",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&signKey,
+		"sign-key",
+		opts.SignKey(),
+		`GPG key for signing debs and rpms, either "file:<path>", "keyring:<id>" or "kms:<id>" (packages are not signed if empty)`,
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&provenance,
+		"provenance",
+		opts.Provenance(),
+		"write an in-toto SLSA provenance statement next to every package (signed if --sign-key is set)",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&validatePackages,
+		"validate-packages",
+		opts.ValidatePackages(),
+		"validate the name, version, architecture, maintainer and dependencies of every built deb and rpm (requires dpkg-deb or rpm)",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&lintPackages,
+		"lint-packages",
+		opts.LintPackages(),
+		"check every built deb via lintian and rpm via rpmlint inside the container image of the build type",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&lintFailSeverity,
+		"lint-fail-severity",
+		string(opts.LintFailSeverity()),
+		`lowest severity of lint findings which fails the build, either "error", "warning" or "info"`,
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&uploadBucket,
+		"upload-bucket",
+		opts.UploadBucket(),
+		"GCS bucket to upload the packages, checksums and build summary to after a successful run (nothing gets uploaded if empty)",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&uploadPath,
+		"upload-path",
+		opts.UploadPath(),
+		"path within the upload bucket (defaults to stage/<kube-version>/packages if a single Kubernetes version gets built)",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&notifyURL,
+		"notify-url",
+		opts.NotifyURL(),
+		"webhook URL to notify when the builds start and finish, including a summary of the results (notifications are disabled if empty)",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&notifyFormat,
+		"notify-format",
+		string(opts.NotifyFormat()),
+		`payload format of the notifications, either "webhook" for a JSON summary or "slack" for a Slack incoming webhook`,
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&logsURL,
+		"logs-url",
+		opts.LogsURL(),
+		"location of the build logs to be linked by the notifications, for example the URL of the CI job",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&issueRepo,
+		"issue-repo",
+		opts.IssueRepo(),
+		"GitHub repository like kubernetes/release to open or update a tracking issue in if the builds, signing or upload fail (requires $GITHUB_TOKEN, disabled if empty)",
+	)
+
+	rootCmd.PersistentFlags().StringSliceVar(
+		&issueLabels,
+		"issue-labels",
+		opts.IssueLabels(),
+		"labels of the tracking issues, which are also used to find the existing issue of a failure",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&dryRun,
+		"dry-run",
+		opts.DryRun(),
+		"rehearse the whole run without modifying any remote locations, which means that uploads, publishing, notifications and tracking issues only get logged",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&configFile,
+		"config",
+		"",
+		"YAML or JSON config file containing the options, which can be overridden by flags",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&interactive,
+		"interactive",
+		false,
+		"prompt for the Kubernetes versions, the sign key and the upload path if they have not been set",
+	)
+
 	rootCmd.PersistentFlags().StringVar(
 		&logLevel,
 		"log-level",
 		"info",
 		fmt.Sprintf("the logging verbosity, either %s", log.LevelNames()),
 	)
+
+	rootCmd.PersistentFlags().BoolVarP(
+		&quiet,
+		"quiet",
+		"q",
+		false,
+		"only log errors and omit the output of the build tools, the summary is still printed",
+	)
+
+	rootCmd.PersistentFlags().CountVarP(
+		&verbosity,
+		"verbose",
+		"v",
+		"log debug messages including all executed commands, can be repeated to log trace messages",
+	)
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return kubepkg.WithCategory(err, kubepkg.ErrorCategoryValidation)
+	})
+
+	markDeprecatedFlags()
+	registerCompletions()
+}
+
+// initCommand sets the flags from their environment variables and
+// initializes the logging.
+func initCommand(cmd *cobra.Command, args []string) error {
+	if err := bindEnv(cmd); err != nil {
+		return err
+	}
+	if err := initLogging(cmd, args); err != nil {
+		return err
+	}
+	warnDeprecatedFlags(cmd)
+	return nil
 }
 
 func initLogging(*cobra.Command, []string) error {
-	return log.SetupGlobalLogger(logLevel)
+	level, err := effectiveLogLevel()
+	if err != nil {
+		return err
+	}
+	if err := log.SetupGlobalLogger(level); err != nil {
+		return err
+	}
+	logrus.AddHook(&kubepkg.LogPrefixHook{})
+
+	// Executed commands and their output get logged on debug level, like
+	// the ones of the git operations. Their output is written to stdout,
+	// which is reserved for the JSON output if enabled.
+	command.SetGlobalVerbose(
+		logrus.IsLevelEnabled(logrus.DebugLevel) &&
+			!jsonOutput && outputFormat != string(options.OutputFormatJSON),
+	)
+	return nil
+}
+
+// effectiveLogLevel returns the log level set via --log-level, which gets
+// overridden by --quiet and --verbose.
+func effectiveLogLevel() (string, error) {
+	switch {
+	case quiet && verbosity > 0:
+		return "", kubepkg.WithCategory(
+			errors.New("--quiet and --verbose cannot be combined"),
+			kubepkg.ErrorCategoryValidation,
+		)
+	case quiet:
+		return logrus.ErrorLevel.String(), nil
+	case verbosity == 1:
+		return logrus.DebugLevel.String(), nil
+	case verbosity > 1:
+		return logrus.TraceLevel.String(), nil
+	}
+	return logLevel, nil
+}
+
+// setOptions populates the global options from the config file (if any) and
+// the command line flags. Flags which have been explicitly set always take
+// precedence over the config file values.
+func setOptions() error {
+	if configFile != "" {
+		config, err := options.LoadConfig(configFile)
+		if err != nil {
+			return kubepkg.WithCategory(
+				errors.Wrap(err, "loading config"), kubepkg.ErrorCategoryValidation,
+			)
+		}
+		opts.WithConfig(config)
+	}
+
+	isSet := func(flag string) bool {
+		return configFile == "" || rootCmd.PersistentFlags().Changed(flag)
+	}
+
+	if isSet("backend") {
+		opts.WithBackend(options.Backend(backend))
+	}
+	if isSet("packages") {
+		opts.WithPackages(packages...)
+	}
+	if isSet("channels") {
+		opts.WithChannels(channels...)
+	}
+	if isSet("channel-names") {
+		opts.WithChannelNames(channelNames)
+	}
+	if isSet("arch") {
+		opts.WithArchitectures(architectures...)
+	}
+	if isSet("distros") {
+		opts.WithDistros(distros...)
+	}
+	if isSet("cni-plugins") {
+		opts.WithCNIPlugins(cniPlugins...)
+	}
+	if isSet("kube-version") {
+		opts.WithKubeVersions(kubeVersions...)
+	}
+	if isSet("revision") {
+		opts.WithRevision(revision)
+	}
+	if isSet("cni-version") {
+		opts.WithCNIVersion(cniVersion)
+	}
+	if isSet("cri-tools-version") {
+		opts.WithCRIToolsVersion(criToolsVersion)
+	}
+	if isSet("release-download-link-base") {
+		opts.WithReleaseDownloadLinkBase(releaseDownloadLinkBase)
+	}
+	if isSet("template-dir") {
+		opts.WithTemplateDir(templateDir)
+	}
+	if isSet("extra-template-dirs") {
+		opts.WithExtraTemplateDirs(extraTemplateDirs...)
+	}
+	if isSet("spec-only") {
+		opts.WithSpecOnly(specOnly)
+	}
+	systemd := opts.Systemd()
+	if isSet("kubelet-cgroup-driver") {
+		systemd.CgroupDriver = kubeletCgroupDriver
+	}
+	if isSet("kubelet-extra-args") {
+		systemd.ExtraArgs = kubeletExtraArgs
+	}
+	if isSet("kubelet-environment-files") {
+		systemd.EnvironmentFiles = kubeletEnvironmentFiles
+	}
+	opts.WithSystemd(systemd)
+	if isSet("release-notes") {
+		opts.WithReleaseNotes(releaseNotes)
+	}
+	if isSet("output-dir") {
+		opts.WithOutputDir(outputDir)
+	}
+	if isSet("force") {
+		opts.WithForce(force)
+	}
+	if isSet("binary-dir") {
+		opts.WithBinaryDir(binaryDir)
+	}
+	if isSet("cache-dir") {
+		opts.WithCacheDir(cacheDir)
+	}
+	if isSet("source-dir") {
+		opts.WithSourceDir(sourceDir)
+	}
+	if isSet("build-in-container") {
+		opts.WithBuildInContainer(buildInContainer)
+	}
+	if isSet("container-runtime") {
+		opts.WithContainerRuntime(containerRuntime)
+	}
+	if isSet("container-image") {
+		opts.WithContainerImage(containerImage)
+	}
+	if isSet("container-images") {
+		opts.WithContainerImages(containerImages)
+	}
+	if isSet("concurrency") {
+		opts.WithConcurrency(concurrency)
+	}
+	if isSet("download-concurrency") {
+		opts.WithDownloadConcurrency(downloadConcurrency)
+	}
+	if isSet("output-format") {
+		opts.WithOutputFormat(options.OutputFormat(outputFormat))
+	}
+	if jsonOutput {
+		opts.WithOutputFormat(options.OutputFormatJSON)
+	}
+	if isSet("sign-key") {
+		opts.WithSignKey(signKey)
+	}
+	if isSet("provenance") {
+		opts.WithProvenance(provenance)
+	}
+	if isSet("validate-packages") {
+		opts.WithValidatePackages(validatePackages)
+	}
+	if isSet("lint-packages") {
+		opts.WithLintPackages(lintPackages)
+	}
+	if isSet("lint-fail-severity") {
+		opts.WithLintFailSeverity(options.LintSeverity(lintFailSeverity))
+	}
+	if isSet("upload-bucket") {
+		opts.WithUploadBucket(uploadBucket)
+	}
+	if isSet("upload-path") {
+		opts.WithUploadPath(uploadPath)
+	}
+	if isSet("notify-url") {
+		opts.WithNotifyURL(notifyURL)
+	}
+	if isSet("notify-format") {
+		opts.WithNotifyFormat(options.NotifyFormat(notifyFormat))
+	}
+	if isSet("logs-url") {
+		opts.WithLogsURL(logsURL)
+	}
+	if isSet("issue-repo") {
+		opts.WithIssueRepo(issueRepo)
+	}
+	if isSet("issue-labels") {
+		opts.WithIssueLabels(issueLabels...)
+	}
+	if isSet("dry-run") {
+		opts.WithDryRun(dryRun)
+	}
+
+	if interactive {
+		if err := promptOptions(newPrompter()); err != nil {
+			return kubepkg.WithCategory(
+				errors.Wrap(err, "prompting for options"), kubepkg.ErrorCategoryValidation,
+			)
+		}
+	}
+
+	return opts.Validate()
 }
 
 func run(buildType options.BuildType) error {
-	opts := opts.WithPackages(packages...).
-		WithChannels(channels...).
-		WithArchitectures(architectures...).
-		WithKubeVersion(kubeVersion).
-		WithRevision(revision).
-		WithCNIVersion(cniVersion).
-		WithCRIToolsVersion(criToolsVersion).
-		WithReleaseDownloadLinkBase(releaseDownloadLinkBase).
-		WithTemplateDir(templateDir).
-		WithSpecOnly(specOnly).
-		WithBuildType(buildType)
+	opts := opts.WithBuildType(buildType)
 	logrus.Debugf("Using options: %+v", opts)
 
 	client := kubepkg.New(opts)
@@ -160,5 +709,18 @@ func run(buildType options.BuildType) error {
 	if err != nil {
 		return errors.Wrap(err, "running kubepkg")
 	}
-	return client.WalkBuilds(builds)
+	walkErr := client.WalkBuilds(builds)
+
+	// The summary contains the successful builds even if others failed
+	switch opts.OutputFormat() {
+	case options.OutputFormatJSON:
+		summary, err := client.Summary().JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(summary))
+	case options.OutputFormatText:
+		fmt.Print(client.Summary().Table())
+	}
+	return walkErr
 }
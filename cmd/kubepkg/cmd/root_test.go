@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+)
+
+func TestEffectiveLogLevel(t *testing.T) {
+	prevLogLevel, prevQuiet, prevVerbosity := logLevel, quiet, verbosity
+	defer func() { logLevel, quiet, verbosity = prevLogLevel, prevQuiet, prevVerbosity }()
+
+	for _, tc := range []struct {
+		name      string
+		logLevel  string
+		quiet     bool
+		verbosity int
+		shouldErr bool
+		expected  string
+	}{
+		{
+			name:     "log level",
+			logLevel: "warn",
+			expected: "warn",
+		},
+		{
+			name:     "quiet overrides the log level",
+			logLevel: "debug",
+			quiet:    true,
+			expected: "error",
+		},
+		{
+			name:      "verbose overrides the log level",
+			logLevel:  "warn",
+			verbosity: 1,
+			expected:  "debug",
+		},
+		{
+			name:      "repeated verbose enables tracing",
+			logLevel:  "info",
+			verbosity: 3,
+			expected:  "trace",
+		},
+		{
+			name:      "quiet and verbose",
+			logLevel:  "info",
+			quiet:     true,
+			verbosity: 1,
+			shouldErr: true,
+		},
+	} {
+		logLevel, quiet, verbosity = tc.logLevel, tc.quiet, tc.verbosity
+		res, err := effectiveLogLevel()
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.name)
+			require.Equal(t, kubepkg.ErrorCategoryValidation, kubepkg.ErrorCategoryOf(err), tc.name)
+			continue
+		}
+		require.Nil(t, err, tc.name)
+		require.Equal(t, tc.expected, res, tc.name)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// validateCmd represents the command to validate the metadata of packages
+var validateCmd = &cobra.Command{
+	Use:   "validate <file>...",
+	Short: "validate inspects built debs and rpms and checks their metadata",
+	Long: `validate inspects built debs and rpms and checks their metadata.
+
+The name, version and architecture of every package have to match its file
+name, it has to be maintained by the Kubernetes Authors and it has to depend on
+all packages required by kubepkg. The fields are read via dpkg-deb for debs and
+rpm for rpms, which have to be available on the host.
+
+Packages can also be validated right after being built by setting
+--validate-packages, which additionally checks the expected versions.`,
+	Example:       "kubepkg validate bin/release/*.deb",
+	Args:          cobra.MinimumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(*cobra.Command, []string) error {
+		return setOptions()
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runValidate(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(paths []string) error {
+	results, err := kubepkg.New(opts).ValidatePackages(paths...)
+	if opts.OutputFormat() == options.OutputFormatJSON {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, result := range results {
+			status := "OK"
+			if result.Err != nil {
+				status = "FAILED"
+			}
+			fmt.Printf("%-60s %s\n", result.Path, status)
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "validating packages")
+	}
+	logrus.Info("All packages validated successfully")
+	return nil
+}
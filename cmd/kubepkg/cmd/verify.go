@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+var (
+	verifyType          string
+	verifyPackageDir    string
+	verifyArchitectures []string
+	verifySetupQEMU     bool
+)
+
+// verifyCmd represents the command to verify built packages
+var verifyCmd = &cobra.Command{
+	Use:   "verify [--type <deb|rpm>] [--package-dir <dir>] [--packages <packages>] [--verify-arch <architectures>]",
+	Short: "verify installs built packages inside distribution containers and runs smoke tests",
+	Long: fmt.Sprintf(`verify installs built packages inside distribution containers and runs smoke tests.
+
+All selected packages of the host architecture within the package directory
+get installed together on every supported distribution. Afterwards basic
+commands like "kubeadm version" or "kubectl version --client" are run to
+verify the installed binaries.
+
+Packages of foreign architectures can be verified by using --verify-arch,
+which requires QEMU user emulation registered via binfmt_misc on the host.
+The emulation gets registered automatically if --setup-qemu is set.
+
+Supported distributions for debs: %s
+Supported distributions for rpms: %s`,
+		strings.Join(kubepkg.VerifyDistros(options.BuildDeb), ", "),
+		strings.Join(kubepkg.VerifyDistros(options.BuildRpm), ", "),
+	),
+	Example:       "kubepkg verify --type deb --package-dir bin/release",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		if err := setOptions(); err != nil {
+			return err
+		}
+		// The config file value is only overridden if the flag is set
+		if configFile == "" || cmd.Flags().Changed("setup-qemu") {
+			opts.WithSetupQEMU(verifySetupQEMU)
+		}
+		return opts.WithBuildType(options.BuildType(verifyType)).Validate()
+	},
+	RunE: func(*cobra.Command, []string) error {
+		return runVerify()
+	},
+}
+
+func init() {
+	verifyCmd.PersistentFlags().StringVar(
+		&verifyType,
+		"type",
+		string(options.BuildDeb),
+		"package type to verify, either deb or rpm",
+	)
+
+	verifyCmd.PersistentFlags().StringVar(
+		&verifyPackageDir,
+		"package-dir",
+		filepath.Join("bin", string(kubepkg.ChannelRelease)),
+		"directory containing the built packages",
+	)
+
+	verifyCmd.PersistentFlags().StringSliceVar(
+		&verifyArchitectures,
+		"verify-arch",
+		[]string{runtime.GOARCH},
+		"architectures of the packages to verify, foreign architectures require QEMU user emulation",
+	)
+
+	verifyCmd.PersistentFlags().BoolVar(
+		&verifySetupQEMU,
+		"setup-qemu",
+		false,
+		fmt.Sprintf("register QEMU user emulation via %s if it is required for foreign architectures (requires privileged containers)", kubepkg.BinfmtImage),
+	)
+
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify() error {
+	results, err := kubepkg.New(opts).Verify(verifyPackageDir, verifyArchitectures...)
+	if opts.OutputFormat() == options.OutputFormatJSON {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, result := range results {
+			status := "OK"
+			if result.Err != nil {
+				status = "FAILED"
+			}
+			fmt.Printf("%-40s %-8s %s\n", result.Distro, result.Arch, status)
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "verifying packages")
+	}
+	logrus.Info("All packages verified successfully")
+	return nil
+}
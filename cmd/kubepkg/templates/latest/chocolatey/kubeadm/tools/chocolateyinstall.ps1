@@ -0,0 +1,17 @@
+$ErrorActionPreference = 'Stop'
+
+$toolsDir = "$(Split-Path -parent $MyInvocation.MyCommand.Definition)"
+
+$packageArgs = @{
+  packageName    = 'kubeadm'
+  fileFullPath   = Join-Path $toolsDir 'kubeadm.exe'
+{{- range .WindowsInstallers }}
+{{- if eq .Architecture "x64" }}
+  url64bit       = '{{ .URL }}'
+  checksum64     = '{{ .SHA256 }}'
+  checksumType64 = 'sha256'
+{{- end }}
+{{- end }}
+}
+
+Get-ChocolateyWebFile @packageArgs
@@ -40,6 +40,16 @@ install -p -m 755 -t %{buildroot}%{_bindir}/ cri-tools/crictl
 #%doc add-docs-here
 
 
+{{ with .Hooks.PostInstall -}}
+%post
+{{ . }}
+
+{{ end -}}
+{{ with .Hooks.PreRemove -}}
+%preun
+{{ . }}
+
+{{ end -}}
 %changelog
 * Sat Jan  4 2020 Stephen Augustus <saugustus@vmware.com> - 1.18.0
 - Create separate spec file for cri-tools
@@ -50,6 +50,22 @@ install -p -m 644 -t %{buildroot}%{_unitdir}/kubelet.service.d/ 10-kubeadm.conf
 #%doc add-docs-here
 
 
+{{ with .Hooks.PostInstall -}}
+%post
+{{ . }}
+
+{{ end -}}
+{{ with .Hooks.PreRemove -}}
+%preun
+{{ . }}
+
+{{ end -}}
 %changelog
+{{- with .ReleaseNotes }}
+* {{ rpmDate }} Kubernetes Authors <kubernetes-dev@googlegroups.com> - {{ $.Version }}-{{ $.Revision }}
+{{- range . }}
+- {{ . }}
+{{- end }}
+{{ end }}
 * Sat Jan  4 2020 Stephen Augustus <saugustus@vmware.com> - 1.18.0
 - Create separate spec file for kubeadm
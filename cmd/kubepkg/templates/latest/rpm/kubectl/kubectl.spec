@@ -40,6 +40,22 @@ install -p -m 755 -t %{buildroot}%{_bindir}/ kubectl
 #%doc add-docs-here
 
 
+{{ with .Hooks.PostInstall -}}
+%post
+{{ . }}
+
+{{ end -}}
+{{ with .Hooks.PreRemove -}}
+%preun
+{{ . }}
+
+{{ end -}}
 %changelog
+{{- with .ReleaseNotes }}
+* {{ rpmDate }} Kubernetes Authors <kubernetes-dev@googlegroups.com> - {{ $.Version }}-{{ $.Revision }}
+{{- range . }}
+- {{ . }}
+{{- end }}
+{{ end }}
 * Sat Jan  4 2020 Stephen Augustus <saugustus@vmware.com> - 1.18.0
 - Create separate spec file for kubectl
@@ -15,8 +15,8 @@ Requires: socat
 Requires: util-linux
 Requires: ethtool
 Requires: iproute
-Requires: ebtables
-Requires: conntrack
+Requires: {{ .DependencyName "ebtables" }}
+Requires: {{ .DependencyName "conntrack" }}{{ with index .Dependencies "conntrack" }} >= {{ . }}{{ end }}
 
 %description
 The node agent of Kubernetes, the container cluster manager.
@@ -58,7 +58,23 @@ install -p -m 644 -T kubelet.env %{buildroot}%{_sysconfdir}/sysconfig/kubelet
 #%doc add-docs-here
 
 
+{{ with .Hooks.PostInstall -}}
+%post
+{{ . }}
+
+{{ end -}}
+{{ with .Hooks.PreRemove -}}
+%preun
+{{ . }}
+
+{{ end -}}
 %changelog
+{{- with .ReleaseNotes }}
+* {{ rpmDate }} Kubernetes Authors <kubernetes-dev@googlegroups.com> - {{ $.Version }}-{{ $.Revision }}
+{{- range . }}
+- {{ . }}
+{{- end }}
+{{ end }}
 * Mon Jun 22 2020 Stephen Augustus <saugustus@vmware.com> - 1.18.4
 - Unbundle CNI plugins (v0.8.6) from kubelet package and release as kubernetes-cni
 
@@ -43,6 +43,16 @@ mv cni-plugins/* %{buildroot}/opt/cni/bin/
 #%doc add-docs-here
 
 
+{{ with .Hooks.PostInstall -}}
+%post
+{{ . }}
+
+{{ end -}}
+{{ with .Hooks.PreRemove -}}
+%preun
+{{ . }}
+
+{{ end -}}
 %changelog
 * Sat Jan  4 2020 Stephen Augustus <saugustus@vmware.com> - 1.18.0
 - Create separate spec file for kubernetes-cni
@@ -58,6 +58,11 @@ to the asset file:
 
   --asset="_output/kubernetes-1.18.2-2.fc33.x86_64.rpm:RPM Package for amd64"
 
+The SHA256SUMS and SHA512SUMS files of all assets get uploaded as well. If a
+GPG key is specified with the --sign-key flag, then detached signatures
+(.asc) of all assets and checksum files get uploaded, too. Every asset gets
+downloaded again after its upload to verify its checksum.
+
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Run the PR creation function
@@ -73,6 +78,7 @@ type githubPageCmdLineOptions struct {
 	template      string
 	substitutions []string
 	assets        []string
+	signKey       string
 }
 
 var ghPageOpts = &githubPageCmdLineOptions{}
@@ -124,6 +130,12 @@ func init() {
 		false,
 		"Mark the release as a draft in GitHub so you can finish editing and publish it manually.",
 	)
+	githuPageCmd.PersistentFlags().StringVar(
+		&ghPageOpts.signKey,
+		"sign-key",
+		"",
+		"GPG key ID used to sign the assets and checksum files",
+	)
 	rootCmd.AddCommand(githuPageCmd)
 }
 
@@ -136,6 +148,7 @@ func runGithubPage(opts *githubPageCmdLineOptions) error {
 		UpdateIfReleaseExists: !opts.noupdate,
 		Name:                  opts.name,
 		Draft:                 opts.draft,
+		SignKey:               opts.signKey,
 	}
 
 	// Assign the repository data
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "List merged pull requests without a usable release note",
+	Long: `release-notes check
+
+Lists the pull requests merged in the revision range whose release-note block
+is missing, malformed or "NONE" despite labels indicating a user facing change,
+like kind/feature or kind/api-change.
+
+The report is a markdown checklist mentioning the pull request authors, which
+can be used to ping them before the release cut. If --format is json, then the
+list is written as JSON instead.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runCheck,
+	PreRunE: func(*cobra.Command, []string) error {
+		return opts.ValidateAndFinish()
+	},
+}
+
+func init() {
+	cmd.AddCommand(checkCmd)
+}
+
+func runCheck(*cobra.Command, []string) error {
+	gatherer, err := notes.NewGatherer(context.Background(), opts)
+	if err != nil {
+		return errors.Wrap(err, "retrieving notes gatherer")
+	}
+
+	missing, err := gatherer.ListMissingReleaseNotes()
+	if err != nil {
+		return errors.Wrap(err, "listing missing release notes")
+	}
+	logrus.Infof("Found %d pull requests without a usable release note", len(missing))
+
+	var report []byte
+	if opts.Format == options.FormatJSON {
+		report, err = json.MarshalIndent(missing, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "encoding JSON report")
+		}
+		report = append(report, '\n')
+	} else {
+		report = []byte(notes.MissingNotesReport(missing))
+	}
+
+	if releaseNotesOpts.outputFile == "" {
+		_, err := os.Stdout.Write(report)
+		return errors.Wrap(err, "writing report")
+	}
+	if err := os.WriteFile(
+		releaseNotesOpts.outputFile, report, os.FileMode(0o644),
+	); err != nil {
+		return errors.Wrap(err, "writing report file")
+	}
+	logrus.Infof("Report written to file: %s", releaseNotesOpts.outputFile)
+	return nil
+}
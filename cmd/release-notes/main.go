@@ -161,6 +161,7 @@ func init() {
 		fmt.Sprintf("The format for notes output (options: %s)",
 			strings.Join([]string{
 				options.FormatJSON,
+				options.FormatJSONV1,
 				options.FormatMarkdown,
 			}, ", "),
 		),
@@ -174,6 +175,7 @@ func init() {
 		fmt.Sprintf("The go template to be used if --format=markdown (options: %s)",
 			strings.Join([]string{
 				options.GoTemplateDefault,
+				options.GoTemplateSIG,
 				options.GoTemplateInline + "<template>",
 				options.GoTemplatePrefix + "<file.template>",
 			}, ", "),
@@ -187,6 +189,41 @@ func init() {
 		"Only commits from this GitHub user are considered. Set to empty string to include all users",
 	)
 
+	cmd.PersistentFlags().StringSliceVar(
+		&opts.Filter.SIGs,
+		"sig",
+		[]string{},
+		"Only include notes of these SIGs, like node or sig/node. Can be specified multiple times",
+	)
+
+	cmd.PersistentFlags().StringSliceVar(
+		&opts.Filter.Kinds,
+		"kind",
+		[]string{},
+		"Only include notes of these kinds, like feature or kind/feature. Can be specified multiple times",
+	)
+
+	cmd.PersistentFlags().StringSliceVar(
+		&opts.Filter.Areas,
+		"area",
+		[]string{},
+		"Only include notes of these areas, like kubelet or area/kubelet. Can be specified multiple times",
+	)
+
+	cmd.PersistentFlags().StringSliceVar(
+		&opts.Filter.Authors,
+		"author",
+		[]string{},
+		"Only include notes of pull requests by these GitHub users. Can be specified multiple times",
+	)
+
+	cmd.PersistentFlags().StringSliceVar(
+		&opts.Filter.Labels,
+		"label",
+		[]string{},
+		"Only include notes of pull requests with these labels. Can be specified multiple times",
+	)
+
 	cmd.PersistentFlags().BoolVar(
 		&opts.Debug,
 		"debug",
@@ -243,6 +280,13 @@ func init() {
 		"Replay a previously recorded API from a directory",
 	)
 
+	cmd.PersistentFlags().StringVar(
+		&opts.CacheDir,
+		"cache-dir",
+		env.Default("CACHE_DIR", ""),
+		"Cache the pull requests of commits in a directory, which speeds up subsequent runs",
+	)
+
 	cmd.PersistentFlags().BoolVar(
 		&releaseNotesOpts.dependencies,
 		"dependencies",
@@ -263,6 +307,12 @@ func init() {
 		false,
 		"enable experimental implementation to list commits (ListReleaseNotesV2)",
 	)
+	cmd.PersistentFlags().BoolVar(
+		&opts.GraphQL,
+		"graphql",
+		false,
+		"retrieve the commits and pull requests in bulk via the GitHub GraphQL API, which is much faster for big releases",
+	)
 }
 
 func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
@@ -323,6 +373,19 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 		if err := enc.Encode(releaseNotes.ByPR()); err != nil {
 			return errors.Wrapf(err, "encoding JSON output")
 		}
+	} else if opts.Format == options.FormatJSONV1 {
+		// The versioned JSON describes a single revision range, which is why
+		// existing notes are not merged but replaced
+		j, err := releaseNotes.JSON(opts.StartRev, opts.EndRev)
+		if err != nil {
+			return errors.Wrap(err, "generating versioned JSON output")
+		}
+		if err := output.Truncate(0); err != nil {
+			return errors.Wrap(err, "truncating output file")
+		}
+		if _, err := output.Write(append(j, '\n')); err != nil {
+			return errors.Wrap(err, "writing output file")
+		}
 	} else {
 		doc, err := document.New(releaseNotes, opts.StartRev, opts.EndRev)
 		if err != nil {
@@ -22,9 +22,11 @@ import (
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/schedule"
 )
 
-func parseSchedule(patchSchedule PatchSchedule) string {
+func parseSchedule(patchSchedule *schedule.PatchSchedule) string {
 	output := []string{}
 	output = append(output, "### Timeline\n")
 	for _, releaseSchedule := range patchSchedule.Schedules {
@@ -60,7 +62,7 @@ func parseSchedule(patchSchedule PatchSchedule) string {
 	return scheduleOut
 }
 
-func patchReleaseInPreviousList(a string, previousPatches []PreviousPatches) bool {
+func patchReleaseInPreviousList(a string, previousPatches []schedule.PreviousPatches) bool {
 	for _, b := range previousPatches {
 		if b.Release == a {
 			return true
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/schedule"
 )
 
 const expectedPatchSchedule = `### Timeline
@@ -41,19 +43,19 @@ End of Life for **X.Y** is **NOW**
 func TestParseSchedule(t *testing.T) {
 	testcases := []struct {
 		name     string
-		schedule PatchSchedule
+		schedule schedule.PatchSchedule
 	}{
 		{
 			name: "next patch is not in previous patch list",
-			schedule: PatchSchedule{
-				Schedules: []Schedule{
+			schedule: schedule.PatchSchedule{
+				Schedules: []schedule.Schedule{
 					{
 						Release:            "X.Y",
 						Next:               "X.Y.ZZZ",
 						CherryPickDeadline: "2020-06-12",
 						TargetDate:         "2020-06-17",
 						EndOfLifeDate:      "NOW",
-						PreviousPatches: []PreviousPatches{
+						PreviousPatches: []schedule.PreviousPatches{
 							{
 								Release:            "X.Y.XXX",
 								CherryPickDeadline: "2020-05-15",
@@ -72,15 +74,15 @@ func TestParseSchedule(t *testing.T) {
 		},
 		{
 			name: "next patch is in previous patch list",
-			schedule: PatchSchedule{
-				Schedules: []Schedule{
+			schedule: schedule.PatchSchedule{
+				Schedules: []schedule.Schedule{
 					{
 						Release:            "X.Y",
 						Next:               "X.Y.ZZZ",
 						CherryPickDeadline: "2020-06-12",
 						TargetDate:         "2020-06-17",
 						EndOfLifeDate:      "NOW",
-						PreviousPatches: []PreviousPatches{
+						PreviousPatches: []schedule.PreviousPatches{
 							{
 								Release:            "X.Y.ZZZ",
 								CherryPickDeadline: "2020-06-12",
@@ -106,7 +108,7 @@ func TestParseSchedule(t *testing.T) {
 
 	for _, tc := range testcases {
 		fmt.Printf("Test case: %s\n", tc.name)
-		out := parseSchedule(tc.schedule)
+		out := parseSchedule(&tc.schedule)
 		require.Equal(t, out, expectedPatchSchedule)
 	}
 }
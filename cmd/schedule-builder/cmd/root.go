@@ -24,8 +24,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"k8s.io/release/pkg/schedule"
 	"sigs.k8s.io/release-utils/log"
-	"sigs.k8s.io/yaml"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -104,18 +104,10 @@ func run(opts *options) error {
 		return errors.Wrap(err, "validating schedule-path options")
 	}
 
-	logrus.Infof("Reading the schedule file %s...", opts.configPath)
-	data, err := os.ReadFile(opts.configPath)
-	if err != nil {
-		return errors.Wrap(err, "failed to read the file")
-	}
-
-	var patchSchedule PatchSchedule
-
 	logrus.Info("Parsing the schedule...")
-	err = yaml.UnmarshalStrict(data, &patchSchedule)
+	patchSchedule, err := schedule.ParseFile(opts.configPath)
 	if err != nil {
-		return errors.Wrap(err, "failed to decode the file")
+		return errors.Wrap(err, "parsing the schedule")
 	}
 
 	logrus.Info("Generating the markdown output...")
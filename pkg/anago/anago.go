@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/announce"
 	"k8s.io/release/pkg/git"
 	"k8s.io/release/pkg/release"
 	"k8s.io/release/pkg/version"
@@ -53,10 +54,10 @@ const (
 // Options are settings which will be used by `StageOptions` as well as
 // `ReleaseOptions`.
 type Options struct {
-	// Run the whole process in non-mocked mode. Which means that it uses
+	// Mode determines if the whole process runs in mock mode or uses the
 	// production remote locations for storing artifacts and modifying git
 	// repositories.
-	NoMock bool
+	Mode release.Mode
 
 	// The release type which should be produced. Can be either `alpha`,
 	// `beta`, `rc` or `official`.
@@ -69,11 +70,23 @@ type Options struct {
 	// The build version to be released. Has to be specified in the format:
 	// `vX.Y.Z-[alpha|beta|rc].N.C+SHA`
 	BuildVersion string
+
+	// StateFile is the path to a file which records the completed steps of
+	// the process. Rerunning the process with the same options and state file
+	// resumes it after the last completed step. The recording is disabled if
+	// empty.
+	StateFile string
+
+	// SlackWebhooks are the incoming webhook URLs of the Slack channels
+	// which get notified about staged and released versions. Messages are
+	// only posted in nomock mode.
+	SlackWebhooks []string
 }
 
 // DefaultOptions returns a new Options instance.
 func DefaultOptions() *Options {
 	return &Options{
+		Mode:          release.ModeMock,
 		ReleaseType:   release.ReleaseTypeAlpha,
 		ReleaseBranch: git.DefaultBranch,
 	}
@@ -82,8 +95,8 @@ func DefaultOptions() *Options {
 // String returns a string representation for the `ReleaseOptions` type.
 func (o *Options) String() string {
 	return fmt.Sprintf(
-		"NoMock: %v, ReleaseType: %q, BuildVersion: %q, ReleaseBranch: %q",
-		o.NoMock, o.ReleaseType, o.BuildVersion, o.ReleaseBranch,
+		"Mode: %q, ReleaseType: %q, BuildVersion: %q, ReleaseBranch: %q",
+		o.Mode, o.ReleaseType, o.BuildVersion, o.ReleaseBranch,
 	)
 }
 
@@ -91,6 +104,10 @@ func (o *Options) String() string {
 func (o *Options) Validate(state *State) error {
 	logrus.Infof("Validating generic options: %s", o.String())
 
+	if err := o.Mode.Validate(); err != nil {
+		return err
+	}
+
 	if o.ReleaseType != release.ReleaseTypeAlpha &&
 		o.ReleaseType != release.ReleaseTypeBeta &&
 		o.ReleaseType != release.ReleaseTypeRC &&
@@ -120,20 +137,22 @@ func (o *Options) Validate(state *State) error {
 	return nil
 }
 
+// slackOptions returns the options for posting Slack messages.
+func (o *Options) slackOptions() *announce.SlackOptions {
+	return &announce.SlackOptions{
+		Webhooks: o.SlackWebhooks,
+		NoMock:   o.Mode.IsNoMock(),
+	}
+}
+
 // Bucket returns the Google Cloud Bucket for these `Options`.
 func (o *Options) Bucket() string {
-	if o.NoMock {
-		return release.ProductionBucket
-	}
-	return release.TestBucket
+	return o.Mode.Bucket()
 }
 
 // ContainerRegistry returns the container registry for these `Options`.
 func (o *Options) ContainerRegistry() string {
-	if o.NoMock {
-		return release.GCRIOPathStaging
-	}
-	return release.GCRIOPathMock
+	return o.Mode.ContainerRegistry()
 }
 
 // State holds all inferred and calculated values from the release process
@@ -183,6 +202,16 @@ func DefaultStageState() *StageState {
 // StageOptions contains the options for running `Stage`.
 type StageOptions struct {
 	*Options
+
+	// HealthGate refuses to stage the release if the release blocking
+	// dashboard of the release branch contains failing jobs.
+	HealthGate bool
+
+	// AllowFlaky does not let flaky jobs fail the health gate.
+	AllowFlaky bool
+
+	// IgnoredTabs are the dashboard tabs which never fail the health gate.
+	IgnoredTabs []string
 }
 
 // DefaultStageOptions createa a new default `StageOptions`.
@@ -207,12 +236,13 @@ func (s *StageOptions) Validate(state *State) error {
 
 // Stage is the structure to be used for staging releases.
 type Stage struct {
-	client stageClient
+	client  stageClient
+	options *StageOptions
 }
 
 // NewStage creates a new `Stage` instance.
 func NewStage(options *StageOptions) *Stage {
-	return &Stage{NewDefaultStage(options)}
+	return &Stage{NewDefaultStage(options), options}
 }
 
 // SetClient can be used to set the internal stage client.
@@ -257,43 +287,55 @@ func (s *Stage) Run() error {
 		return errors.Wrap(err, "check release branch state")
 	}
 
+	if s.options.HealthGate {
+		logger.Info("Checking release health")
+		if err := s.client.CheckReleaseHealth(); err != nil {
+			return errors.Wrap(err, "check release health")
+		}
+	}
+
 	logger.WithStep().Info("Generating release version")
 	if err := s.client.GenerateReleaseVersion(); err != nil {
 		return errors.Wrap(err, "generate release version")
 	}
 
+	cp, err := release.NewCheckpoint(s.options.StateFile, s.options.String())
+	if err != nil {
+		return errors.Wrap(err, "load state file")
+	}
+
 	logger.WithStep().Info("Preparing workspace")
-	if err := s.client.PrepareWorkspace(); err != nil {
+	if err := cp.Run("prepare workspace", s.client.PrepareWorkspace); err != nil {
 		return errors.Wrap(err, "prepare workspace")
 	}
 
 	logger.WithStep().Info("Tagging repository")
-	if err := s.client.TagRepository(); err != nil {
+	if err := cp.Run("tag repository", s.client.TagRepository); err != nil {
 		return errors.Wrap(err, "tag repository")
 	}
 
 	logger.WithStep().Info("Building release")
-	if err := s.client.Build(); err != nil {
+	if err := cp.Run("build release", s.client.Build); err != nil {
 		return errors.Wrap(err, "build release")
 	}
 
 	logger.WithStep().Info("Generating changelog")
-	if err := s.client.GenerateChangelog(); err != nil {
+	if err := cp.Run("generate changelog", s.client.GenerateChangelog); err != nil {
 		return errors.Wrap(err, "generate changelog")
 	}
 
 	logger.WithStep().Info("Verifying artifacts")
-	if err := s.client.VerifyArtifacts(); err != nil {
-		return errors.Wrap(err, "verifying artifacts")
+	if err := cp.Run("verify artifacts", s.client.VerifyArtifacts); err != nil {
+		return errors.Wrap(err, "verify artifacts")
 	}
 
 	logger.WithStep().Info("Generating bill of materials")
-	if err := s.client.GenerateBillOfMaterials(); err != nil {
-		return errors.Wrap(err, "generating sbom")
+	if err := cp.Run("generate sbom", s.client.GenerateBillOfMaterials); err != nil {
+		return errors.Wrap(err, "generate sbom")
 	}
 
 	logger.WithStep().Info("Staging artifacts")
-	if err := s.client.StageArtifacts(); err != nil {
+	if err := cp.Run("stage release artifacts", s.client.StageArtifacts); err != nil {
 		return errors.Wrap(err, "stage release artifacts")
 	}
 
@@ -340,12 +382,13 @@ func (r *ReleaseOptions) Validate(state *State) error {
 
 // Release is the structure to be used for releasing staged releases.
 type Release struct {
-	client releaseClient
+	client  releaseClient
+	options *ReleaseOptions
 }
 
 // NewRelease creates a new `Release` instance.
 func NewRelease(options *ReleaseOptions) *Release {
-	return &Release{NewDefaultRelease(options)}
+	return &Release{NewDefaultRelease(options), options}
 }
 
 // SetClient can be used to set the internal stage client.
@@ -394,33 +437,38 @@ func (r *Release) Run() error {
 		return errors.Wrap(err, "generate release version")
 	}
 
+	cp, err := release.NewCheckpoint(r.options.StateFile, r.options.String())
+	if err != nil {
+		return errors.Wrap(err, "load state file")
+	}
+
 	logger.WithStep().Info("Preparing workspace")
-	if err := r.client.PrepareWorkspace(); err != nil {
+	if err := cp.Run("prepare workspace", r.client.PrepareWorkspace); err != nil {
 		return errors.Wrap(err, "prepare workspace")
 	}
 
 	logger.WithStep().Info("Pushing artifacts")
-	if err := r.client.PushArtifacts(); err != nil {
+	if err := cp.Run("push artifacts", r.client.PushArtifacts); err != nil {
 		return errors.Wrap(err, "push artifacts")
 	}
 
 	logger.WithStep().Info("Pushing git objects")
-	if err := r.client.PushGitObjects(); err != nil {
+	if err := cp.Run("push git objects", r.client.PushGitObjects); err != nil {
 		return errors.Wrap(err, "push git objects")
 	}
 
 	logger.WithStep().Info("Creating announcement")
-	if err := r.client.CreateAnnouncement(); err != nil {
+	if err := cp.Run("create announcement", r.client.CreateAnnouncement); err != nil {
 		return errors.Wrap(err, "create announcement")
 	}
 
 	logger.WithStep().Info("Updating GitHub release page")
-	if err := r.client.UpdateGitHubPage(); err != nil {
-		return errors.Wrap(err, "updating github page")
+	if err := cp.Run("update github page", r.client.UpdateGitHubPage); err != nil {
+		return errors.Wrap(err, "update github page")
 	}
 
 	logger.WithStep().Info("Archiving release")
-	if err := r.client.Archive(); err != nil {
+	if err := cp.Run("archive release", r.client.Archive); err != nil {
 		return errors.Wrap(err, "archive release")
 	}
 
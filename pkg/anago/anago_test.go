@@ -18,6 +18,8 @@ package anago_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -130,6 +132,31 @@ func TestRunStage(t *testing.T) {
 	}
 }
 
+func TestRunStageHealthGate(t *testing.T) {
+	// Given
+	opts := anago.DefaultStageOptions()
+	sut := anago.NewStage(opts)
+	mock := &anagofakes.FakeStageClient{}
+	mock.CheckReleaseHealthReturns(err)
+	sut.SetClient(mock)
+
+	// When the health gate is disabled
+	err := sut.Run()
+
+	// Then
+	require.Nil(t, err)
+	require.Zero(t, mock.CheckReleaseHealthCallCount())
+
+	// When the health gate is enabled
+	opts.HealthGate = true
+	err = sut.Run()
+
+	// Then
+	require.NotNil(t, err)
+	require.Equal(t, 1, mock.CheckReleaseHealthCallCount())
+	require.Equal(t, 1, mock.TagRepositoryCallCount())
+}
+
 func TestRunRelease(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeReleaseClient)
@@ -222,6 +249,100 @@ func TestRunRelease(t *testing.T) {
 	}
 }
 
+func TestRunStageResume(t *testing.T) {
+	// Given
+	opts := anago.DefaultStageOptions()
+	opts.StateFile = filepath.Join(t.TempDir(), "state.json")
+	sut := anago.NewStage(opts)
+	mock := &anagofakes.FakeStageClient{}
+	mock.BuildReturns(err)
+	sut.SetClient(mock)
+
+	// When
+	require.NotNil(t, sut.Run())
+
+	// Then
+	require.Equal(t, 1, mock.TagRepositoryCallCount())
+	require.FileExists(t, opts.StateFile)
+
+	// When the run gets resumed
+	mock = &anagofakes.FakeStageClient{}
+	sut.SetClient(mock)
+	require.Nil(t, sut.Run())
+
+	// Then
+	require.Equal(t, 1, mock.GenerateReleaseVersionCallCount())
+	require.Zero(t, mock.PrepareWorkspaceCallCount())
+	require.Zero(t, mock.TagRepositoryCallCount())
+	require.Equal(t, 1, mock.BuildCallCount())
+	require.Equal(t, 1, mock.StageArtifactsCallCount())
+	state, err := os.ReadFile(opts.StateFile)
+	require.Nil(t, err)
+	require.Contains(t, string(state), `"verify artifacts"`)
+	require.Contains(t, string(state), `"generate sbom"`)
+
+	// When the run gets resumed again
+	require.Nil(t, sut.Run())
+
+	// Then
+	require.Equal(t, 1, mock.BuildCallCount())
+	require.Equal(t, 1, mock.StageArtifactsCallCount())
+
+	// When the options differ
+	opts.BuildVersion = testVersionTag
+	require.NotNil(t, sut.Run())
+
+	// Then
+	require.Equal(t, 1, mock.BuildCallCount())
+}
+
+func TestRunReleaseResume(t *testing.T) {
+	// Given
+	opts := anago.DefaultReleaseOptions()
+	opts.StateFile = filepath.Join(t.TempDir(), "state.json")
+	sut := anago.NewRelease(opts)
+	mock := &anagofakes.FakeReleaseClient{}
+	mock.CreateAnnouncementReturns(err)
+	sut.SetClient(mock)
+
+	// When
+	require.NotNil(t, sut.Run())
+
+	// Then
+	require.Equal(t, 1, mock.PushGitObjectsCallCount())
+
+	// When the run gets resumed
+	mock = &anagofakes.FakeReleaseClient{}
+	sut.SetClient(mock)
+	require.Nil(t, sut.Run())
+
+	// Then
+	require.Zero(t, mock.PushArtifactsCallCount())
+	require.Zero(t, mock.PushGitObjectsCallCount())
+	require.Equal(t, 1, mock.CreateAnnouncementCallCount())
+	require.Equal(t, 1, mock.ArchiveCallCount())
+	state, err := os.ReadFile(opts.StateFile)
+	require.Nil(t, err)
+	require.Contains(t, string(state), `"update github page"`)
+}
+
+func TestRunStageStateFileFailure(t *testing.T) {
+	// Given
+	opts := anago.DefaultStageOptions()
+	opts.StateFile = filepath.Join(t.TempDir(), "state.json")
+	require.Nil(t, os.WriteFile(opts.StateFile, []byte("invalid"), 0o644))
+	sut := anago.NewStage(opts)
+	mock := &anagofakes.FakeStageClient{}
+	sut.SetClient(mock)
+
+	// When
+	err := sut.Run()
+
+	// Then
+	require.NotNil(t, err)
+	require.Zero(t, mock.PrepareWorkspaceCallCount())
+}
+
 func TestValidateOptions(t *testing.T) {
 	for _, tc := range []struct {
 		provided    *anago.Options
@@ -229,20 +350,31 @@ func TestValidateOptions(t *testing.T) {
 	}{
 		{ // success
 			provided: &anago.Options{
+				Mode:          release.ModeMock,
 				ReleaseType:   release.ReleaseTypeAlpha,
 				ReleaseBranch: git.DefaultBranch,
 				BuildVersion:  "v1.20.0-beta.1.203+8f6ffb24df9896",
 			},
 			shouldError: false,
 		},
+		{ // invalid mode
+			provided: &anago.Options{
+				ReleaseType:   release.ReleaseTypeAlpha,
+				ReleaseBranch: git.DefaultBranch,
+				BuildVersion:  "v1.20.0-beta.1.203+8f6ffb24df9896",
+			},
+			shouldError: true,
+		},
 		{ // invalid release type
 			provided: &anago.Options{
+				Mode:        release.ModeMock,
 				ReleaseType: "invalid",
 			},
 			shouldError: true,
 		},
 		{ // invalid release branch
 			provided: &anago.Options{
+				Mode:          release.ModeMock,
 				ReleaseType:   release.ReleaseTypeAlpha,
 				ReleaseBranch: "invalid",
 			},
@@ -250,6 +382,7 @@ func TestValidateOptions(t *testing.T) {
 		},
 		{ // invalid build version
 			provided: &anago.Options{
+				Mode:          release.ModeMock,
 				ReleaseType:   release.ReleaseTypeAlpha,
 				ReleaseBranch: git.DefaultBranch,
 				BuildVersion:  "invalid",
@@ -300,6 +433,24 @@ func TestSubmitStage(t *testing.T) {
 	}
 }
 
+func TestSubmitStageHealthGate(t *testing.T) {
+	// Given
+	opts := anago.DefaultStageOptions()
+	opts.HealthGate = true
+	sut := anago.NewStage(opts)
+	mock := &anagofakes.FakeStageClient{}
+	mock.CheckReleaseHealthReturns(err)
+	sut.SetClient(mock)
+
+	// When
+	err := sut.Submit(false)
+
+	// Then the gate is left to the stage job
+	require.Nil(t, err)
+	require.Zero(t, mock.CheckReleaseHealthCallCount())
+	require.Equal(t, 1, mock.SubmitCallCount())
+}
+
 func TestSubmitRelease(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeReleaseClient)
@@ -167,6 +167,18 @@ type FakeReleaseImpl struct {
 		result1 string
 		result2 error
 	}
+	PostSlackMessageStub        func(*announce.SlackOptions, string) error
+	postSlackMessageMutex       sync.RWMutex
+	postSlackMessageArgsForCall []struct {
+		arg1 *announce.SlackOptions
+		arg2 string
+	}
+	postSlackMessageReturns struct {
+		result1 error
+	}
+	postSlackMessageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PrepareWorkspaceReleaseStub        func(string, string) error
 	prepareWorkspaceReleaseMutex       sync.RWMutex
 	prepareWorkspaceReleaseArgsForCall []struct {
@@ -291,6 +303,30 @@ type FakeReleaseImpl struct {
 	validateImagesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateStagedArtifactsStub        func(string, string, string) error
+	validateStagedArtifactsMutex       sync.RWMutex
+	validateStagedArtifactsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	validateStagedArtifactsReturns struct {
+		result1 error
+	}
+	validateStagedArtifactsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VerifyBranchProtectionStub        func(string) error
+	verifyBranchProtectionMutex       sync.RWMutex
+	verifyBranchProtectionArgsForCall []struct {
+		arg1 string
+	}
+	verifyBranchProtectionReturns struct {
+		result1 error
+	}
+	verifyBranchProtectionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -980,6 +1016,68 @@ func (fake *FakeReleaseImpl) NormalizePathReturnsOnCall(i int, result1 string, r
 	}{result1, result2}
 }
 
+func (fake *FakeReleaseImpl) PostSlackMessage(arg1 *announce.SlackOptions, arg2 string) error {
+	fake.postSlackMessageMutex.Lock()
+	ret, specificReturn := fake.postSlackMessageReturnsOnCall[len(fake.postSlackMessageArgsForCall)]
+	fake.postSlackMessageArgsForCall = append(fake.postSlackMessageArgsForCall, struct {
+		arg1 *announce.SlackOptions
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PostSlackMessageStub
+	fakeReturns := fake.postSlackMessageReturns
+	fake.recordInvocation("PostSlackMessage", []interface{}{arg1, arg2})
+	fake.postSlackMessageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) PostSlackMessageCallCount() int {
+	fake.postSlackMessageMutex.RLock()
+	defer fake.postSlackMessageMutex.RUnlock()
+	return len(fake.postSlackMessageArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) PostSlackMessageCalls(stub func(*announce.SlackOptions, string) error) {
+	fake.postSlackMessageMutex.Lock()
+	defer fake.postSlackMessageMutex.Unlock()
+	fake.PostSlackMessageStub = stub
+}
+
+func (fake *FakeReleaseImpl) PostSlackMessageArgsForCall(i int) (*announce.SlackOptions, string) {
+	fake.postSlackMessageMutex.RLock()
+	defer fake.postSlackMessageMutex.RUnlock()
+	argsForCall := fake.postSlackMessageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeReleaseImpl) PostSlackMessageReturns(result1 error) {
+	fake.postSlackMessageMutex.Lock()
+	defer fake.postSlackMessageMutex.Unlock()
+	fake.PostSlackMessageStub = nil
+	fake.postSlackMessageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) PostSlackMessageReturnsOnCall(i int, result1 error) {
+	fake.postSlackMessageMutex.Lock()
+	defer fake.postSlackMessageMutex.Unlock()
+	fake.PostSlackMessageStub = nil
+	if fake.postSlackMessageReturnsOnCall == nil {
+		fake.postSlackMessageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.postSlackMessageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseImpl) PrepareWorkspaceRelease(arg1 string, arg2 string) error {
 	fake.prepareWorkspaceReleaseMutex.Lock()
 	ret, specificReturn := fake.prepareWorkspaceReleaseReturnsOnCall[len(fake.prepareWorkspaceReleaseArgsForCall)]
@@ -1619,6 +1717,130 @@ func (fake *FakeReleaseImpl) ValidateImagesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeReleaseImpl) ValidateStagedArtifacts(arg1 string, arg2 string, arg3 string) error {
+	fake.validateStagedArtifactsMutex.Lock()
+	ret, specificReturn := fake.validateStagedArtifactsReturnsOnCall[len(fake.validateStagedArtifactsArgsForCall)]
+	fake.validateStagedArtifactsArgsForCall = append(fake.validateStagedArtifactsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ValidateStagedArtifactsStub
+	fakeReturns := fake.validateStagedArtifactsReturns
+	fake.recordInvocation("ValidateStagedArtifacts", []interface{}{arg1, arg2, arg3})
+	fake.validateStagedArtifactsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) ValidateStagedArtifactsCallCount() int {
+	fake.validateStagedArtifactsMutex.RLock()
+	defer fake.validateStagedArtifactsMutex.RUnlock()
+	return len(fake.validateStagedArtifactsArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) ValidateStagedArtifactsCalls(stub func(string, string, string) error) {
+	fake.validateStagedArtifactsMutex.Lock()
+	defer fake.validateStagedArtifactsMutex.Unlock()
+	fake.ValidateStagedArtifactsStub = stub
+}
+
+func (fake *FakeReleaseImpl) ValidateStagedArtifactsArgsForCall(i int) (string, string, string) {
+	fake.validateStagedArtifactsMutex.RLock()
+	defer fake.validateStagedArtifactsMutex.RUnlock()
+	argsForCall := fake.validateStagedArtifactsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReleaseImpl) ValidateStagedArtifactsReturns(result1 error) {
+	fake.validateStagedArtifactsMutex.Lock()
+	defer fake.validateStagedArtifactsMutex.Unlock()
+	fake.ValidateStagedArtifactsStub = nil
+	fake.validateStagedArtifactsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) ValidateStagedArtifactsReturnsOnCall(i int, result1 error) {
+	fake.validateStagedArtifactsMutex.Lock()
+	defer fake.validateStagedArtifactsMutex.Unlock()
+	fake.ValidateStagedArtifactsStub = nil
+	if fake.validateStagedArtifactsReturnsOnCall == nil {
+		fake.validateStagedArtifactsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateStagedArtifactsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) VerifyBranchProtection(arg1 string) error {
+	fake.verifyBranchProtectionMutex.Lock()
+	ret, specificReturn := fake.verifyBranchProtectionReturnsOnCall[len(fake.verifyBranchProtectionArgsForCall)]
+	fake.verifyBranchProtectionArgsForCall = append(fake.verifyBranchProtectionArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.VerifyBranchProtectionStub
+	fakeReturns := fake.verifyBranchProtectionReturns
+	fake.recordInvocation("VerifyBranchProtection", []interface{}{arg1})
+	fake.verifyBranchProtectionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) VerifyBranchProtectionCallCount() int {
+	fake.verifyBranchProtectionMutex.RLock()
+	defer fake.verifyBranchProtectionMutex.RUnlock()
+	return len(fake.verifyBranchProtectionArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) VerifyBranchProtectionCalls(stub func(string) error) {
+	fake.verifyBranchProtectionMutex.Lock()
+	defer fake.verifyBranchProtectionMutex.Unlock()
+	fake.VerifyBranchProtectionStub = stub
+}
+
+func (fake *FakeReleaseImpl) VerifyBranchProtectionArgsForCall(i int) string {
+	fake.verifyBranchProtectionMutex.RLock()
+	defer fake.verifyBranchProtectionMutex.RUnlock()
+	argsForCall := fake.verifyBranchProtectionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReleaseImpl) VerifyBranchProtectionReturns(result1 error) {
+	fake.verifyBranchProtectionMutex.Lock()
+	defer fake.verifyBranchProtectionMutex.Unlock()
+	fake.VerifyBranchProtectionStub = nil
+	fake.verifyBranchProtectionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) VerifyBranchProtectionReturnsOnCall(i int, result1 error) {
+	fake.verifyBranchProtectionMutex.Lock()
+	defer fake.verifyBranchProtectionMutex.Unlock()
+	fake.VerifyBranchProtectionStub = nil
+	if fake.verifyBranchProtectionReturnsOnCall == nil {
+		fake.verifyBranchProtectionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifyBranchProtectionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1644,6 +1866,8 @@ func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	defer fake.newGitPusherMutex.RUnlock()
 	fake.normalizePathMutex.RLock()
 	defer fake.normalizePathMutex.RUnlock()
+	fake.postSlackMessageMutex.RLock()
+	defer fake.postSlackMessageMutex.RUnlock()
 	fake.prepareWorkspaceReleaseMutex.RLock()
 	defer fake.prepareWorkspaceReleaseMutex.RUnlock()
 	fake.publishReleaseNotesIndexMutex.RLock()
@@ -1664,6 +1888,10 @@ func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	defer fake.updateGitHubPageMutex.RUnlock()
 	fake.validateImagesMutex.RLock()
 	defer fake.validateImagesMutex.RUnlock()
+	fake.validateStagedArtifactsMutex.RLock()
+	defer fake.validateStagedArtifactsMutex.RUnlock()
+	fake.verifyBranchProtectionMutex.RLock()
+	defer fake.verifyBranchProtectionMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -52,6 +52,15 @@ type FakeStageClient struct {
 	checkReleaseBranchStateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CheckReleaseHealthStub        func() error
+	checkReleaseHealthMutex       sync.RWMutex
+	checkReleaseHealthArgsForCall []struct{}
+	checkReleaseHealthReturns     struct {
+		result1 error
+	}
+	checkReleaseHealthReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GenerateBillOfMaterialsStub        func() error
 	generateBillOfMaterialsMutex       sync.RWMutex
 	generateBillOfMaterialsArgsForCall []struct {
@@ -320,6 +329,58 @@ func (fake *FakeStageClient) CheckReleaseBranchStateReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeStageClient) CheckReleaseHealth() error {
+	fake.checkReleaseHealthMutex.Lock()
+	ret, specificReturn := fake.checkReleaseHealthReturnsOnCall[len(fake.checkReleaseHealthArgsForCall)]
+	fake.checkReleaseHealthArgsForCall = append(fake.checkReleaseHealthArgsForCall, struct{}{})
+	stub := fake.CheckReleaseHealthStub
+	fakeReturns := fake.checkReleaseHealthReturns
+	fake.recordInvocation("CheckReleaseHealth", []interface{}{})
+	fake.checkReleaseHealthMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) CheckReleaseHealthCallCount() int {
+	fake.checkReleaseHealthMutex.RLock()
+	defer fake.checkReleaseHealthMutex.RUnlock()
+	return len(fake.checkReleaseHealthArgsForCall)
+}
+
+func (fake *FakeStageClient) CheckReleaseHealthCalls(stub func() error) {
+	fake.checkReleaseHealthMutex.Lock()
+	defer fake.checkReleaseHealthMutex.Unlock()
+	fake.CheckReleaseHealthStub = stub
+}
+
+func (fake *FakeStageClient) CheckReleaseHealthReturns(result1 error) {
+	fake.checkReleaseHealthMutex.Lock()
+	defer fake.checkReleaseHealthMutex.Unlock()
+	fake.CheckReleaseHealthStub = nil
+	fake.checkReleaseHealthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) CheckReleaseHealthReturnsOnCall(i int, result1 error) {
+	fake.checkReleaseHealthMutex.Lock()
+	defer fake.checkReleaseHealthMutex.Unlock()
+	fake.CheckReleaseHealthStub = nil
+	if fake.checkReleaseHealthReturnsOnCall == nil {
+		fake.checkReleaseHealthReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkReleaseHealthReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageClient) GenerateBillOfMaterials() error {
 	fake.generateBillOfMaterialsMutex.Lock()
 	ret, specificReturn := fake.generateBillOfMaterialsReturnsOnCall[len(fake.generateBillOfMaterialsArgsForCall)]
@@ -891,6 +952,8 @@ func (fake *FakeStageClient) Invocations() map[string][][]interface{} {
 	defer fake.checkPrerequisitesMutex.RUnlock()
 	fake.checkReleaseBranchStateMutex.RLock()
 	defer fake.checkReleaseBranchStateMutex.RUnlock()
+	fake.checkReleaseHealthMutex.RLock()
+	defer fake.checkReleaseHealthMutex.RUnlock()
 	fake.generateBillOfMaterialsMutex.RLock()
 	defer fake.generateBillOfMaterialsMutex.RUnlock()
 	fake.generateChangelogMutex.RLock()
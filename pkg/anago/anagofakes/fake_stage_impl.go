@@ -21,12 +21,14 @@ import (
 	"sync"
 
 	"github.com/blang/semver"
+	"k8s.io/release/pkg/announce"
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/changelog"
 	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/git"
 	"k8s.io/release/pkg/release"
 	"k8s.io/release/pkg/spdx"
+	"k8s.io/release/pkg/testgrid"
 )
 
 type FakeStageImpl struct {
@@ -277,6 +279,18 @@ type FakeStageImpl struct {
 		result1 *git.Repo
 		result2 error
 	}
+	PostSlackMessageStub        func(*announce.SlackOptions, string) error
+	postSlackMessageMutex       sync.RWMutex
+	postSlackMessageArgsForCall []struct {
+		arg1 *announce.SlackOptions
+		arg2 string
+	}
+	postSlackMessageReturns struct {
+		result1 error
+	}
+	postSlackMessageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PrepareWorkspaceStageStub        func() error
 	prepareWorkspaceStageMutex       sync.RWMutex
 	prepareWorkspaceStageArgsForCall []struct {
@@ -311,6 +325,20 @@ type FakeStageImpl struct {
 	pushReleaseArtifactsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReleaseHealthStub        func(string, *testgrid.HealthOptions) (*testgrid.HealthReport, error)
+	releaseHealthMutex       sync.RWMutex
+	releaseHealthArgsForCall []struct {
+		arg1 string
+		arg2 *testgrid.HealthOptions
+	}
+	releaseHealthReturns struct {
+		result1 *testgrid.HealthReport
+		result2 error
+	}
+	releaseHealthReturnsOnCall map[int]struct {
+		result1 *testgrid.HealthReport
+		result2 error
+	}
 	RevParseStub        func(*git.Repo, string) (string, error)
 	revParseMutex       sync.RWMutex
 	revParseArgsForCall []struct {
@@ -398,6 +426,21 @@ type FakeStageImpl struct {
 	toFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateTagStub        func(*git.Repo, string, string) (*release.TagPolicyReport, error)
+	validateTagMutex       sync.RWMutex
+	validateTagArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+		arg3 string
+	}
+	validateTagReturns struct {
+		result1 *release.TagPolicyReport
+		result2 error
+	}
+	validateTagReturnsOnCall map[int]struct {
+		result1 *release.TagPolicyReport
+		result2 error
+	}
 	VerifyArtifactsStub        func([]string) error
 	verifyArtifactsMutex       sync.RWMutex
 	verifyArtifactsArgsForCall []struct {
@@ -1633,6 +1676,68 @@ func (fake *FakeStageImpl) OpenRepoReturnsOnCall(i int, result1 *git.Repo, resul
 	}{result1, result2}
 }
 
+func (fake *FakeStageImpl) PostSlackMessage(arg1 *announce.SlackOptions, arg2 string) error {
+	fake.postSlackMessageMutex.Lock()
+	ret, specificReturn := fake.postSlackMessageReturnsOnCall[len(fake.postSlackMessageArgsForCall)]
+	fake.postSlackMessageArgsForCall = append(fake.postSlackMessageArgsForCall, struct {
+		arg1 *announce.SlackOptions
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PostSlackMessageStub
+	fakeReturns := fake.postSlackMessageReturns
+	fake.recordInvocation("PostSlackMessage", []interface{}{arg1, arg2})
+	fake.postSlackMessageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) PostSlackMessageCallCount() int {
+	fake.postSlackMessageMutex.RLock()
+	defer fake.postSlackMessageMutex.RUnlock()
+	return len(fake.postSlackMessageArgsForCall)
+}
+
+func (fake *FakeStageImpl) PostSlackMessageCalls(stub func(*announce.SlackOptions, string) error) {
+	fake.postSlackMessageMutex.Lock()
+	defer fake.postSlackMessageMutex.Unlock()
+	fake.PostSlackMessageStub = stub
+}
+
+func (fake *FakeStageImpl) PostSlackMessageArgsForCall(i int) (*announce.SlackOptions, string) {
+	fake.postSlackMessageMutex.RLock()
+	defer fake.postSlackMessageMutex.RUnlock()
+	argsForCall := fake.postSlackMessageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStageImpl) PostSlackMessageReturns(result1 error) {
+	fake.postSlackMessageMutex.Lock()
+	defer fake.postSlackMessageMutex.Unlock()
+	fake.PostSlackMessageStub = nil
+	fake.postSlackMessageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) PostSlackMessageReturnsOnCall(i int, result1 error) {
+	fake.postSlackMessageMutex.Lock()
+	defer fake.postSlackMessageMutex.Unlock()
+	fake.PostSlackMessageStub = nil
+	if fake.postSlackMessageReturnsOnCall == nil {
+		fake.postSlackMessageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.postSlackMessageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) PrepareWorkspaceStage() error {
 	fake.prepareWorkspaceStageMutex.Lock()
 	ret, specificReturn := fake.prepareWorkspaceStageReturnsOnCall[len(fake.prepareWorkspaceStageArgsForCall)]
@@ -1810,6 +1915,71 @@ func (fake *FakeStageImpl) PushReleaseArtifactsReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeStageImpl) ReleaseHealth(arg1 string, arg2 *testgrid.HealthOptions) (*testgrid.HealthReport, error) {
+	fake.releaseHealthMutex.Lock()
+	ret, specificReturn := fake.releaseHealthReturnsOnCall[len(fake.releaseHealthArgsForCall)]
+	fake.releaseHealthArgsForCall = append(fake.releaseHealthArgsForCall, struct {
+		arg1 string
+		arg2 *testgrid.HealthOptions
+	}{arg1, arg2})
+	stub := fake.ReleaseHealthStub
+	fakeReturns := fake.releaseHealthReturns
+	fake.recordInvocation("ReleaseHealth", []interface{}{arg1, arg2})
+	fake.releaseHealthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStageImpl) ReleaseHealthCallCount() int {
+	fake.releaseHealthMutex.RLock()
+	defer fake.releaseHealthMutex.RUnlock()
+	return len(fake.releaseHealthArgsForCall)
+}
+
+func (fake *FakeStageImpl) ReleaseHealthCalls(stub func(string, *testgrid.HealthOptions) (*testgrid.HealthReport, error)) {
+	fake.releaseHealthMutex.Lock()
+	defer fake.releaseHealthMutex.Unlock()
+	fake.ReleaseHealthStub = stub
+}
+
+func (fake *FakeStageImpl) ReleaseHealthArgsForCall(i int) (string, *testgrid.HealthOptions) {
+	fake.releaseHealthMutex.RLock()
+	defer fake.releaseHealthMutex.RUnlock()
+	argsForCall := fake.releaseHealthArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStageImpl) ReleaseHealthReturns(result1 *testgrid.HealthReport, result2 error) {
+	fake.releaseHealthMutex.Lock()
+	defer fake.releaseHealthMutex.Unlock()
+	fake.ReleaseHealthStub = nil
+	fake.releaseHealthReturns = struct {
+		result1 *testgrid.HealthReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) ReleaseHealthReturnsOnCall(i int, result1 *testgrid.HealthReport, result2 error) {
+	fake.releaseHealthMutex.Lock()
+	defer fake.releaseHealthMutex.Unlock()
+	fake.ReleaseHealthStub = nil
+	if fake.releaseHealthReturnsOnCall == nil {
+		fake.releaseHealthReturnsOnCall = make(map[int]struct {
+			result1 *testgrid.HealthReport
+			result2 error
+		})
+	}
+	fake.releaseHealthReturnsOnCall[i] = struct {
+		result1 *testgrid.HealthReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStageImpl) RevParse(arg1 *git.Repo, arg2 string) (string, error) {
 	fake.revParseMutex.Lock()
 	ret, specificReturn := fake.revParseReturnsOnCall[len(fake.revParseArgsForCall)]
@@ -2249,6 +2419,72 @@ func (fake *FakeStageImpl) ToFileReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStageImpl) ValidateTag(arg1 *git.Repo, arg2 string, arg3 string) (*release.TagPolicyReport, error) {
+	fake.validateTagMutex.Lock()
+	ret, specificReturn := fake.validateTagReturnsOnCall[len(fake.validateTagArgsForCall)]
+	fake.validateTagArgsForCall = append(fake.validateTagArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ValidateTagStub
+	fakeReturns := fake.validateTagReturns
+	fake.recordInvocation("ValidateTag", []interface{}{arg1, arg2, arg3})
+	fake.validateTagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStageImpl) ValidateTagCallCount() int {
+	fake.validateTagMutex.RLock()
+	defer fake.validateTagMutex.RUnlock()
+	return len(fake.validateTagArgsForCall)
+}
+
+func (fake *FakeStageImpl) ValidateTagCalls(stub func(*git.Repo, string, string) (*release.TagPolicyReport, error)) {
+	fake.validateTagMutex.Lock()
+	defer fake.validateTagMutex.Unlock()
+	fake.ValidateTagStub = stub
+}
+
+func (fake *FakeStageImpl) ValidateTagArgsForCall(i int) (*git.Repo, string, string) {
+	fake.validateTagMutex.RLock()
+	defer fake.validateTagMutex.RUnlock()
+	argsForCall := fake.validateTagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStageImpl) ValidateTagReturns(result1 *release.TagPolicyReport, result2 error) {
+	fake.validateTagMutex.Lock()
+	defer fake.validateTagMutex.Unlock()
+	fake.ValidateTagStub = nil
+	fake.validateTagReturns = struct {
+		result1 *release.TagPolicyReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) ValidateTagReturnsOnCall(i int, result1 *release.TagPolicyReport, result2 error) {
+	fake.validateTagMutex.Lock()
+	defer fake.validateTagMutex.Unlock()
+	fake.ValidateTagStub = nil
+	if fake.validateTagReturnsOnCall == nil {
+		fake.validateTagReturnsOnCall = make(map[int]struct {
+			result1 *release.TagPolicyReport
+			result2 error
+		})
+	}
+	fake.validateTagReturnsOnCall[i] = struct {
+		result1 *release.TagPolicyReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStageImpl) VerifyArtifacts(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -2418,12 +2654,16 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.makeCrossMutex.RUnlock()
 	fake.openRepoMutex.RLock()
 	defer fake.openRepoMutex.RUnlock()
+	fake.postSlackMessageMutex.RLock()
+	defer fake.postSlackMessageMutex.RUnlock()
 	fake.prepareWorkspaceStageMutex.RLock()
 	defer fake.prepareWorkspaceStageMutex.RUnlock()
 	fake.pushContainerImagesMutex.RLock()
 	defer fake.pushContainerImagesMutex.RUnlock()
 	fake.pushReleaseArtifactsMutex.RLock()
 	defer fake.pushReleaseArtifactsMutex.RUnlock()
+	fake.releaseHealthMutex.RLock()
+	defer fake.releaseHealthMutex.RUnlock()
 	fake.revParseMutex.RLock()
 	defer fake.revParseMutex.RUnlock()
 	fake.revParseTagMutex.RLock()
@@ -2438,6 +2678,8 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.tagMutex.RUnlock()
 	fake.toFileMutex.RLock()
 	defer fake.toFileMutex.RUnlock()
+	fake.validateTagMutex.RLock()
+	defer fake.validateTagMutex.RUnlock()
 	fake.verifyArtifactsMutex.RLock()
 	defer fake.verifyArtifactsMutex.RUnlock()
 	fake.writeSourceBOMMutex.RLock()
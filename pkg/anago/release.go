@@ -130,6 +130,7 @@ type releaseImpl interface {
 		releaseType, version, branch string, branchFromMaster bool,
 	) (*release.Versions, error)
 	CheckReleaseBucket(options *build.Options) error
+	ValidateStagedArtifacts(bucket, buildVersion, version string) error
 	CopyStagedFromGCS(
 		options *build.Options, stagedBucket, buildVersion string,
 	) error
@@ -154,6 +155,8 @@ type releaseImpl interface {
 		gcsIndexRootPath, gcsReleaseNotesPath, version string,
 	) error
 	CreatePubBotBranchIssue(string) error
+	VerifyBranchProtection(string) error
+	PostSlackMessage(options *announce.SlackOptions, text string) error
 }
 
 func (d *defaultReleaseImpl) Submit(options *gcb.Options) error {
@@ -201,6 +204,16 @@ func (d *defaultReleaseImpl) CheckReleaseBucket(
 	return build.NewInstance(options).CheckReleaseBucket()
 }
 
+func (d *defaultReleaseImpl) ValidateStagedArtifacts(
+	bucket, buildVersion, version string,
+) error {
+	return release.NewArtifactValidator(release.DefaultArtifactLayout()).
+		ValidateBucket(
+			filepath.Join(bucket, release.StagePath, buildVersion, version),
+			version,
+		)
+}
+
 func (d *defaultReleaseImpl) CopyStagedFromGCS(
 	options *build.Options, stagedBucket, buildVersion string,
 ) error {
@@ -228,7 +241,7 @@ func (d *DefaultRelease) Submit(stream bool) error {
 	options := gcb.NewDefaultOptions()
 	options.Stream = stream
 	options.Release = true
-	options.NoMock = d.options.NoMock
+	options.Mode = d.options.Mode
 	options.Branch = d.options.ReleaseBranch
 	options.ReleaseType = d.options.ReleaseType
 	options.BuildVersion = d.options.BuildVersion
@@ -309,6 +322,16 @@ func (d *defaultReleaseImpl) CreatePubBotBranchIssue(branchName string) error {
 	return release.CreatePubBotBranchIssue(branchName)
 }
 
+func (d *defaultReleaseImpl) VerifyBranchProtection(branchName string) error {
+	return release.VerifyReleaseBranchProtection(branchName)
+}
+
+func (d *defaultReleaseImpl) PostSlackMessage(
+	options *announce.SlackOptions, text string,
+) error {
+	return announce.PostSlackMessage(options, text)
+}
+
 // NewGitPusher returns a new instance of the git pusher to reuse
 func (d *defaultReleaseImpl) NewGitPusher(
 	opts *release.GitObjectPusherOptions,
@@ -390,6 +413,14 @@ func (d *DefaultRelease) PushArtifacts() error {
 			return errors.Wrap(err, "check release bucket access")
 		}
 
+		// Ensure that the stage did not miss any artifact before publishing
+		// the release
+		if err := d.impl.ValidateStagedArtifacts(
+			bucket, d.options.BuildVersion, version,
+		); err != nil {
+			return errors.Wrap(err, "validate staged artifacts")
+		}
+
 		if err := d.impl.CopyStagedFromGCS(
 			pushBuildOptions, bucket, d.options.BuildVersion,
 		); err != nil {
@@ -400,7 +431,7 @@ func (d *DefaultRelease) PushArtifacts() error {
 		// images have been promoted from staging to production, so we do the
 		// image manifest validation against production instead of staging.
 		targetRegistry := containerRegistry
-		if targetRegistry == release.GCRIOPathStaging {
+		if d.options.Mode.IsNoMock() {
 			targetRegistry = release.GCRIOPathProd
 		}
 
@@ -458,7 +489,7 @@ func (d *DefaultRelease) PushGitObjects() error {
 	// Build the git object pusher
 	pusher, err := d.impl.NewGitPusher(
 		&release.GitObjectPusherOptions{
-			DryRun: !d.options.NoMock,
+			DryRun: d.options.Mode.DryRun(),
 			// MaxRetries: options.maxRetries,
 			RepoPath: gitRoot,
 		})
@@ -485,6 +516,16 @@ func (d *DefaultRelease) PushGitObjects() error {
 		return errors.Wrap(err, "pushing branches to the remote repository")
 	}
 
+	// Verify the protection of a newly cut release branch
+	if d.state.createReleaseBranch && d.options.Mode.IsNoMock() {
+		if err := d.impl.VerifyBranchProtection(d.options.ReleaseBranch); err != nil {
+			// The protection is managed outside of the release process,
+			// which is why the drift is only reported and not fatal:
+			logrus.Warn("Failed to verify the release branch protection")
+			logrus.Error(err)
+		}
+	}
+
 	// For files created on master with new branches and
 	// for $CHANGELOG_FILEPATH, update the main branch
 	if err := d.impl.PushMainBranch(pusher); err != nil {
@@ -516,9 +557,10 @@ func (d *DefaultRelease) CreateAnnouncement() error {
 	announceOpts.WithTag(d.state.versions.Prime())
 
 	// Path to the changelog in the k/k repo (used to build a link to it)
-	announceOpts.WithChangelogPath(
-		fmt.Sprintf("CHANGELOG/CHANGELOG-%d.%d.md", primeSemver.Major, primeSemver.Minor),
+	changelogPath := fmt.Sprintf(
+		"CHANGELOG/CHANGELOG-%d.%d.md", primeSemver.Major, primeSemver.Minor,
 	)
+	announceOpts.WithChangelogPath(changelogPath)
 
 	// Pass the file path as a string to the annoucement options
 	announceOpts.WithChangelogFile(releaseNotesHTMLFile)
@@ -528,11 +570,19 @@ func (d *DefaultRelease) CreateAnnouncement() error {
 		return errors.Wrap(err, "creating the announcement")
 	}
 
+	// Posting to Slack is best effort and does not fail the release
+	if err := d.impl.PostSlackMessage(
+		d.options.slackOptions(),
+		announce.SlackReleaseText(d.state.versions.Prime(), changelogPath),
+	); err != nil {
+		logrus.Warnf("Unable to post release announcement to Slack: %v", err)
+	}
+
 	// Check if we are releasing is the initial minor (eg 1.20.0),
 	// and we are working on a release-M.m branch
 	if primeSemver.Patch == 0 && len(primeSemver.Pre) == 0 &&
 		d.options.ReleaseBranch != git.DefaultBranch {
-		if d.options.NoMock {
+		if d.options.Mode.IsNoMock() {
 			// Create the publishing bot issue
 			if err := d.impl.CreatePubBotBranchIssue(d.options.ReleaseBranch); err != nil {
 				// If it fails, log the error, but do not treat it
@@ -573,7 +623,7 @@ func (d *DefaultRelease) UpdateGitHubPage() error {
 	ghPageOpts := &announce.GitHubPageOptions{
 		AssetFiles:            assetList,
 		Tag:                   d.state.versions.Prime(),
-		NoMock:                d.options.NoMock,
+		NoMock:                d.options.Mode.IsNoMock(),
 		UpdateIfReleaseExists: true,
 		Name:                  "Kubernetes " + d.state.versions.Prime(),
 		Draft:                 false,
@@ -610,7 +660,7 @@ func (d *DefaultRelease) Archive() error {
 	}
 
 	args := ""
-	if d.options.NoMock {
+	if d.options.Mode.IsNoMock() {
 		args += " --nomock"
 	}
 	args += " --tag=" + d.state.versions.Prime()
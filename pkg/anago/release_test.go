@@ -202,6 +202,12 @@ func TestPushArtifacts(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // ValidateStagedArtifacts fails
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.ValidateStagedArtifactsReturns(err)
+			},
+			shouldError: true,
+		},
 		{ // CopyStagedFromGCSReturns fails
 			prepare: func(mock *anagofakes.FakeReleaseImpl) {
 				mock.CopyStagedFromGCSReturns(err)
@@ -335,6 +341,12 @@ func TestCreateAnnouncement(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // Posting to Slack fails, which is not fatal
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.PostSlackMessageReturns(err)
+			},
+			shouldError: false,
+		},
 	} {
 		opts := anago.DefaultReleaseOptions()
 		sut := anago.NewDefaultRelease(opts)
@@ -404,6 +416,35 @@ func TestPushGitObjects(t *testing.T) {
 	}
 }
 
+func TestPushGitObjectsVerifyBranchProtection(t *testing.T) {
+	createReleaseBranch := true
+	opts := anago.DefaultReleaseOptions()
+	opts.Mode = release.ModeNoMock
+	opts.ReleaseBranch = "release-1.22"
+	sut := anago.NewDefaultRelease(opts)
+	sut.SetState(generateTestingReleaseState(&testStateParameters{
+		versionsTag:         &testVersionTag,
+		createReleaseBranch: &createReleaseBranch,
+	}))
+	mock := &anagofakes.FakeReleaseImpl{}
+	sut.SetImpl(mock)
+
+	// A protection drift is not fatal
+	mock.VerifyBranchProtectionReturns(err)
+	require.Nil(t, sut.PushGitObjects())
+	require.Equal(t, 1, mock.VerifyBranchProtectionCallCount())
+	require.Equal(t, "release-1.22", mock.VerifyBranchProtectionArgsForCall(0))
+
+	// Existing branches do not get verified
+	createReleaseBranch = false
+	sut.SetState(generateTestingReleaseState(&testStateParameters{
+		versionsTag:         &testVersionTag,
+		createReleaseBranch: &createReleaseBranch,
+	}))
+	require.Nil(t, sut.PushGitObjects())
+	require.Equal(t, 1, mock.VerifyBranchProtectionCallCount())
+}
+
 func TestUpdateGitHubPage(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeReleaseImpl)
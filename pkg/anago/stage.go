@@ -26,12 +26,14 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/announce"
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/changelog"
 	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/git"
 	"k8s.io/release/pkg/release"
 	"k8s.io/release/pkg/spdx"
+	"k8s.io/release/pkg/testgrid"
 	"sigs.k8s.io/release-utils/log"
 )
 
@@ -61,6 +63,10 @@ type stageClient interface {
 	// be created.
 	CheckReleaseBranchState() error
 
+	// CheckReleaseHealth verifies that the release blocking dashboard of the
+	// release branch does not contain any failing jobs.
+	CheckReleaseHealth() error
+
 	// GenerateReleaseVersion discovers the next versions to be released.
 	GenerateReleaseVersion() error
 
@@ -139,10 +145,16 @@ type stageImpl interface {
 	) (*release.Versions, error)
 	OpenRepo(repoPath string) (*git.Repo, error)
 	RevParse(repo *git.Repo, rev string) (string, error)
+	ReleaseHealth(
+		branch string, options *testgrid.HealthOptions,
+	) (*testgrid.HealthReport, error)
 	RevParseTag(repo *git.Repo, rev string) (string, error)
 	Checkout(repo *git.Repo, rev string, args ...string) error
 	CurrentBranch(repo *git.Repo) (string, error)
 	CommitEmpty(repo *git.Repo, msg string) error
+	ValidateTag(
+		repo *git.Repo, tag, branch string,
+	) (*release.TagPolicyReport, error)
 	Tag(repo *git.Repo, name, message string) error
 	CheckReleaseBucket(options *build.Options) error
 	DockerHubLogin() error
@@ -166,6 +178,7 @@ type stageImpl interface {
 	AddBinariesToSBOM(*spdx.Document, string) error
 	AddTarfilesToSBOM(*spdx.Document, string) error
 	VerifyArtifacts([]string) error
+	PostSlackMessage(options *announce.SlackOptions, text string) error
 }
 
 func (d *defaultStageImpl) Submit(options *gcb.Options) error {
@@ -188,6 +201,12 @@ func (d *defaultStageImpl) BranchNeedsCreation(
 	)
 }
 
+func (d *defaultStageImpl) ReleaseHealth(
+	branch string, options *testgrid.HealthOptions,
+) (*testgrid.HealthReport, error) {
+	return testgrid.New().ReleaseHealth(branch, options)
+}
+
 func (d *defaultStageImpl) PrepareWorkspaceStage() error {
 	if err := release.PrepareWorkspaceStage(gitRoot); err != nil {
 		return err
@@ -227,6 +246,12 @@ func (d *defaultStageImpl) CommitEmpty(repo *git.Repo, msg string) error {
 	return repo.CommitEmpty(msg)
 }
 
+func (d *defaultStageImpl) ValidateTag(
+	repo *git.Repo, tag, branch string,
+) (*release.TagPolicyReport, error) {
+	return release.NewTagValidator(repo).Validate(tag, branch)
+}
+
 func (d *defaultStageImpl) Tag(repo *git.Repo, name, message string) error {
 	return repo.Tag(name, message)
 }
@@ -277,9 +302,12 @@ func (d *DefaultStage) Submit(stream bool) error {
 	options := gcb.NewDefaultOptions()
 	options.Stream = stream
 	options.Stage = true
-	options.NoMock = d.options.NoMock
+	options.Mode = d.options.Mode
 	options.Branch = d.options.ReleaseBranch
 	options.ReleaseType = d.options.ReleaseType
+	options.HealthGate = d.options.HealthGate
+	options.AllowFlaky = d.options.AllowFlaky
+	options.IgnoredTabs = d.options.IgnoredTabs
 	return d.impl.Submit(options)
 }
 
@@ -319,6 +347,12 @@ func (d *defaultStageImpl) VerifyArtifacts(versions []string) error {
 	return nil
 }
 
+func (d *defaultStageImpl) PostSlackMessage(
+	options *announce.SlackOptions, text string,
+) error {
+	return announce.PostSlackMessage(options, text)
+}
+
 func (d *DefaultStage) InitLogFile() error {
 	logrus.SetFormatter(
 		&logrus.TextFormatter{FullTimestamp: true, ForceColors: true},
@@ -360,6 +394,21 @@ func (d *DefaultStage) CheckReleaseBranchState() error {
 	return nil
 }
 
+func (d *DefaultStage) CheckReleaseHealth() error {
+	report, err := d.impl.ReleaseHealth(
+		d.options.ReleaseBranch,
+		&testgrid.HealthOptions{
+			AllowFlaky:  d.options.AllowFlaky,
+			IgnoredTabs: d.options.IgnoredTabs,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "retrieve release health")
+	}
+	logrus.Info(report.String())
+	return report.Err()
+}
+
 func (d *DefaultStage) GenerateReleaseVersion() error {
 	versions, err := d.impl.GenerateReleaseVersion(
 		d.options.ReleaseType,
@@ -442,6 +491,19 @@ func (d *DefaultStage) TagRepository() error {
 		}
 		if branch != "" {
 			logrus.Infof("Current branch is %s", branch)
+
+			// The tags of the branch are only available before the release
+			// commit gets created or HEAD gets detached
+			report, err := d.impl.ValidateTag(repo, version, branch)
+			if err != nil {
+				return errors.Wrapf(err, "validate tag %s", version)
+			}
+			logrus.Info(report.String())
+			if err := report.Err(); err != nil {
+				return err
+			}
+		} else {
+			logrus.Warnf("Not validating tag %s in detached HEAD state", version)
 		}
 
 		// For release branches, we create an empty release commit to avoid
@@ -800,8 +862,16 @@ func (d *DefaultStage) StageArtifacts() error {
 		}
 	}
 
+	// Posting to Slack is best effort and does not fail the stage
+	if err := d.impl.PostSlackMessage(
+		d.options.slackOptions(),
+		announce.SlackStageText(d.options.BuildVersion, d.state.versions.Ordered()),
+	); err != nil {
+		logrus.Warnf("Unable to post stage update to Slack: %v", err)
+	}
+
 	args := ""
-	if d.options.NoMock {
+	if d.options.Mode.IsNoMock() {
 		args += " --nomock"
 	}
 	if d.options.ReleaseType != DefaultOptions().ReleaseType {
@@ -26,6 +26,7 @@ import (
 	"k8s.io/release/pkg/git"
 	"k8s.io/release/pkg/release"
 	"k8s.io/release/pkg/spdx"
+	"k8s.io/release/pkg/testgrid"
 )
 
 func generateTestingStageState(params *testStateParameters) *anago.StageState {
@@ -148,6 +149,58 @@ func TestCheckReleaseBranchStateStage(t *testing.T) {
 	}
 }
 
+func TestCheckReleaseHealthStage(t *testing.T) {
+	for _, tc := range []struct {
+		prepare     func(*anagofakes.FakeStageImpl)
+		shouldError bool
+	}{
+		{ // success
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ReleaseHealthReturns(&testgrid.HealthReport{
+					Tabs: []testgrid.TabHealth{{Status: testgrid.StatusPassing}},
+				}, nil)
+			},
+			shouldError: false,
+		},
+		{ // ReleaseHealth fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ReleaseHealthReturns(nil, err)
+			},
+			shouldError: true,
+		},
+		{ // blocking jobs
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ReleaseHealthReturns(&testgrid.HealthReport{
+					Tabs: []testgrid.TabHealth{{
+						Status: testgrid.StatusFailing, Blocking: true,
+					}},
+				}, nil)
+			},
+			shouldError: true,
+		},
+	} {
+		opts := anago.DefaultStageOptions()
+		opts.AllowFlaky = true
+		opts.IgnoredTabs = []string{"tab"}
+		sut := anago.NewDefaultStage(opts)
+
+		mock := &anagofakes.FakeStageImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+
+		err := sut.CheckReleaseHealth()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+			branch, healthOpts := mock.ReleaseHealthArgsForCall(0)
+			require.Equal(t, opts.ReleaseBranch, branch)
+			require.True(t, healthOpts.AllowFlaky)
+			require.Equal(t, opts.IgnoredTabs, healthOpts.IgnoredTabs)
+		}
+	}
+}
+
 func TestGenerateReleaseVersionStage(t *testing.T) {
 	for _, tc := range []struct {
 		prepare             func(*anagofakes.FakeStageImpl)
@@ -361,6 +414,39 @@ func TestTagRepository(t *testing.T) {
 			releaseBranch: git.DefaultBranch,
 			shouldError:   true,
 		},
+		{ // success new beta complying with the tag policy
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.RevParseTagReturns("", err)
+				mock.CurrentBranchReturns(git.DefaultBranch, nil)
+			},
+			versions:      newBetaVersions,
+			releaseBranch: git.DefaultBranch,
+			shouldError:   false,
+		},
+		{ // new beta failure on ValidateTag
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.RevParseTagReturns("", err)
+				mock.CurrentBranchReturns(git.DefaultBranch, nil)
+				mock.ValidateTagReturns(nil, err)
+			},
+			versions:      newBetaVersions,
+			releaseBranch: git.DefaultBranch,
+			shouldError:   true,
+		},
+		{ // new beta violating the tag policy
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.RevParseTagReturns("", err)
+				mock.CurrentBranchReturns(git.DefaultBranch, nil)
+				mock.ValidateTagReturns(&release.TagPolicyReport{
+					Checks: []release.TagPolicyCheck{
+						{Name: release.TagCheckSequence, Passed: false},
+					},
+				}, nil)
+			},
+			versions:      newBetaVersions,
+			releaseBranch: git.DefaultBranch,
+			shouldError:   true,
+		},
 	} {
 		opts := anago.DefaultStageOptions()
 		opts.BuildVersion = "v1.20.0-beta.1.358+4628c605aadb9b"
@@ -376,6 +462,7 @@ func TestTagRepository(t *testing.T) {
 		sut.SetState(&anago.StageState{state})
 
 		mock := &anagofakes.FakeStageImpl{}
+		mock.ValidateTagReturns(&release.TagPolicyReport{}, nil)
 		tc.prepare(mock)
 		sut.SetImpl(mock)
 
@@ -476,6 +563,12 @@ func TestStageArtifacts(t *testing.T) {
 			prepare:     func(*anagofakes.FakeStageImpl) {},
 			shouldError: false,
 		},
+		{ // PostSlackMessage fails, which is not fatal
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.PostSlackMessageReturns(err)
+			},
+			shouldError: false,
+		},
 		{ // CheckReleaseBucket fails
 			prepare: func(mock *anagofakes.FakeStageImpl) {
 				mock.CheckReleaseBucketReturns(err)
@@ -551,6 +644,9 @@ func TestSubmitStageImpl(t *testing.T) {
 		},
 	} {
 		opts := anago.DefaultStageOptions()
+		opts.HealthGate = true
+		opts.AllowFlaky = true
+		opts.IgnoredTabs = []string{"tab"}
 		sut := anago.NewDefaultStage(opts)
 		mock := &anagofakes.FakeStageImpl{}
 		tc.prepare(mock)
@@ -560,6 +656,11 @@ func TestSubmitStageImpl(t *testing.T) {
 			require.NotNil(t, err)
 		} else {
 			require.Nil(t, err)
+			gcbOpts := mock.SubmitArgsForCall(0)
+			require.True(t, gcbOpts.Stage)
+			require.True(t, gcbOpts.HealthGate)
+			require.True(t, gcbOpts.AllowFlaky)
+			require.Equal(t, opts.IgnoredTabs, gcbOpts.IgnoredTabs)
 		}
 	}
 }
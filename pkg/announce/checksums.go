@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/github"
+	"sigs.k8s.io/release-utils/command"
+)
+
+const (
+	// SHA256SumsFile is the release asset containing the SHA256 checksums
+	// of all other assets.
+	SHA256SumsFile = "SHA256SUMS"
+
+	// SHA512SumsFile is the release asset containing the SHA512 checksums
+	// of all other assets.
+	SHA512SumsFile = "SHA512SUMS"
+
+	// signatureExtension is the extension of the detached signatures.
+	signatureExtension = ".asc"
+)
+
+// writeChecksumAssets writes the SHA256SUMS and SHA512SUMS files of the
+// release assets into dir and returns their paths. The files use the format
+// of sha256sum(1) and sha512sum(1), which means that downloaded assets can be
+// verified via `sha256sum --check SHA256SUMS`.
+func writeChecksumAssets(dir string, releaseAssets []map[string]string) ([]string, error) {
+	paths := []string{}
+	for _, sum := range []struct{ file, key string }{
+		{SHA256SumsFile, "sha256"},
+		{SHA512SumsFile, "sha512"},
+	} {
+		content := &strings.Builder{}
+		for _, assetData := range releaseAssets {
+			fmt.Fprintf(content, "%s  %s\n", assetData[sum.key], assetData["filename"])
+		}
+
+		path := filepath.Join(dir, sum.file)
+		logrus.Infof("Writing checksums of %d assets to %s", len(releaseAssets), path)
+		if err := os.WriteFile(
+			path, []byte(content.String()), os.FileMode(0o644),
+		); err != nil {
+			return nil, errors.Wrapf(err, "writing %s", path)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// signAssets creates armored detached signatures of the files into dir using
+// the GPG key signKey and returns the paths of the signatures.
+func signAssets(dir, signKey string, files []string) ([]string, error) {
+	signatures := []string{}
+	for _, file := range files {
+		signature := filepath.Join(dir, filepath.Base(file)+signatureExtension)
+		logrus.Infof("Signing %s using key %s", filepath.Base(file), signKey)
+		if err := command.New(
+			"gpg", "--batch", "--yes", "--local-user", signKey, "--armor",
+			"--detach-sign", "--output", signature, file,
+		).RunSilentSuccess(); err != nil {
+			return nil, errors.Wrapf(err, "signing %s", file)
+		}
+		signatures = append(signatures, signature)
+	}
+	return signatures, nil
+}
+
+// uploadAndVerifyAsset uploads the file to the release and downloads it
+// again afterwards, to ensure that the transfer did not corrupt the asset.
+func uploadAndVerifyAsset(
+	gh *github.GitHub, owner, repo string, releaseID int64,
+	rawPath, path, sha256 string,
+) error {
+	logrus.Infof("Uploading %s as release asset", path)
+	asset, err := gh.UploadReleaseAsset(owner, repo, releaseID, rawPath)
+	if err != nil {
+		return errors.Wrapf(err, "uploading %s to the release", path)
+	}
+	logrus.Info("Successfully uploaded asset #", asset.GetID())
+
+	uploaded, err := gh.ReleaseAssetSHA256(owner, repo, asset.GetID())
+	if err != nil {
+		return errors.Wrapf(err, "verifying uploaded asset %s", asset.GetName())
+	}
+	if uploaded != sha256 {
+		return errors.Errorf(
+			"SHA256 of uploaded asset %s is %s, but expected %s",
+			asset.GetName(), uploaded, sha256,
+		)
+	}
+	logrus.Infof("Verified SHA256 of uploaded asset %s", asset.GetName())
+	return nil
+}
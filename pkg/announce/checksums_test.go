@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/github"
+	"k8s.io/release/pkg/github/githubfakes"
+	"sigs.k8s.io/release-utils/command"
+)
+
+func TestWriteChecksumAssets(t *testing.T) {
+	// Given
+	dir, err := os.MkdirTemp("", "checksums-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	releaseAssets := []map[string]string{
+		{"filename": "a.tar.gz", "sha256": "a256", "sha512": "a512"},
+		{"filename": "b.tar.gz", "sha256": "b256", "sha512": "b512"},
+	}
+
+	// When
+	paths, err := writeChecksumAssets(dir, releaseAssets)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, SHA256SumsFile),
+		filepath.Join(dir, SHA512SumsFile),
+	}, paths)
+
+	for path, expected := range map[string]string{
+		paths[0]: "a256  a.tar.gz\nb256  b.tar.gz\n",
+		paths[1]: "a512  a.tar.gz\nb512  b.tar.gz\n",
+	} {
+		content, err := os.ReadFile(path)
+		require.Nil(t, err)
+		require.Equal(t, expected, string(content))
+	}
+
+	// When the directory does not exist
+	_, err = writeChecksumAssets(filepath.Join(dir, "missing"), releaseAssets)
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestSignAssets(t *testing.T) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg is not available")
+	}
+
+	// Given
+	dir, err := os.MkdirTemp("", "sign-assets-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	gnupgHome := filepath.Join(dir, "gnupg")
+	require.Nil(t, os.Mkdir(gnupgHome, os.FileMode(0o700)))
+	prevGnupgHome, hadGnupgHome := os.LookupEnv("GNUPGHOME")
+	require.Nil(t, os.Setenv("GNUPGHOME", gnupgHome))
+	defer func() {
+		// Stop the agent started for the temporary home
+		command.New("gpgconf", "--kill", "gpg-agent").RunSilent() // nolint: errcheck
+		if hadGnupgHome {
+			os.Setenv("GNUPGHOME", prevGnupgHome)
+		} else {
+			os.Unsetenv("GNUPGHOME")
+		}
+	}()
+
+	const signKey = "test@example.com"
+	require.Nil(t, command.New(
+		"gpg", "--batch", "--passphrase", "", "--quick-generate-key",
+		signKey, "default", "default", "never",
+	).RunSilentSuccess())
+
+	file := filepath.Join(dir, "asset.tar.gz")
+	require.Nil(t, os.WriteFile(file, []byte("asset"), os.FileMode(0o644)))
+
+	signatureDir := filepath.Join(dir, "signatures")
+	require.Nil(t, os.Mkdir(signatureDir, os.FileMode(0o755)))
+
+	// When
+	signatures, err := signAssets(signatureDir, signKey, []string{file})
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, []string{filepath.Join(signatureDir, "asset.tar.gz.asc")}, signatures)
+	content, err := os.ReadFile(signatures[0])
+	require.Nil(t, err)
+	require.Contains(t, string(content), "BEGIN PGP SIGNATURE")
+	require.Nil(t, command.New(
+		"gpg", "--batch", "--verify", signatures[0], file,
+	).RunSilentSuccess())
+
+	// When the key does not exist
+	_, err = signAssets(signatureDir, "missing@example.com", []string{file})
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestUploadAndVerifyAsset(t *testing.T) {
+	const (
+		content = "test"
+		sha256  = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	)
+
+	dir, err := os.MkdirTemp("", "upload-asset-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "asset.tar.gz")
+	require.Nil(t, os.WriteFile(path, []byte(content), os.FileMode(0o644)))
+
+	for _, tc := range []struct {
+		name      string
+		prepare   func(*githubfakes.FakeClient)
+		shouldErr bool
+		downloads int
+	}{
+		{
+			name: "checksum matches",
+			prepare: func(client *githubfakes.FakeClient) {
+				client.DownloadReleaseAssetReturns(
+					io.NopCloser(strings.NewReader(content)), "", nil,
+				)
+			},
+			downloads: 1,
+		},
+		{
+			name: "checksum mismatches",
+			prepare: func(client *githubfakes.FakeClient) {
+				client.DownloadReleaseAssetReturns(
+					io.NopCloser(strings.NewReader("corrupted")), "", nil,
+				)
+			},
+			shouldErr: true,
+			downloads: 1,
+		},
+		{
+			name: "upload fails",
+			prepare: func(client *githubfakes.FakeClient) {
+				client.UploadReleaseAssetReturns(nil, errors.New("error"))
+			},
+			shouldErr: true,
+		},
+		{
+			name: "download fails",
+			prepare: func(client *githubfakes.FakeClient) {
+				client.DownloadReleaseAssetReturns(nil, "", errors.New("error"))
+			},
+			shouldErr: true,
+			downloads: 1,
+		},
+	} {
+		// Given
+		client := &githubfakes.FakeClient{}
+		client.UploadReleaseAssetReturns(
+			&gogithub.ReleaseAsset{
+				ID:   gogithub.Int64(1),
+				Name: gogithub.String("asset.tar.gz"),
+			}, nil,
+		)
+		tc.prepare(client)
+		gh := github.New()
+		gh.SetClient(client)
+
+		// When
+		err := uploadAndVerifyAsset(gh, "owner", "repo", 2, path, path, sha256)
+
+		// Then
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.name)
+		} else {
+			require.Nil(t, err, tc.name)
+		}
+		require.Equal(t, 1, client.UploadReleaseAssetCallCount(), tc.name)
+		_, _, _, releaseID, _, _ := client.UploadReleaseAssetArgsForCall(0)
+		require.EqualValues(t, 2, releaseID, tc.name)
+		require.Equal(t, tc.downloads, client.DownloadReleaseAssetCallCount(), tc.name)
+		if tc.downloads > 0 {
+			_, _, _, assetID := client.DownloadReleaseAssetArgsForCall(0)
+			require.EqualValues(t, 1, assetID, tc.name)
+		}
+	}
+}
@@ -96,6 +96,10 @@ type GitHubPageOptions struct {
 	// We automatizally calculate most values, but more substitutions for
 	// the template can be supplied
 	Substitutions map[string]string
+
+	// SignKey is the GPG key used to create detached signatures of all
+	// assets and checksum files. No signatures get uploaded if empty.
+	SignKey string
 }
 
 // UpdateGitHubPage updates a github page with data from the release
@@ -247,15 +251,61 @@ func UpdateGitHubPage(opts *GitHubPageOptions) (err error) {
 	}
 
 	// publish binary
-	for _, assetData := range releaseAssets {
-		logrus.Infof("Uploading %s as release asset", assetData["realpath"])
-		asset, err := gh.UploadReleaseAsset(opts.Owner, opts.Repo, release.GetID(), assetData["rawpath"])
+	if err := uploadReleaseAssets(gh, opts, release.GetID(), releaseAssets); err != nil {
+		return errors.Wrap(err, "uploading the release assets")
+	}
+	logrus.Infof("Release %s published on GitHub", opts.Tag)
+	return nil
+}
+
+// uploadReleaseAssets uploads the assets to the release together with their
+// checksum files and, if a sign key is set, the detached signatures of all
+// of them. Every asset gets downloaded again after its upload to verify its
+// checksum.
+func uploadReleaseAssets(
+	gh *github.GitHub, opts *GitHubPageOptions, releaseID int64,
+	releaseAssets []map[string]string,
+) error {
+	if len(releaseAssets) == 0 {
+		return nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "release-assets-")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	generated, err := writeChecksumAssets(tempDir, releaseAssets)
+	if err != nil {
+		return errors.Wrap(err, "writing the checksum files")
+	}
+
+	if opts.SignKey != "" {
+		files := append([]string{}, generated...)
+		for _, assetData := range releaseAssets {
+			files = append(files, assetData["realpath"])
+		}
+		signatures, err := signAssets(tempDir, opts.SignKey, files)
 		if err != nil {
-			return errors.Wrapf(err, "uploading %s to the release", assetData["realpath"])
+			return errors.Wrap(err, "signing the release assets")
+		}
+		generated = append(generated, signatures...)
+	}
+
+	generatedAssets, err := processAssetFiles(generated)
+	if err != nil {
+		return errors.Wrap(err, "processing the generated asset files")
+	}
+
+	for _, assetData := range append(releaseAssets, generatedAssets...) {
+		if err := uploadAndVerifyAsset(
+			gh, opts.Owner, opts.Repo, releaseID,
+			assetData["rawpath"], assetData["realpath"], assetData["sha256"],
+		); err != nil {
+			return err
 		}
-		logrus.Info("Successfully uploaded asset #", asset.GetID())
 	}
-	logrus.Infof("Release %s published on GitHub", opts.Tag)
 	return nil
 }
 
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// SlackWebhooksEnvKey is the environment variable containing the comma
+// separated Slack incoming webhook URLs which receive the announcements.
+const SlackWebhooksEnvKey = "SLACK_WEBHOOK_URLS"
+
+// SlackOptions are the settings for posting messages to Slack.
+type SlackOptions struct {
+	// Webhooks are the incoming webhook URLs of the Slack channels.
+	Webhooks []string
+
+	// NoMock posts the message, otherwise it only gets logged.
+	NoMock bool
+}
+
+// SlackWebhooksFromEnv returns the webhook URLs configured by the
+// $SLACK_WEBHOOK_URLS environment variable.
+func SlackWebhooksFromEnv() []string {
+	webhooks := []string{}
+	for _, webhook := range strings.Split(os.Getenv(SlackWebhooksEnvKey), ",") {
+		if webhook = strings.TrimSpace(webhook); webhook != "" {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks
+}
+
+// SlackReleaseText returns the Slack message announcing the release of tag,
+// which links the changelog at changelogPath in kubernetes/kubernetes.
+func SlackReleaseText(tag, changelogPath string) string {
+	return fmt.Sprintf(
+		":kubernetes: Kubernetes %s has been released!\n"+
+			"<https://github.com/kubernetes/kubernetes/releases/tag/%s|Release page> | "+
+			"<https://github.com/kubernetes/kubernetes/blob/master/%s|Changelog>",
+		tag, tag, changelogPath,
+	)
+}
+
+// SlackStageText returns the Slack message announcing that the versions
+// have been staged from the build version.
+func SlackStageText(buildVersion string, versions []string) string {
+	return fmt.Sprintf(
+		":package: Staged Kubernetes %s from build %s",
+		strings.Join(versions, ", "), buildVersion,
+	)
+}
+
+// PostSlackMessage posts the text to all webhooks of the options. Every
+// webhook is tried, even if posting to a previous one failed.
+func PostSlackMessage(options *SlackOptions, text string) error {
+	if len(options.Webhooks) == 0 {
+		logrus.Info("No Slack webhooks configured, skipping Slack message")
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return errors.Wrap(err, "marshaling Slack message")
+	}
+
+	if !options.NoMock {
+		logrus.Infof(
+			"Mock mode: not posting Slack message to %d channel(s):\n%s",
+			len(options.Webhooks), text,
+		)
+		return nil
+	}
+
+	failed := 0
+	for i, webhook := range options.Webhooks {
+		// The URL is not logged, because webhook URLs contain a secret
+		logrus.Infof("Posting Slack message to webhook #%d", i+1)
+		if err := postSlackWebhook(webhook, payload); err != nil {
+			logrus.Warnf("Unable to post Slack message to webhook #%d: %v", i+1, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf(
+			"posting Slack message failed for %d of %d webhooks",
+			failed, len(options.Webhooks),
+		)
+	}
+	return nil
+}
+
+// postSlackWebhook sends the payload to the webhook.
+func postSlackWebhook(webhook string, payload []byte) error {
+	response, err := http.Post(webhook, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		// Strip the URL from the error, because it must not be shown
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return errors.Wrap(err, "sending request")
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return errors.Errorf("unexpected status %s", response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/announce"
+)
+
+func TestPostSlackMessage(t *testing.T) {
+	// Given
+	received := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		payload := map[string]string{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+		received = append(received, r.URL.Path+": "+payload["text"])
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	text := announce.SlackReleaseText("v1.21.0", "CHANGELOG/CHANGELOG-1.21.md")
+	require.Contains(t, text, "Kubernetes v1.21.0 has been released!")
+	require.Contains(t, text, "/releases/tag/v1.21.0|")
+	require.Contains(t, text, "/blob/master/CHANGELOG/CHANGELOG-1.21.md|")
+
+	// When
+	err := announce.PostSlackMessage(&announce.SlackOptions{
+		Webhooks: []string{server.URL + "/a", server.URL + "/b"},
+	}, text)
+
+	// Then
+	require.Nil(t, err)
+	require.Empty(t, received)
+
+	// When
+	err = announce.PostSlackMessage(&announce.SlackOptions{
+		Webhooks: []string{server.URL + "/broken", server.URL + "/b"},
+		NoMock:   true,
+	}, "staged")
+
+	// Then
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "failed for 1 of 2 webhooks")
+	require.Equal(t, []string{"/broken: staged", "/b: staged"}, received)
+}
+
+func TestSlackWebhooksFromEnv(t *testing.T) {
+	// Given
+	prev, set := os.LookupEnv(announce.SlackWebhooksEnvKey)
+	defer func() {
+		if set {
+			os.Setenv(announce.SlackWebhooksEnvKey, prev)
+		} else {
+			os.Unsetenv(announce.SlackWebhooksEnvKey)
+		}
+	}()
+	require.Nil(t, os.Setenv(
+		announce.SlackWebhooksEnvKey, " https://hooks.slack.com/a,,https://hooks.slack.com/b ",
+	))
+
+	// When
+	res := announce.SlackWebhooksFromEnv()
+
+	// Then
+	require.Equal(t, []string{"https://hooks.slack.com/a", "https://hooks.slack.com/b"}, res)
+}
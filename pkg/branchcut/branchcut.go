@@ -0,0 +1,311 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchcut
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// Options are the options of a release branch cut.
+type Options struct {
+	// Branch is the release branch to be created, like release-1.23.
+	Branch string
+
+	// Revision is the commit on the default branch the release branch gets
+	// created from. Defaults to the head of the remote default branch.
+	Revision string
+
+	// Mode determines whether the branch and the version marker are pushed
+	// to the remote or only simulated.
+	Mode release.Mode
+
+	// RepoPath is the local path to the Kubernetes repository.
+	RepoPath string
+
+	// StateFile is the path to the file recording the completed steps,
+	// which allows resuming a failed branch cut. Empty disables it.
+	StateFile string
+}
+
+// Validate checks if the options are valid for a branch cut.
+func (o *Options) Validate() error {
+	if err := o.Mode.Validate(); err != nil {
+		return err
+	}
+	if !git.IsReleaseBranch(o.Branch) || o.Branch == git.DefaultBranch {
+		return errors.Errorf("%s is not a valid release branch", o.Branch)
+	}
+	return nil
+}
+
+// String returns a string representation for the options, which identifies
+// a branch cut in the state file.
+func (o *Options) String() string {
+	return fmt.Sprintf(
+		"branch: %s, revision: %s, mode: %s", o.Branch, o.Revision, o.Mode,
+	)
+}
+
+// Result is the outcome of a branch cut.
+type Result struct {
+	// Branch is the created release branch.
+	Branch string
+
+	// Revision is the commit the release branch points to.
+	Revision string
+
+	// Tag is the version marker of the next minor release on the default
+	// branch, like v1.24.0-alpha.0.
+	Tag string
+
+	// FollowUps are the manual tasks to be done after the branch cut.
+	FollowUps []string
+}
+
+// BranchCut creates a new release branch from the default branch.
+type BranchCut struct {
+	options *Options
+	impl
+}
+
+// New creates a new BranchCut instance.
+func New(options *Options) *BranchCut {
+	return &BranchCut{options: options, impl: &defaultImpl{}}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (b *BranchCut) SetImpl(impl impl) {
+	b.impl = impl
+}
+
+// Run cuts the release branch: it verifies that the branch does not exist
+// yet and that the revision belongs to the release cycle of the branch,
+// creates and pushes the branch, and pushes the first alpha tag of the next
+// minor version on top of the default branch. The latter keeps `git
+// describe` on both branches unambiguous. All completed steps are recorded
+// in the state file if configured.
+func (b *BranchCut) Run() (*Result, error) {
+	if err := b.options.Validate(); err != nil {
+		return nil, errors.Wrap(err, "validating options")
+	}
+	var major, minor uint64
+	if _, err := fmt.Sscanf(
+		b.options.Branch, "release-%d.%d", &major, &minor,
+	); err != nil {
+		return nil, errors.Wrapf(err, "parsing release branch %s", b.options.Branch)
+	}
+	tag := fmt.Sprintf("v%d.%d.0-alpha.0", major, minor+1)
+
+	repo, err := b.impl.CloneOrOpenRepo(b.options.RepoPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "open Kubernetes repository")
+	}
+	pusherOptions := &release.GitObjectPusherOptions{
+		DryRun:     b.options.Mode.DryRun(),
+		MaxRetries: 10,
+		RepoPath:   b.options.RepoPath,
+	}
+
+	revision := b.options.Revision
+	if revision == "" {
+		revision = git.Remotify(git.DefaultBranch)
+	}
+	revision, err = b.impl.RevParse(repo, revision)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving revision %s", b.options.Revision)
+	}
+
+	cp, err := release.NewCheckpoint(b.options.StateFile, b.options.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "loading state file")
+	}
+
+	if err := cp.Run("verify revision", func() error {
+		return b.verify(repo, revision, major, minor)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := cp.Run("create release branch", func() error {
+		logrus.Infof("Creating branch %s from %s", b.options.Branch, revision)
+		return errors.Wrap(
+			b.impl.Checkout(repo, "-b", b.options.Branch, revision),
+			"create release branch",
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := cp.Run("push release branch", func() error {
+		return errors.Wrap(
+			b.impl.PushBranch(pusherOptions, b.options.Branch),
+			"push release branch",
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := cp.Run("tag default branch", func() error {
+		return b.tagDefaultBranch(repo, tag)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := cp.Run("push default branch", func() error {
+		if err := b.impl.PushMain(pusherOptions); err != nil {
+			return errors.Wrapf(err, "push %s branch", git.DefaultBranch)
+		}
+		return errors.Wrapf(
+			b.impl.PushTag(pusherOptions, tag), "push tag %s", tag,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	branchRevision, err := b.impl.RevParse(repo, b.options.Branch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving branch %s", b.options.Branch)
+	}
+
+	return &Result{
+		Branch:    b.options.Branch,
+		Revision:  branchRevision,
+		Tag:       tag,
+		FollowUps: followUps(b.options.Branch, major, minor),
+	}, nil
+}
+
+// verify checks that the release branch does not exist on the remote and
+// that the revision is part of the default branch and within the release
+// cycle of the branch, which means that the latest tag is a pre-release of
+// its minor version.
+func (b *BranchCut) verify(repo *git.Repo, revision string, major, minor uint64) error {
+	logrus.Infof("Checking if branch %s already exists", b.options.Branch)
+	exists, err := b.impl.HasRemoteBranch(repo, b.options.Branch)
+	if err != nil {
+		return errors.Wrapf(err, "checking if branch %s exists", b.options.Branch)
+	}
+	if exists {
+		return errors.Errorf("branch %s already exists on the remote", b.options.Branch)
+	}
+
+	ahead, _, err := b.impl.AheadBehind(repo, git.Remotify(git.DefaultBranch), revision)
+	if err != nil {
+		return errors.Wrapf(err, "comparing %s with %s", revision, git.DefaultBranch)
+	}
+	if ahead > 0 {
+		return errors.Errorf(
+			"revision %s is not part of the %s branch", revision, git.DefaultBranch,
+		)
+	}
+
+	latestTag, err := b.impl.Describe(repo,
+		git.NewDescribeOptions().
+			WithRevision(revision).
+			WithAbbrev(0).
+			WithTags(),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "describing revision %s", revision)
+	}
+	version, err := util.TagStringToSemver(latestTag)
+	if err != nil {
+		return errors.Wrapf(err, "parsing latest tag %s", latestTag)
+	}
+	if version.Major != major || version.Minor != minor || len(version.Pre) == 0 {
+		return errors.Errorf(
+			"latest tag %s of revision %s is not a pre-release of %d.%d",
+			latestTag, revision, major, minor,
+		)
+	}
+	logrus.Infof("Latest tag of revision %s is %s", revision, latestTag)
+	return nil
+}
+
+// tagDefaultBranch creates an empty commit on top of the remote default
+// branch and tags it as the first alpha of the next minor version. Tagging the
+// commit of the branch cut instead would cause the release branch to be
+// described as the next minor version as well. Like anago, the step is
+// skipped if the tag already exists, and the tag has to comply with the tag
+// policy. Resetting the local branch ensures that an interrupted run does not
+// leave a second marker commit behind.
+func (b *BranchCut) tagDefaultBranch(repo *git.Repo, tag string) error {
+	if _, err := b.impl.RevParseTag(repo, tag); err == nil {
+		logrus.Infof("Tag %s already exists, skipping", tag)
+		return nil
+	}
+
+	remoteBranch := git.Remotify(git.DefaultBranch)
+	logrus.Infof("Resetting %s branch to %s", git.DefaultBranch, remoteBranch)
+	if err := b.impl.Checkout(repo, "-B", git.DefaultBranch, remoteBranch); err != nil {
+		return errors.Wrapf(err, "reset %s branch", git.DefaultBranch)
+	}
+
+	report, err := b.impl.ValidateTag(repo, tag, git.DefaultBranch)
+	if err != nil {
+		return errors.Wrapf(err, "validate tag %s", tag)
+	}
+	logrus.Info(report.String())
+	if err := report.Err(); err != nil {
+		return err
+	}
+
+	logrus.Infof("Creating version marker commit for %s", tag)
+	if err := b.impl.CommitEmpty(
+		repo, fmt.Sprintf("Start the development of Kubernetes %s", tag),
+	); err != nil {
+		return errors.Wrap(err, "create version marker commit")
+	}
+
+	logrus.Infof("Tagging version %s", tag)
+	return errors.Wrapf(
+		b.impl.Tag(
+			repo, tag, fmt.Sprintf("Kubernetes %s release %s", release.ReleaseTypeAlpha, tag),
+		),
+		"tag version %s", tag,
+	)
+}
+
+// followUps returns the manual tasks to be done after cutting the branch.
+func followUps(branch string, major, minor uint64) []string {
+	return []string{
+		fmt.Sprintf(
+			"Announce the creation of %s using `krel announce build branch`", branch,
+		),
+		fmt.Sprintf("Verify the branch protection of %s", branch),
+		fmt.Sprintf(
+			"Create the CI jobs and testgrid dashboards for %s in kubernetes/test-infra",
+			branch,
+		),
+		fmt.Sprintf(
+			"Stage and release v%d.%d.0-rc.0 from %s", major, minor, branch,
+		),
+		fmt.Sprintf(
+			"Fast forward %s during code freeze using `krel ff`", branch,
+		),
+		fmt.Sprintf(
+			"Add the %d.%d patch releases to the patch release schedule", major, minor,
+		),
+	}
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchcut_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/branchcut"
+	"k8s.io/release/pkg/branchcut/branchcutfakes"
+	"k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/release"
+)
+
+var err = errors.New("error")
+
+func newOptions() *branchcut.Options {
+	return &branchcut.Options{
+		Branch: "release-1.23",
+		Mode:   release.ModeMock,
+	}
+}
+
+func newMock() *branchcutfakes.FakeImpl {
+	mock := &branchcutfakes.FakeImpl{}
+	mock.RevParseReturns("abcdef", nil)
+	mock.AheadBehindReturns(0, 10, nil)
+	mock.DescribeReturns("v1.23.0-beta.2", nil)
+	mock.RevParseTagReturns("", err)
+	mock.ValidateTagReturns(&release.TagPolicyReport{}, nil)
+	return mock
+}
+
+func TestRunSuccess(t *testing.T) {
+	// Given
+	mock := newMock()
+	sut := branchcut.New(newOptions())
+	sut.SetImpl(mock)
+
+	// When
+	res, err := sut.Run()
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "release-1.23", res.Branch)
+	require.Equal(t, "abcdef", res.Revision)
+	require.Equal(t, "v1.24.0-alpha.0", res.Tag)
+	require.Len(t, res.FollowUps, 6)
+
+	_, rev := mock.RevParseArgsForCall(0)
+	require.Equal(t, git.Remotify(git.DefaultBranch), rev)
+
+	_, rev, args := mock.CheckoutArgsForCall(0)
+	require.Equal(t, "-b", rev)
+	require.Equal(t, []string{"release-1.23", "abcdef"}, args)
+
+	_, rev, args = mock.CheckoutArgsForCall(1)
+	require.Equal(t, "-B", rev)
+	require.Equal(t, []string{git.DefaultBranch, git.Remotify(git.DefaultBranch)}, args)
+	_, tag, branch := mock.ValidateTagArgsForCall(0)
+	require.Equal(t, "v1.24.0-alpha.0", tag)
+	require.Equal(t, git.DefaultBranch, branch)
+	require.Equal(t, 1, mock.CommitEmptyCallCount())
+
+	_, tag, _ = mock.TagArgsForCall(0)
+	require.Equal(t, "v1.24.0-alpha.0", tag)
+
+	opts, branch := mock.PushBranchArgsForCall(0)
+	require.True(t, opts.DryRun)
+	require.Equal(t, "release-1.23", branch)
+	require.Equal(t, 1, mock.PushMainCallCount())
+	_, tag = mock.PushTagArgsForCall(0)
+	require.Equal(t, "v1.24.0-alpha.0", tag)
+}
+
+func TestRunTagExists(t *testing.T) {
+	// Given
+	mock := newMock()
+	mock.RevParseTagReturns("abcdef", nil)
+	sut := branchcut.New(newOptions())
+	sut.SetImpl(mock)
+
+	// When
+	res, err := sut.Run()
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "v1.24.0-alpha.0", res.Tag)
+	_, tag := mock.RevParseTagArgsForCall(0)
+	require.Equal(t, "v1.24.0-alpha.0", tag)
+	require.Equal(t, 1, mock.CheckoutCallCount())
+	require.Zero(t, mock.ValidateTagCallCount())
+	require.Zero(t, mock.CommitEmptyCallCount())
+	require.Zero(t, mock.TagCallCount())
+	require.Equal(t, 1, mock.PushMainCallCount())
+	require.Equal(t, 1, mock.PushTagCallCount())
+}
+
+func TestRunNextTags(t *testing.T) {
+	// Given
+	branchTags := []string{
+		"v1.22.0", "v1.23.0-alpha.4", "v1.23.0-beta.0", "v1.23.0-beta.1",
+	}
+	mock := newMock()
+	mock.DescribeReturns(branchTags[len(branchTags)-1], nil)
+	sut := branchcut.New(newOptions())
+	sut.SetImpl(mock)
+
+	// When
+	res, err := sut.Run()
+
+	// Then the release branch continues with its first release candidate
+	require.Nil(t, err)
+	tags, err := release.NextTags(release.ReleaseTypeRC, res.Branch, branchTags)
+	require.Nil(t, err)
+	require.Equal(t, []string{"v1.23.0-rc.0"}, tags)
+	require.Contains(t, res.FollowUps,
+		fmt.Sprintf("Stage and release %s from %s", tags[0], res.Branch),
+	)
+
+	// And the default branch with the next alpha
+	tags, err = release.NextTags(
+		release.ReleaseTypeAlpha, git.DefaultBranch, append(branchTags, res.Tag),
+	)
+	require.Nil(t, err)
+	require.Equal(t, []string{"v1.24.0-alpha.1"}, tags)
+}
+
+func TestRunSuccessNoMockRevision(t *testing.T) {
+	// Given
+	mock := newMock()
+	opts := newOptions()
+	opts.Mode = release.ModeNoMock
+	opts.Revision = "v1.23.0-beta.2"
+	sut := branchcut.New(opts)
+	sut.SetImpl(mock)
+
+	// When
+	_, err := sut.Run()
+
+	// Then
+	require.Nil(t, err)
+	_, rev := mock.RevParseArgsForCall(0)
+	require.Equal(t, "v1.23.0-beta.2", rev)
+	pusherOpts, _ := mock.PushBranchArgsForCall(0)
+	require.False(t, pusherOpts.DryRun)
+}
+
+func TestRunFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		prepare func(*branchcut.Options, *branchcutfakes.FakeImpl)
+	}{
+		{
+			name: "invalid branch",
+			prepare: func(opts *branchcut.Options, _ *branchcutfakes.FakeImpl) {
+				opts.Branch = "release-1"
+			},
+		},
+		{
+			name: "default branch",
+			prepare: func(opts *branchcut.Options, _ *branchcutfakes.FakeImpl) {
+				opts.Branch = git.DefaultBranch
+			},
+		},
+		{
+			name: "invalid mode",
+			prepare: func(opts *branchcut.Options, _ *branchcutfakes.FakeImpl) {
+				opts.Mode = ""
+			},
+		},
+		{
+			name: "clone repo fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.CloneOrOpenRepoReturns(nil, err)
+			},
+		},
+		{
+			name: "resolve revision fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.RevParseReturns("", err)
+			},
+		},
+		{
+			name: "check remote branch fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.HasRemoteBranchReturns(false, err)
+			},
+		},
+		{
+			name: "branch already exists",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.HasRemoteBranchReturns(true, nil)
+			},
+		},
+		{
+			name: "ahead behind fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.AheadBehindReturns(0, 0, err)
+			},
+		},
+		{
+			name: "revision not on default branch",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.AheadBehindReturns(1, 0, nil)
+			},
+		},
+		{
+			name: "describe fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.DescribeReturns("", err)
+			},
+		},
+		{
+			name: "latest tag of another minor",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.DescribeReturns("v1.22.0-beta.2", nil)
+			},
+		},
+		{
+			name: "latest tag no pre-release",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.DescribeReturns("v1.23.0", nil)
+			},
+		},
+		{
+			name: "create branch fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.CheckoutReturnsOnCall(0, err)
+			},
+		},
+		{
+			name: "push branch fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.PushBranchReturns(err)
+			},
+		},
+		{
+			name: "checkout default branch fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.CheckoutReturnsOnCall(1, err)
+			},
+		},
+		{
+			name: "validate tag fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.ValidateTagReturns(nil, err)
+			},
+		},
+		{
+			name: "tag violates the tag policy",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.ValidateTagReturns(&release.TagPolicyReport{
+					Checks: []release.TagPolicyCheck{
+						{Name: release.TagCheckRemoteTag, Passed: false},
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "commit fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.CommitEmptyReturns(err)
+			},
+		},
+		{
+			name: "tag fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.TagReturns(err)
+			},
+		},
+		{
+			name: "push default branch fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.PushMainReturns(err)
+			},
+		},
+		{
+			name: "push tag fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.PushTagReturns(err)
+			},
+		},
+		{
+			name: "resolve branch fails",
+			prepare: func(_ *branchcut.Options, mock *branchcutfakes.FakeImpl) {
+				mock.RevParseReturnsOnCall(1, "", err)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			mock := newMock()
+			opts := newOptions()
+			tc.prepare(opts, mock)
+			sut := branchcut.New(opts)
+			sut.SetImpl(mock)
+
+			// When
+			_, err := sut.Run()
+
+			// Then
+			require.NotNil(t, err)
+		})
+	}
+}
+
+func TestRunResume(t *testing.T) {
+	// Given
+	mock := newMock()
+	mock.PushMainReturns(err)
+	opts := newOptions()
+	opts.StateFile = filepath.Join(t.TempDir(), "state.json")
+	sut := branchcut.New(opts)
+	sut.SetImpl(mock)
+
+	// When
+	_, err := sut.Run()
+
+	// Then
+	require.NotNil(t, err)
+	require.Equal(t, 1, mock.HasRemoteBranchCallCount())
+	require.Equal(t, 2, mock.CheckoutCallCount())
+	require.Equal(t, 1, mock.PushBranchCallCount())
+	require.Equal(t, 1, mock.TagCallCount())
+
+	// When the branch cut gets resumed
+	mock.PushMainReturns(nil)
+	_, err = sut.Run()
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, 1, mock.HasRemoteBranchCallCount())
+	require.Equal(t, 2, mock.CheckoutCallCount())
+	require.Equal(t, 1, mock.PushBranchCallCount())
+	require.Equal(t, 1, mock.TagCallCount())
+	require.Equal(t, 2, mock.PushMainCallCount())
+	require.Equal(t, 1, mock.PushTagCallCount())
+
+	// When the branch cut gets resumed after tagging failed
+	opts.StateFile = filepath.Join(t.TempDir(), "state.json")
+	mock = newMock()
+	mock.TagReturns(errors.New("error"))
+	sut.SetImpl(mock)
+	_, err = sut.Run()
+
+	// Then
+	require.NotNil(t, err)
+	require.Equal(t, 1, mock.CommitEmptyCallCount())
+
+	// When the tagging succeeds
+	mock.TagReturns(nil)
+	_, err = sut.Run()
+
+	// Then the marker commit is recreated on top of the remote branch
+	require.Nil(t, err)
+	require.Equal(t, 2, mock.CommitEmptyCallCount())
+	require.Equal(t, 3, mock.CheckoutCallCount())
+	_, rev, args := mock.CheckoutArgsForCall(2)
+	require.Equal(t, "-B", rev)
+	require.Equal(t, []string{git.DefaultBranch, git.Remotify(git.DefaultBranch)}, args)
+
+	// When the state file belongs to another branch cut
+	opts.Branch = "release-1.24"
+	_, err = sut.Run()
+
+	// Then
+	require.NotNil(t, err)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branchcut
+
+import (
+	"k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/release"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . impl
+type impl interface {
+	CloneOrOpenRepo(repoPath string) (*git.Repo, error)
+	HasRemoteBranch(repo *git.Repo, branch string) (bool, error)
+	RevParse(repo *git.Repo, rev string) (string, error)
+	RevParseTag(repo *git.Repo, rev string) (string, error)
+	AheadBehind(repo *git.Repo, base, rev string) (int, int, error)
+	Describe(repo *git.Repo, opts *git.DescribeOptions) (string, error)
+	Checkout(repo *git.Repo, rev string, args ...string) error
+	CommitEmpty(repo *git.Repo, msg string) error
+	ValidateTag(repo *git.Repo, tag, branch string) (*release.TagPolicyReport, error)
+	Tag(repo *git.Repo, name, message string) error
+	PushBranch(opts *release.GitObjectPusherOptions, branch string) error
+	PushTag(opts *release.GitObjectPusherOptions, tag string) error
+	PushMain(opts *release.GitObjectPusherOptions) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) CloneOrOpenRepo(repoPath string) (*git.Repo, error) {
+	return git.CloneOrOpenDefaultGitHubRepoSSH(repoPath)
+}
+
+func (*defaultImpl) HasRemoteBranch(repo *git.Repo, branch string) (bool, error) {
+	return repo.HasRemoteBranch(branch)
+}
+
+func (*defaultImpl) RevParse(repo *git.Repo, rev string) (string, error) {
+	return repo.RevParse(rev)
+}
+
+func (*defaultImpl) RevParseTag(repo *git.Repo, rev string) (string, error) {
+	return repo.RevParseTag(rev)
+}
+
+func (*defaultImpl) AheadBehind(repo *git.Repo, base, rev string) (ahead, behind int, err error) {
+	return repo.AheadBehind(base, rev)
+}
+
+func (*defaultImpl) Describe(repo *git.Repo, opts *git.DescribeOptions) (string, error) {
+	return repo.Describe(opts)
+}
+
+func (*defaultImpl) Checkout(repo *git.Repo, rev string, args ...string) error {
+	return repo.Checkout(rev, args...)
+}
+
+func (*defaultImpl) CommitEmpty(repo *git.Repo, msg string) error {
+	return repo.CommitEmpty(msg)
+}
+
+func (*defaultImpl) ValidateTag(
+	repo *git.Repo, tag, branch string,
+) (*release.TagPolicyReport, error) {
+	return release.NewTagValidator(repo).Validate(tag, branch)
+}
+
+func (*defaultImpl) Tag(repo *git.Repo, name, message string) error {
+	return repo.Tag(name, message)
+}
+
+func (*defaultImpl) PushBranch(opts *release.GitObjectPusherOptions, branch string) error {
+	pusher, err := release.NewGitPusher(opts)
+	if err != nil {
+		return err
+	}
+	return pusher.PushBranch(branch)
+}
+
+func (*defaultImpl) PushTag(opts *release.GitObjectPusherOptions, tag string) error {
+	pusher, err := release.NewGitPusher(opts)
+	if err != nil {
+		return err
+	}
+	return pusher.PushTag(tag)
+}
+
+func (*defaultImpl) PushMain(opts *release.GitObjectPusherOptions) error {
+	pusher, err := release.NewGitPusher(opts)
+	if err != nil {
+		return err
+	}
+	return pusher.PushMain()
+}
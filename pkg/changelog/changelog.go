@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/blang/semver"
@@ -34,6 +35,10 @@ import (
 	"sigs.k8s.io/release-utils/util"
 )
 
+// sectionRegex matches the header of a release section in the changelog,
+// like `# v1.21.3`.
+var sectionRegex = regexp.MustCompile(`(?m)^# (v[0-9]+\.[0-9]+\.[0-9]+\S*)[ \t]*$`)
+
 // Options are the main settings for generating the changelog.
 type Options struct {
 	RepoPath     string
@@ -48,6 +53,15 @@ type Options struct {
 	CVEDataDir   string
 	CloneCVEMaps bool
 	Dependencies bool
+
+	// Fork is the GitHub fork of kubernetes/kubernetes in the form org/repo
+	// or org. If set, the changes of the default branch are proposed in a
+	// pull request from the fork instead of being committed directly.
+	Fork string
+
+	// DryRun does neither push the pull request branch nor create the pull
+	// request.
+	DryRun bool
 }
 
 // Changelog can be used to generate the changelog for a release.
@@ -230,6 +244,14 @@ func (c *Changelog) Run() error {
 		return errors.Wrap(err, "write JSON")
 	}
 
+	if c.options.Fork != "" {
+		logrus.Info("Creating pull request")
+		return errors.Wrap(
+			c.createPullRequest(repo, branch, tag),
+			"create pull request",
+		)
+	}
+
 	logrus.Info("Committing changes")
 	return errors.Wrap(
 		c.commitChanges(repo, branch, tag),
@@ -314,7 +336,7 @@ func (c *Changelog) writeMarkdown(
 		return writeFile(toc, markdown)
 	}
 
-	// Changelog seems to exist, prepend the notes and re-generate the TOC
+	// Changelog seems to exist, insert the notes and re-generate the TOC
 	logrus.Infof("Adding new content to changelog file %s ", changelogPath)
 	content, err := c.impl.ReadFile(changelogPath)
 	if err != nil {
@@ -329,8 +351,8 @@ func (c *Changelog) writeMarkdown(
 		)
 	}
 
-	mergedMarkdown := fmt.Sprintf(
-		"%s\n%s", markdown, string(content[(len(TocEnd)+tocEndIndex):]),
+	mergedMarkdown := mergeMarkdown(
+		string(content[(len(TocEnd)+tocEndIndex):]), markdown, tag,
 	)
 	mergedTOC, err := c.impl.GenerateTOC(mergedMarkdown)
 	if err != nil {
@@ -342,6 +364,41 @@ func (c *Changelog) writeMarkdown(
 	)
 }
 
+// mergeMarkdown inserts the markdown of the release tag into the existing
+// changelog content, which consists of one section per release ordered by
+// descending version. The section of the same release gets replaced, which
+// makes re-running the changelog generation for a tag idempotent.
+func mergeMarkdown(content, markdown string, tag semver.Version) string {
+	start, end := len(content), len(content)
+	sections := sectionRegex.FindAllStringSubmatchIndex(content, -1)
+	for i, section := range sections {
+		header := content[section[2]:section[3]]
+		version, err := util.TagStringToSemver(header)
+		if err != nil {
+			logrus.Debugf("Skipping changelog section %s: %v", header, err)
+			continue
+		}
+		if version.GT(tag) {
+			continue
+		}
+		start, end = section[0], section[0]
+		if version.EQ(tag) {
+			logrus.Infof("Replacing existing changelog section of %s", header)
+			end = len(content)
+			if i+1 < len(sections) {
+				end = sections[i+1][0]
+			}
+		}
+		break
+	}
+
+	section := strings.TrimSpace(markdown) + strings.Repeat(nl, 2)
+	if start == len(content) {
+		return strings.TrimRight(content, nl) + strings.Repeat(nl, 2) + section
+	}
+	return content[:start] + section + content[end:]
+}
+
 func (c *Changelog) htmlChangelogFilename(tag semver.Version) string {
 	if c.options.HTMLFile != "" {
 		return c.options.HTMLFile
@@ -454,25 +511,8 @@ func (c *Changelog) commitChanges(
 ) error {
 	// main branch modifications
 	releaseChangelog := markdownChangelogFilename(tag)
-	changelogReadme := markdownChangelogReadme()
-
-	changelogFiles := []string{
-		releaseChangelog,
-		changelogReadme,
-	}
-
-	for _, filename := range changelogFiles {
-		logrus.Infof("Adding %s to repository", filename)
-		if err := c.impl.Add(repo, filename); err != nil {
-			return errors.Wrapf(err, "add file %s to repository", filename)
-		}
-	}
-
-	logrus.Info("Committing changes to main branch in repository")
-	if err := c.impl.Commit(repo, fmt.Sprintf(
-		"CHANGELOG: Update directory for %s release", util.SemverToTagString(tag),
-	)); err != nil {
-		return errors.Wrap(err, "committing changes into repository")
+	if err := c.commitMainChanges(repo, tag); err != nil {
+		return err
 	}
 
 	if branch != git.DefaultBranch {
@@ -509,6 +549,136 @@ func (c *Changelog) commitChanges(
 	return nil
 }
 
+// commitMainChanges commits the modified changelog files of the main branch
+// into the currently checked out branch.
+func (c *Changelog) commitMainChanges(repo *git.Repo, tag semver.Version) error {
+	changelogFiles := []string{
+		markdownChangelogFilename(tag),
+		markdownChangelogReadme(),
+	}
+
+	for _, filename := range changelogFiles {
+		logrus.Infof("Adding %s to repository", filename)
+		if err := c.impl.Add(repo, filename); err != nil {
+			return errors.Wrapf(err, "add file %s to repository", filename)
+		}
+	}
+
+	logrus.Info("Committing changes to main branch in repository")
+	return errors.Wrap(
+		c.impl.Commit(repo, fmt.Sprintf(
+			"CHANGELOG: Update directory for %s release", util.SemverToTagString(tag),
+		)),
+		"committing changes into repository",
+	)
+}
+
+// createPullRequest commits the changes of the main branch into a new
+// branch, pushes it to the fork and creates a pull request against the main
+// branch of kubernetes/kubernetes. The release branch does not get modified,
+// because its changelog has to match the merged one.
+func (c *Changelog) createPullRequest(
+	repo *git.Repo, branch string, tag semver.Version,
+) error {
+	org, forkRepo, err := git.ParseRepoSlug(c.options.Fork)
+	if err != nil {
+		return errors.Wrapf(err, "parse fork %s", c.options.Fork)
+	}
+	if forkRepo == "" {
+		forkRepo = git.DefaultGithubRepo
+	}
+
+	tagString := util.SemverToTagString(tag)
+	prBranch := prBranchPrefix + tagString
+	logrus.Infof("Checking out pull request branch %s", prBranch)
+	if err := c.impl.Checkout(repo, "-B", prBranch); err != nil {
+		return errors.Wrapf(err, "checkout branch %s", prBranch)
+	}
+
+	if err := c.commitMainChanges(repo, tag); err != nil {
+		return err
+	}
+
+	if err := c.setupForkRemote(repo, org, forkRepo); err != nil {
+		return err
+	}
+
+	if c.options.DryRun {
+		logrus.Infof(
+			"Dry run: not pushing %s to %s/%s and not creating a pull request",
+			prBranch, org, forkRepo,
+		)
+		return nil
+	}
+
+	logrus.Infof("Pushing %s to %s/%s", prBranch, org, forkRepo)
+	if err := c.impl.PushToRemote(repo, forkRemote, prBranch); err != nil {
+		return errors.Wrapf(err, "push %s to %s/%s", prBranch, org, forkRepo)
+	}
+
+	pr, err := c.impl.CreatePullRequest(
+		git.DefaultGithubOrg, git.DefaultGithubRepo, git.DefaultBranch,
+		fmt.Sprintf("%s:%s", org, prBranch),
+		fmt.Sprintf("CHANGELOG: Update directory for %s release", tagString),
+		fmt.Sprintf(prBodyTemplate, tagString),
+	)
+	if err != nil {
+		return errors.Wrap(err, "create pull request")
+	}
+	logrus.Infof(
+		"Created pull request %s/%s#%d",
+		git.DefaultGithubOrg, git.DefaultGithubRepo, pr.GetNumber(),
+	)
+
+	if err := c.impl.AddLabels(
+		git.DefaultGithubOrg, git.DefaultGithubRepo, pr.GetNumber(), prLabels...,
+	); err != nil {
+		logrus.Warnf("Unable to label pull request #%d: %v", pr.GetNumber(), err)
+	}
+
+	if branch != git.DefaultBranch {
+		logrus.Infof(
+			"The changelog of branch %s has to be updated after merging the pull request",
+			branch,
+		)
+	}
+	return nil
+}
+
+// setupForkRemote points the fork remote to the provided fork. An existing
+// remote gets reused if it already has the fork URL, otherwise its URL gets
+// updated.
+func (c *Changelog) setupForkRemote(repo *git.Repo, org, forkRepo string) error {
+	url := git.GetRepoURL(org, forkRepo, true)
+	remotes, err := c.impl.Remotes(repo)
+	if err != nil {
+		return errors.Wrap(err, "list remotes")
+	}
+
+	for _, remote := range remotes {
+		if remote.Name() != forkRemote {
+			continue
+		}
+		for _, remoteURL := range remote.URLs() {
+			if remoteURL == url {
+				logrus.Infof("Using existing remote %s (%s)", forkRemote, url)
+				return nil
+			}
+		}
+		logrus.Infof("Updating the URL of remote %s to %s", forkRemote, url)
+		return errors.Wrapf(
+			c.impl.SetURL(repo, forkRemote, url),
+			"set URL of remote %s", forkRemote,
+		)
+	}
+
+	logrus.Infof("Adding remote %s for %s/%s", forkRemote, org, forkRepo)
+	return errors.Wrapf(
+		c.impl.AddRemote(repo, forkRemote, org, forkRepo),
+		"add remote %s", forkRemote,
+	)
+}
+
 func (c *Changelog) adaptChangelogReadmeFile(
 	repo *git.Repo, tag semver.Version,
 ) error {
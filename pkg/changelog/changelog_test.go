@@ -18,13 +18,16 @@ package changelog_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/blang/semver"
+	gogithub "github.com/google/go-github/v37/github"
 	"github.com/stretchr/testify/require"
 
 	"k8s.io/release/pkg/changelog"
 	"k8s.io/release/pkg/changelog/changelogfakes"
+	"k8s.io/release/pkg/git"
 	"k8s.io/release/pkg/github"
 	"k8s.io/release/pkg/notes"
 )
@@ -306,3 +309,235 @@ func TestRun(t *testing.T) {
 		}
 	}
 }
+
+func TestRunMergesChangelog(t *testing.T) {
+	const (
+		v1194 = "# v1.19.4\n\nNotes of v1.19.4\n\n"
+		v1193 = "# v1.19.3\n\nNotes of v1.19.3\n\n"
+		v1192 = "# v1.19.2\n\nNotes of v1.19.2\n\n"
+	)
+	for _, tc := range []struct {
+		existing string
+		expected string
+	}{
+		{ // prepend to previous releases
+			existing: v1192,
+			expected: v1193 + v1192,
+		},
+		{ // insert between newer and older releases
+			existing: v1194 + v1192,
+			expected: v1194 + v1193 + v1192,
+		},
+		{ // append after newer releases
+			existing: v1194,
+			expected: v1194 + v1193,
+		},
+		{ // replace the section of the same release
+			existing: v1194 + "# v1.19.3\n\nOutdated notes\n\n" + v1192,
+			expected: v1194 + v1193 + v1192,
+		},
+		{ // no existing releases
+			existing: "",
+			expected: v1193,
+		},
+	} {
+		// Given
+		options := &changelog.Options{}
+		sut := changelog.New(options)
+		mock := &changelogfakes.FakeImpl{}
+		mock.TagStringToSemverReturns(semver.Version{
+			Major: 1,
+			Minor: 19,
+			Patch: 3,
+		}, nil)
+		mock.ReadFileReturns([]byte(changelog.TocEnd+"\n\n"+tc.existing), nil)
+		mock.GatherReleaseNotesReturns(&notes.ReleaseNotes{}, nil)
+		mock.RenderMarkdownTemplateReturns("# v1.19.3\n\nNotes of v1.19.3\n", nil)
+		mock.GenerateTOCReturns("- toc", nil)
+		sut.SetImpl(mock)
+
+		// When
+		err := sut.Run()
+
+		// Then
+		require.Nil(t, err)
+		path, content, _ := mock.WriteFileArgsForCall(0)
+		require.Equal(t, "CHANGELOG/CHANGELOG-1.19.md", path)
+		require.Equal(t,
+			"<!-- BEGIN MUNGE: GENERATED_TOC -->\n\n- toc\n"+changelog.TocEnd+"\n\n"+
+				strings.TrimSpace(tc.expected),
+			string(content),
+		)
+		markdown := mock.GenerateTOCArgsForCall(1)
+		require.Equal(t, strings.TrimSpace(tc.expected), strings.TrimSpace(markdown))
+	}
+}
+
+func TestRunCreatesPullRequest(t *testing.T) {
+	err := errors.New("")
+	for _, tc := range []struct {
+		prepare func(*changelogfakes.FakeImpl, *changelog.Options)
+		assert  func(*changelogfakes.FakeImpl, error)
+	}{
+		{ // success
+			prepare: func(*changelogfakes.FakeImpl, *changelog.Options) {},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+
+				_, rev, args := mock.CheckoutArgsForCall(1)
+				require.Equal(t, "-B", rev)
+				require.Equal(t, []string{"changelog-v1.19.3"}, args)
+				require.Equal(t, 2, mock.AddCallCount())
+				require.Equal(t, 1, mock.CommitCallCount())
+
+				_, remote, owner, repo := mock.AddRemoteArgsForCall(0)
+				require.Equal(t, "userfork", remote)
+				require.Equal(t, "user", owner)
+				require.Equal(t, "kubernetes", repo)
+
+				_, remote, branch := mock.PushToRemoteArgsForCall(0)
+				require.Equal(t, "userfork", remote)
+				require.Equal(t, "changelog-v1.19.3", branch)
+
+				owner, repo, base, head, _, body := mock.CreatePullRequestArgsForCall(0)
+				require.Equal(t, "kubernetes", owner)
+				require.Equal(t, "kubernetes", repo)
+				require.Equal(t, "master", base)
+				require.Equal(t, "user:changelog-v1.19.3", head)
+				require.Contains(t, body, "```release-note\nNONE\n```")
+
+				_, _, number, labels := mock.AddLabelsArgsForCall(0)
+				require.Equal(t, 42, number)
+				require.Contains(t, labels, "kind/documentation")
+			},
+		},
+		{ // success with existing remote
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.RemotesReturns([]*git.Remote{
+					git.NewRemote("origin", []string{"url"}),
+					git.NewRemote("userfork", []string{
+						git.GetRepoURL("user", "kubernetes", true),
+					}),
+				}, nil)
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				require.Zero(t, mock.AddRemoteCallCount())
+				require.Zero(t, mock.SetURLCallCount())
+				require.Equal(t, 1, mock.CreatePullRequestCallCount())
+			},
+		},
+		{ // success with existing remote of another fork
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.RemotesReturns([]*git.Remote{
+					git.NewRemote("userfork", []string{
+						git.GetRepoURL("other", "kubernetes", true),
+					}),
+				}, nil)
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				require.Zero(t, mock.AddRemoteCallCount())
+				_, remote, url := mock.SetURLArgsForCall(0)
+				require.Equal(t, "userfork", remote)
+				require.Equal(t, git.GetRepoURL("user", "kubernetes", true), url)
+				require.Equal(t, 1, mock.CreatePullRequestCallCount())
+			},
+		},
+		{ // Remotes fails
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.RemotesReturns(nil, err)
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+				require.Zero(t, mock.PushToRemoteCallCount())
+			},
+		},
+		{ // AddRemote fails
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.AddRemoteReturns(err)
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+				require.Zero(t, mock.PushToRemoteCallCount())
+			},
+		},
+		{ // SetURL fails
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.RemotesReturns([]*git.Remote{
+					git.NewRemote("userfork", []string{"url"}),
+				}, nil)
+				mock.SetURLReturns(err)
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+				require.Zero(t, mock.PushToRemoteCallCount())
+			},
+		},
+		{ // success dry run
+			prepare: func(_ *changelogfakes.FakeImpl, o *changelog.Options) {
+				o.DryRun = true
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				require.Equal(t, 1, mock.CommitCallCount())
+				require.Zero(t, mock.PushToRemoteCallCount())
+				require.Zero(t, mock.CreatePullRequestCallCount())
+			},
+		},
+		{ // success even if labeling fails
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.AddLabelsReturns(err)
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+			},
+		},
+		{ // invalid fork
+			prepare: func(_ *changelogfakes.FakeImpl, o *changelog.Options) {
+				o.Fork = "user/fork/invalid"
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+			},
+		},
+		{ // PushToRemote fails
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.PushToRemoteReturns(err)
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+				require.Zero(t, mock.CreatePullRequestCallCount())
+			},
+		},
+		{ // CreatePullRequest fails
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.CreatePullRequestReturns(nil, err)
+			},
+			assert: func(mock *changelogfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+			},
+		},
+	} {
+		// Given
+		options := &changelog.Options{Fork: "user"}
+		sut := changelog.New(options)
+		mock := &changelogfakes.FakeImpl{}
+		mock.TagStringToSemverReturns(semver.Version{
+			Major: 1,
+			Minor: 19,
+			Patch: 3,
+		}, nil)
+		mock.ReadFileReturns([]byte(changelog.TocEnd), nil)
+		mock.GatherReleaseNotesReturns(&notes.ReleaseNotes{}, nil)
+		mock.CreatePullRequestReturns(&gogithub.PullRequest{Number: gogithub.Int(42)}, nil)
+		tc.prepare(mock, options)
+		sut.SetImpl(mock)
+
+		// When
+		err := sut.Run()
+
+		// Then
+		tc.assert(mock, err)
+	}
+}
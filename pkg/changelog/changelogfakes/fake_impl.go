@@ -24,6 +24,7 @@ import (
 	"text/template"
 
 	"github.com/blang/semver"
+	githuba "github.com/google/go-github/v37/github"
 	"github.com/yuin/goldmark/parser"
 	"k8s.io/release/pkg/git"
 	"k8s.io/release/pkg/github"
@@ -58,6 +59,34 @@ type FakeImpl struct {
 	addReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AddLabelsStub        func(string, string, int, ...string) error
+	addLabelsMutex       sync.RWMutex
+	addLabelsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 []string
+	}
+	addLabelsReturns struct {
+		result1 error
+	}
+	addLabelsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AddRemoteStub        func(*git.Repo, string, string, string) error
+	addRemoteMutex       sync.RWMutex
+	addRemoteArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	addRemoteReturns struct {
+		result1 error
+	}
+	addRemoteReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckoutStub        func(*git.Repo, string, ...string) error
 	checkoutMutex       sync.RWMutex
 	checkoutArgsForCall []struct {
@@ -110,6 +139,24 @@ type FakeImpl struct {
 	createDownloadsTableReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CreatePullRequestStub        func(string, string, string, string, string, string) (*githuba.PullRequest, error)
+	createPullRequestMutex       sync.RWMutex
+	createPullRequestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+	}
+	createPullRequestReturns struct {
+		result1 *githuba.PullRequest
+		result2 error
+	}
+	createPullRequestReturnsOnCall map[int]struct {
+		result1 *githuba.PullRequest
+		result2 error
+	}
 	CurrentBranchStub        func(*git.Repo) (string, error)
 	currentBranchMutex       sync.RWMutex
 	currentBranchArgsForCall []struct {
@@ -242,6 +289,19 @@ type FakeImpl struct {
 		result1 *template.Template
 		result2 error
 	}
+	PushToRemoteStub        func(*git.Repo, string, string) error
+	pushToRemoteMutex       sync.RWMutex
+	pushToRemoteArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+		arg3 string
+	}
+	pushToRemoteReturns struct {
+		result1 error
+	}
+	pushToRemoteReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReadFileStub        func(string) ([]byte, error)
 	readFileMutex       sync.RWMutex
 	readFileArgsForCall []struct {
@@ -255,6 +315,19 @@ type FakeImpl struct {
 		result1 []byte
 		result2 error
 	}
+	RemotesStub        func(*git.Repo) ([]*git.Remote, error)
+	remotesMutex       sync.RWMutex
+	remotesArgsForCall []struct {
+		arg1 *git.Repo
+	}
+	remotesReturns struct {
+		result1 []*git.Remote
+		result2 error
+	}
+	remotesReturnsOnCall map[int]struct {
+		result1 []*git.Remote
+		result2 error
+	}
 	RenderMarkdownTemplateStub        func(*document.Document, string, string, string) (string, error)
 	renderMarkdownTemplateMutex       sync.RWMutex
 	renderMarkdownTemplateArgsForCall []struct {
@@ -323,6 +396,19 @@ type FakeImpl struct {
 	rmReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetURLStub        func(*git.Repo, string, string) error
+	setURLMutex       sync.RWMutex
+	setURLArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+		arg3 string
+	}
+	setURLReturns struct {
+		result1 error
+	}
+	setURLReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StatStub        func(string) (fs.FileInfo, error)
 	statMutex       sync.RWMutex
 	statArgsForCall []struct {
@@ -516,6 +602,134 @@ func (fake *FakeImpl) AddReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeImpl) AddLabels(arg1 string, arg2 string, arg3 int, arg4 ...string) error {
+	fake.addLabelsMutex.Lock()
+	ret, specificReturn := fake.addLabelsReturnsOnCall[len(fake.addLabelsArgsForCall)]
+	fake.addLabelsArgsForCall = append(fake.addLabelsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 []string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AddLabelsStub
+	fakeReturns := fake.addLabelsReturns
+	fake.recordInvocation("AddLabels", []interface{}{arg1, arg2, arg3, arg4})
+	fake.addLabelsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) AddLabelsCallCount() int {
+	fake.addLabelsMutex.RLock()
+	defer fake.addLabelsMutex.RUnlock()
+	return len(fake.addLabelsArgsForCall)
+}
+
+func (fake *FakeImpl) AddLabelsCalls(stub func(string, string, int, ...string) error) {
+	fake.addLabelsMutex.Lock()
+	defer fake.addLabelsMutex.Unlock()
+	fake.AddLabelsStub = stub
+}
+
+func (fake *FakeImpl) AddLabelsArgsForCall(i int) (string, string, int, []string) {
+	fake.addLabelsMutex.RLock()
+	defer fake.addLabelsMutex.RUnlock()
+	argsForCall := fake.addLabelsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) AddLabelsReturns(result1 error) {
+	fake.addLabelsMutex.Lock()
+	defer fake.addLabelsMutex.Unlock()
+	fake.AddLabelsStub = nil
+	fake.addLabelsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) AddLabelsReturnsOnCall(i int, result1 error) {
+	fake.addLabelsMutex.Lock()
+	defer fake.addLabelsMutex.Unlock()
+	fake.AddLabelsStub = nil
+	if fake.addLabelsReturnsOnCall == nil {
+		fake.addLabelsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addLabelsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) AddRemote(arg1 *git.Repo, arg2 string, arg3 string, arg4 string) error {
+	fake.addRemoteMutex.Lock()
+	ret, specificReturn := fake.addRemoteReturnsOnCall[len(fake.addRemoteArgsForCall)]
+	fake.addRemoteArgsForCall = append(fake.addRemoteArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AddRemoteStub
+	fakeReturns := fake.addRemoteReturns
+	fake.recordInvocation("AddRemote", []interface{}{arg1, arg2, arg3, arg4})
+	fake.addRemoteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) AddRemoteCallCount() int {
+	fake.addRemoteMutex.RLock()
+	defer fake.addRemoteMutex.RUnlock()
+	return len(fake.addRemoteArgsForCall)
+}
+
+func (fake *FakeImpl) AddRemoteCalls(stub func(*git.Repo, string, string, string) error) {
+	fake.addRemoteMutex.Lock()
+	defer fake.addRemoteMutex.Unlock()
+	fake.AddRemoteStub = stub
+}
+
+func (fake *FakeImpl) AddRemoteArgsForCall(i int) (*git.Repo, string, string, string) {
+	fake.addRemoteMutex.RLock()
+	defer fake.addRemoteMutex.RUnlock()
+	argsForCall := fake.addRemoteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) AddRemoteReturns(result1 error) {
+	fake.addRemoteMutex.Lock()
+	defer fake.addRemoteMutex.Unlock()
+	fake.AddRemoteStub = nil
+	fake.addRemoteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) AddRemoteReturnsOnCall(i int, result1 error) {
+	fake.addRemoteMutex.Lock()
+	defer fake.addRemoteMutex.Unlock()
+	fake.AddRemoteStub = nil
+	if fake.addRemoteReturnsOnCall == nil {
+		fake.addRemoteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addRemoteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImpl) Checkout(arg1 *git.Repo, arg2 string, arg3 ...string) error {
 	fake.checkoutMutex.Lock()
 	ret, specificReturn := fake.checkoutReturnsOnCall[len(fake.checkoutArgsForCall)]
@@ -762,6 +976,75 @@ func (fake *FakeImpl) CreateDownloadsTableReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeImpl) CreatePullRequest(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) (*githuba.PullRequest, error) {
+	fake.createPullRequestMutex.Lock()
+	ret, specificReturn := fake.createPullRequestReturnsOnCall[len(fake.createPullRequestArgsForCall)]
+	fake.createPullRequestArgsForCall = append(fake.createPullRequestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CreatePullRequestStub
+	fakeReturns := fake.createPullRequestReturns
+	fake.recordInvocation("CreatePullRequest", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.createPullRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) CreatePullRequestCallCount() int {
+	fake.createPullRequestMutex.RLock()
+	defer fake.createPullRequestMutex.RUnlock()
+	return len(fake.createPullRequestArgsForCall)
+}
+
+func (fake *FakeImpl) CreatePullRequestCalls(stub func(string, string, string, string, string, string) (*githuba.PullRequest, error)) {
+	fake.createPullRequestMutex.Lock()
+	defer fake.createPullRequestMutex.Unlock()
+	fake.CreatePullRequestStub = stub
+}
+
+func (fake *FakeImpl) CreatePullRequestArgsForCall(i int) (string, string, string, string, string, string) {
+	fake.createPullRequestMutex.RLock()
+	defer fake.createPullRequestMutex.RUnlock()
+	argsForCall := fake.createPullRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeImpl) CreatePullRequestReturns(result1 *githuba.PullRequest, result2 error) {
+	fake.createPullRequestMutex.Lock()
+	defer fake.createPullRequestMutex.Unlock()
+	fake.CreatePullRequestStub = nil
+	fake.createPullRequestReturns = struct {
+		result1 *githuba.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) CreatePullRequestReturnsOnCall(i int, result1 *githuba.PullRequest, result2 error) {
+	fake.createPullRequestMutex.Lock()
+	defer fake.createPullRequestMutex.Unlock()
+	fake.CreatePullRequestStub = nil
+	if fake.createPullRequestReturnsOnCall == nil {
+		fake.createPullRequestReturnsOnCall = make(map[int]struct {
+			result1 *githuba.PullRequest
+			result2 error
+		})
+	}
+	fake.createPullRequestReturnsOnCall[i] = struct {
+		result1 *githuba.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeImpl) CurrentBranch(arg1 *git.Repo) (string, error) {
 	fake.currentBranchMutex.Lock()
 	ret, specificReturn := fake.currentBranchReturnsOnCall[len(fake.currentBranchArgsForCall)]
@@ -1396,6 +1679,69 @@ func (fake *FakeImpl) ParseHTMLTemplateReturnsOnCall(i int, result1 *template.Te
 	}{result1, result2}
 }
 
+func (fake *FakeImpl) PushToRemote(arg1 *git.Repo, arg2 string, arg3 string) error {
+	fake.pushToRemoteMutex.Lock()
+	ret, specificReturn := fake.pushToRemoteReturnsOnCall[len(fake.pushToRemoteArgsForCall)]
+	fake.pushToRemoteArgsForCall = append(fake.pushToRemoteArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.PushToRemoteStub
+	fakeReturns := fake.pushToRemoteReturns
+	fake.recordInvocation("PushToRemote", []interface{}{arg1, arg2, arg3})
+	fake.pushToRemoteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) PushToRemoteCallCount() int {
+	fake.pushToRemoteMutex.RLock()
+	defer fake.pushToRemoteMutex.RUnlock()
+	return len(fake.pushToRemoteArgsForCall)
+}
+
+func (fake *FakeImpl) PushToRemoteCalls(stub func(*git.Repo, string, string) error) {
+	fake.pushToRemoteMutex.Lock()
+	defer fake.pushToRemoteMutex.Unlock()
+	fake.PushToRemoteStub = stub
+}
+
+func (fake *FakeImpl) PushToRemoteArgsForCall(i int) (*git.Repo, string, string) {
+	fake.pushToRemoteMutex.RLock()
+	defer fake.pushToRemoteMutex.RUnlock()
+	argsForCall := fake.pushToRemoteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) PushToRemoteReturns(result1 error) {
+	fake.pushToRemoteMutex.Lock()
+	defer fake.pushToRemoteMutex.Unlock()
+	fake.PushToRemoteStub = nil
+	fake.pushToRemoteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) PushToRemoteReturnsOnCall(i int, result1 error) {
+	fake.pushToRemoteMutex.Lock()
+	defer fake.pushToRemoteMutex.Unlock()
+	fake.PushToRemoteStub = nil
+	if fake.pushToRemoteReturnsOnCall == nil {
+		fake.pushToRemoteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushToRemoteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
 	fake.readFileMutex.Lock()
 	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
@@ -1460,6 +1806,70 @@ func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeImpl) Remotes(arg1 *git.Repo) ([]*git.Remote, error) {
+	fake.remotesMutex.Lock()
+	ret, specificReturn := fake.remotesReturnsOnCall[len(fake.remotesArgsForCall)]
+	fake.remotesArgsForCall = append(fake.remotesArgsForCall, struct {
+		arg1 *git.Repo
+	}{arg1})
+	stub := fake.RemotesStub
+	fakeReturns := fake.remotesReturns
+	fake.recordInvocation("Remotes", []interface{}{arg1})
+	fake.remotesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) RemotesCallCount() int {
+	fake.remotesMutex.RLock()
+	defer fake.remotesMutex.RUnlock()
+	return len(fake.remotesArgsForCall)
+}
+
+func (fake *FakeImpl) RemotesCalls(stub func(*git.Repo) ([]*git.Remote, error)) {
+	fake.remotesMutex.Lock()
+	defer fake.remotesMutex.Unlock()
+	fake.RemotesStub = stub
+}
+
+func (fake *FakeImpl) RemotesArgsForCall(i int) *git.Repo {
+	fake.remotesMutex.RLock()
+	defer fake.remotesMutex.RUnlock()
+	argsForCall := fake.remotesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) RemotesReturns(result1 []*git.Remote, result2 error) {
+	fake.remotesMutex.Lock()
+	defer fake.remotesMutex.Unlock()
+	fake.RemotesStub = nil
+	fake.remotesReturns = struct {
+		result1 []*git.Remote
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) RemotesReturnsOnCall(i int, result1 []*git.Remote, result2 error) {
+	fake.remotesMutex.Lock()
+	defer fake.remotesMutex.Unlock()
+	fake.RemotesStub = nil
+	if fake.remotesReturnsOnCall == nil {
+		fake.remotesReturnsOnCall = make(map[int]struct {
+			result1 []*git.Remote
+			result2 error
+		})
+	}
+	fake.remotesReturnsOnCall[i] = struct {
+		result1 []*git.Remote
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeImpl) RenderMarkdownTemplate(arg1 *document.Document, arg2 string, arg3 string, arg4 string) (string, error) {
 	fake.renderMarkdownTemplateMutex.Lock()
 	ret, specificReturn := fake.renderMarkdownTemplateReturnsOnCall[len(fake.renderMarkdownTemplateArgsForCall)]
@@ -1781,6 +2191,69 @@ func (fake *FakeImpl) RmReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeImpl) SetURL(arg1 *git.Repo, arg2 string, arg3 string) error {
+	fake.setURLMutex.Lock()
+	ret, specificReturn := fake.setURLReturnsOnCall[len(fake.setURLArgsForCall)]
+	fake.setURLArgsForCall = append(fake.setURLArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.SetURLStub
+	fakeReturns := fake.setURLReturns
+	fake.recordInvocation("SetURL", []interface{}{arg1, arg2, arg3})
+	fake.setURLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) SetURLCallCount() int {
+	fake.setURLMutex.RLock()
+	defer fake.setURLMutex.RUnlock()
+	return len(fake.setURLArgsForCall)
+}
+
+func (fake *FakeImpl) SetURLCalls(stub func(*git.Repo, string, string) error) {
+	fake.setURLMutex.Lock()
+	defer fake.setURLMutex.Unlock()
+	fake.SetURLStub = stub
+}
+
+func (fake *FakeImpl) SetURLArgsForCall(i int) (*git.Repo, string, string) {
+	fake.setURLMutex.RLock()
+	defer fake.setURLMutex.RUnlock()
+	argsForCall := fake.setURLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) SetURLReturns(result1 error) {
+	fake.setURLMutex.Lock()
+	defer fake.setURLMutex.Unlock()
+	fake.SetURLStub = nil
+	fake.setURLReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) SetURLReturnsOnCall(i int, result1 error) {
+	fake.setURLMutex.Lock()
+	defer fake.setURLMutex.Unlock()
+	fake.SetURLStub = nil
+	if fake.setURLReturnsOnCall == nil {
+		fake.setURLReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setURLReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImpl) Stat(arg1 string) (fs.FileInfo, error) {
 	fake.statMutex.Lock()
 	ret, specificReturn := fake.statReturnsOnCall[len(fake.statArgsForCall)]
@@ -2108,6 +2581,10 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.absMutex.RUnlock()
 	fake.addMutex.RLock()
 	defer fake.addMutex.RUnlock()
+	fake.addLabelsMutex.RLock()
+	defer fake.addLabelsMutex.RUnlock()
+	fake.addRemoteMutex.RLock()
+	defer fake.addRemoteMutex.RUnlock()
 	fake.checkoutMutex.RLock()
 	defer fake.checkoutMutex.RUnlock()
 	fake.cloneCVEDataMutex.RLock()
@@ -2116,6 +2593,8 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.commitMutex.RUnlock()
 	fake.createDownloadsTableMutex.RLock()
 	defer fake.createDownloadsTableMutex.RUnlock()
+	fake.createPullRequestMutex.RLock()
+	defer fake.createPullRequestMutex.RUnlock()
 	fake.currentBranchMutex.RLock()
 	defer fake.currentBranchMutex.RUnlock()
 	fake.dependencyChangesMutex.RLock()
@@ -2136,8 +2615,12 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.openRepoMutex.RUnlock()
 	fake.parseHTMLTemplateMutex.RLock()
 	defer fake.parseHTMLTemplateMutex.RUnlock()
+	fake.pushToRemoteMutex.RLock()
+	defer fake.pushToRemoteMutex.RUnlock()
 	fake.readFileMutex.RLock()
 	defer fake.readFileMutex.RUnlock()
+	fake.remotesMutex.RLock()
+	defer fake.remotesMutex.RUnlock()
 	fake.renderMarkdownTemplateMutex.RLock()
 	defer fake.renderMarkdownTemplateMutex.RUnlock()
 	fake.repoDirMutex.RLock()
@@ -2148,6 +2631,8 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.revParseTagMutex.RUnlock()
 	fake.rmMutex.RLock()
 	defer fake.rmMutex.RUnlock()
+	fake.setURLMutex.RLock()
+	defer fake.setURLMutex.RUnlock()
 	fake.statMutex.RLock()
 	defer fake.statMutex.RUnlock()
 	fake.tagStringToSemverMutex.RLock()
@@ -124,3 +124,24 @@ This release contains changes that address the following vulnerabilities:
   </body>
 </html>`
 )
+
+const (
+	// forkRemote is the name of the remote of the user's fork.
+	forkRemote = "userfork"
+
+	// prBranchPrefix is the prefix of the pull request branch in the fork.
+	prBranchPrefix = "changelog-"
+
+	// prBodyTemplate is the body of the changelog pull request.
+	prBodyTemplate = `Update the CHANGELOG directory for the %s release.
+
+` + "```release-note\nNONE\n```\n"
+)
+
+// prLabels are the labels of the pull request which updates the changelog.
+var prLabels = []string{
+	"kind/documentation",
+	"sig/release",
+	"area/release-eng",
+	"release-note-none",
+}
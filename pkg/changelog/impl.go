@@ -23,6 +23,7 @@ import (
 	"text/template"
 
 	"github.com/blang/semver"
+	gogithub "github.com/google/go-github/v37/github"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/yuin/goldmark"
@@ -93,6 +94,16 @@ type impl interface {
 	Commit(repo *git.Repo, msg string) error
 	Rm(repo *git.Repo, force bool, files ...string) error
 	CloneCVEData() (cveDir string, err error)
+
+	// Used in `createPullRequest()`
+	Remotes(repo *git.Repo) ([]*git.Remote, error)
+	AddRemote(repo *git.Repo, name, owner, repoName string) error
+	SetURL(repo *git.Repo, remote, url string) error
+	PushToRemote(repo *git.Repo, remote, branch string) error
+	CreatePullRequest(
+		owner, repoName, baseBranch, headBranch, title, body string,
+	) (*gogithub.PullRequest, error)
+	AddLabels(owner, repoName string, number int, labels ...string) error
 }
 
 type defaultImpl struct{}
@@ -251,3 +262,33 @@ func (*defaultImpl) CloneCVEData() (cveDir string, err error) {
 	logrus.Infof("Successfully synchronized CVE data maps from %s", remoteSrc)
 	return tmpdir, nil
 }
+
+func (*defaultImpl) Remotes(repo *git.Repo) ([]*git.Remote, error) {
+	return repo.Remotes()
+}
+
+func (*defaultImpl) AddRemote(repo *git.Repo, name, owner, repoName string) error {
+	return repo.AddRemote(name, owner, repoName)
+}
+
+func (*defaultImpl) SetURL(repo *git.Repo, remote, url string) error {
+	return repo.SetURL(remote, url)
+}
+
+func (*defaultImpl) PushToRemote(repo *git.Repo, remote, branch string) error {
+	return repo.PushToRemote(remote, branch)
+}
+
+func (*defaultImpl) CreatePullRequest(
+	owner, repoName, baseBranch, headBranch, title, body string,
+) (*gogithub.PullRequest, error) {
+	return github.New().CreatePullRequest(
+		owner, repoName, baseBranch, headBranch, title, body,
+	)
+}
+
+func (*defaultImpl) AddLabels(
+	owner, repoName string, number int, labels ...string,
+) error {
+	return github.New().AddLabels(owner, repoName, []int{number}, labels...)
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cherrypick
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	kgit "k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/github"
+	"k8s.io/release/pkg/release/regex"
+)
+
+// cherryPickDocs are the docs about the cherry pick process, which get
+// linked by every cherry pick pull request.
+const cherryPickDocs = "https://git.k8s.io/community/contributors/devel/sig-release/cherry-picks.md"
+
+var (
+	// copiedLabelPrefixes are the label prefixes of the original pull request
+	// which get applied to its cherry picks as well.
+	copiedLabelPrefixes = []string{"area/", "kind/", "priority/", "sig/"}
+
+	// releaseNoteRegex matches the release note block of a pull request
+	// description.
+	releaseNoteRegex = regexp.MustCompile("(?sU)```release-note[s]?\\r?\\n(?P<note>.+)\\r?\\n```")
+)
+
+// Options is the main structure for configuring a cherry pick.
+type Options struct {
+	// PullRequest is the number of the merged pull request to be picked.
+	PullRequest int
+
+	// Branches are the release branches the pull request gets picked into.
+	Branches []string
+
+	// Org and Repo are the GitHub repository of the pull request.
+	Org  string
+	Repo string
+
+	// ForkOwner is the GitHub user or organization owning the fork of the
+	// repository, where the cherry pick branches get pushed to.
+	ForkOwner string
+
+	// RepoPath is the local path to the repository to be used.
+	RepoPath string
+
+	// NoMock pushes the branches and creates the pull requests, otherwise
+	// the branches only get picked locally.
+	NoMock bool
+
+	// Cleanup removes the local repository after the run.
+	Cleanup bool
+}
+
+// Validate checks if the options are valid.
+func (o *Options) Validate() error {
+	if o.PullRequest <= 0 {
+		return errors.New("please specify a valid pull request number")
+	}
+	if len(o.Branches) == 0 {
+		return errors.New("please specify at least one release branch")
+	}
+	for _, branch := range o.Branches {
+		if branch == kgit.DefaultBranch || !regex.BranchRegex.MatchString(branch) {
+			return errors.Errorf("%s is not a release branch", branch)
+		}
+	}
+	if o.ForkOwner == "" {
+		return errors.New("please specify the owner of the fork to push to")
+	}
+	return nil
+}
+
+// Run picks the pull request into all release branches. For every branch, the
+// merge commit gets picked onto a new branch, which gets pushed to the fork,
+// and a pull request gets opened with the labels of the original pull request
+// and the milestone of the release branch.
+func Run(opts *Options) error {
+	if err := opts.Validate(); err != nil {
+		return errors.Wrap(err, "validating options")
+	}
+
+	gh := github.New()
+	pr, _, err := gh.Client().GetPullRequest(
+		context.Background(), opts.Org, opts.Repo, opts.PullRequest,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "getting pull request #%d", opts.PullRequest)
+	}
+	if !pr.GetMerged() {
+		return errors.Errorf("pull request #%d is not merged", opts.PullRequest)
+	}
+	logrus.Infof(
+		"Picking #%d (%s) into %s",
+		pr.GetNumber(), pr.GetTitle(), strings.Join(opts.Branches, ", "),
+	)
+
+	repo, err := kgit.CloneOrOpenGitHubRepo(opts.RepoPath, opts.Org, opts.Repo, true)
+	if err != nil {
+		return errors.Wrap(err, "cloning repository")
+	}
+	if !opts.NoMock {
+		logrus.Info("Using dry mode, which does not modify any remote content")
+		repo.SetDry()
+	}
+	if opts.Cleanup {
+		defer repo.Cleanup() // nolint: errcheck
+	}
+
+	if !repo.HasRemote(opts.ForkOwner, kgit.GetRepoURL(opts.ForkOwner, opts.Repo, true)) {
+		logrus.Infof("Adding remote %s for the fork", opts.ForkOwner)
+		if err := repo.AddRemote(opts.ForkOwner, opts.ForkOwner, opts.Repo); err != nil {
+			return errors.Wrap(err, "adding fork remote")
+		}
+	}
+
+	for _, branch := range opts.Branches {
+		if err := pick(gh, repo, opts, pr, branch); err != nil {
+			return errors.Wrapf(err, "picking #%d into %s", opts.PullRequest, branch)
+		}
+	}
+	return nil
+}
+
+// pick cherry picks the pull request into the release branch.
+func pick(
+	gh *github.GitHub, repo *kgit.Repo, opts *Options,
+	pr *gogithub.PullRequest, branch string,
+) error {
+	hasBranch, err := repo.HasRemoteBranch(branch)
+	if err != nil {
+		return errors.Wrap(err, "checking if branch exists on the default remote")
+	}
+	if !hasBranch {
+		return errors.New("branch does not exist on the default remote")
+	}
+
+	pickBranch := BranchName(pr.GetNumber(), branch)
+	logrus.Infof("Creating branch %s", pickBranch)
+	if err := repo.Checkout("-B", pickBranch, kgit.Remotify(branch)); err != nil {
+		return errors.Wrapf(err, "checking out branch %s", pickBranch)
+	}
+
+	logrus.Infof("Cherry picking %s", pr.GetMergeCommitSHA())
+	if err := repo.CherryPick(pr.GetMergeCommitSHA()); err != nil {
+		return err
+	}
+
+	logrus.Infof("Pushing branch %s to %s", pickBranch, opts.ForkOwner)
+	if err := repo.PushToRemote(opts.ForkOwner, pickBranch); err != nil {
+		return errors.Wrapf(err, "pushing branch %s", pickBranch)
+	}
+
+	if !opts.NoMock {
+		logrus.Infof("Skipping pull request creation for %s in mock mode", branch)
+		return nil
+	}
+
+	newPR, err := gh.CreatePullRequest(
+		opts.Org, opts.Repo, branch, opts.ForkOwner+":"+pickBranch,
+		Title(pr), Body(pr, branch),
+	)
+	if err != nil {
+		return errors.Wrap(err, "creating pull request")
+	}
+
+	issueOpts := &github.NewIssueOptions{Labels: Labels(pr)}
+	milestone := Milestone(branch)
+	if _, exists, err := gh.GetMilestone(opts.Org, opts.Repo, milestone); err != nil {
+		return errors.Wrap(err, "looking up milestone")
+	} else if exists {
+		issueOpts.Milestone = milestone
+	} else {
+		logrus.Warnf("Milestone %s does not exist, not setting it", milestone)
+	}
+	if _, err := gh.UpdateIssue(
+		opts.Org, opts.Repo, newPR.GetNumber(), issueOpts,
+	); err != nil {
+		return errors.Wrapf(err, "updating pull request #%d", newPR.GetNumber())
+	}
+
+	logrus.Infof("Created cherry pick pull request %s", newPR.GetHTMLURL())
+	return nil
+}
+
+// BranchName returns the name of the branch containing the cherry pick of
+// the pull request into the release branch.
+func BranchName(number int, branch string) string {
+	return fmt.Sprintf("automated-cherry-pick-of-#%d-upstream-%s", number, branch)
+}
+
+// Title returns the title of the cherry pick pull request.
+func Title(pr *gogithub.PullRequest) string {
+	return fmt.Sprintf(
+		"Automated cherry pick of #%d: %s", pr.GetNumber(), pr.GetTitle(),
+	)
+}
+
+// Body returns the description of the cherry pick pull request, which
+// references the original pull request and takes over its release note.
+func Body(pr *gogithub.PullRequest, branch string) string {
+	note := "NONE"
+	if match := releaseNoteRegex.FindStringSubmatch(pr.GetBody()); match != nil {
+		note = strings.TrimSpace(strings.ReplaceAll(match[1], "\r", ""))
+	}
+	return fmt.Sprintf(
+		"Cherry pick of #%d on %s.\n\n#%d: %s\n\n"+
+			"For details on the cherry pick process, see the "+
+			"[cherry pick requests](%s) page.\n\n"+
+			"```release-note\n%s\n```\n",
+		pr.GetNumber(), branch, pr.GetNumber(), pr.GetTitle(), cherryPickDocs, note,
+	)
+}
+
+// Labels returns the labels of the pull request which get applied to its
+// cherry picks.
+func Labels(pr *gogithub.PullRequest) []string {
+	labels := []string{}
+	for _, label := range pr.Labels {
+		for _, prefix := range copiedLabelPrefixes {
+			if strings.HasPrefix(label.GetName(), prefix) {
+				labels = append(labels, label.GetName())
+				break
+			}
+		}
+	}
+	return labels
+}
+
+// Milestone returns the milestone of the release branch, for example v1.22
+// for release-1.22.
+func Milestone(branch string) string {
+	return "v" + strings.TrimPrefix(branch, "release-")
+}
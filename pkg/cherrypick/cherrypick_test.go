@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cherrypick_test
+
+import (
+	"testing"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/cherrypick"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		opts      *cherrypick.Options
+		shouldErr bool
+	}{
+		{
+			name: "valid",
+			opts: &cherrypick.Options{
+				PullRequest: 1, Branches: []string{"release-1.22"}, ForkOwner: "user",
+			},
+		},
+		{
+			name:      "no pull request",
+			opts:      &cherrypick.Options{Branches: []string{"release-1.22"}, ForkOwner: "user"},
+			shouldErr: true,
+		},
+		{
+			name:      "no branches",
+			opts:      &cherrypick.Options{PullRequest: 1, ForkOwner: "user"},
+			shouldErr: true,
+		},
+		{
+			name: "no release branch",
+			opts: &cherrypick.Options{
+				PullRequest: 1, Branches: []string{"release-1.22", "master"}, ForkOwner: "user",
+			},
+			shouldErr: true,
+		},
+		{
+			name:      "no fork owner",
+			opts:      &cherrypick.Options{PullRequest: 1, Branches: []string{"release-1.22"}},
+			shouldErr: true,
+		},
+	} {
+		err := tc.opts.Validate()
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.name)
+		} else {
+			require.Nil(t, err, tc.name)
+		}
+	}
+}
+
+func TestPullRequestContent(t *testing.T) {
+	pr := &gogithub.PullRequest{
+		Number: gogithub.Int(100),
+		Title:  gogithub.String("Fix the kubelet"),
+		Body:   gogithub.String("Fixes it.\r\n\r\n```release-note\r\nFixed the kubelet.\r\n```\r\n"),
+		Labels: []*gogithub.Label{
+			{Name: gogithub.String("kind/bug")},
+			{Name: gogithub.String("sig/node")},
+			{Name: gogithub.String("lgtm")},
+			{Name: gogithub.String("priority/important-soon")},
+		},
+	}
+
+	require.Equal(t, "automated-cherry-pick-of-#100-upstream-release-1.22",
+		cherrypick.BranchName(100, "release-1.22"))
+	require.Equal(t, "Automated cherry pick of #100: Fix the kubelet", cherrypick.Title(pr))
+	require.Equal(t, "v1.22", cherrypick.Milestone("release-1.22"))
+	require.Equal(t,
+		[]string{"kind/bug", "sig/node", "priority/important-soon"},
+		cherrypick.Labels(pr),
+	)
+
+	body := cherrypick.Body(pr, "release-1.22")
+	require.Contains(t, body, "Cherry pick of #100 on release-1.22.")
+	require.Contains(t, body, "```release-note\nFixed the kubelet.\n```")
+
+	pr.Body = gogithub.String("No note")
+	require.Contains(t, cherrypick.Body(pr, "release-1.22"), "```release-note\nNONE\n```")
+}
@@ -18,6 +18,7 @@ package fastforward
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -125,6 +126,31 @@ func Run(opts *Options) error {
 	}
 	logrus.Infof("Verified that the latest tag on the main branch is the same as the merge base tag")
 
+	logrus.Infof("Checking if %s diverged from the merge base", branch)
+	ahead, behind, err := repo.AheadBehind(opts.MainRef, kgit.Remotify(branch))
+	if err != nil {
+		return errors.Wrapf(err, "comparing %s with %s", branch, opts.MainRef)
+	}
+	logrus.Infof(
+		"Release branch is %d commits ahead and %d commits behind %s",
+		ahead, behind, opts.MainRef,
+	)
+	if ahead > 0 {
+		// Previous fast-forward merge commits are expected, but every other
+		// commit would be buried by the merge
+		diverged, err := repo.CommitsAhead(mergeBase, kgit.Remotify(branch))
+		if err != nil {
+			return errors.Wrap(err, "listing diverged commits")
+		}
+		if len(diverged) > 0 {
+			divergedMessage(branch, mergeBase, diverged)
+			return errors.Errorf(
+				"unable to fast forward: %s contains %d commits which are not part of %s",
+				branch, len(diverged), kgit.DefaultBranch,
+			)
+		}
+	}
+
 	releaseRev, err := repo.Head()
 	if err != nil {
 		return err
@@ -192,3 +218,19 @@ func prepushMessage(gitRoot, branch, ref, releaseRev, headRev string) {
 		headRev[:11],
 	)
 }
+
+func divergedMessage(branch, mergeBase string, commits []string) {
+	fmt.Printf(`The release branch %s diverged from its merge base %s with the following commits:
+
+	%s
+
+Please make sure that they are part of the %s branch and revert them on the
+release branch, or fast forward the branch manually.
+
+`,
+		branch,
+		mergeBase[:11],
+		strings.Join(commits, "\n\t"),
+		kgit.DefaultBranch,
+	)
+}
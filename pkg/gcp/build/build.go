@@ -46,6 +46,10 @@ const (
 	gcsSourceDir = "/source"
 	gcsLogsDir   = "/logs"
 
+	// substitutionsDelimiter separates the substitutions if a value contains
+	// a comma.
+	substitutionsDelimiter = "~"
+
 	DefaultCloudbuildFile = "cloudbuild.yaml"
 )
 
@@ -179,6 +183,21 @@ func getExtraSubs(o *Options) map[string]string {
 	return subs
 }
 
+// substitutionsArg joins the substitutions for `gcloud builds submit`. If a
+// value contains a comma, then an alternate delimiter gets used as described
+// by `gcloud topic escaping`.
+func substitutionsArg(subs []string) string {
+	for _, sub := range subs {
+		if strings.Contains(sub, ",") {
+			return fmt.Sprintf(
+				"^%s^%s", substitutionsDelimiter,
+				strings.Join(subs, substitutionsDelimiter),
+			)
+		}
+	}
+	return strings.Join(subs, ",")
+}
+
 func RunSingleJob(o *Options, jobName, uploaded, version string, subs map[string]string) error {
 	s := make([]string, 0, len(subs)+1)
 	for k, v := range subs {
@@ -190,7 +209,7 @@ func RunSingleJob(o *Options, jobName, uploaded, version string, subs map[string
 		"builds", "submit",
 		"--verbosity", "info",
 		"--config", o.CloudbuildFile,
-		"--substitutions", strings.Join(s, ","),
+		"--substitutions", substitutionsArg(s),
 	}
 
 	if o.Project != "" {
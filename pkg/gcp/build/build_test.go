@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstitutionsArg(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		subs     []string
+		expected string
+	}{
+		{
+			name:     "no substitutions",
+			subs:     []string{},
+			expected: "",
+		},
+		{
+			name:     "plain values",
+			subs:     []string{"_A=a", "_B=b"},
+			expected: "_A=a,_B=b",
+		},
+		{
+			name:     "value with a comma",
+			subs:     []string{"_A=a", "_B=b,c"},
+			expected: "^~^_A=a~_B=b,c",
+		},
+	} {
+		require.Equal(t, tc.expected, substitutionsArg(tc.subs), tc.name)
+	}
+}
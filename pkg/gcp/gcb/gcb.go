@@ -80,7 +80,7 @@ func (g *GCB) SetReleaseClient(client Release) {
 
 type Options struct {
 	build.Options
-	NoMock       bool
+	Mode         release.Mode
 	Stage        bool
 	Release      bool
 	Stream       bool
@@ -91,11 +91,15 @@ type Options struct {
 	GcpUser      string
 	LogLevel     string
 	LastJobs     int64
+	HealthGate   bool
+	AllowFlaky   bool
+	IgnoredTabs  []string
 }
 
 // NewDefaultOptions returns a new default `*Options` instance.
 func NewDefaultOptions() *Options {
 	return &Options{
+		Mode:     release.ModeMock,
 		LogLevel: logrus.StandardLogger().GetLevel().String(),
 		Options:  *build.NewDefaultOptions(),
 	}
@@ -104,7 +108,7 @@ func NewDefaultOptions() *Options {
 //counterfeiter:generate . Repository
 type Repository interface {
 	Open() error
-	CheckState(string, string, string, bool) error
+	CheckState(string, string, string, release.Mode) error
 	GetTag() (string, error)
 }
 
@@ -197,7 +201,7 @@ func (g *GCB) Submit() error {
 		return errors.Wrap(err, "open release repo")
 	}
 
-	if err := g.repoClient.CheckState(toolOrg, toolRepo, toolRef, g.options.NoMock); err != nil {
+	if err := g.repoClient.CheckState(toolOrg, toolRepo, toolRef, g.options.Mode); err != nil {
 		return errors.Wrap(err, "verifying repository state")
 	}
 
@@ -217,7 +221,7 @@ func (g *GCB) Submit() error {
 		return gcbSubsErr
 	}
 
-	if g.options.NoMock {
+	if g.options.Mode.IsNoMock() {
 		// TODO: Consider a '--yes' flag so we can mock this
 		_, nomockSubmit, askErr := util.Ask(
 			fmt.Sprintf("Really submit a --nomock release job against the %s branch? (yes/no)", g.options.Branch),
@@ -245,8 +249,7 @@ func (g *GCB) Submit() error {
 			return userBucketSetErr
 		}
 
-		testBucket := fmt.Sprintf("%s%s", bucketPrefix, "gcb")
-		testBucketSetErr := os.Setenv("BUCKET", testBucket)
+		testBucketSetErr := os.Setenv("BUCKET", g.options.Mode.Bucket())
 		if testBucketSetErr != nil {
 			return testBucketSetErr
 		}
@@ -267,6 +270,7 @@ func (g *GCB) Submit() error {
 	// TODO: Consider a '--validate' flag to validate the GCB config without submitting
 	case g.options.Stage:
 		jobType = "stage"
+		g.setHealthGateSubstitutions(gcbSubs)
 	case g.options.Release:
 		jobType = "release"
 	default:
@@ -292,6 +296,25 @@ func (g *GCB) Submit() error {
 	)
 }
 
+// setHealthGateSubstitutions forwards the health gate options to the stage
+// job, which checks the release health before tagging the repository.
+func (g *GCB) setHealthGateSubstitutions(gcbSubs map[string]string) {
+	gcbSubs["HEALTH_GATE"] = ""
+	gcbSubs["ALLOW_FLAKY"] = ""
+	gcbSubs["IGNORED_TABS"] = ""
+	if !g.options.HealthGate {
+		return
+	}
+
+	gcbSubs["HEALTH_GATE"] = "--health-gate"
+	if g.options.AllowFlaky {
+		gcbSubs["ALLOW_FLAKY"] = "--allow-flaky"
+	}
+	if len(g.options.IgnoredTabs) > 0 {
+		gcbSubs["IGNORED_TABS"] = "--ignored-tabs=" + strings.Join(g.options.IgnoredTabs, ",")
+	}
+}
+
 // SetGCBSubstitutions takes a set of `Options` and returns a map of GCB
 // substitutions.
 func (g *GCB) SetGCBSubstitutions(toolOrg, toolRepo, toolRef string) (map[string]string, error) {
@@ -21,16 +21,17 @@ import (
 	"sync"
 
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/release"
 )
 
 type FakeRepository struct {
-	CheckStateStub        func(string, string, string, bool) error
+	CheckStateStub        func(string, string, string, release.Mode) error
 	checkStateMutex       sync.RWMutex
 	checkStateArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 string
-		arg4 bool
+		arg4 release.Mode
 	}
 	checkStateReturns struct {
 		result1 error
@@ -64,14 +65,14 @@ type FakeRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeRepository) CheckState(arg1 string, arg2 string, arg3 string, arg4 bool) error {
+func (fake *FakeRepository) CheckState(arg1 string, arg2 string, arg3 string, arg4 release.Mode) error {
 	fake.checkStateMutex.Lock()
 	ret, specificReturn := fake.checkStateReturnsOnCall[len(fake.checkStateArgsForCall)]
 	fake.checkStateArgsForCall = append(fake.checkStateArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 string
-		arg4 bool
+		arg4 release.Mode
 	}{arg1, arg2, arg3, arg4})
 	stub := fake.CheckStateStub
 	fakeReturns := fake.checkStateReturns
@@ -92,13 +93,13 @@ func (fake *FakeRepository) CheckStateCallCount() int {
 	return len(fake.checkStateArgsForCall)
 }
 
-func (fake *FakeRepository) CheckStateCalls(stub func(string, string, string, bool) error) {
+func (fake *FakeRepository) CheckStateCalls(stub func(string, string, string, release.Mode) error) {
 	fake.checkStateMutex.Lock()
 	defer fake.checkStateMutex.Unlock()
 	fake.CheckStateStub = stub
 }
 
-func (fake *FakeRepository) CheckStateArgsForCall(i int) (string, string, string, bool) {
+func (fake *FakeRepository) CheckStateArgsForCall(i int) (string, string, string, release.Mode) {
 	fake.checkStateMutex.RLock()
 	defer fake.checkStateMutex.RUnlock()
 	argsForCall := fake.checkStateArgsForCall[i]
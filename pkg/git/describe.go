@@ -31,6 +31,9 @@ type DescribeOptions struct {
 	always   bool
 	dirty    bool
 	tags     bool
+	long     bool
+	match    string
+	exclude  []string
 }
 
 // NewDescribeOptions creates new repository describe options
@@ -41,6 +44,7 @@ func NewDescribeOptions() *DescribeOptions {
 		always:   false,
 		dirty:    false,
 		tags:     false,
+		long:     false,
 	}
 }
 
@@ -74,6 +78,25 @@ func (d *DescribeOptions) WithTags() *DescribeOptions {
 	return d
 }
 
+// WithLong sets long to true in the DescribeOptions
+func (d *DescribeOptions) WithLong() *DescribeOptions {
+	d.long = true
+	return d
+}
+
+// WithMatch sets the --match=<pattern> in the DescribeOptions
+func (d *DescribeOptions) WithMatch(pattern string) *DescribeOptions {
+	d.match = pattern
+	return d
+}
+
+// WithExclude sets the --exclude=<pattern> for every pattern in the
+// DescribeOptions
+func (d *DescribeOptions) WithExclude(patterns ...string) *DescribeOptions {
+	d.exclude = patterns
+	return d
+}
+
 // toArgs converts DescribeOptions to string arguments
 func (d *DescribeOptions) toArgs() (args []string) {
 	if d.tags {
@@ -85,9 +108,18 @@ func (d *DescribeOptions) toArgs() (args []string) {
 	if d.always {
 		args = append(args, "--always")
 	}
+	if d.long {
+		args = append(args, "--long")
+	}
 	if d.abbrev >= 0 {
 		args = append(args, fmt.Sprintf("--abbrev=%d", d.abbrev))
 	}
+	if d.match != "" {
+		args = append(args, "--match="+d.match)
+	}
+	for _, pattern := range d.exclude {
+		args = append(args, "--exclude="+pattern)
+	}
 	if d.revision != "" {
 		args = append(args, d.revision)
 	}
@@ -34,13 +34,20 @@ func TestDescribeOptionsArguments(t *testing.T) {
 		WithDirty().
 		WithAbbrev(3).
 		WithAlways().
+		WithLong().
+		WithMatch("v*").
+		WithExclude("v1.0.0", "v1.1.0").
 		WithRevision("rev")
 	require.NotNil(t, sut)
 	require.Equal(t, []string{
 		"--tags",
 		"--dirty",
 		"--always",
+		"--long",
 		"--abbrev=3",
+		"--match=v*",
+		"--exclude=v1.0.0",
+		"--exclude=v1.1.0",
 		"rev",
 	}, sut.toArgs())
 }
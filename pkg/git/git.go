@@ -18,7 +18,6 @@ package git
 
 import (
 	"bufio"
-	"bytes"
 	"fmt"
 	"io"
 	"math"
@@ -39,6 +38,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/output"
 	"k8s.io/release/pkg/release/regex"
 	"sigs.k8s.io/release-utils/command"
 	"sigs.k8s.io/release-utils/util"
@@ -379,7 +379,7 @@ func CloneOrOpenRepo(repoPath, repoURL string, useSSH bool) (*Repo, error) {
 		targetDir = t
 	}
 
-	progressBuffer := &bytes.Buffer{}
+	progressBuffer := output.NewLimitedBuffer(output.MaxSize())
 	progressWriters := []io.Writer{progressBuffer}
 
 	// Only output the clone progress on debug or trace level,
@@ -768,6 +768,38 @@ func (r *Repo) MergeBase(from, to string) (string, error) {
 	return mergeBase, nil
 }
 
+// AheadBehind returns the amount of commits which are reachable from rev but
+// not from base (ahead) and the amount of commits which are reachable from
+// base but not from rev (behind).
+func (r *Repo) AheadBehind(base, rev string) (ahead, behind int, err error) {
+	output, err := r.runGitCmd(
+		"rev-list", "--left-right", "--count", fmt.Sprintf("%s...%s", base, rev),
+	)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "counting commits between %s and %s", base, rev)
+	}
+	if _, err := fmt.Sscanf(output, "%d\t%d", &behind, &ahead); err != nil {
+		return 0, 0, errors.Wrapf(err, "parsing commit counts %q", output)
+	}
+	return ahead, behind, nil
+}
+
+// CommitsAhead returns the commits which are reachable from rev but not from
+// base, newest first, in the format "<short hash> <subject>". Merge commits
+// are skipped because they do not introduce changes on their own.
+func (r *Repo) CommitsAhead(base, rev string) ([]string, error) {
+	output, err := r.runGitCmd(
+		"log", "--no-merges", "--format=%h %s", fmt.Sprintf("%s..%s", base, rev),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing commits between %s and %s", base, rev)
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
 // Remotify returns the name prepended with the default remote
 func Remotify(name string) string {
 	split := strings.Split(name, "/")
@@ -784,6 +816,31 @@ func (r *Repo) Merge(from string) error {
 	).RunSilentSuccess(), "run git merge")
 }
 
+// CherryPick applies the changes of the provided commit onto the current
+// branch and records the origin of the commit in its message. Merge commits
+// get picked relative to their first parent. A failed cherry pick, for
+// example because of conflicts, gets aborted to keep the worktree clean.
+func (r *Repo) CherryPick(commit string) error {
+	parents, err := r.runGitCmd("rev-list", "--parents", "-n", "1", commit)
+	if err != nil {
+		return errors.Wrapf(err, "resolving parents of %s", commit)
+	}
+
+	args := []string{"-x"}
+	if len(strings.Fields(parents)) > 2 {
+		args = append(args, "-m", "1")
+	}
+	args = append(args, commit)
+
+	if _, err := r.runGitCmd("cherry-pick", args...); err != nil {
+		if _, abortErr := r.runGitCmd("cherry-pick", "--abort"); abortErr != nil {
+			logrus.Warnf("Unable to abort cherry pick of %s: %v", commit, abortErr)
+		}
+		return errors.Wrapf(err, "cherry picking %s", commit)
+	}
+	return nil
+}
+
 // Push does push the specified branch to the default remote, but only if the
 // repository is not in dry run mode
 func (r *Repo) Push(remoteBranch string) (err error) {
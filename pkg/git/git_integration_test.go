@@ -317,6 +317,32 @@ func TestFailureMerge(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestSuccessCherryPick(t *testing.T) {
+	testRepo := newTestRepo(t)
+	defer testRepo.cleanup(t)
+
+	require.Nil(t, testRepo.sut.Checkout(git.DefaultBranch))
+	require.Nil(t, testRepo.sut.CherryPick(testRepo.thirdBranchCommit))
+
+	lastCommit, err := testRepo.sut.ShowLastCommit()
+	require.Nil(t, err)
+	require.Contains(t, lastCommit, "Fourth commit")
+	require.Contains(t, lastCommit, "cherry picked from commit "+testRepo.thirdBranchCommit)
+}
+
+func TestFailureCherryPick(t *testing.T) {
+	testRepo := newTestRepo(t)
+	defer testRepo.cleanup(t)
+
+	// The commit is already part of the branch
+	require.NotNil(t, testRepo.sut.CherryPick(testRepo.thirdBranchCommit))
+	require.NotNil(t, testRepo.sut.CherryPick("wrong"))
+
+	dirty, err := testRepo.sut.IsDirty()
+	require.Nil(t, err)
+	require.False(t, dirty)
+}
+
 func TestSuccessMergeBase(t *testing.T) {
 	testRepo := newTestRepo(t)
 	defer testRepo.cleanup(t)
@@ -326,6 +352,61 @@ func TestSuccessMergeBase(t *testing.T) {
 	require.Equal(t, testRepo.firstCommit, mergeBase)
 }
 
+func TestSuccessAheadBehind(t *testing.T) {
+	testRepo := newTestRepo(t)
+	defer testRepo.cleanup(t)
+
+	ahead, behind, err := testRepo.sut.AheadBehind(
+		git.Remotify(git.DefaultBranch), testRepo.branchName,
+	)
+	require.Nil(t, err)
+	require.Equal(t, 3, ahead)
+	require.Equal(t, 0, behind)
+
+	ahead, behind, err = testRepo.sut.AheadBehind(
+		testRepo.thirdBranchCommit, git.Remotify(git.DefaultBranch),
+	)
+	require.Nil(t, err)
+	require.Equal(t, 0, ahead)
+	require.Equal(t, 3, behind)
+}
+
+func TestFailureAheadBehind(t *testing.T) {
+	testRepo := newTestRepo(t)
+	defer testRepo.cleanup(t)
+
+	_, _, err := testRepo.sut.AheadBehind(git.DefaultBranch, "wrong")
+	require.NotNil(t, err)
+}
+
+func TestSuccessCommitsAhead(t *testing.T) {
+	testRepo := newTestRepo(t)
+	defer testRepo.cleanup(t)
+
+	commits, err := testRepo.sut.CommitsAhead(
+		testRepo.firstBranchCommit, testRepo.branchName,
+	)
+	require.Nil(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, testRepo.thirdBranchCommit[:7], commits[0][:7])
+	require.Contains(t, commits[0], " Fourth commit")
+	require.Contains(t, commits[1], " Third commit")
+
+	commits, err = testRepo.sut.CommitsAhead(
+		testRepo.branchName, git.Remotify(git.DefaultBranch),
+	)
+	require.Nil(t, err)
+	require.Empty(t, commits)
+}
+
+func TestFailureCommitsAhead(t *testing.T) {
+	testRepo := newTestRepo(t)
+	defer testRepo.cleanup(t)
+
+	_, err := testRepo.sut.CommitsAhead(git.DefaultBranch, "wrong")
+	require.NotNil(t, err)
+}
+
 func TestSuccessRevParse(t *testing.T) {
 	testRepo := newTestRepo(t)
 	defer testRepo.cleanup(t)
@@ -0,0 +1,250 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// AppIDEnvKey is the environment variable containing the ID of the
+	// GitHub App to authenticate as.
+	AppIDEnvKey = "GITHUB_APP_ID"
+
+	// AppInstallationIDEnvKey is the environment variable containing the ID
+	// of the GitHub App installation to authenticate as.
+	AppInstallationIDEnvKey = "GITHUB_APP_INSTALLATION_ID"
+
+	// AppPrivateKeyEnvKey is the environment variable containing the path
+	// to the PEM encoded private key of the GitHub App.
+	AppPrivateKeyEnvKey = "GITHUB_APP_PRIVATE_KEY"
+
+	// appJWTLifetime is the lifetime of the JWTs used to request
+	// installation tokens, where GitHub allows ten minutes at most.
+	appJWTLifetime = 9 * time.Minute
+
+	// appJWTClockDrift is subtracted from the issue date of the JWTs to
+	// allow clock drift between the local machine and GitHub.
+	appJWTClockDrift = time.Minute
+)
+
+// appTokenSource is an oauth2.TokenSource which returns GitHub App
+// installation tokens.
+type appTokenSource struct {
+	apiURL         string
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	client         *http.Client
+}
+
+// NewAppTokenSource returns a token source for the installation of the GitHub
+// App using the GitHub API at apiURL, for example https://api.github.com/.
+// The returned tokens are valid for one hour and get refreshed automatically
+// before they expire, which makes the source suitable for long running jobs.
+func NewAppTokenSource(
+	apiURL string, appID, installationID int64, privateKey []byte,
+) (oauth2.TokenSource, error) {
+	key, err := parseAppPrivateKey(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing GitHub App private key")
+	}
+	if !strings.HasSuffix(apiURL, "/") {
+		apiURL += "/"
+	}
+	return oauth2.ReuseTokenSource(nil, &appTokenSource{
+		apiURL:         apiURL,
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+		client:         http.DefaultClient,
+	}), nil
+}
+
+// NewWithApp creates a new GitHub client which authenticates as the
+// installation of the GitHub App.
+func NewWithApp(appID, installationID int64, privateKey []byte) (*GitHub, error) {
+	ghclient := github.NewClient(nil)
+	return newWithApp(ghclient.BaseURL.String(), "", "", appID, installationID, privateKey)
+}
+
+// NewEnterpriseWithApp creates a new GitHub Enterprise client which
+// authenticates as the installation of the GitHub App.
+func NewEnterpriseWithApp(
+	baseURL, uploadURL string, appID, installationID int64, privateKey []byte,
+) (*GitHub, error) {
+	ghclient, err := github.NewEnterpriseClient(baseURL, uploadURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to new github client")
+	}
+	return newWithApp(
+		ghclient.BaseURL.String(), baseURL, uploadURL,
+		appID, installationID, privateKey,
+	)
+}
+
+func newWithApp(
+	apiURL, baseURL, uploadURL string,
+	appID, installationID int64, privateKey []byte,
+) (*GitHub, error) {
+	tokenSource, err := NewAppTokenSource(apiURL, appID, installationID, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	client := oauth2.NewClient(httpContext(appScope(appID, installationID)), tokenSource)
+
+	ghclient := github.NewClient(client)
+	if baseURL != "" {
+		ghclient, err = github.NewEnterpriseClient(baseURL, uploadURL, client)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to new github client")
+		}
+	}
+
+	logrus.Debugf("Using GitHub App %d installation %d client", appID, installationID)
+	return &GitHub{
+		client:  &githubClient{Client: ghclient},
+		options: DefaultOptions(),
+	}, nil
+}
+
+// newWithAppFromEnv creates a GitHub App client from the $GITHUB_APP_ID,
+// $GITHUB_APP_INSTALLATION_ID and $GITHUB_APP_PRIVATE_KEY environment
+// variables.
+func newWithAppFromEnv() (*GitHub, error) {
+	ids := []int64{}
+	for _, key := range []string{AppIDEnvKey, AppInstallationIDEnvKey} {
+		id, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing $%s", key)
+		}
+		ids = append(ids, id)
+	}
+
+	privateKey, err := os.ReadFile(os.Getenv(AppPrivateKeyEnvKey))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading private key from $%s", AppPrivateKeyEnvKey)
+	}
+	return NewWithApp(ids[0], ids[1], privateKey)
+}
+
+// Token requests a new installation token of the GitHub App.
+func (s *appTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := s.jwt(time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "creating GitHub App JWT")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf(
+		"%sapp/installations/%d/access_tokens", s.apiURL, s.installationID,
+	), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating installation token request")
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	logrus.Debugf("Requesting token of GitHub App installation %d", s.installationID)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "requesting installation token")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, errors.Errorf(
+			"requesting installation token returned status %s", resp.Status,
+		)
+	}
+
+	token := struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, errors.Wrap(err, "decoding installation token")
+	}
+	return &oauth2.Token{
+		AccessToken: token.Token,
+		TokenType:   "Bearer",
+		Expiry:      token.ExpiresAt,
+	}, nil
+}
+
+// jwt returns the RS256 signed JSON Web Token which authenticates the GitHub
+// App itself.
+func (s *appTokenSource) jwt(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling header")
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-appJWTClockDrift).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(s.appID, 10),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling claims")
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", errors.Wrap(err, "signing token")
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseAppPrivateKey parses the PEM encoded RSA private key of a GitHub App,
+// which can be either in PKCS #1 or PKCS #8 form.
+func parseAppPrivateKey(privateKey []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing private key")
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/github"
+)
+
+func newAppTokenServer(t *testing.T, key *rsa.PrivateKey, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(requests, 1)
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/app/installations/2/access_tokens", r.URL.Path)
+
+			// Verify the JWT signature and issuer
+			jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			parts := strings.Split(jwt, ".")
+			require.Len(t, parts, 3)
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			require.Nil(t, err)
+			hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			require.Nil(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+
+			rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+			require.Nil(t, err)
+			claims := map[string]interface{}{}
+			require.Nil(t, json.Unmarshal(rawClaims, &claims))
+			require.Equal(t, "1", claims["iss"])
+
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": "token", "expires_at": %q}`,
+				time.Now().Add(time.Hour).Format(time.RFC3339),
+			)
+		},
+	))
+}
+
+func TestAppTokenSource(t *testing.T) {
+	// Given
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{
+		Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	var requests int32
+	server := newAppTokenServer(t, key, &requests)
+	defer server.Close()
+
+	sut, err := github.NewAppTokenSource(server.URL, 1, 2, privateKey)
+	require.Nil(t, err)
+
+	// When
+	token, err := sut.Token()
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "token", token.AccessToken)
+	require.True(t, token.Valid())
+
+	// When the token is still valid
+	_, err = sut.Token()
+
+	// Then
+	require.Nil(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&requests))
+}
+
+func TestAppTokenSourceInvalidKey(t *testing.T) {
+	_, err := github.NewAppTokenSource("https://api.github.com/", 1, 2, []byte("key"))
+	require.NotNil(t, err)
+}
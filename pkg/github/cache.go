@@ -0,0 +1,247 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+// CacheDirEnvKey is the environment variable containing the directory of the
+// on-disk cache for GitHub API responses. Responses are not cached if it is
+// not set.
+const CacheDirEnvKey = "GITHUB_CACHE_DIR"
+
+// cacheEntry is a cached response of the GitHub API.
+type cacheEntry struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// cacheTransport is an http.RoundTripper which caches GitHub API responses
+// on disk and revalidates them via conditional requests.
+type cacheTransport struct {
+	dir   string
+	scope string
+	base  http.RoundTripper
+}
+
+// NewCacheTransport returns a transport which caches the successful responses
+// of GET requests in dir, if they contain an ETag or Last-Modified header.
+// Cached responses get revalidated by conditional requests, which are answered
+// with 304 Not Modified by GitHub if nothing changed. Those responses are
+// fast and do not count against the rate limit. The requests are sent via
+// base, or the default transport if base is nil.
+//
+// The scope identifies who is authenticated, because different users may
+// see different data. Transports only share their responses if they use the
+// same scope, which means that it has to be stable across token renewals.
+func NewCacheTransport(dir, scope string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &cacheTransport{dir: dir, scope: scope, base: base}
+}
+
+// RoundTrip sends the request, or serves it from the cache if the cached
+// response is still valid.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	path := t.path(req)
+	entry, err := readCacheEntry(path)
+	if err != nil {
+		logrus.Debugf("Ignoring cached response of %s: %v", req.URL, err)
+	}
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		logrus.Debugf("Using cached response of %s", req.URL)
+		// The rate limit of the cached response is outdated
+		for key, values := range resp.Header {
+			if strings.HasPrefix(key, "X-Ratelimit-") {
+				entry.Header[key] = values
+			}
+		}
+		return entry.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK ||
+		(resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading response body")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := writeCacheEntry(path, &cacheEntry{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}); err != nil {
+		logrus.Warnf("Unable to cache response of %s: %v", req.URL, err)
+	}
+	return resp, nil
+}
+
+// path returns the cache file of the request. The Authorization header is
+// not part of the key, because the scope already identifies its owner and
+// tokens like the ones of GitHub App installations expire after an hour.
+func (t *cacheTransport) path(req *http.Request) string {
+	hash := sha256.New()
+	for _, part := range []string{
+		t.scope,
+		req.Method,
+		req.URL.String(),
+		req.Header.Get("Accept"),
+	} {
+		fmt.Fprintln(hash, part)
+	}
+	return filepath.Join(t.dir, hex.EncodeToString(hash.Sum(nil))+".json")
+}
+
+// response returns the cached response to the request.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// readCacheEntry reads the cached response at path, which is nil if nothing
+// has been cached yet.
+func readCacheEntry(path string) (*cacheEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry := &cacheEntry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		return nil, errors.Wrap(err, "decoding cache entry")
+	}
+	return entry, nil
+}
+
+// writeCacheEntry writes the response to path. The entry gets renamed into
+// place, which means that concurrent readers never see partial entries.
+func writeCacheEntry(path string, entry *cacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "encoding cache entry")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)); err != nil {
+		return errors.Wrap(err, "creating cache directory")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "creating cache entry")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "writing cache entry")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "closing cache entry")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "renaming cache entry")
+}
+
+// httpContext returns the context used to create the HTTP clients of the
+// GitHub API, which contains a caching client if $GITHUB_CACHE_DIR is set.
+// The scope of the cache is the one of the authenticated identity, as
+// returned by tokenScope or appScope.
+func httpContext(scope string) context.Context {
+	ctx := context.Background()
+	dir := os.Getenv(CacheDirEnvKey)
+	if dir == "" {
+		return ctx
+	}
+	logrus.Debugf("Caching GitHub API responses in %s", dir)
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{
+		Transport: NewCacheTransport(dir, scope, nil),
+	})
+}
+
+// tokenScope returns the cache scope of a client authenticated by token,
+// which is empty for unauthenticated clients.
+func tokenScope(token string) string {
+	if token == "" {
+		return ""
+	}
+	return fmt.Sprintf("token/%x", sha256.Sum256([]byte(token)))
+}
+
+// appScope returns the cache scope of a client authenticated as GitHub App
+// installation, which does not change when the installation token expires.
+func appScope(appID, installationID int64) string {
+	return fmt.Sprintf("app/%d/installation/%d", appID, installationID)
+}
+
+// httpClient returns the unauthenticated HTTP client stored in the context
+// by httpContext, or the default client.
+func httpClient(ctx context.Context) *http.Client {
+	if client, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		return client
+	}
+	return http.DefaultClient
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"k8s.io/release/pkg/github"
+)
+
+// newCacheServer returns a server which answers conditional requests with
+// 304 Not Modified until the version gets changed.
+func newCacheServer(version, notModified *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			etag := fmt.Sprintf(`"%d"`, atomic.LoadInt32(version))
+			if r.Header.Get("If-None-Match") == etag {
+				remaining := 100 - atomic.AddInt32(notModified, 1)
+				w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(remaining))
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("X-RateLimit-Remaining", "100")
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Link", `<https://api.github.com/next>; rel="next"`)
+			fmt.Fprintf(w, "%s %s", r.URL.Path, etag)
+		},
+	))
+}
+
+func get(t *testing.T, client *http.Client, url string) (body string, header http.Header) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.Nil(t, err)
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	content, err := io.ReadAll(resp.Body)
+	require.Nil(t, err)
+	return string(content), resp.Header
+}
+
+func TestCacheTransport(t *testing.T) {
+	// Given
+	var version, notModified int32
+	server := newCacheServer(&version, &notModified)
+	defer server.Close()
+
+	dir, err := os.MkdirTemp("", "github-cache-test-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	client := &http.Client{Transport: github.NewCacheTransport(dir, "", nil)}
+
+	// When
+	body, _ := get(t, client, server.URL+"/repos")
+
+	// Then
+	require.Equal(t, `/repos "0"`, body)
+	require.EqualValues(t, 0, atomic.LoadInt32(&notModified))
+
+	// When the response did not change
+	body, header := get(t, client, server.URL+"/repos")
+
+	// Then
+	require.Equal(t, `/repos "0"`, body)
+	require.EqualValues(t, 1, atomic.LoadInt32(&notModified))
+	require.Equal(t, `<https://api.github.com/next>; rel="next"`, header.Get("Link"))
+	require.Equal(t, "99", header.Get("X-RateLimit-Remaining"))
+
+	// When another URL is requested
+	body, _ = get(t, client, server.URL+"/issues")
+
+	// Then
+	require.Equal(t, `/issues "0"`, body)
+	require.EqualValues(t, 1, atomic.LoadInt32(&notModified))
+
+	// When the response changed
+	atomic.AddInt32(&version, 1)
+	body, _ = get(t, client, server.URL+"/repos")
+
+	// Then
+	require.Equal(t, `/repos "1"`, body)
+	require.EqualValues(t, 1, atomic.LoadInt32(&notModified))
+
+	// When the cache is used by a new client
+	client = &http.Client{Transport: github.NewCacheTransport(dir, "", nil)}
+	body, _ = get(t, client, server.URL+"/repos")
+
+	// Then
+	require.Equal(t, `/repos "1"`, body)
+	require.EqualValues(t, 2, atomic.LoadInt32(&notModified))
+}
+
+func TestCacheTransportScope(t *testing.T) {
+	// Given
+	var version, notModified int32
+	server := newCacheServer(&version, &notModified)
+	defer server.Close()
+
+	dir, err := os.MkdirTemp("", "github-cache-test-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	newClient := func(scope, token string) *http.Client {
+		return &http.Client{Transport: &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+			Base:   github.NewCacheTransport(dir, scope, nil),
+		}}
+	}
+
+	// When
+	body, _ := get(t, newClient("app/1/installation/2", "token"), server.URL+"/repos")
+
+	// Then
+	require.Equal(t, `/repos "0"`, body)
+	require.EqualValues(t, 0, atomic.LoadInt32(&notModified))
+
+	// When the token of the same scope got renewed
+	body, _ = get(t, newClient("app/1/installation/2", "renewed"), server.URL+"/repos")
+
+	// Then
+	require.Equal(t, `/repos "0"`, body)
+	require.EqualValues(t, 1, atomic.LoadInt32(&notModified))
+
+	// When another scope requests the same URL
+	body, _ = get(t, newClient("app/1/installation/3", "token"), server.URL+"/repos")
+
+	// Then
+	require.Equal(t, `/repos "0"`, body)
+	require.EqualValues(t, 1, atomic.LoadInt32(&notModified))
+
+	entries, err := os.ReadDir(dir)
+	require.Nil(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestCacheTransportSkipsUncacheable(t *testing.T) {
+	// Given
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			require.Empty(t, r.Header.Get("If-None-Match"))
+			require.Empty(t, r.Header.Get("If-Modified-Since"))
+			if r.Method == http.MethodPost {
+				w.Header().Set("ETag", `"post"`)
+			}
+			fmt.Fprint(w, "response")
+		},
+	))
+	defer server.Close()
+
+	dir, err := os.MkdirTemp("", "github-cache-test-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	client := &http.Client{Transport: github.NewCacheTransport(dir, "", nil)}
+
+	for i := 0; i < 2; i++ {
+		// When the response has no validator
+		body, _ := get(t, client, server.URL)
+
+		// Then
+		require.Equal(t, "response", body)
+
+		// When the request is not a GET
+		resp, err := client.Post(server.URL, "application/json", nil)
+
+		// Then
+		require.Nil(t, err)
+		resp.Body.Close()
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.Nil(t, err)
+	require.Empty(t, entries)
+	require.EqualValues(t, 4, atomic.LoadInt32(&requests))
+}
@@ -18,12 +18,14 @@ package github
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/google/go-github/v37/github"
 	"github.com/pkg/errors"
@@ -51,8 +53,17 @@ type GitHub struct {
 
 type githubClient struct {
 	*github.Client
+
+	// prCache contains the already looked up merged pull requests of
+	// PRsForCommits, keyed by owner/repo@sha.
+	prCache   map[string][]*github.PullRequest
+	prCacheMu sync.RWMutex
 }
 
+// prsForCommitsBatchSize is the maximum number of commits looked up per
+// GraphQL request by PRsForCommits.
+const prsForCommitsBatchSize = 100
+
 // Options is a set of options to configure the behavior of the GitHub package
 type Options struct {
 	// How many items to request in calls to the github API
@@ -130,6 +141,10 @@ type Client interface {
 		context.Context, string, string, *github.IssueRequest,
 	) (*github.Issue, error)
 
+	UpdateIssue(
+		context.Context, string, string, int, *github.IssueRequest,
+	) (*github.Issue, error)
+
 	GetRepository(
 		context.Context, string, string,
 	) (*github.Repository, *github.Response, error)
@@ -153,6 +168,30 @@ type Client interface {
 	CreateComment(
 		context.Context, string, string, int, string,
 	) (*github.IssueComment, *github.Response, error)
+
+	PRsForCommits(
+		context.Context, string, string, []string,
+	) (map[string][]*github.PullRequest, error)
+
+	AddLabels(
+		context.Context, string, string, int, []string,
+	) ([]*github.Label, *github.Response, error)
+
+	RemoveLabel(
+		context.Context, string, string, int, string,
+	) (*github.Response, error)
+
+	GetBranchProtection(
+		context.Context, string, string, string,
+	) (*github.Protection, *github.Response, error)
+
+	ListIssues(
+		context.Context, string, string, *github.IssueListByRepoOptions,
+	) ([]*github.Issue, *github.Response, error)
+
+	GraphQL(
+		context.Context, string, map[string]interface{}, interface{},
+	) error
 }
 
 // NewIssueOptions is a struct of optional fields for new issues
@@ -169,12 +208,22 @@ type NewIssueOptions struct {
 // - NewWithToken
 // - NewEnterprise
 // - NewEnterpriseWithToken
+// - NewWithApp
+// - NewEnterpriseWithApp
 
 // New creates a new default GitHub client. Tokens set via the $GITHUB_TOKEN
 // environment variable will result in an authenticated client.
 // If the $GITHUB_TOKEN is not set, then the client will do unauthenticated
-// GitHub requests.
+// GitHub requests. If the $GITHUB_APP_ID environment variable is set, then
+// the client authenticates as GitHub App installation instead.
 func New() *GitHub {
+	if os.Getenv(AppIDEnvKey) != "" {
+		client, err := newWithAppFromEnv()
+		if err == nil {
+			return client
+		}
+		logrus.Warnf("Unable to create GitHub App client, falling back to token: %v", err)
+	}
 	token := env.Default(TokenEnvKey, "")
 	client, _ := NewWithToken(token) // nolint: errcheck
 	return client
@@ -184,8 +233,8 @@ func New() *GitHub {
 // Empty string will result in unauthenticated client, which makes
 // unauthenticated requests.
 func NewWithToken(token string) (*GitHub, error) {
-	ctx := context.Background()
-	client := http.DefaultClient
+	ctx := httpContext(tokenScope(token))
+	client := httpClient(ctx)
 	state := "unauthenticated"
 	if token != "" {
 		state = strings.TrimPrefix(state, "un")
@@ -195,7 +244,7 @@ func NewWithToken(token string) (*GitHub, error) {
 	}
 	logrus.Debugf("Using %s GitHub client", state)
 	return &GitHub{
-		client:  &githubClient{github.NewClient(client)},
+		client:  &githubClient{Client: github.NewClient(client)},
 		options: DefaultOptions(),
 	}, nil
 }
@@ -206,8 +255,8 @@ func NewEnterprise(baseURL, uploadURL string) (*GitHub, error) {
 }
 
 func NewEnterpriseWithToken(baseURL, uploadURL, token string) (*GitHub, error) {
-	ctx := context.Background()
-	client := http.DefaultClient
+	ctx := httpContext(tokenScope(token))
+	client := httpClient(ctx)
 	state := "unauthenticated"
 	if token != "" {
 		state = strings.TrimPrefix(state, "un")
@@ -221,7 +270,7 @@ func NewEnterpriseWithToken(baseURL, uploadURL, token string) (*GitHub, error) {
 		return nil, fmt.Errorf("failed to new github client: %s", err)
 	}
 	return &GitHub{
-		client:  &githubClient{ghclient},
+		client:  &githubClient{Client: ghclient},
 		options: DefaultOptions(),
 	}, nil
 }
@@ -295,6 +344,86 @@ func (g *githubClient) ListPullRequestsWithCommit(
 	}
 }
 
+// PRsForCommits returns the merged pull requests of every provided commit.
+// The commits get looked up in batches of up to prsForCommitsBatchSize per
+// GraphQL request and the results get cached, which means that requesting
+// the same commit again does not result in an API call. Commits without any
+// merged pull request map to an empty slice. All commits which cannot be
+// found are part of the returned error.
+func (g *githubClient) PRsForCommits(
+	ctx context.Context, owner, repo string, shas []string,
+) (map[string][]*github.PullRequest, error) {
+	res := map[string][]*github.PullRequest{}
+	todo := []string{}
+	g.prCacheMu.RLock()
+	for _, sha := range shas {
+		if _, ok := res[sha]; ok {
+			continue
+		}
+		prs, ok := g.prCache[prCacheKey(owner, repo, sha)]
+		if !ok {
+			todo = append(todo, sha)
+		}
+		res[sha] = prs
+	}
+	g.prCacheMu.RUnlock()
+	logrus.Debugf(
+		"Looking up pull requests of %d commits, %d of them are cached",
+		len(res), len(res)-len(todo),
+	)
+
+	missing := []string{}
+	for start := 0; start < len(todo); start += prsForCommitsBatchSize {
+		end := start + prsForCommitsBatchSize
+		if end > len(todo) {
+			end = len(todo)
+		}
+		batch := todo[start:end]
+
+		query, variables := prsForCommitsQuery(owner, repo, batch)
+		result := &graphQLPRsForCommits{}
+		if err := g.GraphQL(ctx, query, variables, result); err != nil {
+			return nil, errors.Wrapf(
+				err, "looking up pull requests of commits %d to %d", start+1, end,
+			)
+		}
+
+		g.prCacheMu.Lock()
+		if g.prCache == nil {
+			g.prCache = map[string][]*github.PullRequest{}
+		}
+		for i, sha := range batch {
+			commit := result.Repository[commitAlias(i)]
+			if commit == nil {
+				missing = append(missing, sha)
+				continue
+			}
+			prs := []*github.PullRequest{}
+			for _, pr := range commit.AssociatedPullRequests.Nodes {
+				if pr.MergedAt != nil {
+					prs = append(prs, pr.PullRequest())
+				}
+			}
+			res[sha] = prs
+			g.prCache[prCacheKey(owner, repo, sha)] = prs
+		}
+		g.prCacheMu.Unlock()
+	}
+
+	if len(missing) > 0 {
+		return nil, errors.Errorf(
+			"%d commits not found in %s/%s: %s",
+			len(missing), owner, repo, strings.Join(missing, ", "),
+		)
+	}
+	return res, nil
+}
+
+// prCacheKey returns the key of the commit within the pull request cache.
+func prCacheKey(owner, repo, sha string) string {
+	return fmt.Sprintf("%s/%s@%s", owner, repo, sha)
+}
+
 func (g *githubClient) ListReleases(
 	ctx context.Context, owner, repo string, opt *github.ListOptions,
 ) ([]*github.RepositoryRelease, *github.Response, error) {
@@ -346,12 +475,15 @@ func (g *githubClient) ListTags(
 func (g *githubClient) ListBranches(
 	ctx context.Context, owner, repo string, opt *github.BranchListOptions,
 ) ([]*github.Branch, *github.Response, error) {
-	branches, response, err := g.Repositories.ListBranches(ctx, owner, repo, opt)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "fetching brnaches from repo")
+	for shouldRetry := internal.DefaultGithubErrChecker(); ; {
+		branches, response, err := g.Repositories.ListBranches(ctx, owner, repo, opt)
+		if !shouldRetry(err) {
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "fetching brnaches from repo")
+			}
+			return branches, response, nil
+		}
 	}
-
-	return branches, response, nil
 }
 
 // ListMilestones calls the github API to retrieve milestones (with retry)
@@ -399,15 +531,30 @@ func (g *githubClient) CreateIssue(
 	return issue, nil
 }
 
+func (g *githubClient) UpdateIssue(
+	ctx context.Context, owner, repo string, number int, req *github.IssueRequest,
+) (*github.Issue, error) {
+	issue, _, err := g.Issues.Edit(ctx, owner, repo, number, req)
+	if err != nil {
+		return issue, errors.Wrapf(err, "updating issue #%d", number)
+	}
+
+	logrus.Infof("Successfully updated issue #%d", number)
+	return issue, nil
+}
+
 func (g *githubClient) GetRepository(
 	ctx context.Context, owner, repo string,
 ) (*github.Repository, *github.Response, error) {
-	pr, resp, err := g.Repositories.Get(ctx, owner, repo)
-	if err != nil {
-		return pr, resp, errors.Wrap(err, "getting repository")
+	for shouldRetry := internal.DefaultGithubErrChecker(); ; {
+		pr, resp, err := g.Repositories.Get(ctx, owner, repo)
+		if !shouldRetry(err) {
+			if err != nil {
+				return pr, resp, errors.Wrap(err, "getting repository")
+			}
+			return pr, resp, nil
+		}
 	}
-
-	return pr, resp, nil
 }
 
 func (g *githubClient) UpdateReleasePage(
@@ -486,6 +633,50 @@ func (g *githubClient) CreateComment(
 	}
 }
 
+func (g *githubClient) AddLabels(
+	ctx context.Context, owner, repo string, number int, labels []string,
+) ([]*github.Label, *github.Response, error) {
+	for shouldRetry := internal.DefaultGithubErrChecker(); ; {
+		res, resp, err := g.Issues.AddLabelsToIssue(ctx, owner, repo, number, labels)
+		if !shouldRetry(err) {
+			return res, resp, err
+		}
+	}
+}
+
+func (g *githubClient) RemoveLabel(
+	ctx context.Context, owner, repo string, number int, label string,
+) (*github.Response, error) {
+	for shouldRetry := internal.DefaultGithubErrChecker(); ; {
+		resp, err := g.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+		if !shouldRetry(err) {
+			return resp, err
+		}
+	}
+}
+
+func (g *githubClient) GetBranchProtection(
+	ctx context.Context, owner, repo, branch string,
+) (*github.Protection, *github.Response, error) {
+	for shouldRetry := internal.DefaultGithubErrChecker(); ; {
+		protection, resp, err := g.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+		if !shouldRetry(err) {
+			return protection, resp, err
+		}
+	}
+}
+
+func (g *githubClient) ListIssues(
+	ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions,
+) ([]*github.Issue, *github.Response, error) {
+	for shouldRetry := internal.DefaultGithubErrChecker(); ; {
+		issues, resp, err := g.Issues.ListByRepo(ctx, owner, repo, opts)
+		if !shouldRetry(err) {
+			return issues, resp, err
+		}
+	}
+}
+
 // SetClient can be used to manually set the internal GitHub client
 func (g *GitHub) SetClient(client Client) {
 	g.client = client
@@ -581,11 +772,21 @@ func (t TagsPerBranch) addIfNotExisting(branch, tag string) {
 // also contain pre/drafted releases.
 // TODO: Create a more descriptive method name and update references
 func (g *GitHub) Releases(owner, repo string, includePrereleases bool) ([]*github.RepositoryRelease, error) {
-	allReleases, _, err := g.client.ListReleases(
-		context.Background(), owner, repo, nil,
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to retrieve GitHub releases")
+	// List releases for all pages
+	allReleases := []*github.RepositoryRelease{}
+	opts := &github.ListOptions{PerPage: g.options.GetItemsPerPage()}
+	for {
+		moreReleases, resp, err := g.client.ListReleases(
+			context.Background(), owner, repo, opts,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to retrieve GitHub releases")
+		}
+		allReleases = append(allReleases, moreReleases...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
 
 	releases := []*github.RepositoryRelease{}
@@ -684,7 +885,7 @@ func (g *GitHub) downloadAssetsParallel(assets []*github.ReleaseAsset, owner, re
 			}
 
 			logrus.Infof("GitHub asset ID: %v, download URL: %s", *asset.ID, *asset.BrowserDownloadURL)
-			assetBody, _, err := g.client.DownloadReleaseAsset(context.Background(), owner, repo, asset.GetID())
+			assetBody, err := g.downloadReleaseAsset(owner, repo, asset.GetID())
 			if err != nil {
 				return errors.Wrap(err, "downloading release assets")
 			}
@@ -772,6 +973,95 @@ func (g *GitHub) UploadReleaseAsset(
 	return asset, nil
 }
 
+// AddLabels applies the labels to all provided issues or pull requests, for
+// example to mark a set of cherry picks as approved. Labels which do not exist
+// yet get created by GitHub.
+func (g *GitHub) AddLabels(owner, repo string, numbers []int, labels ...string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	for _, number := range numbers {
+		logrus.Infof("Adding labels %s to #%d", strings.Join(labels, ", "), number)
+		if _, _, err := g.Client().AddLabels(
+			context.Background(), owner, repo, number, labels,
+		); err != nil {
+			return errors.Wrapf(err, "adding labels to #%d", number)
+		}
+	}
+	return nil
+}
+
+// RemoveLabels removes the labels from all provided issues or pull requests.
+// Labels which are not applied to an issue get skipped.
+func (g *GitHub) RemoveLabels(owner, repo string, numbers []int, labels ...string) error {
+	for _, number := range numbers {
+		for _, label := range labels {
+			logrus.Infof("Removing label %s from #%d", label, number)
+			resp, err := g.Client().RemoveLabel(
+				context.Background(), owner, repo, number, label,
+			)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					logrus.Debugf("Label %s is not applied to #%d", label, number)
+					continue
+				}
+				return errors.Wrapf(err, "removing label %s from #%d", label, number)
+			}
+		}
+	}
+	return nil
+}
+
+// ReleaseAssetSHA256 downloads the release asset and returns its SHA256
+// checksum, for example to verify that an upload did not get corrupted.
+func (g *GitHub) ReleaseAssetSHA256(
+	owner, repo string, assetID int64,
+) (string, error) {
+	body, err := g.downloadReleaseAsset(owner, repo, assetID)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, body); err != nil {
+		return "", errors.Wrapf(err, "reading release asset %d", assetID)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// downloadReleaseAsset returns the content of the release asset. The client
+// may return a redirect URL instead of the content, which gets followed then.
+func (g *GitHub) downloadReleaseAsset(
+	owner, repo string, assetID int64,
+) (io.ReadCloser, error) {
+	body, redirectURL, err := g.Client().DownloadReleaseAsset(
+		context.Background(), owner, repo, assetID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "downloading release asset %d", assetID)
+	}
+	if body != nil {
+		return body, nil
+	}
+	if redirectURL == "" {
+		return nil, errors.Errorf("release asset %d has no content", assetID)
+	}
+
+	logrus.Debugf("Following redirect of release asset %d to %s", assetID, redirectURL)
+	resp, err := http.Get(redirectURL) // nolint: gosec
+	if err != nil {
+		return nil, errors.Wrapf(err, "following redirect of release asset %d", assetID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf(
+			"following redirect of release asset %d: %s", assetID, resp.Status,
+		)
+	}
+	return resp.Body, nil
+}
+
 // ToRequest builds an issue request from the set of options
 func (nio *NewIssueOptions) toRequest() *github.IssueRequest {
 	request := &github.IssueRequest{}
@@ -805,6 +1095,27 @@ func (g *GitHub) CreateIssue(
 	return g.Client().CreateIssue(context.Background(), owner, repo, issueRequest)
 }
 
+// UpdateIssue sets the state, labels, assignees and milestone of an existing
+// issue or pull request. Only the options which are set get updated, whereas
+// the milestone is looked up by its title.
+func (g *GitHub) UpdateIssue(
+	owner, repo string, number int, opts *NewIssueOptions,
+) (*github.Issue, error) {
+	issueRequest := opts.toRequest()
+	if opts.Milestone != "" {
+		milestone, exists, err := g.GetMilestone(owner, repo, opts.Milestone)
+		if err != nil {
+			return nil, errors.Wrap(err, "looking up milestone")
+		}
+		if !exists {
+			return nil, errors.Errorf("milestone %s does not exist", opts.Milestone)
+		}
+		issueRequest.Milestone = milestone.Number
+	}
+
+	return g.Client().UpdateIssue(context.Background(), owner, repo, number, issueRequest)
+}
+
 // CreatePullRequest Creates a new pull request in owner/repo:baseBranch to merge changes from headBranchName
 // which is a string containing a branch in the same repository or a user:branch pair
 func (g *GitHub) CreatePullRequest(
@@ -850,6 +1161,33 @@ func (g *GitHub) GetMilestone(owner, repo, title string) (
 	return nil, false, nil
 }
 
+// ListOpenIssues returns all open issues of the repository which carry all
+// of the provided labels. Pull requests are not part of the result.
+func (g *GitHub) ListOpenIssues(owner, repo string, labels ...string) ([]*github.Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      labels,
+		ListOptions: github.ListOptions{PerPage: g.Options().GetItemsPerPage()},
+	}
+	issues := []*github.Issue{}
+	for {
+		page, resp, err := g.Client().ListIssues(context.Background(), owner, repo, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing repository issues")
+		}
+		for _, issue := range page {
+			if !issue.IsPullRequest() {
+				issues = append(issues, issue)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return issues, nil
+}
+
 // GetRepository gets a repository using the current client
 func (g *GitHub) GetRepository(
 	owner, repo string,
@@ -961,6 +1299,105 @@ func (g *GitHub) UpdateReleasePage(
 	return release, nil
 }
 
+// DraftRelease returns the draft release of the tag, or nil if the
+// repository has no draft release for it. Draft releases are not returned
+// when looking up releases by tag, because their tag does not exist yet.
+func (g *GitHub) DraftRelease(
+	owner, repo, tag string,
+) (*github.RepositoryRelease, error) {
+	releases, err := g.Releases(owner, repo, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing the repository releases")
+	}
+	for _, release := range releases {
+		if release.GetDraft() && release.GetTagName() == tag {
+			return release, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateDraftRelease creates a draft release for the tag, which can be
+// reviewed and updated before getting published via PublishDraftRelease.
+func (g *GitHub) CreateDraftRelease(
+	owner, repo, tag, commitish, name, body string, isPrerelease bool,
+) (*github.RepositoryRelease, error) {
+	existing, err := g.DraftRelease(owner, repo, tag)
+	if err != nil {
+		return nil, errors.Wrap(err, "checking for an existing draft release")
+	}
+	if existing != nil {
+		return nil, errors.Errorf(
+			"draft release %d already exists for %s", existing.GetID(), tag,
+		)
+	}
+
+	logrus.Infof("Creating draft release for %s", tag)
+	return g.UpdateReleasePage(
+		owner, repo, 0, tag, commitish, name, body, true, isPrerelease,
+	)
+}
+
+// UpdateReleaseBody replaces the body of a release, for example with newly
+// generated release notes, and leaves all other fields intact.
+func (g *GitHub) UpdateReleaseBody(
+	owner, repo string, releaseID int64, body string,
+) (*github.RepositoryRelease, error) {
+	if releaseID == 0 {
+		return nil, errors.New("unable to update release body, release ID is empty")
+	}
+	release, err := g.Client().UpdateReleasePage(
+		context.Background(), owner, repo, releaseID,
+		&github.RepositoryRelease{Body: &body},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "updating the release body")
+	}
+	return release, nil
+}
+
+// ReplaceReleaseAsset uploads the file as asset of the release, whereas an
+// existing asset with the same name gets deleted first. Like for
+// UploadReleaseAsset, a label can be appended to the file name with a colon.
+func (g *GitHub) ReplaceReleaseAsset(
+	owner, repo string, releaseID int64, fileName string,
+) (*github.ReleaseAsset, error) {
+	assetName := filepath.Base(strings.SplitN(fileName, ":", 2)[0])
+	assets, err := g.ListReleaseAssets(owner, repo, releaseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing the release assets")
+	}
+	for _, asset := range assets {
+		if asset.GetName() != assetName {
+			continue
+		}
+		logrus.Infof("Deleting existing release asset %s", assetName)
+		if err := g.DeleteReleaseAsset(owner, repo, asset.GetID()); err != nil {
+			return nil, errors.Wrapf(err, "replacing release asset %s", assetName)
+		}
+	}
+	return g.UploadReleaseAsset(owner, repo, releaseID, fileName)
+}
+
+// PublishDraftRelease makes a draft release visible, which also creates its
+// tag if it does not exist yet.
+func (g *GitHub) PublishDraftRelease(
+	owner, repo string, releaseID int64,
+) (*github.RepositoryRelease, error) {
+	if releaseID == 0 {
+		return nil, errors.New("unable to publish draft release, release ID is empty")
+	}
+	logrus.Infof("Publishing draft release %d", releaseID)
+	release, err := g.Client().UpdateReleasePage(
+		context.Background(), owner, repo, releaseID,
+		&github.RepositoryRelease{Draft: github.Bool(false)},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "publishing the draft release")
+	}
+	return release, nil
+}
+
 // DeleteReleaseAsset deletes an asset from a release
 func (g *GitHub) DeleteReleaseAsset(owner, repo string, assetID int64) error {
 	return errors.Wrap(g.Client().DeleteReleaseAsset(
@@ -982,6 +1419,20 @@ func (g *GitHub) ListReleaseAssets(
 	return assets, nil
 }
 
+// PRsForCommits maps the commits to their merged pull requests, for example
+// to retrieve the pull requests of all commits between the start and end SHA
+// of a git.DiscoverResult. Commits which do not belong to any merged pull
+// request map to an empty slice.
+func (g *GitHub) PRsForCommits(
+	owner, repo string, shas []string,
+) (map[string][]*github.PullRequest, error) {
+	prs, err := g.Client().PRsForCommits(context.Background(), owner, repo, shas)
+	if err != nil {
+		return nil, errors.Wrap(err, "looking up pull requests of commits")
+	}
+	return prs, nil
+}
+
 // TagExists returns true is a specified tag exists in the repo
 func (g *GitHub) TagExists(owner, repo, tag string) (exists bool, err error) {
 	options := &github.ListOptions{PerPage: g.Options().GetItemsPerPage()}
@@ -17,8 +17,16 @@ limitations under the License.
 package github_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	gogithub "github.com/google/go-github/v37/github"
@@ -261,6 +269,31 @@ func TestReleasesSuccessWithPreReleases(t *testing.T) {
 	require.Equal(t, tag4, res[3].GetTagName())
 }
 
+func TestReleasesMultiplePages(t *testing.T) {
+	// Given
+	var (
+		tag1 = "v1.18.0"
+		tag2 = "v1.17.0"
+	)
+	sut, client := newSUT()
+	client.ListReleasesReturnsOnCall(0, []*gogithub.RepositoryRelease{
+		{TagName: &tag1},
+	}, &gogithub.Response{NextPage: 2}, nil)
+	client.ListReleasesReturnsOnCall(1, []*gogithub.RepositoryRelease{
+		{TagName: &tag2},
+	}, &gogithub.Response{NextPage: 0}, nil)
+
+	// When
+	res, err := sut.Releases("", "", false)
+
+	// Then
+	require.Nil(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, tag1, res[0].GetTagName())
+	require.Equal(t, tag2, res[1].GetTagName())
+	require.Equal(t, 2, client.ListReleasesCallCount())
+}
+
 func TestReleasesFailed(t *testing.T) {
 	// Given
 	sut, client := newSUT()
@@ -501,3 +534,450 @@ func TestCreateIssue(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateDraftRelease(t *testing.T) {
+	// Given
+	var (
+		tag   = "v1.22.0"
+		aTrue = true
+	)
+	sut, client := newSUT()
+	client.ListReleasesReturns(nil, nil, nil)
+	client.UpdateReleasePageReturns(&gogithub.RepositoryRelease{TagName: &tag}, nil)
+
+	// When
+	release, err := sut.CreateDraftRelease("owner", "repo", tag, "main", tag, "notes", false)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, tag, release.GetTagName())
+	require.Equal(t, 1, client.UpdateReleasePageCallCount())
+	_, _, _, releaseID, data := client.UpdateReleasePageArgsForCall(0)
+	require.Zero(t, releaseID)
+	require.True(t, data.GetDraft())
+	require.Equal(t, "notes", data.GetBody())
+
+	// Given an existing draft
+	client.ListReleasesReturns([]*gogithub.RepositoryRelease{
+		{ID: gogithub.Int64(1), TagName: &tag, Draft: &aTrue},
+	}, nil, nil)
+
+	// When
+	_, err = sut.CreateDraftRelease("owner", "repo", tag, "main", tag, "notes", false)
+
+	// Then
+	require.NotNil(t, err)
+	require.Equal(t, 1, client.UpdateReleasePageCallCount())
+}
+
+func TestDraftRelease(t *testing.T) {
+	// Given
+	var (
+		tag1  = "v1.22.0"
+		tag2  = "v1.21.0"
+		aTrue = true
+	)
+	sut, client := newSUT()
+	client.ListReleasesReturns([]*gogithub.RepositoryRelease{
+		{ID: gogithub.Int64(1), TagName: &tag1},
+		{ID: gogithub.Int64(2), TagName: &tag1, Draft: &aTrue},
+		{ID: gogithub.Int64(3), TagName: &tag2},
+	}, nil, nil)
+
+	// When
+	draft, err := sut.DraftRelease("owner", "repo", tag1)
+	require.Nil(t, err)
+	missing, err := sut.DraftRelease("owner", "repo", tag2)
+	require.Nil(t, err)
+
+	// Then
+	require.Equal(t, int64(2), draft.GetID())
+	require.Nil(t, missing)
+}
+
+func TestUpdateReleaseBody(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.UpdateReleasePageReturns(&gogithub.RepositoryRelease{}, nil)
+
+	// When
+	_, err := sut.UpdateReleaseBody("owner", "repo", 1, "new notes")
+
+	// Then
+	require.Nil(t, err)
+	_, _, _, releaseID, data := client.UpdateReleasePageArgsForCall(0)
+	require.Equal(t, int64(1), releaseID)
+	require.Equal(t, &gogithub.RepositoryRelease{Body: gogithub.String("new notes")}, data)
+
+	_, err = sut.UpdateReleaseBody("owner", "repo", 0, "new notes")
+	require.NotNil(t, err)
+}
+
+func TestReplaceReleaseAsset(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "kubernetes.tar.gz")
+	require.Nil(t, os.WriteFile(fileName, []byte("content"), 0o644))
+
+	sut, client := newSUT()
+	client.ListReleaseAssetsReturns([]*gogithub.ReleaseAsset{
+		{ID: gogithub.Int64(1), Name: gogithub.String("README.md")},
+		{ID: gogithub.Int64(2), Name: gogithub.String("kubernetes.tar.gz")},
+	}, nil)
+	client.UploadReleaseAssetReturns(&gogithub.ReleaseAsset{ID: gogithub.Int64(3)}, nil)
+
+	// When
+	asset, err := sut.ReplaceReleaseAsset("owner", "repo", 1, fileName+":Sources")
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, int64(3), asset.GetID())
+	require.Equal(t, 1, client.DeleteReleaseAssetCallCount())
+	_, _, _, assetID := client.DeleteReleaseAssetArgsForCall(0)
+	require.Equal(t, int64(2), assetID)
+	_, _, _, _, opts, _ := client.UploadReleaseAssetArgsForCall(0)
+	require.Equal(t, "kubernetes.tar.gz", opts.Name)
+	require.Equal(t, "Sources", opts.Label)
+}
+
+func TestReleaseAssetSHA256(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.DownloadReleaseAssetReturns(
+		io.NopCloser(strings.NewReader("test")), "", nil,
+	)
+
+	// When
+	res, err := sut.ReleaseAssetSHA256("owner", "repo", 1)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t,
+		"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", res,
+	)
+	_, _, _, assetID := client.DownloadReleaseAssetArgsForCall(0)
+	require.EqualValues(t, 1, assetID)
+
+	// When the download fails
+	client.DownloadReleaseAssetReturns(nil, "", errors.New("error"))
+	_, err = sut.ReleaseAssetSHA256("owner", "repo", 1)
+
+	// Then
+	require.NotNil(t, err)
+
+	// When there is neither content nor a redirect
+	client.DownloadReleaseAssetReturns(nil, "", nil)
+	_, err = sut.ReleaseAssetSHA256("owner", "repo", 1)
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestReleaseAssetSHA256Redirect(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/asset" {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprint(w, "test")
+		},
+	))
+	defer server.Close()
+	sut, client := newSUT()
+	client.DownloadReleaseAssetReturns(nil, server.URL+"/asset", nil)
+
+	// When
+	res, err := sut.ReleaseAssetSHA256("owner", "repo", 1)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t,
+		"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", res,
+	)
+
+	// When the redirect target does not exist
+	client.DownloadReleaseAssetReturns(nil, server.URL+"/missing", nil)
+	_, err = sut.ReleaseAssetSHA256("owner", "repo", 1)
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestPublishDraftRelease(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.UpdateReleasePageReturns(&gogithub.RepositoryRelease{}, nil)
+
+	// When
+	_, err := sut.PublishDraftRelease("owner", "repo", 1)
+
+	// Then
+	require.Nil(t, err)
+	_, _, _, releaseID, data := client.UpdateReleasePageArgsForCall(0)
+	require.Equal(t, int64(1), releaseID)
+	require.Equal(t, &gogithub.RepositoryRelease{Draft: gogithub.Bool(false)}, data)
+
+	client.UpdateReleasePageReturns(nil, errors.New("error"))
+	_, err = sut.PublishDraftRelease("owner", "repo", 1)
+	require.NotNil(t, err)
+}
+
+func TestUpdateIssue(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.ListMilestonesReturns([]*gogithub.Milestone{
+		{Number: gogithub.Int(22), Title: gogithub.String("v1.22")},
+	}, &gogithub.Response{NextPage: 0}, nil)
+	client.UpdateIssueReturns(&gogithub.Issue{}, nil)
+
+	// When
+	_, err := sut.UpdateIssue("owner", "repo", 100, &github.NewIssueOptions{
+		Labels:    []string{"kind/bug"},
+		Milestone: "v1.22",
+	})
+
+	// Then
+	require.Nil(t, err)
+	_, _, _, number, req := client.UpdateIssueArgsForCall(0)
+	require.Equal(t, 100, number)
+	require.Equal(t, []string{"kind/bug"}, req.GetLabels())
+	require.Equal(t, 22, req.GetMilestone())
+
+	// When the milestone does not exist
+	_, err = sut.UpdateIssue("owner", "repo", 100, &github.NewIssueOptions{
+		Milestone: "v1.23",
+	})
+
+	// Then
+	require.NotNil(t, err)
+	require.Equal(t, 1, client.UpdateIssueCallCount())
+}
+
+func TestPRsForCommits(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	expected := map[string][]*gogithub.PullRequest{
+		"1": {{Number: gogithub.Int(1)}},
+		"2": {},
+	}
+	client.PRsForCommitsReturns(expected, nil)
+
+	// When
+	res, err := sut.PRsForCommits("owner", "repo", []string{"1", "2"})
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, expected, res)
+	_, owner, repo, shas := client.PRsForCommitsArgsForCall(0)
+	require.Equal(t, "owner", owner)
+	require.Equal(t, "repo", repo)
+	require.Equal(t, []string{"1", "2"}, shas)
+
+	// When the lookup fails
+	client.PRsForCommitsReturns(nil, errors.New("error"))
+	_, err = sut.PRsForCommits("owner", "repo", []string{"1", "2"})
+
+	// Then
+	require.NotNil(t, err)
+}
+
+// newPRsForCommitsServer returns a GraphQL server knowing the merged pull
+// request #1 of commit "1" and no pull requests of the other commits except
+// "missing". It counts the requests and the looked up commits.
+func newPRsForCommitsServer(t *testing.T, requests, commits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(requests, 1)
+			require.Equal(t, "/api/graphql", r.URL.Path)
+
+			request := struct {
+				Query     string            `json:"query"`
+				Variables map[string]string `json:"variables"`
+			}{}
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+			require.Equal(t, "owner", request.Variables["owner"])
+			require.Equal(t, "repo", request.Variables["repo"])
+
+			data := map[string]interface{}{}
+			for alias, sha := range request.Variables {
+				if alias == "owner" || alias == "repo" {
+					continue
+				}
+				atomic.AddInt32(commits, 1)
+				require.Contains(t, request.Query, fmt.Sprintf("%s: object(oid: $%s)", alias, alias))
+				switch sha {
+				case "missing":
+					data[alias] = nil
+				case "1":
+					data[alias] = json.RawMessage(`{"associatedPullRequests": {"nodes": [
+						{"number": 1, "mergedAt": "2021-08-01T00:00:00Z", "author": {"login": "user"},
+						 "labels": {"nodes": [{"name": "kind/bug"}]}},
+						{"number": 2}
+					]}}`)
+				default:
+					data[alias] = json.RawMessage(`{"associatedPullRequests": {"nodes": []}}`)
+				}
+			}
+			require.Nil(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"repository": data},
+			}))
+		},
+	))
+}
+
+func TestPRsForCommitsCached(t *testing.T) {
+	// Given
+	var requests, commits int32
+	server := newPRsForCommitsServer(t, &requests, &commits)
+	defer server.Close()
+	sut, err := github.NewEnterpriseWithToken(server.URL+"/", server.URL+"/", "")
+	require.Nil(t, err)
+
+	// When
+	res, err := sut.PRsForCommits("owner", "repo", []string{"1", "2", "1"})
+
+	// Then
+	require.Nil(t, err)
+	require.Len(t, res, 2)
+	require.Len(t, res["1"], 1)
+	require.Equal(t, 1, res["1"][0].GetNumber())
+	require.Equal(t, "user", res["1"][0].GetUser().GetLogin())
+	require.Equal(t, "kind/bug", res["1"][0].Labels[0].GetName())
+	require.Empty(t, res["2"])
+	require.EqualValues(t, 1, atomic.LoadInt32(&requests))
+	require.EqualValues(t, 2, atomic.LoadInt32(&commits))
+
+	// When the commits got already looked up
+	res, err = sut.PRsForCommits("owner", "repo", []string{"2", "1"})
+
+	// Then
+	require.Nil(t, err)
+	require.Len(t, res["1"], 1)
+	require.EqualValues(t, 1, atomic.LoadInt32(&requests))
+
+	// When commits can not be found
+	_, err = sut.PRsForCommits("owner", "repo", []string{"1", "missing", "3", "missing"})
+
+	// Then
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "1 commits not found in owner/repo: missing")
+}
+
+func TestPRsForCommitsBatched(t *testing.T) {
+	// Given
+	var requests, commits int32
+	server := newPRsForCommitsServer(t, &requests, &commits)
+	defer server.Close()
+	sut, err := github.NewEnterpriseWithToken(server.URL+"/", server.URL+"/", "")
+	require.Nil(t, err)
+
+	shas := []string{}
+	for i := 0; i < 250; i++ {
+		shas = append(shas, fmt.Sprint(i))
+	}
+
+	// When
+	res, err := sut.PRsForCommits("owner", "repo", shas)
+
+	// Then
+	require.Nil(t, err)
+	require.Len(t, res, 250)
+	require.Len(t, res["1"], 1)
+	require.EqualValues(t, 3, atomic.LoadInt32(&requests))
+	require.EqualValues(t, 250, atomic.LoadInt32(&commits))
+}
+
+func TestListOpenIssues(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.ListIssuesReturnsOnCall(0, []*gogithub.Issue{
+		{Number: gogithub.Int(1)},
+		{Number: gogithub.Int(2), PullRequestLinks: &gogithub.PullRequestLinks{}},
+	}, &gogithub.Response{NextPage: 2}, nil)
+	client.ListIssuesReturnsOnCall(1, []*gogithub.Issue{
+		{Number: gogithub.Int(3)},
+	}, &gogithub.Response{}, nil)
+
+	// When
+	issues, err := sut.ListOpenIssues("owner", "repo", "kind/failing-test")
+
+	// Then
+	require.Nil(t, err)
+	require.Len(t, issues, 2)
+	require.Equal(t, 1, issues[0].GetNumber())
+	require.Equal(t, 3, issues[1].GetNumber())
+	require.Equal(t, 2, client.ListIssuesCallCount())
+	_, _, _, opts := client.ListIssuesArgsForCall(0)
+	require.Equal(t, "open", opts.State)
+	require.Equal(t, []string{"kind/failing-test"}, opts.Labels)
+}
+
+func TestListOpenIssuesFailed(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.ListIssuesReturns(nil, nil, errors.New("error"))
+
+	// When
+	_, err := sut.ListOpenIssues("owner", "repo")
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestAddLabels(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+
+	// When
+	err := sut.AddLabels("owner", "repo", []int{1, 2}, "cherry-pick-approved", "lgtm")
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, 2, client.AddLabelsCallCount())
+	_, _, _, number, labels := client.AddLabelsArgsForCall(1)
+	require.Equal(t, 2, number)
+	require.Equal(t, []string{"cherry-pick-approved", "lgtm"}, labels)
+
+	// When no labels are provided
+	err = sut.AddLabels("owner", "repo", []int{1, 2})
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, 2, client.AddLabelsCallCount())
+
+	// When adding fails
+	client.AddLabelsReturns(nil, nil, errors.New("error"))
+	err = sut.AddLabels("owner", "repo", []int{1}, "lgtm")
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestRemoveLabels(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.RemoveLabelReturnsOnCall(0, &gogithub.Response{
+		Response: &http.Response{StatusCode: http.StatusNotFound},
+	}, errors.New("not found"))
+
+	// When
+	err := sut.RemoveLabels(
+		"owner", "repo", []int{1, 2}, "do-not-merge/release-note-label-needed",
+	)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, 2, client.RemoveLabelCallCount())
+	_, _, _, number, label := client.RemoveLabelArgsForCall(1)
+	require.Equal(t, 2, number)
+	require.Equal(t, "do-not-merge/release-note-label-needed", label)
+
+	// When removing fails
+	client.RemoveLabelReturns(nil, errors.New("error"))
+	err = sut.RemoveLabels("owner", "repo", []int{1}, "lgtm")
+
+	// Then
+	require.NotNil(t, err)
+}
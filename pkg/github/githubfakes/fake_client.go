@@ -28,6 +28,25 @@ import (
 )
 
 type FakeClient struct {
+	AddLabelsStub        func(context.Context, string, string, int, []string) ([]*githuba.Label, *githuba.Response, error)
+	addLabelsMutex       sync.RWMutex
+	addLabelsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 []string
+	}
+	addLabelsReturns struct {
+		result1 []*githuba.Label
+		result2 *githuba.Response
+		result3 error
+	}
+	addLabelsReturnsOnCall map[int]struct {
+		result1 []*githuba.Label
+		result2 *githuba.Response
+		result3 error
+	}
 	CreateCommentStub        func(context.Context, string, string, int, string) (*githuba.IssueComment, *githuba.Response, error)
 	createCommentMutex       sync.RWMutex
 	createCommentArgsForCall []struct {
@@ -114,6 +133,24 @@ type FakeClient struct {
 		result2 string
 		result3 error
 	}
+	GetBranchProtectionStub        func(context.Context, string, string, string) (*githuba.Protection, *githuba.Response, error)
+	getBranchProtectionMutex       sync.RWMutex
+	getBranchProtectionArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	getBranchProtectionReturns struct {
+		result1 *githuba.Protection
+		result2 *githuba.Response
+		result3 error
+	}
+	getBranchProtectionReturnsOnCall map[int]struct {
+		result1 *githuba.Protection
+		result2 *githuba.Response
+		result3 error
+	}
 	GetCommitStub        func(context.Context, string, string, string) (*githuba.Commit, *githuba.Response, error)
 	getCommitMutex       sync.RWMutex
 	getCommitArgsForCall []struct {
@@ -221,6 +258,20 @@ type FakeClient struct {
 		result2 *githuba.Response
 		result3 error
 	}
+	GraphQLStub        func(context.Context, string, map[string]interface{}, interface{}) error
+	graphQLMutex       sync.RWMutex
+	graphQLArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 map[string]interface{}
+		arg4 interface{}
+	}
+	graphQLReturns struct {
+		result1 error
+	}
+	graphQLReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ListBranchesStub        func(context.Context, string, string, *githuba.BranchListOptions) ([]*githuba.Branch, *githuba.Response, error)
 	listBranchesMutex       sync.RWMutex
 	listBranchesArgsForCall []struct {
@@ -257,6 +308,24 @@ type FakeClient struct {
 		result2 *githuba.Response
 		result3 error
 	}
+	ListIssuesStub        func(context.Context, string, string, *githuba.IssueListByRepoOptions) ([]*githuba.Issue, *githuba.Response, error)
+	listIssuesMutex       sync.RWMutex
+	listIssuesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *githuba.IssueListByRepoOptions
+	}
+	listIssuesReturns struct {
+		result1 []*githuba.Issue
+		result2 *githuba.Response
+		result3 error
+	}
+	listIssuesReturnsOnCall map[int]struct {
+		result1 []*githuba.Issue
+		result2 *githuba.Response
+		result3 error
+	}
 	ListMilestonesStub        func(context.Context, string, string, *githuba.MilestoneListOptions) ([]*githuba.Milestone, *githuba.Response, error)
 	listMilestonesMutex       sync.RWMutex
 	listMilestonesArgsForCall []struct {
@@ -347,6 +416,56 @@ type FakeClient struct {
 		result2 *githuba.Response
 		result3 error
 	}
+	PRsForCommitsStub        func(context.Context, string, string, []string) (map[string][]*githuba.PullRequest, error)
+	pRsForCommitsMutex       sync.RWMutex
+	pRsForCommitsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []string
+	}
+	pRsForCommitsReturns struct {
+		result1 map[string][]*githuba.PullRequest
+		result2 error
+	}
+	pRsForCommitsReturnsOnCall map[int]struct {
+		result1 map[string][]*githuba.PullRequest
+		result2 error
+	}
+	RemoveLabelStub        func(context.Context, string, string, int, string) (*githuba.Response, error)
+	removeLabelMutex       sync.RWMutex
+	removeLabelArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 string
+	}
+	removeLabelReturns struct {
+		result1 *githuba.Response
+		result2 error
+	}
+	removeLabelReturnsOnCall map[int]struct {
+		result1 *githuba.Response
+		result2 error
+	}
+	UpdateIssueStub        func(context.Context, string, string, int, *githuba.IssueRequest) (*githuba.Issue, error)
+	updateIssueMutex       sync.RWMutex
+	updateIssueArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *githuba.IssueRequest
+	}
+	updateIssueReturns struct {
+		result1 *githuba.Issue
+		result2 error
+	}
+	updateIssueReturnsOnCall map[int]struct {
+		result1 *githuba.Issue
+		result2 error
+	}
 	UpdateReleasePageStub        func(context.Context, string, string, int64, *githuba.RepositoryRelease) (*githuba.RepositoryRelease, error)
 	updateReleasePageMutex       sync.RWMutex
 	updateReleasePageArgsForCall []struct {
@@ -386,6 +505,82 @@ type FakeClient struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeClient) AddLabels(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 []string) ([]*githuba.Label, *githuba.Response, error) {
+	var arg5Copy []string
+	if arg5 != nil {
+		arg5Copy = make([]string, len(arg5))
+		copy(arg5Copy, arg5)
+	}
+	fake.addLabelsMutex.Lock()
+	ret, specificReturn := fake.addLabelsReturnsOnCall[len(fake.addLabelsArgsForCall)]
+	fake.addLabelsArgsForCall = append(fake.addLabelsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5Copy})
+	stub := fake.AddLabelsStub
+	fakeReturns := fake.addLabelsReturns
+	fake.recordInvocation("AddLabels", []interface{}{arg1, arg2, arg3, arg4, arg5Copy})
+	fake.addLabelsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) AddLabelsCallCount() int {
+	fake.addLabelsMutex.RLock()
+	defer fake.addLabelsMutex.RUnlock()
+	return len(fake.addLabelsArgsForCall)
+}
+
+func (fake *FakeClient) AddLabelsCalls(stub func(context.Context, string, string, int, []string) ([]*githuba.Label, *githuba.Response, error)) {
+	fake.addLabelsMutex.Lock()
+	defer fake.addLabelsMutex.Unlock()
+	fake.AddLabelsStub = stub
+}
+
+func (fake *FakeClient) AddLabelsArgsForCall(i int) (context.Context, string, string, int, []string) {
+	fake.addLabelsMutex.RLock()
+	defer fake.addLabelsMutex.RUnlock()
+	argsForCall := fake.addLabelsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeClient) AddLabelsReturns(result1 []*githuba.Label, result2 *githuba.Response, result3 error) {
+	fake.addLabelsMutex.Lock()
+	defer fake.addLabelsMutex.Unlock()
+	fake.AddLabelsStub = nil
+	fake.addLabelsReturns = struct {
+		result1 []*githuba.Label
+		result2 *githuba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) AddLabelsReturnsOnCall(i int, result1 []*githuba.Label, result2 *githuba.Response, result3 error) {
+	fake.addLabelsMutex.Lock()
+	defer fake.addLabelsMutex.Unlock()
+	fake.AddLabelsStub = nil
+	if fake.addLabelsReturnsOnCall == nil {
+		fake.addLabelsReturnsOnCall = make(map[int]struct {
+			result1 []*githuba.Label
+			result2 *githuba.Response
+			result3 error
+		})
+	}
+	fake.addLabelsReturnsOnCall[i] = struct {
+		result1 []*githuba.Label
+		result2 *githuba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) CreateComment(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 string) (*githuba.IssueComment, *githuba.Response, error) {
 	fake.createCommentMutex.Lock()
 	ret, specificReturn := fake.createCommentReturnsOnCall[len(fake.createCommentArgsForCall)]
@@ -728,6 +923,76 @@ func (fake *FakeClient) DownloadReleaseAssetReturnsOnCall(i int, result1 io.Read
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) GetBranchProtection(arg1 context.Context, arg2 string, arg3 string, arg4 string) (*githuba.Protection, *githuba.Response, error) {
+	fake.getBranchProtectionMutex.Lock()
+	ret, specificReturn := fake.getBranchProtectionReturnsOnCall[len(fake.getBranchProtectionArgsForCall)]
+	fake.getBranchProtectionArgsForCall = append(fake.getBranchProtectionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetBranchProtectionStub
+	fakeReturns := fake.getBranchProtectionReturns
+	fake.recordInvocation("GetBranchProtection", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getBranchProtectionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) GetBranchProtectionCallCount() int {
+	fake.getBranchProtectionMutex.RLock()
+	defer fake.getBranchProtectionMutex.RUnlock()
+	return len(fake.getBranchProtectionArgsForCall)
+}
+
+func (fake *FakeClient) GetBranchProtectionCalls(stub func(context.Context, string, string, string) (*githuba.Protection, *githuba.Response, error)) {
+	fake.getBranchProtectionMutex.Lock()
+	defer fake.getBranchProtectionMutex.Unlock()
+	fake.GetBranchProtectionStub = stub
+}
+
+func (fake *FakeClient) GetBranchProtectionArgsForCall(i int) (context.Context, string, string, string) {
+	fake.getBranchProtectionMutex.RLock()
+	defer fake.getBranchProtectionMutex.RUnlock()
+	argsForCall := fake.getBranchProtectionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) GetBranchProtectionReturns(result1 *githuba.Protection, result2 *githuba.Response, result3 error) {
+	fake.getBranchProtectionMutex.Lock()
+	defer fake.getBranchProtectionMutex.Unlock()
+	fake.GetBranchProtectionStub = nil
+	fake.getBranchProtectionReturns = struct {
+		result1 *githuba.Protection
+		result2 *githuba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) GetBranchProtectionReturnsOnCall(i int, result1 *githuba.Protection, result2 *githuba.Response, result3 error) {
+	fake.getBranchProtectionMutex.Lock()
+	defer fake.getBranchProtectionMutex.Unlock()
+	fake.GetBranchProtectionStub = nil
+	if fake.getBranchProtectionReturnsOnCall == nil {
+		fake.getBranchProtectionReturnsOnCall = make(map[int]struct {
+			result1 *githuba.Protection
+			result2 *githuba.Response
+			result3 error
+		})
+	}
+	fake.getBranchProtectionReturnsOnCall[i] = struct {
+		result1 *githuba.Protection
+		result2 *githuba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) GetCommit(arg1 context.Context, arg2 string, arg3 string, arg4 string) (*githuba.Commit, *githuba.Response, error) {
 	fake.getCommitMutex.Lock()
 	ret, specificReturn := fake.getCommitReturnsOnCall[len(fake.getCommitArgsForCall)]
@@ -1147,6 +1412,70 @@ func (fake *FakeClient) GetRepositoryReturnsOnCall(i int, result1 *githuba.Repos
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) GraphQL(arg1 context.Context, arg2 string, arg3 map[string]interface{}, arg4 interface{}) error {
+	fake.graphQLMutex.Lock()
+	ret, specificReturn := fake.graphQLReturnsOnCall[len(fake.graphQLArgsForCall)]
+	fake.graphQLArgsForCall = append(fake.graphQLArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 map[string]interface{}
+		arg4 interface{}
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GraphQLStub
+	fakeReturns := fake.graphQLReturns
+	fake.recordInvocation("GraphQL", []interface{}{arg1, arg2, arg3, arg4})
+	fake.graphQLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) GraphQLCallCount() int {
+	fake.graphQLMutex.RLock()
+	defer fake.graphQLMutex.RUnlock()
+	return len(fake.graphQLArgsForCall)
+}
+
+func (fake *FakeClient) GraphQLCalls(stub func(context.Context, string, map[string]interface{}, interface{}) error) {
+	fake.graphQLMutex.Lock()
+	defer fake.graphQLMutex.Unlock()
+	fake.GraphQLStub = stub
+}
+
+func (fake *FakeClient) GraphQLArgsForCall(i int) (context.Context, string, map[string]interface{}, interface{}) {
+	fake.graphQLMutex.RLock()
+	defer fake.graphQLMutex.RUnlock()
+	argsForCall := fake.graphQLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) GraphQLReturns(result1 error) {
+	fake.graphQLMutex.Lock()
+	defer fake.graphQLMutex.Unlock()
+	fake.GraphQLStub = nil
+	fake.graphQLReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) GraphQLReturnsOnCall(i int, result1 error) {
+	fake.graphQLMutex.Lock()
+	defer fake.graphQLMutex.Unlock()
+	fake.GraphQLStub = nil
+	if fake.graphQLReturnsOnCall == nil {
+		fake.graphQLReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.graphQLReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) ListBranches(arg1 context.Context, arg2 string, arg3 string, arg4 *githuba.BranchListOptions) ([]*githuba.Branch, *githuba.Response, error) {
 	fake.listBranchesMutex.Lock()
 	ret, specificReturn := fake.listBranchesReturnsOnCall[len(fake.listBranchesArgsForCall)]
@@ -1287,6 +1616,76 @@ func (fake *FakeClient) ListCommitsReturnsOnCall(i int, result1 []*githuba.Repos
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) ListIssues(arg1 context.Context, arg2 string, arg3 string, arg4 *githuba.IssueListByRepoOptions) ([]*githuba.Issue, *githuba.Response, error) {
+	fake.listIssuesMutex.Lock()
+	ret, specificReturn := fake.listIssuesReturnsOnCall[len(fake.listIssuesArgsForCall)]
+	fake.listIssuesArgsForCall = append(fake.listIssuesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *githuba.IssueListByRepoOptions
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ListIssuesStub
+	fakeReturns := fake.listIssuesReturns
+	fake.recordInvocation("ListIssues", []interface{}{arg1, arg2, arg3, arg4})
+	fake.listIssuesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) ListIssuesCallCount() int {
+	fake.listIssuesMutex.RLock()
+	defer fake.listIssuesMutex.RUnlock()
+	return len(fake.listIssuesArgsForCall)
+}
+
+func (fake *FakeClient) ListIssuesCalls(stub func(context.Context, string, string, *githuba.IssueListByRepoOptions) ([]*githuba.Issue, *githuba.Response, error)) {
+	fake.listIssuesMutex.Lock()
+	defer fake.listIssuesMutex.Unlock()
+	fake.ListIssuesStub = stub
+}
+
+func (fake *FakeClient) ListIssuesArgsForCall(i int) (context.Context, string, string, *githuba.IssueListByRepoOptions) {
+	fake.listIssuesMutex.RLock()
+	defer fake.listIssuesMutex.RUnlock()
+	argsForCall := fake.listIssuesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) ListIssuesReturns(result1 []*githuba.Issue, result2 *githuba.Response, result3 error) {
+	fake.listIssuesMutex.Lock()
+	defer fake.listIssuesMutex.Unlock()
+	fake.ListIssuesStub = nil
+	fake.listIssuesReturns = struct {
+		result1 []*githuba.Issue
+		result2 *githuba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) ListIssuesReturnsOnCall(i int, result1 []*githuba.Issue, result2 *githuba.Response, result3 error) {
+	fake.listIssuesMutex.Lock()
+	defer fake.listIssuesMutex.Unlock()
+	fake.ListIssuesStub = nil
+	if fake.listIssuesReturnsOnCall == nil {
+		fake.listIssuesReturnsOnCall = make(map[int]struct {
+			result1 []*githuba.Issue
+			result2 *githuba.Response
+			result3 error
+		})
+	}
+	fake.listIssuesReturnsOnCall[i] = struct {
+		result1 []*githuba.Issue
+		result2 *githuba.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) ListMilestones(arg1 context.Context, arg2 string, arg3 string, arg4 *githuba.MilestoneListOptions) ([]*githuba.Milestone, *githuba.Response, error) {
 	fake.listMilestonesMutex.Lock()
 	ret, specificReturn := fake.listMilestonesReturnsOnCall[len(fake.listMilestonesArgsForCall)]
@@ -1636,6 +2035,214 @@ func (fake *FakeClient) ListTagsReturnsOnCall(i int, result1 []*githuba.Reposito
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) PRsForCommits(arg1 context.Context, arg2 string, arg3 string, arg4 []string) (map[string][]*githuba.PullRequest, error) {
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.pRsForCommitsMutex.Lock()
+	ret, specificReturn := fake.pRsForCommitsReturnsOnCall[len(fake.pRsForCommitsArgsForCall)]
+	fake.pRsForCommitsArgsForCall = append(fake.pRsForCommitsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []string
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.PRsForCommitsStub
+	fakeReturns := fake.pRsForCommitsReturns
+	fake.recordInvocation("PRsForCommits", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.pRsForCommitsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) PRsForCommitsCallCount() int {
+	fake.pRsForCommitsMutex.RLock()
+	defer fake.pRsForCommitsMutex.RUnlock()
+	return len(fake.pRsForCommitsArgsForCall)
+}
+
+func (fake *FakeClient) PRsForCommitsCalls(stub func(context.Context, string, string, []string) (map[string][]*githuba.PullRequest, error)) {
+	fake.pRsForCommitsMutex.Lock()
+	defer fake.pRsForCommitsMutex.Unlock()
+	fake.PRsForCommitsStub = stub
+}
+
+func (fake *FakeClient) PRsForCommitsArgsForCall(i int) (context.Context, string, string, []string) {
+	fake.pRsForCommitsMutex.RLock()
+	defer fake.pRsForCommitsMutex.RUnlock()
+	argsForCall := fake.pRsForCommitsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) PRsForCommitsReturns(result1 map[string][]*githuba.PullRequest, result2 error) {
+	fake.pRsForCommitsMutex.Lock()
+	defer fake.pRsForCommitsMutex.Unlock()
+	fake.PRsForCommitsStub = nil
+	fake.pRsForCommitsReturns = struct {
+		result1 map[string][]*githuba.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) PRsForCommitsReturnsOnCall(i int, result1 map[string][]*githuba.PullRequest, result2 error) {
+	fake.pRsForCommitsMutex.Lock()
+	defer fake.pRsForCommitsMutex.Unlock()
+	fake.PRsForCommitsStub = nil
+	if fake.pRsForCommitsReturnsOnCall == nil {
+		fake.pRsForCommitsReturnsOnCall = make(map[int]struct {
+			result1 map[string][]*githuba.PullRequest
+			result2 error
+		})
+	}
+	fake.pRsForCommitsReturnsOnCall[i] = struct {
+		result1 map[string][]*githuba.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RemoveLabel(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 string) (*githuba.Response, error) {
+	fake.removeLabelMutex.Lock()
+	ret, specificReturn := fake.removeLabelReturnsOnCall[len(fake.removeLabelArgsForCall)]
+	fake.removeLabelArgsForCall = append(fake.removeLabelArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.RemoveLabelStub
+	fakeReturns := fake.removeLabelReturns
+	fake.recordInvocation("RemoveLabel", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.removeLabelMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RemoveLabelCallCount() int {
+	fake.removeLabelMutex.RLock()
+	defer fake.removeLabelMutex.RUnlock()
+	return len(fake.removeLabelArgsForCall)
+}
+
+func (fake *FakeClient) RemoveLabelCalls(stub func(context.Context, string, string, int, string) (*githuba.Response, error)) {
+	fake.removeLabelMutex.Lock()
+	defer fake.removeLabelMutex.Unlock()
+	fake.RemoveLabelStub = stub
+}
+
+func (fake *FakeClient) RemoveLabelArgsForCall(i int) (context.Context, string, string, int, string) {
+	fake.removeLabelMutex.RLock()
+	defer fake.removeLabelMutex.RUnlock()
+	argsForCall := fake.removeLabelArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeClient) RemoveLabelReturns(result1 *githuba.Response, result2 error) {
+	fake.removeLabelMutex.Lock()
+	defer fake.removeLabelMutex.Unlock()
+	fake.RemoveLabelStub = nil
+	fake.removeLabelReturns = struct {
+		result1 *githuba.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RemoveLabelReturnsOnCall(i int, result1 *githuba.Response, result2 error) {
+	fake.removeLabelMutex.Lock()
+	defer fake.removeLabelMutex.Unlock()
+	fake.RemoveLabelStub = nil
+	if fake.removeLabelReturnsOnCall == nil {
+		fake.removeLabelReturnsOnCall = make(map[int]struct {
+			result1 *githuba.Response
+			result2 error
+		})
+	}
+	fake.removeLabelReturnsOnCall[i] = struct {
+		result1 *githuba.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UpdateIssue(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 *githuba.IssueRequest) (*githuba.Issue, error) {
+	fake.updateIssueMutex.Lock()
+	ret, specificReturn := fake.updateIssueReturnsOnCall[len(fake.updateIssueArgsForCall)]
+	fake.updateIssueArgsForCall = append(fake.updateIssueArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *githuba.IssueRequest
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.UpdateIssueStub
+	fakeReturns := fake.updateIssueReturns
+	fake.recordInvocation("UpdateIssue", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.updateIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) UpdateIssueCallCount() int {
+	fake.updateIssueMutex.RLock()
+	defer fake.updateIssueMutex.RUnlock()
+	return len(fake.updateIssueArgsForCall)
+}
+
+func (fake *FakeClient) UpdateIssueCalls(stub func(context.Context, string, string, int, *githuba.IssueRequest) (*githuba.Issue, error)) {
+	fake.updateIssueMutex.Lock()
+	defer fake.updateIssueMutex.Unlock()
+	fake.UpdateIssueStub = stub
+}
+
+func (fake *FakeClient) UpdateIssueArgsForCall(i int) (context.Context, string, string, int, *githuba.IssueRequest) {
+	fake.updateIssueMutex.RLock()
+	defer fake.updateIssueMutex.RUnlock()
+	argsForCall := fake.updateIssueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeClient) UpdateIssueReturns(result1 *githuba.Issue, result2 error) {
+	fake.updateIssueMutex.Lock()
+	defer fake.updateIssueMutex.Unlock()
+	fake.UpdateIssueStub = nil
+	fake.updateIssueReturns = struct {
+		result1 *githuba.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UpdateIssueReturnsOnCall(i int, result1 *githuba.Issue, result2 error) {
+	fake.updateIssueMutex.Lock()
+	defer fake.updateIssueMutex.Unlock()
+	fake.UpdateIssueStub = nil
+	if fake.updateIssueReturnsOnCall == nil {
+		fake.updateIssueReturnsOnCall = make(map[int]struct {
+			result1 *githuba.Issue
+			result2 error
+		})
+	}
+	fake.updateIssueReturnsOnCall[i] = struct {
+		result1 *githuba.Issue
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) UpdateReleasePage(arg1 context.Context, arg2 string, arg3 string, arg4 int64, arg5 *githuba.RepositoryRelease) (*githuba.RepositoryRelease, error) {
 	fake.updateReleasePageMutex.Lock()
 	ret, specificReturn := fake.updateReleasePageReturnsOnCall[len(fake.updateReleasePageArgsForCall)]
@@ -1776,6 +2383,8 @@ func (fake *FakeClient) UploadReleaseAssetReturnsOnCall(i int, result1 *githuba.
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.addLabelsMutex.RLock()
+	defer fake.addLabelsMutex.RUnlock()
 	fake.createCommentMutex.RLock()
 	defer fake.createCommentMutex.RUnlock()
 	fake.createIssueMutex.RLock()
@@ -1786,6 +2395,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.deleteReleaseAssetMutex.RUnlock()
 	fake.downloadReleaseAssetMutex.RLock()
 	defer fake.downloadReleaseAssetMutex.RUnlock()
+	fake.getBranchProtectionMutex.RLock()
+	defer fake.getBranchProtectionMutex.RUnlock()
 	fake.getCommitMutex.RLock()
 	defer fake.getCommitMutex.RUnlock()
 	fake.getIssueMutex.RLock()
@@ -1798,10 +2409,14 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.getRepoCommitMutex.RUnlock()
 	fake.getRepositoryMutex.RLock()
 	defer fake.getRepositoryMutex.RUnlock()
+	fake.graphQLMutex.RLock()
+	defer fake.graphQLMutex.RUnlock()
 	fake.listBranchesMutex.RLock()
 	defer fake.listBranchesMutex.RUnlock()
 	fake.listCommitsMutex.RLock()
 	defer fake.listCommitsMutex.RUnlock()
+	fake.listIssuesMutex.RLock()
+	defer fake.listIssuesMutex.RUnlock()
 	fake.listMilestonesMutex.RLock()
 	defer fake.listMilestonesMutex.RUnlock()
 	fake.listPullRequestsWithCommitMutex.RLock()
@@ -1812,6 +2427,12 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listReleasesMutex.RUnlock()
 	fake.listTagsMutex.RLock()
 	defer fake.listTagsMutex.RUnlock()
+	fake.pRsForCommitsMutex.RLock()
+	defer fake.pRsForCommitsMutex.RUnlock()
+	fake.removeLabelMutex.RLock()
+	defer fake.removeLabelMutex.RUnlock()
+	fake.updateIssueMutex.RLock()
+	defer fake.updateIssueMutex.RUnlock()
 	fake.updateReleasePageMutex.RLock()
 	defer fake.updateReleasePageMutex.RUnlock()
 	fake.uploadReleaseAssetMutex.RLock()
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/github/internal"
+)
+
+// graphQLResponse is the envelope of all GraphQL API responses.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GraphQL runs the query using the provided variables against the GraphQL
+// API and decodes the data of the response into result (with retry). Errors
+// reported by the GraphQL API fail the call, even if data got returned.
+func (g *githubClient) GraphQL(
+	ctx context.Context, query string, variables map[string]interface{}, result interface{},
+) error {
+	body := map[string]interface{}{"query": query, "variables": variables}
+	for shouldRetry := internal.DefaultGithubErrChecker(); ; {
+		req, err := g.NewRequest(http.MethodPost, graphQLPath(g.BaseURL), body)
+		if err != nil {
+			return errors.Wrap(err, "creating GraphQL request")
+		}
+
+		response := &graphQLResponse{}
+		if _, err := g.Do(ctx, req, response); err != nil {
+			if shouldRetry(err) {
+				continue
+			}
+			return err
+		}
+
+		if len(response.Errors) > 0 {
+			messages := []string{}
+			for _, e := range response.Errors {
+				messages = append(messages, e.Message)
+			}
+			return errors.Errorf("GraphQL query failed: %s", strings.Join(messages, "; "))
+		}
+		return errors.Wrap(json.Unmarshal(response.Data, result), "decoding GraphQL data")
+	}
+}
+
+// graphQLPath returns the path of the GraphQL API relative to the REST API
+// base URL. GitHub Enterprise serves the REST API below /api/v3/ and the
+// GraphQL API at /api/graphql.
+func graphQLPath(baseURL *url.URL) string {
+	if strings.HasSuffix(baseURL.Path, "/api/v3/") {
+		return "../graphql"
+	}
+	return "graphql"
+}
+
+// GraphQLPullRequestFields are the fields of a pull request which can be
+// decoded into a GraphQLPullRequest.
+const GraphQLPullRequestFields = `number
+title
+body
+url
+mergedAt
+author {
+  login
+  url
+}
+labels(first: 100) {
+  nodes {
+    name
+  }
+}`
+
+// GraphQLPullRequest is a pull request queried via GraphQLPullRequestFields.
+type GraphQLPullRequest struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	Body     string     `json:"body"`
+	URL      string     `json:"url"`
+	MergedAt *time.Time `json:"mergedAt"`
+	Author   *struct {
+		Login string `json:"login"`
+		URL   string `json:"url"`
+	} `json:"author"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+}
+
+// PullRequest converts the GraphQL pull request into its REST API
+// representation.
+func (p *GraphQLPullRequest) PullRequest() *github.PullRequest {
+	pr := &github.PullRequest{
+		Number:   github.Int(p.Number),
+		Title:    github.String(p.Title),
+		Body:     github.String(p.Body),
+		HTMLURL:  github.String(p.URL),
+		MergedAt: p.MergedAt,
+	}
+	if p.Author != nil {
+		pr.User = &github.User{
+			Login:   github.String(p.Author.Login),
+			HTMLURL: github.String(p.Author.URL),
+		}
+	}
+	for _, label := range p.Labels.Nodes {
+		pr.Labels = append(pr.Labels, &github.Label{Name: github.String(label.Name)})
+	}
+	return pr
+}
+
+// graphQLPRsForCommits is the result of the prsForCommitsQuery, where the
+// commits are keyed by their alias.
+type graphQLPRsForCommits struct {
+	Repository map[string]*struct {
+		AssociatedPullRequests struct {
+			Nodes []*GraphQLPullRequest `json:"nodes"`
+		} `json:"associatedPullRequests"`
+	} `json:"repository"`
+}
+
+// prsForCommitsQuery returns the query and its variables for retrieving the
+// pull requests of all provided commits at once. Every commit is queried by
+// an alias, which is the commitAlias of its index.
+func prsForCommitsQuery(owner, repo string, shas []string) (string, map[string]interface{}) {
+	variables := map[string]interface{}{"owner": owner, "repo": repo}
+	params := &strings.Builder{}
+	commits := &strings.Builder{}
+	for i, sha := range shas {
+		alias := commitAlias(i)
+		variables[alias] = sha
+		fmt.Fprintf(params, ", $%s: GitObjectID!", alias)
+		fmt.Fprintf(commits, `
+    %s: object(oid: $%s) {
+      ... on Commit {
+        associatedPullRequests(first: 5) {
+          nodes {
+            %s
+          }
+        }
+      }
+    }`, alias, alias, strings.ReplaceAll(GraphQLPullRequestFields, "\n", "\n            "))
+	}
+	return fmt.Sprintf(`query($owner: String!, $repo: String!%s) {
+  repository(owner: $owner, name: $repo) {%s
+  }
+}`, params, commits), variables
+}
+
+// commitAlias returns the alias of the commit at the index within the
+// prsForCommitsQuery.
+func commitAlias(i int) string {
+	return fmt.Sprintf("c%d", i)
+}
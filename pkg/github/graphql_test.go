@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/github"
+)
+
+func newGraphQLServer(t *testing.T, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/api/graphql", r.URL.Path)
+
+			request := struct {
+				Query     string                 `json:"query"`
+				Variables map[string]interface{} `json:"variables"`
+			}{}
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+			require.Equal(t, "query { viewer { login } }", request.Query)
+			require.Equal(t, "value", request.Variables["key"])
+
+			fmt.Fprint(w, response)
+		},
+	))
+}
+
+func TestGraphQL(t *testing.T) {
+	// Given
+	server := newGraphQLServer(t, `{"data": {"viewer": {"login": "user"}}}`)
+	defer server.Close()
+	sut, err := github.NewEnterpriseWithToken(server.URL+"/", server.URL+"/", "")
+	require.Nil(t, err)
+
+	// When
+	result := struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}{}
+	err = sut.Client().GraphQL(
+		context.Background(), "query { viewer { login } }",
+		map[string]interface{}{"key": "value"}, &result,
+	)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "user", result.Viewer.Login)
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	// Given
+	server := newGraphQLServer(t, `{
+		"data": null,
+		"errors": [{"message": "first"}, {"message": "second"}]
+	}`)
+	defer server.Close()
+	sut, err := github.NewEnterpriseWithToken(server.URL+"/", server.URL+"/", "")
+	require.Nil(t, err)
+
+	// When
+	result := map[string]interface{}{}
+	err = sut.Client().GraphQL(
+		context.Background(), "query { viewer { login } }",
+		map[string]interface{}{"key": "value"}, &result,
+	)
+
+	// Then
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "first; second")
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package internal
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v37/github"
@@ -33,6 +36,16 @@ const (
 	// GitHub calls in case we cannot extract that information from the error
 	// itself.
 	defaultGithubSleep = time.Minute
+
+	// maxRateLimitSleep is the maximum amount of time we wait for the primary
+	// rate limit to reset. Calls hitting a rate limit which resets later are
+	// not retried.
+	maxRateLimitSleep = 15 * time.Minute
+
+	// serverErrorSleep is the amount of time we wait before retrying a call
+	// which failed because of a server error for the first time. The time
+	// doubles with every subsequent try.
+	serverErrorSleep = 2 * time.Second
 )
 
 // DefaultGithubErrChecker is a GithubErrChecker set up with a default amount
@@ -47,11 +60,12 @@ func DefaultGithubErrChecker() func(error) bool {
 // should be retried at max, and `sleeper`, a function which implements the
 // sleeping.
 //
-// Currently the only special error that is flagged as retryable is the
-// `AbuseRateLimitError`. If such an error occurs, we sleep for a while (the
-// amount of time the error told us to wait) and then report back that we can
-// retry.
-// Other special errors should be easy to implement too.
+// The following errors are flagged as retryable:
+// - `AbuseRateLimitError` and secondary rate limit errors, after sleeping for
+//   the amount of time the error told us to wait
+// - `RateLimitError`, after sleeping until the rate limit resets, unless that
+//   takes longer than 15 minutes
+// - server errors (5xx), after sleeping with an exponential backoff
 //
 // It can be used like this:
 //  for shouldRetry := GithubErrChecker(10, time.Sleep); ; {
@@ -86,6 +100,70 @@ func GithubErrChecker(maxTries int, sleeper func(time.Duration)) func(error) boo
 			return true
 		}
 
+		if rerr, ok := err.(*github.RateLimitError); ok {
+			waitDuration := defaultGithubSleep
+			if !rerr.Rate.Reset.IsZero() {
+				waitDuration = time.Until(rerr.Rate.Reset.Time)
+			}
+			if waitDuration < 0 {
+				waitDuration = 0
+			}
+			if waitDuration > maxRateLimitSleep {
+				logrus.Errorf("Rate limit resets in %s, not retrying: %v", waitDuration, rerr)
+				return false
+			}
+			logrus.
+				WithField("err", rerr).
+				Infof("Hit the rate limit on try %d, sleeping for %s", try, waitDuration)
+			sleeper(waitDuration)
+			return true
+		}
+
+		if rerr, ok := err.(*github.ErrorResponse); ok && rerr.Response != nil {
+			if isSecondaryRateLimit(rerr) {
+				waitDuration := defaultGithubSleep
+				if d := retryAfter(rerr.Response); d != nil {
+					waitDuration = *d
+				}
+				logrus.
+					WithField("err", rerr).
+					Infof("Hit the secondary rate limit on try %d, sleeping for %s", try, waitDuration)
+				sleeper(waitDuration)
+				return true
+			}
+
+			if rerr.Response.StatusCode >= http.StatusInternalServerError {
+				waitDuration := serverErrorSleep << (try - 1)
+				logrus.
+					WithField("err", rerr).
+					Infof("Got a server error on try %d, sleeping for %s", try, waitDuration)
+				sleeper(waitDuration)
+				return true
+			}
+		}
+
 		return false
 	}
 }
+
+// isSecondaryRateLimit returns true if the error response is caused by the
+// secondary rate limits, which are not detected by go-github as abuse rate
+// limit errors.
+func isSecondaryRateLimit(err *github.ErrorResponse) bool {
+	if err.Response.StatusCode != http.StatusForbidden {
+		return false
+	}
+	return strings.HasSuffix(err.DocumentationURL, "#secondary-rate-limits") ||
+		strings.Contains(strings.ToLower(err.Message), "secondary rate limit")
+}
+
+// retryAfter returns the duration of the Retry-After header of the response
+// or nil if it is not set.
+func retryAfter(resp *http.Response) *time.Duration {
+	seconds, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	d := time.Duration(seconds) * time.Second
+	return &d
+}
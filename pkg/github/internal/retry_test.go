@@ -19,6 +19,7 @@ package internal_test
 import (
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -86,6 +87,51 @@ func TestGithubRetryer(t *testing.T) {
 			errs:            []error{&github.AbuseRateLimitError{RetryAfter: durPtr(42 * time.Minute)}},
 			expectedResults: []bool{true},
 		},
+		"when the error is a github rate limit error without reset, sleep the default amount of time": {
+			maxTries:        1,
+			sleeper:         sleepChecker(t, 1*time.Minute),
+			errs:            []error{&github.RateLimitError{}},
+			expectedResults: []bool{true},
+		},
+		"when the github rate limit resets too late, don't retry": {
+			maxTries: 1,
+			sleeper:  nilSleeper,
+			errs: []error{&github.RateLimitError{
+				Rate: github.Rate{Reset: github.Timestamp{Time: time.Now().Add(time.Hour)}},
+			}},
+			expectedResults: []bool{false},
+		},
+		"when the error is a secondary rate limit error, sleep the Retry-After amount of time": {
+			maxTries: 1,
+			sleeper:  sleepChecker(t, 30*time.Second),
+			errs: []error{&github.ErrorResponse{
+				Response: &http.Response{
+					StatusCode: http.StatusForbidden,
+					Header:     http.Header{"Retry-After": []string{"30"}},
+				},
+				DocumentationURL: "https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits",
+			}},
+			expectedResults: []bool{true},
+		},
+		"when the error is a forbidden error, don't retry": {
+			maxTries: 1,
+			sleeper:  nilSleeper,
+			errs: []error{&github.ErrorResponse{
+				Response: &http.Response{StatusCode: http.StatusForbidden},
+				Message:  "Resource not accessible by integration",
+			}},
+			expectedResults: []bool{false},
+		},
+		"when the error is a server error, retry with an exponential backoff": {
+			maxTries: 2,
+			sleeper:  sleepSequenceChecker(t, 2*time.Second, 4*time.Second),
+			errs: []error{
+				&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadGateway}},
+				&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadGateway}},
+				&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadGateway}},
+			},
+			expectedResults: []bool{true, true, false},
+		},
 	}
 
 	for name, tc := range tests {
@@ -93,7 +139,7 @@ func TestGithubRetryer(t *testing.T) {
 			tc := tc
 			t.Parallel()
 
-			shouldRetry := internal.GithubErrChecker(tc.maxTries, nilSleeper)
+			shouldRetry := internal.GithubErrChecker(tc.maxTries, tc.sleeper)
 
 			for i, err := range tc.errs {
 				if a, e := shouldRetry(err), tc.expectedResults[i]; e != a {
@@ -112,6 +158,20 @@ func sleepChecker(t *testing.T, expectedSleep time.Duration) func(time.Duration)
 	}
 }
 
+func sleepSequenceChecker(t *testing.T, expectedSleeps ...time.Duration) func(time.Duration) {
+	i := 0
+	return func(d time.Duration) {
+		if i >= len(expectedSleeps) {
+			t.Errorf("Expected the sleeper to be called %d times, got called with %s", len(expectedSleeps), d)
+			return
+		}
+		if d != expectedSleeps[i] {
+			t.Errorf("Expected the sleeper to be called with a duration %s, got called with %s", expectedSleeps[i], d)
+		}
+		i++
+	}
+}
+
 func nilSleeper(_ time.Duration) {
 }
 
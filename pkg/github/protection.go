@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// BranchProtectionPolicy is the expected protection of a branch.
+type BranchProtectionPolicy struct {
+	// RequiredChecks are the status checks which have to pass before
+	// merging into the branch.
+	RequiredChecks []string
+
+	// RestrictPushes requires that only selected users, teams or apps are
+	// allowed to push to the branch.
+	RestrictPushes bool
+
+	// Pushers are the user logins, team and app slugs allowed to push to
+	// the branch. It is only checked if RestrictPushes is true and will
+	// accept any pushers if empty.
+	Pushers []string
+}
+
+// BranchProtectionDrift compares the protection of the branch with the
+// provided policy and returns the differences as human readable list. An
+// empty result means that the branch is protected as expected.
+func (g *GitHub) BranchProtectionDrift(
+	owner, repo, branch string, policy *BranchProtectionPolicy,
+) ([]string, error) {
+	protection, resp, err := g.Client().GetBranchProtection(
+		context.Background(), owner, repo, branch,
+	)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return []string{"branch is not protected"}, nil
+		}
+		return nil, errors.Wrapf(err, "getting protection of branch %s", branch)
+	}
+
+	drift := []string{}
+	actualChecks := map[string]bool{}
+	if checks := protection.GetRequiredStatusChecks(); checks != nil {
+		for _, check := range checks.Contexts {
+			actualChecks[check] = true
+		}
+	}
+	for _, check := range policy.RequiredChecks {
+		if !actualChecks[check] {
+			drift = append(drift, fmt.Sprintf("status check %s is not required", check))
+		}
+	}
+
+	if !policy.RestrictPushes {
+		return drift, nil
+	}
+	restrictions := protection.GetRestrictions()
+	if restrictions == nil {
+		return append(drift, "pushes are not restricted"), nil
+	}
+	if len(policy.Pushers) == 0 {
+		return drift, nil
+	}
+
+	actualPushers := map[string]bool{}
+	for _, user := range restrictions.Users {
+		actualPushers[user.GetLogin()] = true
+	}
+	for _, team := range restrictions.Teams {
+		actualPushers[team.GetSlug()] = true
+	}
+	for _, app := range restrictions.Apps {
+		actualPushers[app.GetSlug()] = true
+	}
+	for _, pusher := range policy.Pushers {
+		if !actualPushers[pusher] {
+			drift = append(drift, fmt.Sprintf("%s is not allowed to push", pusher))
+		}
+		delete(actualPushers, pusher)
+	}
+	unexpected := []string{}
+	for pusher := range actualPushers {
+		unexpected = append(unexpected, pusher)
+	}
+	sort.Strings(unexpected)
+	for _, pusher := range unexpected {
+		drift = append(drift, fmt.Sprintf("%s is unexpectedly allowed to push", pusher))
+	}
+	return drift, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/github"
+)
+
+func TestBranchProtectionDrift(t *testing.T) {
+	policy := &github.BranchProtectionPolicy{
+		RequiredChecks: []string{"cla/linuxfoundation", "tide"},
+		RestrictPushes: true,
+		Pushers:        []string{"release-managers", "k8s-release-robot"},
+	}
+
+	for _, tc := range []struct {
+		name       string
+		protection *gogithub.Protection
+		resp       *gogithub.Response
+		err        error
+		expected   []string
+	}{
+		{
+			name: "protected as expected",
+			protection: &gogithub.Protection{
+				RequiredStatusChecks: &gogithub.RequiredStatusChecks{
+					Contexts: []string{"tide", "cla/linuxfoundation", "other"},
+				},
+				Restrictions: &gogithub.BranchRestrictions{
+					Users: []*gogithub.User{{Login: gogithub.String("k8s-release-robot")}},
+					Teams: []*gogithub.Team{{Slug: gogithub.String("release-managers")}},
+				},
+			},
+			expected: []string{},
+		},
+		{
+			name: "drifted",
+			protection: &gogithub.Protection{
+				RequiredStatusChecks: &gogithub.RequiredStatusChecks{
+					Contexts: []string{"tide"},
+				},
+				Restrictions: &gogithub.BranchRestrictions{
+					Users: []*gogithub.User{{Login: gogithub.String("someone")}},
+					Teams: []*gogithub.Team{{Slug: gogithub.String("release-managers")}},
+				},
+			},
+			expected: []string{
+				"status check cla/linuxfoundation is not required",
+				"k8s-release-robot is not allowed to push",
+				"someone is unexpectedly allowed to push",
+			},
+		},
+		{
+			name:       "pushes not restricted",
+			protection: &gogithub.Protection{},
+			expected: []string{
+				"status check cla/linuxfoundation is not required",
+				"status check tide is not required",
+				"pushes are not restricted",
+			},
+		},
+		{
+			name: "not protected",
+			resp: &gogithub.Response{
+				Response: &http.Response{StatusCode: http.StatusNotFound},
+			},
+			err:      errors.New("Branch not protected"),
+			expected: []string{"branch is not protected"},
+		},
+	} {
+		sut, client := newSUT()
+		client.GetBranchProtectionReturns(tc.protection, tc.resp, tc.err)
+
+		res, err := sut.BranchProtectionDrift("owner", "repo", "release-1.22", policy)
+		require.Nil(t, err, tc.name)
+		require.Equal(t, tc.expected, res, tc.name)
+	}
+
+	// Failing requests result in an error
+	sut, client := newSUT()
+	client.GetBranchProtectionReturns(nil, nil, errors.New("error"))
+	_, err := sut.BranchProtectionDrift("owner", "repo", "release-1.22", policy)
+	require.NotNil(t, err)
+}
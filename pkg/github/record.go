@@ -47,6 +47,10 @@ const (
 	gitHubAPIListReleaseAssets          gitHubAPI = "ListReleaseAssets"
 	gitHubAPICreateComment              gitHubAPI = "CreateComment"
 	gitHubAPIListMilestones             gitHubAPI = "ListMilestones"
+	gitHubAPIPRsForCommits              gitHubAPI = "PRsForCommits"
+	gitHubAPIGetBranchProtection        gitHubAPI = "GetBranchProtection"
+	gitHubAPIListIssues                 gitHubAPI = "ListIssues"
+	gitHubAPIGraphQL                    gitHubAPI = "GraphQL"
 )
 
 type apiRecord struct {
@@ -106,6 +110,17 @@ func (c *githubNotesRecordClient) ListPullRequestsWithCommit(ctx context.Context
 	return prs, resp, nil
 }
 
+func (c *githubNotesRecordClient) PRsForCommits(ctx context.Context, owner, repo string, shas []string) (map[string][]*github.PullRequest, error) {
+	prs, err := c.client.PRsForCommits(ctx, owner, repo, shas)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.recordAPICall(gitHubAPIPRsForCommits, prs, nil); err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
 func (c *githubNotesRecordClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
 	pr, resp, err := c.client.GetPullRequest(ctx, owner, repo, number)
 	if err != nil {
@@ -227,6 +242,27 @@ func (c *githubNotesRecordClient) ListBranches(
 	return branches, resp, nil
 }
 
+// AddLabels modifies an issue, not recorded
+func (c *githubNotesRecordClient) AddLabels(
+	context.Context, string, string, int, []string,
+) ([]*github.Label, *github.Response, error) {
+	return []*github.Label{}, &github.Response{}, nil
+}
+
+// RemoveLabel modifies an issue, not recorded
+func (c *githubNotesRecordClient) RemoveLabel(
+	context.Context, string, string, int, string,
+) (*github.Response, error) {
+	return &github.Response{}, nil
+}
+
+// UpdateIssue modifies an issue, not recorded
+func (c *githubNotesRecordClient) UpdateIssue(
+	context.Context, string, string, int, *github.IssueRequest,
+) (*github.Issue, error) {
+	return &github.Issue{}, nil
+}
+
 // UpdateReleasePage modifies a release, not recorded
 func (c *githubNotesRecordClient) UpdateReleasePage(
 	ctx context.Context, owner, repo string, releaseID int64, releaseData *github.RepositoryRelease,
@@ -286,8 +322,42 @@ func (c *githubNotesRecordClient) CreateComment(ctx context.Context, owner, repo
 	return issueComment, resp, nil
 }
 
+func (c *githubNotesRecordClient) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	protection, resp, err := c.client.GetBranchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		return nil, resp, err
+	}
+	if err := c.recordAPICall(gitHubAPIGetBranchProtection, protection, resp); err != nil {
+		return nil, nil, err
+	}
+	return protection, resp, nil
+}
+
+func (c *githubNotesRecordClient) ListIssues(
+	ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions,
+) ([]*github.Issue, *github.Response, error) {
+	issues, resp, err := c.client.ListIssues(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.recordAPICall(gitHubAPIListIssues, issues, resp); err != nil {
+		return nil, nil, err
+	}
+	return issues, resp, nil
+}
+
+func (c *githubNotesRecordClient) GraphQL(
+	ctx context.Context, query string, variables map[string]interface{}, result interface{},
+) error {
+	if err := c.client.GraphQL(ctx, query, variables, result); err != nil {
+		return err
+	}
+	return c.recordAPICall(gitHubAPIGraphQL, result, nil)
+}
+
 // recordAPICall records a single GitHub API call into a JSON file by ensuring
 // naming conventions
+
 func (c *githubNotesRecordClient) recordAPICall(
 	api gitHubAPI, result interface{}, response *github.Response,
 ) error {
@@ -80,6 +80,19 @@ func (c *githubNotesReplayClient) ListPullRequestsWithCommit(ctx context.Context
 	return result, record.response(), nil
 }
 
+func (c *githubNotesReplayClient) PRsForCommits(ctx context.Context, owner, repo string, shas []string) (map[string][]*github.PullRequest, error) {
+	data, err := c.readRecordedData(gitHubAPIPRsForCommits)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string][]*github.PullRequest{}
+	record := apiRecord{Result: &result}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *githubNotesReplayClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
 	data, err := c.readRecordedData(gitHubAPIGetPullRequest)
 	if err != nil {
@@ -247,6 +260,27 @@ func (c *githubNotesReplayClient) readRecordedData(api gitHubAPI) ([]byte, error
 	return file, nil
 }
 
+// AddLabels modifies an issue, not recorded
+func (c *githubNotesReplayClient) AddLabels(
+	context.Context, string, string, int, []string,
+) ([]*github.Label, *github.Response, error) {
+	return []*github.Label{}, &github.Response{}, nil
+}
+
+// RemoveLabel modifies an issue, not recorded
+func (c *githubNotesReplayClient) RemoveLabel(
+	context.Context, string, string, int, string,
+) (*github.Response, error) {
+	return &github.Response{}, nil
+}
+
+// UpdateIssue modifies an issue, not recorded
+func (c *githubNotesReplayClient) UpdateIssue(
+	context.Context, string, string, int, *github.IssueRequest,
+) (*github.Issue, error) {
+	return &github.Issue{}, nil
+}
+
 // UpdateReleasePage modifies a release, not recorded
 func (c *githubNotesReplayClient) UpdateReleasePage(
 	ctx context.Context, owner, repo string, releaseID int64, releaseData *github.RepositoryRelease,
@@ -294,3 +328,42 @@ func (c *githubNotesReplayClient) CreateComment(ctx context.Context, owner, repo
 	}
 	return result, record.response(), nil
 }
+
+func (c *githubNotesReplayClient) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	data, err := c.readRecordedData(gitHubAPIGetBranchProtection)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &github.Protection{}
+	record := apiRecord{Result: result}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, nil, err
+	}
+	return result, record.response(), nil
+}
+
+func (c *githubNotesReplayClient) ListIssues(
+	ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions,
+) ([]*github.Issue, *github.Response, error) {
+	data, err := c.readRecordedData(gitHubAPIListIssues)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues := make([]*github.Issue, 0)
+	record := apiRecord{Result: &issues}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, nil, err
+	}
+	return issues, record.response(), nil
+}
+
+func (c *githubNotesReplayClient) GraphQL(
+	ctx context.Context, query string, variables map[string]interface{}, result interface{},
+) error {
+	data, err := c.readRecordedData(gitHubAPIGraphQL)
+	if err != nil {
+		return err
+	}
+	record := apiRecord{Result: result}
+	return json.Unmarshal(data, &record)
+}
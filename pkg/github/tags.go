@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/blang/semver"
+	"github.com/google/go-github/v37/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+// ErrNoMatchingTag is returned by LatestTag if no tag satisfies the
+// constraint.
+var ErrNoMatchingTag = errors.New("no matching tag found")
+
+// LatestTag returns the newest release tag of the repository, which satisfies
+// the semver range of the constraint, for example ">=1.22.0 <1.23.0". Every
+// release matches an empty constraint. Tags of pre-releases, drafts and tags
+// which are not valid semver are ignored. Repositories without any releases
+// fall back to their tags.
+func (g *GitHub) LatestTag(owner, repo, constraint string) (string, error) {
+	matches := func(semver.Version) bool { return true }
+	if constraint != "" {
+		versionRange, err := semver.ParseRange(constraint)
+		if err != nil {
+			return "", errors.Wrapf(err, "parsing constraint %q", constraint)
+		}
+		matches = versionRange
+	}
+
+	tags, err := g.releaseTagNames(owner, repo)
+	if err != nil {
+		return "", err
+	}
+	if len(tags) == 0 {
+		logrus.Debugf("No releases found in %s/%s, using the tags", owner, repo)
+		tags, err = g.tagNames(owner, repo)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var latestTag string
+	var latest semver.Version
+	for _, tag := range tags {
+		version, err := util.TagStringToSemver(tag)
+		if err != nil || len(version.Pre) > 0 || !matches(version) {
+			continue
+		}
+		if latestTag == "" || version.GT(latest) {
+			latestTag, latest = tag, version
+		}
+	}
+	if latestTag == "" {
+		return "", errors.Wrapf(
+			ErrNoMatchingTag, "repository %s/%s with constraint %q", owner, repo, constraint,
+		)
+	}
+	return latestTag, nil
+}
+
+// releaseTagNames returns the tags of all published releases.
+func (g *GitHub) releaseTagNames(owner, repo string) ([]string, error) {
+	releases, err := g.Releases(owner, repo, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting releases")
+	}
+	tags := []string{}
+	for _, release := range releases {
+		if !release.GetDraft() {
+			tags = append(tags, release.GetTagName())
+		}
+	}
+	return tags, nil
+}
+
+// tagNames returns all tags of the repository.
+func (g *GitHub) tagNames(owner, repo string) ([]string, error) {
+	tags := []string{}
+	opts := &github.ListOptions{PerPage: g.options.GetItemsPerPage()}
+	for {
+		moreTags, resp, err := g.client.ListTags(context.Background(), owner, repo, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing repository tags")
+		}
+		for _, tag := range moreTags {
+			tags = append(tags, tag.GetName())
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return tags, nil
+}
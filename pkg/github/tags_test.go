@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"errors"
+	"testing"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/github"
+)
+
+func TestLatestTag(t *testing.T) {
+	releases := []*gogithub.RepositoryRelease{
+		{TagName: gogithub.String("v1.22.0")},
+		{TagName: gogithub.String("v1.23.0"), Draft: gogithub.Bool(true)},
+		{TagName: gogithub.String("v1.22.3")},
+		{TagName: gogithub.String("v1.22.10")},
+		{TagName: gogithub.String("v1.23.0-rc.0"), Prerelease: gogithub.Bool(true)},
+		{TagName: gogithub.String("v1.21.5")},
+		{TagName: gogithub.String("latest")},
+	}
+
+	for _, tc := range []struct {
+		name       string
+		constraint string
+		expected   string
+		noMatch    bool
+	}{
+		{
+			name:     "no constraint",
+			expected: "v1.22.10",
+		},
+		{
+			name:       "minor constraint",
+			constraint: ">=1.21.0 <1.22.0",
+			expected:   "v1.21.5",
+		},
+		{
+			name:       "minimum constraint",
+			constraint: ">=1.22.4",
+			expected:   "v1.22.10",
+		},
+		{
+			name:       "no match",
+			constraint: ">=1.23.0",
+			noMatch:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			sut, client := newSUT()
+			client.ListReleasesReturns(releases, &gogithub.Response{}, nil)
+
+			// When
+			tag, err := sut.LatestTag("kubernetes-sigs", "cri-tools", tc.constraint)
+
+			// Then
+			if tc.noMatch {
+				require.True(t, errors.Is(err, github.ErrNoMatchingTag))
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, tag)
+			require.Zero(t, client.ListTagsCallCount())
+		})
+	}
+}
+
+func TestLatestTagWithoutReleases(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.ListReleasesReturns(nil, &gogithub.Response{}, nil)
+	client.ListTagsReturnsOnCall(0, []*gogithub.RepositoryTag{
+		{Name: gogithub.String("v0.9.1")},
+		{Name: gogithub.String("v1.0.0-rc1")},
+	}, &gogithub.Response{NextPage: 2}, nil)
+	client.ListTagsReturnsOnCall(1, []*gogithub.RepositoryTag{
+		{Name: gogithub.String("v0.8.7")},
+	}, &gogithub.Response{}, nil)
+
+	// When
+	tag, err := sut.LatestTag("containernetworking", "plugins", ">=0.8.6")
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "v0.9.1", tag)
+	require.Equal(t, 2, client.ListTagsCallCount())
+}
+
+func TestLatestTagFailure(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+
+	// When the constraint is invalid
+	_, err := sut.LatestTag("owner", "repo", "not a range")
+
+	// Then
+	require.NotNil(t, err)
+	require.Zero(t, client.ListReleasesCallCount())
+
+	// When the releases cannot be listed
+	client.ListReleasesReturns(nil, nil, errors.New("error"))
+	_, err = sut.LatestTag("owner", "repo", "")
+
+	// Then
+	require.NotNil(t, err)
+	require.False(t, errors.Is(err, github.ErrNoMatchingTag))
+}
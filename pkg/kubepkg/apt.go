@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+const (
+	aptFtpArchiveExecutable = "apt-ftparchive"
+
+	// DefaultAptSuite is the default suite (distribution) of the APT
+	// repository.
+	DefaultAptSuite = "kubernetes-xenial"
+
+	// DefaultAptComponent is the default component of the APT repository.
+	DefaultAptComponent = "main"
+
+	// aptOrigin is the origin and label of the APT repository.
+	aptOrigin = "kubernetes"
+)
+
+// AptRepoOptions are the settings for creating an APT repository.
+type AptRepoOptions struct {
+	// PackageDir is the directory containing the debs.
+	PackageDir string
+
+	// RepoDir is the root directory of the repository.
+	RepoDir string
+
+	// Suite is the distribution name, like kubernetes-xenial.
+	Suite string
+
+	// Component is the repository component, like main.
+	Component string
+}
+
+// CreateAptRepo assembles all debs of the package directory into an APT
+// repository, which uses the same pool and dists layout as reprepro. The
+// Release file gets signed into Release.gpg and InRelease if a sign key has
+// been configured.
+func (c *Client) CreateAptRepo(o *AptRepoOptions) error {
+	if !c.impl.Available(aptFtpArchiveExecutable) {
+		return errors.Errorf(
+			"%s is required for creating APT repositories", aptFtpArchiveExecutable,
+		)
+	}
+
+	debs, err := filepath.Glob(filepath.Join(o.PackageDir, "*.deb"))
+	if err != nil {
+		return errors.Wrapf(err, "finding debs in %s", o.PackageDir)
+	}
+	if len(debs) == 0 {
+		return errors.Errorf("no debs found in %s", o.PackageDir)
+	}
+
+	logrus.Infof("Creating APT repository in %s from %d debs", o.RepoDir, len(debs))
+	archs := map[string]bool{}
+	poolDir := filepath.Join("pool", o.Component)
+	for _, deb := range debs {
+		pkg, arch := parsePackageFileName(options.BuildDeb, filepath.Base(deb))
+		if pkg == "" {
+			return errors.Errorf("unable to parse deb file name %s", deb)
+		}
+		if arch != "all" {
+			archs[arch] = true
+		}
+
+		// reprepro style: pool/<component>/<first letter>/<package>/<deb>
+		dst := filepath.Join(o.RepoDir, poolDir, pkg[:1], pkg, filepath.Base(deb))
+		if err := c.copyFile(deb, dst); err != nil {
+			return err
+		}
+	}
+
+	architectures := []string{}
+	for arch := range archs {
+		architectures = append(architectures, arch)
+	}
+	sort.Strings(architectures)
+
+	suiteDir := filepath.Join("dists", o.Suite)
+	for _, arch := range architectures {
+		logrus.Infof("Generating package index for %s", arch)
+		packages, err := c.impl.RunOutputWithWorkDir(
+			o.RepoDir,
+			aptFtpArchiveExecutable,
+			"--arch", arch,
+			"packages", poolDir,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "generating package index for %s", arch)
+		}
+
+		binaryDir := filepath.Join(o.RepoDir, suiteDir, o.Component, "binary-"+arch)
+		if err := c.writeAptIndex(binaryDir, packages+"\n"); err != nil {
+			return err
+		}
+	}
+
+	logrus.Infof("Generating release file for %s", o.Suite)
+	release, err := c.impl.RunOutputWithWorkDir(
+		o.RepoDir,
+		aptFtpArchiveExecutable,
+		"-o", "APT::FTPArchive::Release::Origin="+aptOrigin,
+		"-o", "APT::FTPArchive::Release::Label="+aptOrigin,
+		"-o", "APT::FTPArchive::Release::Suite="+o.Suite,
+		"-o", "APT::FTPArchive::Release::Codename="+o.Suite,
+		"-o", "APT::FTPArchive::Release::Components="+o.Component,
+		"-o", "APT::FTPArchive::Release::Architectures="+strings.Join(architectures, " "),
+		"release", suiteDir,
+	)
+	if err != nil {
+		return errors.Wrap(err, "generating release file")
+	}
+
+	releaseFile := filepath.Join(o.RepoDir, suiteDir, "Release")
+	if err := c.impl.WriteFile(
+		releaseFile, []byte(release+"\n"), os.FileMode(0o644),
+	); err != nil {
+		return errors.Wrapf(err, "writing %s", releaseFile)
+	}
+
+	if c.options.SignKey() != "" {
+		defer c.cleanupSigner()
+		if err := c.signFile(
+			releaseFile, filepath.Join(filepath.Dir(releaseFile), "Release.gpg"), false,
+		); err != nil {
+			return err
+		}
+		if err := c.signFile(
+			releaseFile, filepath.Join(filepath.Dir(releaseFile), "InRelease"), true,
+		); err != nil {
+			return err
+		}
+	} else {
+		logrus.Warn("No sign key provided, the APT repository will be unsigned")
+	}
+
+	logrus.Infof("Successfully created APT repository in %s", o.RepoDir)
+	return nil
+}
+
+// writeAptIndex writes the plain and gzip compressed Packages file to dir.
+func (c *Client) writeAptIndex(dir, packages string) error {
+	if err := os.MkdirAll(dir, os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "creating %s", dir)
+	}
+
+	packagesFile := filepath.Join(dir, "Packages")
+	if err := c.impl.WriteFile(
+		packagesFile, []byte(packages), os.FileMode(0o644),
+	); err != nil {
+		return errors.Wrapf(err, "writing %s", packagesFile)
+	}
+
+	compressed := &bytes.Buffer{}
+	w := gzip.NewWriter(compressed)
+	if _, err := w.Write([]byte(packages)); err != nil {
+		return errors.Wrap(err, "compressing package index")
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "compressing package index")
+	}
+
+	if err := c.impl.WriteFile(
+		packagesFile+".gz", compressed.Bytes(), os.FileMode(0o644),
+	); err != nil {
+		return errors.Wrapf(err, "writing %s.gz", packagesFile)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestCreateAptRepo(t *testing.T) {
+	packageDir, err := os.MkdirTemp("", "kubepkg-packages-")
+	require.Nil(t, err)
+	defer os.RemoveAll(packageDir)
+	repoDir, err := os.MkdirTemp("", "kubepkg-repo-")
+	require.Nil(t, err)
+	defer os.RemoveAll(repoDir)
+
+	for _, deb := range []string{
+		"kubeadm_1.22.1-0_amd64.deb",
+		"kubeadm_1.22.1-0_arm64.deb",
+		"cri-tools_1.22.0-0_amd64.deb",
+	} {
+		require.Nil(t, os.WriteFile(filepath.Join(packageDir, deb), []byte(deb), 0o644))
+	}
+
+	sut, mock := newSUT(options.New().WithSignKey("ABCDEF12"))
+	mock.AvailableReturns(true)
+	mock.ReadFileStub = os.ReadFile
+	mock.WriteFileStub = os.WriteFile
+	mock.RunOutputWithWorkDirStub = func(workDir, cmd string, args ...string) (string, error) {
+		if cmd != "apt-ftparchive" {
+			return "", nil
+		}
+		require.Equal(t, repoDir, workDir)
+		return args[len(args)-2], nil
+	}
+
+	require.Nil(t, sut.CreateAptRepo(&kubepkg.AptRepoOptions{
+		PackageDir: packageDir,
+		RepoDir:    repoDir,
+		Suite:      kubepkg.DefaultAptSuite,
+		Component:  kubepkg.DefaultAptComponent,
+	}))
+
+	for _, file := range []string{
+		"pool/main/k/kubeadm/kubeadm_1.22.1-0_amd64.deb",
+		"pool/main/k/kubeadm/kubeadm_1.22.1-0_arm64.deb",
+		"pool/main/c/cri-tools/cri-tools_1.22.0-0_amd64.deb",
+		"dists/kubernetes-xenial/main/binary-amd64/Packages",
+		"dists/kubernetes-xenial/main/binary-amd64/Packages.gz",
+		"dists/kubernetes-xenial/main/binary-arm64/Packages",
+		"dists/kubernetes-xenial/main/binary-arm64/Packages.gz",
+		"dists/kubernetes-xenial/Release",
+	} {
+		require.FileExists(t, filepath.Join(repoDir, file))
+	}
+
+	// 2 package indexes before the release file
+	_, _, releaseArgs := mock.RunOutputWithWorkDirArgsForCall(2)
+	require.Contains(t, releaseArgs, "APT::FTPArchive::Release::Architectures=amd64 arm64")
+
+	// Release.gpg and InRelease
+	require.Equal(t, 2, mock.RunSuccessWithWorkDirCallCount())
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(1)
+	require.Equal(t, "gpg", cmd)
+	require.Contains(t, args, "--clearsign")
+	require.Contains(t, args, filepath.Join(repoDir, "dists/kubernetes-xenial/InRelease"))
+}
+
+func TestCreateAptRepoFailureNoDebs(t *testing.T) {
+	packageDir, err := os.MkdirTemp("", "kubepkg-packages-")
+	require.Nil(t, err)
+	defer os.RemoveAll(packageDir)
+
+	sut, mock := newSUT(nil)
+	mock.AvailableReturns(true)
+	require.NotNil(t, sut.CreateAptRepo(&kubepkg.AptRepoOptions{PackageDir: packageDir}))
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// runMakepkg builds the Arch Linux package from the PKGBUILD rendered into
+// specDirWithArch. The target architecture is passed via $CARCH, which allows
+// producing packages for foreign architectures as long as no binaries have to
+// be compiled.
+func (c *Client) runMakepkg(bc *buildConfig, specDir, specDirWithArch string) error {
+	bc.log.Infof("Running makepkg for %s (%s/%s)", bc.Package, bc.GoArch, bc.BuildArch)
+
+	if err := c.runBuildCommand(
+		bc,
+		specDir,
+		specDirWithArch,
+		"env",
+		"CARCH="+bc.BuildArch,
+		"makepkg",
+		"--nodeps",
+		"--ignorearch",
+		"--force",
+		"--noconfirm",
+	); err != nil {
+		return errors.Wrap(err, "running Arch Linux package build")
+	}
+
+	return c.copyPackage(bc, filepath.Join(specDirWithArch, packageFileName(bc)))
+}
+
+// archLinuxVersion converts the provided version into a valid Arch Linux
+// pkgver, which must not contain any hyphens.
+func archLinuxVersion(version string) string {
+	return strings.ReplaceAll(version, "-", "_")
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessArchLinux(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubectl").
+		WithChannels("release").
+		WithArchitectures("arm64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildArchLinux)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.Nil(t, err)
+
+	require.Equal(t, 1, mock.RunSuccessWithWorkDirCallCount())
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+	require.Equal(t, "env", cmd)
+	require.Contains(t, args, "CARCH=aarch64")
+	require.Contains(t, args, "makepkg")
+
+	require.Equal(t, 1, mock.ReadFileCallCount())
+	require.Equal(t,
+		"kubectl-1.18.0-0-aarch64.pkg.tar.zst",
+		filepath.Base(mock.ReadFileArgsForCall(0)),
+	)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// localArtifactsDir is the directory within the spec directory, which
+// contains the staged binaries of the binary directory. It uses the same
+// layout as the Kubernetes _output tree, which is what KUBE_LOCAL_ARTIFACTS
+// of the debian/rules templates expects.
+const localArtifactsDir = "_output"
+
+// binaryDirLayouts are the subdirectories of a Kubernetes _output tree, which
+// may contain the bin/linux/<arch> directories. This allows passing either
+// _output itself or one of its local or dockerized subdirectories.
+var binaryDirLayouts = []string{"", "local", "dockerized"}
+
+// isKubernetesBinary returns true if the package contains a binary of the
+// Kubernetes build, which means that it can be built from the binary
+// directory. CNI plugins and CRI tools are always downloaded.
+func isKubernetesBinary(pkg string) bool {
+	return pkg == "kubelet" || pkg == "kubectl" || pkg == "kubeadm"
+}
+
+// checkBinaryDir verifies that the selected build type can be built from the
+// binary directory, if one has been set.
+func (c *Client) checkBinaryDir() error {
+	if c.options.BinaryDir() == "" {
+		return nil
+	}
+	if c.usesNfpm() || c.options.BuildType() == options.BuildDeb {
+		return nil
+	}
+	return errors.Errorf(
+		"building %s packages from a binary directory is not supported, "+
+			"use debs or the %q backend",
+		c.options.BuildType(), options.BackendNfpm,
+	)
+}
+
+// localBinary returns the path of the package binary within the binary
+// directory or an empty string if the package has to be downloaded.
+func (c *Client) localBinary(pkg, arch string) (string, error) {
+	binaryDir := c.options.BinaryDir()
+	if binaryDir == "" || !isKubernetesBinary(pkg) {
+		return "", nil
+	}
+
+	for _, layout := range binaryDirLayouts {
+		path := filepath.Join(binaryDir, layout, "bin", "linux", arch, pkg)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.Errorf(
+		"binary %s for linux/%s not found in %s", pkg, arch, binaryDir,
+	)
+}
+
+// stageLocalBinary copies the local binary of the build into the
+// localArtifactsDir of the spec directory, which allows the debian/rules to
+// pick it up on the host as well as inside of containers.
+func (c *Client) stageLocalBinary(bc *buildConfig, specDir string) error {
+	bc.log.Infof("Using local binary %s", bc.localBinary)
+	return c.copyBinary(bc.localBinary, localArtifactPath(bc, specDir))
+}
+
+// localArtifactPath returns the location of the build binary within the
+// localArtifactsDir of the spec directory.
+func localArtifactPath(bc *buildConfig, specDir string) string {
+	return filepath.Join(
+		specDir, localArtifactsDir, "bin", "linux", bc.GoArch, bc.Package,
+	)
+}
+
+// copyBinary copies the executable src to dst, whereas the directory of dst
+// gets created if required.
+func (c *Client) copyBinary(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "creating %s", filepath.Dir(dst))
+	}
+
+	content, err := c.impl.ReadFile(src)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", src)
+	}
+
+	if err := c.impl.WriteFile(dst, content, os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "writing %s", dst)
+	}
+	return nil
+}
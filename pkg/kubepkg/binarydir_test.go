@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func newBinaryDir(t *testing.T, arch string, binaries ...string) string {
+	binaryDir, err := os.MkdirTemp("", "kubepkg-output-")
+	require.Nil(t, err)
+	binDir := filepath.Join(binaryDir, "local", "bin", "linux", arch)
+	require.Nil(t, os.MkdirAll(binDir, 0o755))
+	for _, binary := range binaries {
+		require.Nil(t, os.WriteFile(filepath.Join(binDir, binary), []byte(binary), 0o755))
+	}
+	return binaryDir
+}
+
+func TestWalkBuildsSuccessBinaryDir(t *testing.T) {
+	binaryDir := newBinaryDir(t, "amd64", "kubelet")
+	defer os.RemoveAll(binaryDir)
+
+	for _, tc := range []struct {
+		backend     options.Backend
+		buildType   options.BuildType
+		expectedDst string
+	}{
+		{
+			backend:     options.BackendNative,
+			buildType:   options.BuildDeb,
+			expectedDst: "_output/bin/linux/amd64/kubelet",
+		},
+		{
+			backend:     options.BackendNfpm,
+			buildType:   options.BuildRpm,
+			expectedDst: "bin/kubelet",
+		},
+	} {
+		opts := options.New().
+			WithBackend(tc.backend).
+			WithPackages("kubelet", "cri-tools").
+			WithChannels("release").
+			WithArchitectures("amd64").
+			WithBinaryDir(binaryDir)
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, tc.buildType)
+		defer cleanup()
+		mock.ReadFileStub = func(path string) ([]byte, error) {
+			if strings.HasPrefix(path, binaryDir) {
+				return os.ReadFile(path)
+			}
+			return nil, nil
+		}
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		require.Nil(t, sut.WalkBuilds(builds))
+
+		dst, content, mode := mock.WriteFileArgsForCall(0)
+		require.Contains(t, dst, tc.expectedDst)
+		require.Equal(t, "kubelet", string(content))
+		require.Equal(t, os.FileMode(0o755), mode)
+
+		// cri-tools are not part of the binary directory
+		if tc.backend == options.BackendNfpm {
+			require.Equal(t, 1, mock.DownloadFileCallCount())
+			url, _ := mock.DownloadFileArgsForCall(0)
+			require.Contains(t, url, "crictl")
+		}
+	}
+}
+
+func TestWalkBuildsFailureBinaryDir(t *testing.T) {
+	binaryDir := newBinaryDir(t, "amd64")
+	defer os.RemoveAll(binaryDir)
+
+	// Missing binary
+	opts := options.New().
+		WithPackages("kubelet").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithBinaryDir(binaryDir)
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.NotNil(t, sut.WalkBuilds(builds))
+
+	// Unsupported build type
+	opts = options.New().
+		WithPackages("kubectl").
+		WithBinaryDir(binaryDir)
+	sut, cleanup, _ = sutWithTemplateDir(t, opts, options.BuildSnap)
+	defer cleanup()
+
+	builds, err = sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.NotNil(t, sut.WalkBuilds(builds))
+}
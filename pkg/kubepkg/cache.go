@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/kubepkg/options"
+	"sigs.k8s.io/release-utils/hash"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// download retrieves url to dst. Every URL gets downloaded only once per
+// walk, while all builds requiring it copy the shared file.
+func (c *Client) download(bc *buildConfig, url, dst string) error {
+	src, err := c.fetch(bc, url)
+	if err != nil {
+		return err
+	}
+	return copyCachedFile(src, dst)
+}
+
+// fetchCached returns the path of url within the cache directory, which is
+// keyed by the published SHA256 checksum of the URL and the file name.
+// Downloads get verified against the checksum before they are added to the
+// cache, and cached files get verified again before they are used.
+func (c *Client) fetchCached(bc *buildConfig, url string) (string, error) {
+	cacheDir := c.options.CacheDir()
+	sha256, err := c.publishedSHA256(url)
+	if err != nil {
+		return "", err
+	}
+
+	cached := filepath.Join(cacheDir, sha256, path.Base(url))
+	if util.Exists(cached) {
+		if err := verifySHA256(cached, sha256); err == nil {
+			bc.log.Infof("Using cached %s for %s", cached, url)
+			return cached, nil
+		}
+		bc.log.Warnf("Removing corrupted cache entry %s", cached)
+		if err := os.RemoveAll(cached); err != nil {
+			return "", errors.Wrapf(err, "removing %s", cached)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cached), os.FileMode(0o755)); err != nil {
+		return "", errors.Wrapf(err, "creating cache directory %s", filepath.Dir(cached))
+	}
+
+	// Concurrent kubepkg runs may download the same URL, which is why every
+	// download uses its own temporary file before being moved atomically.
+	tmpFile, err := os.CreateTemp(filepath.Dir(cached), ".download-")
+	if err != nil {
+		return "", errors.Wrap(err, "creating temporary download file")
+	}
+	tmpFile.Close()
+	defer os.RemoveAll(tmpFile.Name())
+
+	bc.log.Infof("Downloading %s to cache %s", url, cached)
+	if err := c.downloadFile(url, tmpFile.Name()); err != nil {
+		return "", err
+	}
+	if err := verifySHA256(tmpFile.Name(), sha256); err != nil {
+		return "", errors.Wrapf(err, "verifying download of %s", url)
+	}
+	if err := os.Rename(tmpFile.Name(), cached); err != nil {
+		return "", errors.Wrapf(err, "adding %s to cache", url)
+	}
+	return cached, nil
+}
+
+// cachesDebBinary returns true if the Kubernetes binary of a native deb
+// build gets retrieved via the download cache. The binary is then staged like
+// a local binary instead of being downloaded by the debian/rules.
+func (c *Client) cachesDebBinary(bc *buildConfig) bool {
+	return c.options.CacheDir() != "" &&
+		bc.Type == options.BuildDeb &&
+		!c.usesNfpm() &&
+		isKubernetesBinary(bc.Package)
+}
+
+// publishedSHA256 returns the checksum of the published .sha256 file of url.
+func (c *Client) publishedSHA256(url string) (string, error) {
+	response, err := c.impl.GetURLResponse(url+".sha256", true)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting checksum for %s", url)
+	}
+	sha256 := strings.Fields(response)
+	if len(sha256) == 0 {
+		return "", errors.Errorf("empty checksum for %s", url)
+	}
+	return sha256[0], nil
+}
+
+// verifySHA256 returns an error if the SHA256 checksum of the file does not
+// match the expected one.
+func verifySHA256(file, expected string) error {
+	actual, err := hash.SHA256ForFile(file)
+	if err != nil {
+		return errors.Wrapf(err, "calculating checksum of %s", file)
+	}
+	if !strings.EqualFold(actual, expected) {
+		return errors.Errorf(
+			"checksum mismatch for %s: expected %s, got %s",
+			file, expected, actual,
+		)
+	}
+	return nil
+}
+
+// copyCachedFile copies the cached file to dst. The file is marked as
+// executable, which matches the behavior of a regular download.
+func copyCachedFile(cached, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "creating %s", filepath.Dir(dst))
+	}
+	if err := util.CopyFileLocal(cached, dst, true); err != nil {
+		return errors.Wrapf(err, "copying %s to %s", cached, dst)
+	}
+	return os.Chmod(dst, os.FileMode(0o755))
+}
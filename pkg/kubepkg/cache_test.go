@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/kubepkgfakes"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+const kubeletSHA256 = "1ca4bc7eb9b3d6f1e205da9cfab437c89d3760d0765a29a6bcbccf4ad51a2cb1"
+
+func sutWithDownloadCache(
+	t *testing.T, backend options.Backend, buildType options.BuildType,
+) (sut *kubepkg.Client, cleanup func(), mock *kubepkgfakes.FakeImpl, cacheDir string) {
+	cacheDir, err := os.MkdirTemp("", "kubepkg-cache-")
+	require.Nil(t, err)
+
+	opts := options.New().
+		WithBackend(backend).
+		WithPackages("kubelet").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithCacheDir(cacheDir)
+	sut, templateCleanup, mock := sutWithTemplateDir(t, opts, buildType)
+	cleanup = func() {
+		templateCleanup()
+		require.Nil(t, os.RemoveAll(cacheDir))
+	}
+
+	mock.GetURLResponseReturns(kubeletSHA256+"  kubelet", nil)
+	mock.DownloadFileStub = func(_, dst string) error {
+		return os.WriteFile(dst, []byte("kubelet"), 0o755)
+	}
+	return sut, cleanup, mock, cacheDir
+}
+
+func TestWalkBuildsSuccessDownloadCache(t *testing.T) {
+	for _, tc := range []struct {
+		backend   options.Backend
+		buildType options.BuildType
+	}{
+		{options.BackendNfpm, options.BuildRpm},
+		{options.BackendNative, options.BuildDeb},
+	} {
+		sut, cleanup, mock, cacheDir := sutWithDownloadCache(t, tc.backend, tc.buildType)
+		defer cleanup()
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+
+		// The second walk uses the cache
+		require.Nil(t, sut.WalkBuilds(builds))
+		require.Nil(t, sut.WalkBuilds(builds))
+
+		// The build dependencies of the Kubernetes version get fetched first
+		require.Equal(t, 1, mock.DownloadFileCallCount())
+		require.Equal(t, 3, mock.GetURLResponseCallCount())
+		url, _ := mock.GetURLResponseArgsForCall(1)
+		require.Equal(t, "https://dl.k8s.io/v1.18.0/bin/linux/amd64/kubelet.sha256", url)
+		require.FileExists(t, filepath.Join(cacheDir, kubeletSHA256, "kubelet"))
+	}
+}
+
+func TestWalkBuildsSuccessDownloadCacheCorrupted(t *testing.T) {
+	sut, cleanup, mock, cacheDir := sutWithDownloadCache(
+		t, options.BackendNfpm, options.BuildRpm,
+	)
+	defer cleanup()
+
+	cached := filepath.Join(cacheDir, kubeletSHA256, "kubelet")
+	require.Nil(t, os.MkdirAll(filepath.Dir(cached), 0o755))
+	require.Nil(t, os.WriteFile(cached, []byte("corrupted"), 0o644))
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	require.Equal(t, 1, mock.DownloadFileCallCount())
+	content, err := os.ReadFile(cached)
+	require.Nil(t, err)
+	require.Equal(t, "kubelet", string(content))
+}
+
+func TestWalkBuildsFailureDownloadCacheChecksumMismatch(t *testing.T) {
+	sut, cleanup, mock, cacheDir := sutWithDownloadCache(
+		t, options.BackendNfpm, options.BuildRpm,
+	)
+	defer cleanup()
+	mock.GetURLResponseReturns("0123", nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.NotNil(t, sut.WalkBuilds(builds))
+	require.NoFileExists(t, filepath.Join(cacheDir, "0123", "kubelet"))
+}
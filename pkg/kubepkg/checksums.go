@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SHA256SumsFile is the name of the file containing the SHA256
+	// checksums of all artifacts within a directory.
+	SHA256SumsFile = "SHA256SUMS"
+
+	// SHA512SumsFile is the name of the file containing the SHA512
+	// checksums of all artifacts within a directory.
+	SHA512SumsFile = "SHA512SUMS"
+)
+
+// writeChecksums writes the SHA256SUMS and SHA512SUMS files into every
+// directory containing artifacts of the current run. The files use the
+// format of sha256sum(1) and sha512sum(1), which means that they can be
+// verified via `sha256sum --check SHA256SUMS`. If a sign key has been
+// configured, then the files get signed into detached .asc signatures.
+func (c *Client) writeChecksums() error {
+	artifactsByDir := map[string][]Artifact{}
+	for _, artifact := range c.Summary().Artifacts {
+		dir := filepath.Dir(artifact.Path)
+		artifactsByDir[dir] = append(artifactsByDir[dir], artifact)
+	}
+
+	dirs := []string{}
+	for dir := range artifactsByDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		for file, checksum := range map[string]func(Artifact) string{
+			SHA256SumsFile: func(a Artifact) string { return a.SHA256 },
+			SHA512SumsFile: func(a Artifact) string { return a.SHA512 },
+		} {
+			path := filepath.Join(dir, file)
+			if err := c.writeChecksumFile(
+				path, artifactsByDir[dir], checksum,
+			); err != nil {
+				return err
+			}
+
+			if c.options.SignKey() != "" {
+				if err := c.signFile(path, path+".asc", false); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) writeChecksumFile(
+	path string, artifacts []Artifact, checksum func(Artifact) string,
+) error {
+	content := &strings.Builder{}
+	for _, artifact := range artifacts {
+		fmt.Fprintf(content, "%s  %s\n", checksum(artifact), filepath.Base(artifact.Path))
+	}
+
+	logrus.Infof("Writing checksums of %d artifacts to %s", len(artifacts), path)
+	if err := c.impl.WriteFile(
+		path, []byte(content.String()), os.FileMode(0o644),
+	); err != nil {
+		return errors.Wrapf(err, "writing %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsChecksums(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64", "arm64").
+		WithSignKey("keyring:ABCDEF12")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(true)
+	mock.ReadFileReturns([]byte("content"), nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	checksums := map[string]string{}
+	for i := 0; i < mock.WriteFileCallCount(); i++ {
+		path, content, _ := mock.WriteFileArgsForCall(i)
+		checksums[filepath.Base(path)] = string(content)
+	}
+	require.Equal(t,
+		"ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73  kubeadm_1.18.0-0_amd64.deb\n"+
+			"ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73  kubeadm_1.18.0-0_arm64.deb\n",
+		checksums[kubepkg.SHA256SumsFile],
+	)
+	require.Contains(t, checksums[kubepkg.SHA512SumsFile], "  kubeadm_1.18.0-0_arm64.deb\n")
+
+	// Both checksum files get signed after the builds and package signatures
+	signatures := []string{}
+	for i := 4; i < mock.RunSuccessWithWorkDirCallCount(); i++ {
+		_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(i)
+		require.Equal(t, "gpg", cmd)
+		signatures = append(signatures, filepath.Base(args[len(args)-2]))
+	}
+	require.ElementsMatch(t,
+		[]string{kubepkg.SHA256SumsFile + ".asc", kubepkg.SHA512SumsFile + ".asc"},
+		signatures,
+	)
+}
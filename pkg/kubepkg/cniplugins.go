@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+const (
+	// cniPackage is the package containing all CNI plugins.
+	cniPackage = "kubernetes-cni"
+
+	// cniPluginTemplatePackage is the template package of the individual
+	// CNI plugin packages.
+	cniPluginTemplatePackage = "kubernetes-cni-plugin"
+)
+
+// cniPluginPackage returns the name of the package for the provided CNI
+// plugin.
+func cniPluginPackage(plugin string) string {
+	return cniPackage + "-" + plugin
+}
+
+// packages returns all packages to be built, which are the selected ones
+// followed by the individual CNI plugin packages if kubernetes-cni is
+// selected.
+func (c *Client) packages() []string {
+	pkgs := append([]string{}, c.options.Packages()...)
+	for _, pkg := range c.options.Packages() {
+		if pkg != cniPackage {
+			continue
+		}
+		for _, plugin := range c.options.CNIPlugins() {
+			pkgs = append(pkgs, cniPluginPackage(plugin))
+		}
+	}
+	return pkgs
+}
+
+// cniPlugin returns the CNI plugin of the provided package or an empty string
+// if the package is not an individual CNI plugin package.
+func (c *Client) cniPlugin(pkg string) string {
+	for _, plugin := range c.options.CNIPlugins() {
+		if pkg == cniPluginPackage(plugin) {
+			return plugin
+		}
+	}
+	return ""
+}
+
+// sourcePackage returns the package whose sources get packaged, which is
+// kubernetes-cni for the individual CNI plugin packages.
+func sourcePackage(pd *PackageDefinition) string {
+	if pd.CNIPlugin != "" {
+		return cniPackage
+	}
+	return pd.Name
+}
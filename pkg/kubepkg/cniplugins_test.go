@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessCNIPlugins(t *testing.T) {
+	opts := options.New().
+		WithBackend(options.BackendNfpm).
+		WithPackages("kubernetes-cni").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithCNIPlugins("bridge", "host-local")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildRpm)
+	defer cleanup()
+	require.Nil(t, os.MkdirAll(
+		filepath.Join(opts.TemplateDir(), "nfpm", "kubernetes-cni-plugin"), 0o755,
+	))
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Len(t, builds, 3)
+	require.Equal(t, "kubernetes-cni-bridge", builds[1].Package)
+	require.Equal(t, "bridge", builds[1].Definitions[0].CNIPlugin)
+	require.Equal(t, "kubernetes-cni-plugin", filepath.Base(builds[1].TemplateDir))
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// All packages get built from the CNI plugins tarball, which gets
+	// downloaded only once
+	require.Equal(t, 1, mock.DownloadFileCallCount())
+	for i := 0; i < mock.DownloadFileCallCount(); i++ {
+		url, _ := mock.DownloadFileArgsForCall(i)
+		require.Equal(t,
+			"https://storage.googleapis.com/k8s-artifacts-cni/release/v0.8.6/cni-plugins-linux-amd64-v0.8.6.tgz",
+			url,
+		)
+	}
+	packages := []string{}
+	for i := 0; i < mock.ReadFileCallCount(); i++ {
+		packages = append(packages, filepath.Base(mock.ReadFileArgsForCall(i)))
+	}
+	require.ElementsMatch(t, []string{
+		"kubernetes-cni-0.8.6-0.x86_64.rpm",
+		"kubernetes-cni-bridge-0.8.6-0.x86_64.rpm",
+		"kubernetes-cni-host-local-0.8.6-0.x86_64.rpm",
+	}, packages)
+}
+
+func TestConstructBuildsSuccessCNIPluginsWithoutCNI(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubelet").
+		WithCNIPlugins("bridge")
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Len(t, builds, 1)
+	require.Equal(t, "kubelet", builds[0].Package)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+const (
+	// DefaultDebBuildImage is the container image used for building debs
+	// if no custom image has been specified.
+	DefaultDebBuildImage = "gcr.io/k8s-staging-releng/kubepkg:latest"
+
+	// DefaultRpmBuildImage is the container image used for building rpms
+	// if no custom image has been specified.
+	DefaultRpmBuildImage = "gcr.io/k8s-staging-releng/kubepkg-rpm:latest"
+
+	// DefaultArchLinuxBuildImage is the container image used for building
+	// Arch Linux packages if no custom image has been specified.
+	DefaultArchLinuxBuildImage = "docker.io/library/archlinux:base-devel"
+
+	// DefaultSnapBuildImage is the container image used for building snaps
+	// if no custom image has been specified.
+	DefaultSnapBuildImage = "docker.io/snapcore/snapcraft:stable"
+
+	// DefaultMsiBuildImage is the container image used for building
+	// Windows Installer packages if no custom image has been specified.
+	DefaultMsiBuildImage = "gcr.io/k8s-staging-releng/kubepkg-msi:latest"
+
+	containerWorkspace = "/workspace"
+)
+
+// distroBuildImages are the container images for building the packages of
+// the supported distributions, which are built from the kubepkg Dockerfiles
+// on top of the base image of the distribution. Generic packages use the
+// default image of their build type.
+var distroBuildImages = map[string]string{
+	"debian-bullseye": "gcr.io/k8s-staging-releng/kubepkg:debian-bullseye",
+	"debian-bookworm": "gcr.io/k8s-staging-releng/kubepkg:debian-bookworm",
+	"ubuntu-focal":    "gcr.io/k8s-staging-releng/kubepkg:ubuntu-focal",
+	"ubuntu-jammy":    "gcr.io/k8s-staging-releng/kubepkg:ubuntu-jammy",
+	"el8":             "gcr.io/k8s-staging-releng/kubepkg-rpm:el8",
+	"el9":             "gcr.io/k8s-staging-releng/kubepkg-rpm:el9",
+	"fc39":            "gcr.io/k8s-staging-releng/kubepkg-rpm:fc39",
+	"fc40":            "gcr.io/k8s-staging-releng/kubepkg-rpm:fc40",
+}
+
+// containerRuntimes are the supported container runtimes in order of
+// preference for auto detection.
+var containerRuntimes = []string{"docker", "podman"}
+
+// runBuildCommand runs the provided build command in workDir. If the build in
+// containers has been selected, then the command runs inside the container
+// image for the distribution or build type, where baseDir gets mounted as workspace. The
+// workDir has to be part of the baseDir. Build types which cannot be built on
+// the host always run inside a container.
+func (c *Client) runBuildCommand(
+	bc *buildConfig, baseDir, workDir, cmd string, args ...string,
+) error {
+	if !c.options.BuildInContainer() && !requiresContainer(bc.Type) {
+		return c.impl.RunSuccessWithWorkDir(workDir, cmd, args...)
+	}
+
+	runtime, err := c.containerRuntime()
+	if err != nil {
+		return err
+	}
+
+	relWorkDir, err := filepath.Rel(baseDir, workDir)
+	if err != nil {
+		return errors.Wrapf(err, "%s is not part of %s", workDir, baseDir)
+	}
+
+	image := c.containerImage(bc)
+	bc.log.Infof("Running %s in container image %s using %s", cmd, image, runtime)
+
+	return c.impl.RunSuccessWithWorkDir(baseDir, runtime, append([]string{
+		"run",
+		"--rm",
+		"--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+		"--volume", fmt.Sprintf("%s:%s:Z", baseDir, containerWorkspace),
+		"--workdir", filepath.Join(containerWorkspace, relWorkDir),
+		"--entrypoint", cmd,
+		image,
+	}, args...)...)
+}
+
+// containerRuntime returns the selected container runtime or the first
+// available one if none has been selected.
+func (c *Client) containerRuntime() (string, error) {
+	if runtime := c.options.ContainerRuntime(); runtime != "" {
+		if !c.impl.Available(runtime) {
+			return "", errors.Errorf(
+				"selected container runtime %s is not available in $PATH", runtime,
+			)
+		}
+		return runtime, nil
+	}
+
+	for _, runtime := range containerRuntimes {
+		if c.impl.Available(runtime) {
+			logrus.Debugf("Using detected container runtime %s", runtime)
+			return runtime, nil
+		}
+	}
+
+	return "", errors.Errorf(
+		"no container runtime found in $PATH, tried: %v", containerRuntimes,
+	)
+}
+
+// containerImage returns the image for building the packages of the build.
+// Custom images selected for the distribution take precedence over the ones
+// selected for the build type, followed by the default image of the
+// distribution and the default image of the build type.
+func (c *Client) containerImage(bc *buildConfig) string {
+	images := c.options.ContainerImages()
+	if image, ok := images[bc.Distro]; ok && bc.Distro != "" {
+		return image
+	}
+	if image, ok := images[string(bc.Type)]; ok {
+		return image
+	}
+	// Deprecated in favor of the images by distribution and build type
+	if image := c.options.ContainerImage(); image != "" {
+		return image
+	}
+	if image, ok := distroBuildImages[bc.Distro]; ok {
+		return image
+	}
+	switch bc.Type {
+	case options.BuildRpm:
+		return DefaultRpmBuildImage
+	case options.BuildArchLinux:
+		return DefaultArchLinuxBuildImage
+	case options.BuildSnap:
+		return DefaultSnapBuildImage
+	case options.BuildMsi:
+		return DefaultMsiBuildImage
+	}
+	return DefaultDebBuildImage
+}
+
+// requiresContainer returns true if the provided build type cannot be built
+// on the host.
+func requiresContainer(buildType options.BuildType) bool {
+	return buildType == options.BuildMsi
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessDebInContainer(t *testing.T) {
+	opts := options.New().
+		WithBuildInContainer(true).
+		WithPackages("kubectl").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	mock.AvailableReturns(true)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.Nil(t, err)
+
+	require.Equal(t, 1, mock.RunSuccessWithWorkDirCallCount())
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+	require.Equal(t, "docker", cmd)
+	require.Contains(t, args, kubepkg.DefaultDebBuildImage)
+	require.Contains(t, args, "dpkg-buildpackage")
+}
+
+func TestWalkBuildsSuccessDistroInContainer(t *testing.T) {
+	for _, tc := range []struct {
+		images   map[string]string
+		expected string
+	}{
+		{ // default image of the distro
+			expected: "gcr.io/k8s-staging-releng/kubepkg:debian-bookworm",
+		},
+		{ // custom image of the build type
+			images:   map[string]string{"deb": "deb-image"},
+			expected: "deb-image",
+		},
+		{ // custom image of the distro wins over the build type
+			images:   map[string]string{"deb": "deb-image", "debian-bookworm": "bookworm-image"},
+			expected: "bookworm-image",
+		},
+	} {
+		opts := options.New().
+			WithBuildInContainer(true).
+			WithContainerImages(tc.images).
+			WithDistros("debian-bookworm").
+			WithPackages("kubectl").
+			WithChannels("release").
+			WithArchitectures("amd64")
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+		mock.AvailableReturns(true)
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		require.Nil(t, sut.WalkBuilds(builds))
+
+		require.Equal(t, 1, mock.RunSuccessWithWorkDirCallCount())
+		_, _, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+		require.Contains(t, args, tc.expected)
+		cleanup()
+	}
+}
+
+func TestWalkBuildsFailureNoContainerRuntime(t *testing.T) {
+	opts := options.New().WithBuildInContainer(true)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	mock.AvailableReturns(false)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.NotNil(t, err)
+	require.Zero(t, mock.RunSuccessWithWorkDirCallCount())
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+	"sigs.k8s.io/release-utils/util"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// kubernetesDependenciesURL is the location of the build dependencies
+	// file of the kubernetes/kubernetes repository for a git ref.
+	kubernetesDependenciesURL = "https://raw.githubusercontent.com/kubernetes/kubernetes/%s/build/dependencies.yaml"
+
+	// Names of the dependencies within the build dependencies file.
+	cniDependency    = "cni"
+	crictlDependency = "crictl"
+)
+
+// kubernetesDependencies is the subset of the build/dependencies.yaml of
+// kubernetes/kubernetes used by kubepkg.
+type kubernetesDependencies struct {
+	Dependencies []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"dependencies"`
+}
+
+// resolveDependencyVersions sets the CNI and CRI tools versions of the build,
+// which have neither been provided as option nor pinned for the channel, to
+// the ones the Kubernetes version has been tested with. The build keeps the
+// default versions if the dependencies cannot be retrieved.
+func (c *Client) resolveDependencyVersions(bc *buildConfig) {
+	var needsCNI, needsCRITools bool
+	switch sourcePackage(bc.PackageDefinition) {
+	case "kubelet":
+		needsCNI = bc.CNIVersion == ""
+	case "kubernetes-cni":
+		needsCNI = bc.CNIVersion == "" && bc.Version == ""
+	case "cri-tools":
+		needsCRITools = bc.Version == ""
+	}
+	if !needsCNI && !needsCRITools {
+		return
+	}
+
+	versions, err := c.kubernetesDependencies(bc.KubernetesVersion)
+	if err != nil {
+		bc.log.Warnf("Unable to resolve dependency versions, using defaults: %v", err)
+		return
+	}
+
+	cniVersion := versions[cniDependency]
+	switch {
+	case !needsCNI || cniVersion == "":
+	case !isMinimumCNIVersion(cniVersion):
+		bc.log.Infof(
+			"Ignoring CNI version %s of Kubernetes %s, which is lower than %s",
+			cniVersion, bc.KubernetesVersion, MinimumCNIVersion,
+		)
+	default:
+		bc.log.Infof("Using CNI version %s of Kubernetes %s", cniVersion, bc.KubernetesVersion)
+		bc.CNIVersion = cniVersion
+		if sourcePackage(bc.PackageDefinition) == "kubernetes-cni" {
+			bc.Version = cniVersion
+		}
+	}
+
+	if criToolsVersion := versions[crictlDependency]; needsCRITools && criToolsVersion != "" {
+		bc.log.Infof("Using CRI tools version %s of Kubernetes %s", criToolsVersion, bc.KubernetesVersion)
+		bc.Version = criToolsVersion
+	}
+}
+
+// kubernetesDependencies returns the versions of the build dependencies file
+// for the provided Kubernetes version by their name. The dependencies are
+// shared between all packages of the same version and therefore fetched only
+// once, even if they are not available.
+func (c *Client) kubernetesDependencies(kubeVersion string) (map[string]string, error) {
+	ref, err := kubernetesGitRef(kubeVersion)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf(kubernetesDependenciesURL, ref)
+
+	c.dependenciesMu.Lock()
+	defer c.dependenciesMu.Unlock()
+
+	if versions, ok := c.dependenciesCache[url]; ok {
+		return versions, nil
+	}
+	if c.dependenciesCache == nil {
+		c.dependenciesCache = map[string]map[string]string{}
+	}
+	c.dependenciesCache[url] = map[string]string{}
+
+	response, err := c.impl.GetURLResponse(url, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching %s", url)
+	}
+
+	dependencies := &kubernetesDependencies{}
+	if err := yaml.Unmarshal([]byte(response), dependencies); err != nil {
+		return nil, errors.Wrapf(err, "parsing dependencies of %s", url)
+	}
+
+	versions := map[string]string{}
+	for _, dependency := range dependencies.Dependencies {
+		versions[dependency.Name] = util.TrimTagPrefix(dependency.Version)
+	}
+	c.dependenciesCache[url] = versions
+	return versions, nil
+}
+
+// kubernetesGitRef returns the git reference of the provided Kubernetes
+// version, which is the commit for CI versions like
+// 1.22.0-alpha.0.42+0123456789abcd and the tag otherwise.
+func kubernetesGitRef(kubeVersion string) (string, error) {
+	kubeSemver, err := util.TagStringToSemver(kubeVersion)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing Kubernetes version %s", kubeVersion)
+	}
+	if len(kubeSemver.Build) > 0 {
+		return kubeSemver.Build[len(kubeSemver.Build)-1], nil
+	}
+	return util.AddTagPrefix(kubeSemver.String()), nil
+}
+
+// resolveLatestVersion returns the provided dependency version, or the newest
+// release of the repository which is at least the minimum version if the
+// version is "latest".
+func (c *Client) resolveLatestVersion(version, owner, repo, minimum string) (string, error) {
+	if version != options.LatestVersion {
+		return version, nil
+	}
+	tag, err := c.impl.LatestTag(owner, repo, ">="+minimum)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting latest release of %s/%s", owner, repo)
+	}
+	logrus.Infof("Resolved latest release of %s/%s to %s", owner, repo, tag)
+	return util.TrimTagPrefix(tag), nil
+}
+
+// isMinimumCNIVersion returns true if the provided CNI version can be
+// packaged.
+func isMinimumCNIVersion(cniVersion string) bool {
+	cniSemver, err := util.TagStringToSemver(cniVersion)
+	if err != nil {
+		return false
+	}
+	return cniSemver.GTE(semver.MustParse(MinimumCNIVersion))
+}
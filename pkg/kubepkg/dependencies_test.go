@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestPlanSuccessDependenciesFile(t *testing.T) {
+	for _, tc := range []struct {
+		kubeVersion      string
+		criToolsVersion  string
+		dependencies     string
+		expectedURL      string
+		expectedVersions []string
+	}{
+		{ // versions of the dependencies file
+			kubeVersion:      "v1.24.0",
+			dependencies:     "dependencies:\n  - name: cni\n    version: 1.1.1\n  - name: crictl\n    version: v1.24.2\n",
+			expectedURL:      "https://raw.githubusercontent.com/kubernetes/kubernetes/v1.24.0/build/dependencies.yaml",
+			expectedVersions: []string{"1.1.1", "1.24.2"},
+		},
+		{ // CI versions use the commit and provided versions take precedence
+			kubeVersion:      "v1.25.0-alpha.0.42+0123456789abcd",
+			criToolsVersion:  "1.23.0",
+			dependencies:     "dependencies:\n  - name: cni\n    version: 1.1.1\n  - name: crictl\n    version: 1.24.2\n",
+			expectedURL:      "https://raw.githubusercontent.com/kubernetes/kubernetes/0123456789abcd/build/dependencies.yaml",
+			expectedVersions: []string{"1.1.1", "1.23.0"},
+		},
+		{ // CNI versions lower than the minimum are ignored
+			kubeVersion:      "v1.18.0",
+			dependencies:     "dependencies:\n  - name: cni\n    version: 0.7.5\n",
+			expectedURL:      "https://raw.githubusercontent.com/kubernetes/kubernetes/v1.18.0/build/dependencies.yaml",
+			expectedVersions: []string{kubepkg.MinimumCNIVersion, "1.18.0"},
+		},
+	} {
+		opts := options.New().
+			WithPackages("kubernetes-cni", "cri-tools").
+			WithChannels("release").
+			WithArchitectures("amd64")
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+		defer cleanup()
+		opts.WithKubeVersion(tc.kubeVersion).WithCRIToolsVersion(tc.criToolsVersion)
+		mock.GetURLResponseReturns(tc.dependencies, nil)
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		plan, err := sut.Plan(builds)
+		require.Nil(t, err)
+
+		// The dependencies are fetched only once per Kubernetes version
+		require.Equal(t, 1, mock.GetURLResponseCallCount())
+		url, _ := mock.GetURLResponseArgsForCall(0)
+		require.Equal(t, tc.expectedURL, url)
+
+		versions := []string{}
+		for _, build := range plan.Builds {
+			versions = append(versions, build.Version)
+		}
+		require.Equal(t, tc.expectedVersions, versions)
+	}
+}
+
+func TestPlanSuccessLatestDependencies(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubelet", "kubernetes-cni", "cri-tools").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	opts.WithKubeVersion("v1.24.0").
+		WithCNIVersion(options.LatestVersion).
+		WithCRIToolsVersion(options.LatestVersion)
+	mock.LatestTagReturnsOnCall(0, "v1.3.0", nil)
+	mock.LatestTagReturnsOnCall(1, "v1.30.1", nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	plan, err := sut.Plan(builds)
+	require.Nil(t, err)
+
+	// The latest versions are resolved only once
+	require.Equal(t, 2, mock.LatestTagCallCount())
+	owner, repo, constraint := mock.LatestTagArgsForCall(0)
+	require.Equal(t, []string{"containernetworking", "plugins", ">=" + kubepkg.MinimumCNIVersion},
+		[]string{owner, repo, constraint})
+	owner, repo, _ = mock.LatestTagArgsForCall(1)
+	require.Equal(t, []string{"kubernetes-sigs", "cri-tools"}, []string{owner, repo})
+
+	versions := []string{}
+	for _, build := range plan.Builds {
+		versions = append(versions, build.Version)
+	}
+	require.Equal(t, []string{"1.24.0", "1.3.0", "1.30.1"}, versions)
+	require.Zero(t, mock.GetURLResponseCallCount())
+}
+
+func TestConstructBuildsFailureLatestDependencies(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubernetes-cni").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithCNIVersion(options.LatestVersion)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.LatestTagReturns("", errors.New("error"))
+
+	_, err := sut.ConstructBuilds()
+	require.NotNil(t, err)
+}
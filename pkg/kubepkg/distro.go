@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// distrosTemplateDir is the subdirectory of a template directory containing
+// the distro-specific template variants, laid out like
+// distros/<distro>/<type>/<package>.
+const distrosTemplateDir = "distros"
+
+// distroDependencyNames are the names of the package dependencies, which
+// differ from the generic ones on individual distributions.
+var distroDependencyNames = map[string]map[string]string{
+	// dh-systemd has been merged into debhelper
+	"debian-bullseye": {"dh-systemd": "debhelper"},
+	"debian-bookworm": {"dh-systemd": "debhelper"},
+	"ubuntu-focal":    {"dh-systemd": "debhelper"},
+	"ubuntu-jammy":    {"dh-systemd": "debhelper"},
+
+	// ebtables is only available via the nftables based compatibility
+	// layer
+	"el8":  {"conntrack": "conntrack-tools", "ebtables": "iptables-ebtables"},
+	"el9":  {"conntrack": "conntrack-tools", "ebtables": "iptables-ebtables"},
+	"fc39": {"conntrack": "conntrack-tools", "ebtables": "iptables-nft"},
+	"fc40": {"conntrack": "conntrack-tools", "ebtables": "iptables-nft"},
+}
+
+// DependencyName returns the name of the provided package dependency on the
+// distribution of the build. It is available to the templates, for example
+// as {{ .DependencyName "conntrack" }}.
+func (bc *buildConfig) DependencyName(name string) string {
+	if distroName, ok := distroDependencyNames[bc.Distro][name]; ok {
+		return distroName
+	}
+	return name
+}
+
+// distroTemplateDirs returns the package template directories of all
+// template directories for the provided distribution, whose files override
+// the generic templates of the package.
+func (c *Client) distroTemplateDirs(distro, pkg string) []string {
+	dirs := []string{}
+	for _, dir := range append(
+		[]string{c.options.TemplateDir()}, c.options.ExtraTemplateDirs()...,
+	) {
+		distroTemplateDir := filepath.Join(
+			dir, distrosTemplateDir, distro, c.templateDirType(), pkg,
+		)
+		if _, err := os.Stat(distroTemplateDir); err == nil {
+			dirs = append(dirs, distroTemplateDir)
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessDistros(t *testing.T) {
+	outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+	require.Nil(t, err)
+	defer os.RemoveAll(outputDir)
+
+	opts := options.New().
+		WithPackages("kubelet").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithDistros("el8", "el9").
+		WithOutputDir(outputDir).
+		WithSpecOnly(true)
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildRpm)
+	defer cleanup()
+
+	for dir, files := range map[string]map[string]string{
+		filepath.Join(opts.TemplateDir(), "rpm", "kubelet"): {
+			"kubelet.spec": `Requires: {{ .DependencyName "conntrack" }} {{ .DependencyName "socat" }}`,
+			"kubelet.env":  "base {{ .Distro }}",
+		},
+		filepath.Join(opts.TemplateDir(), "distros", "el9", "rpm", "kubelet"): {
+			"kubelet.env": "el9 {{ .Distro }}",
+		},
+	} {
+		require.Nil(t, os.MkdirAll(dir, 0o755))
+		for file, content := range files {
+			require.Nil(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644))
+		}
+	}
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Len(t, builds[0].Definitions, 2)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	specDir := filepath.Join(outputDir, "specs", "release")
+	for file, expected := range map[string]string{
+		"el8/kubelet/amd64/kubelet.spec": "Requires: conntrack-tools socat",
+		"el8/kubelet/amd64/kubelet.env":  "base el8",
+		"el9/kubelet/amd64/kubelet.spec": "Requires: conntrack-tools socat",
+		"el9/kubelet/amd64/kubelet.env":  "el9 el9",
+	} {
+		content, err := os.ReadFile(filepath.Join(specDir, file))
+		require.Nil(t, err)
+		require.Equal(t, expected, string(content))
+	}
+}
+
+func TestWalkBuildsSuccessChannelDependencies(t *testing.T) {
+	outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+	require.Nil(t, err)
+	defer os.RemoveAll(outputDir)
+
+	opts := options.New().
+		WithPackages("kubelet", "kubernetes-cni").
+		WithChannels("release", "nightly").
+		WithArchitectures("amd64").
+		WithOutputDir(outputDir).
+		WithSpecOnly(true).
+		WithChannelDependencies(map[string]options.DependencyVersions{
+			"nightly": {CNIVersion: "1.0.1", ConntrackVersion: "1.4.6"},
+		})
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	opts.WithKubeVersion("")
+
+	for pkg, content := range map[string]string{
+		"kubelet":        `{{ index .Dependencies "kubernetes-cni" }} {{ index .Dependencies "conntrack" }}`,
+		"kubernetes-cni": "{{ .Version }}",
+	} {
+		require.Nil(t, os.WriteFile(
+			filepath.Join(opts.TemplateDir(), "deb", pkg, "control"),
+			[]byte(content), 0o644,
+		))
+	}
+
+	mock.GetKubeVersionReturns("v1.23.0", nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	specDir := filepath.Join(outputDir, "specs")
+	for file, expected := range map[string]string{
+		"release/kubelet/amd64/control":        "0.8.6 ",
+		"release/kubernetes-cni/amd64/control": "0.8.6",
+		"nightly/kubelet/amd64/control":        "1.0.1 1.4.6",
+		"nightly/kubernetes-cni/amd64/control": "1.0.1",
+	} {
+		content, err := os.ReadFile(filepath.Join(specDir, file))
+		require.Nil(t, err)
+		require.Equal(t, expected, string(content), file)
+	}
+}
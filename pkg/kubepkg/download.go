@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/retry"
+)
+
+const (
+	// downloadRetries is the number of times a failed download gets retried,
+	// which matches the `curl --retry 5` of the package templates.
+	downloadRetries = 5
+
+	// downloadMaxWait is the maximum time to wait between two attempts.
+	downloadMaxWait = 60 * time.Second
+
+	// downloadTimeout is the maximum time a single attempt may take.
+	downloadTimeout = 10 * time.Minute
+)
+
+// downloader retrieves remote files and retries failed attempts using an
+// exponential backoff. Partially downloaded files get resumed if the server
+// supports range requests.
+type downloader struct {
+	client  *http.Client
+	backoff *retry.Backoff
+}
+
+func newDownloader() *downloader {
+	return &downloader{
+		client:  &http.Client{Timeout: downloadTimeout},
+		backoff: retry.New(downloadRetries, downloadMaxWait),
+	}
+}
+
+// Get returns the content of url.
+func (d *downloader) Get(url string) (content []byte, err error) {
+	err = d.backoff.Run("getting "+url, func() error {
+		response, err := d.request(url, 0)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+
+		content, err = io.ReadAll(response.Body)
+		return errors.Wrapf(err, "reading response of %s", url)
+	})
+	return content, err
+}
+
+// DownloadFile writes the content of url to dst. Failed attempts continue
+// where the previous attempt stopped.
+func (d *downloader) DownloadFile(url, dst string) error {
+	file, err := os.OpenFile(
+		dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0o755),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "creating %s", dst)
+	}
+	defer file.Close()
+
+	return d.backoff.Run("downloading "+url, func() error {
+		offset, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return retry.Permanent(errors.Wrapf(err, "seeking %s", dst))
+		}
+
+		response, err := d.request(url, offset)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+
+		// The server ignored the range request, so start from scratch
+		if offset > 0 && response.StatusCode != http.StatusPartialContent {
+			logrus.Debugf("Server does not support resuming %s", url)
+			if err := file.Truncate(0); err != nil {
+				return retry.Permanent(errors.Wrapf(err, "truncating %s", dst))
+			}
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return retry.Permanent(errors.Wrapf(err, "seeking %s", dst))
+			}
+		}
+
+		_, err = io.Copy(file, response.Body)
+		return errors.Wrapf(err, "downloading %s to %s", url, dst)
+	})
+}
+
+// request sends a GET request for url, starting at offset if it is greater
+// than zero.
+func (d *downloader) request(url string, offset int64) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, retry.Permanent(errors.Wrapf(err, "creating request for %s", url))
+	}
+	if offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return nil, WithCategory(errors.Wrapf(err, "getting %s", url), ErrorCategoryNetwork)
+	}
+	return checkResponse(response, url)
+}
+
+// Post sends body as JSON to url and discards the response.
+func (d *downloader) Post(url string, body []byte) error {
+	return d.backoff.Run("posting to "+url, func() error {
+		response, err := d.client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return WithCategory(errors.Wrapf(err, "posting to %s", url), ErrorCategoryNetwork)
+		}
+		response, err = checkResponse(response, url)
+		if err != nil {
+			return err
+		}
+		return response.Body.Close()
+	})
+}
+
+// checkResponse returns the response if it succeeded. Server errors are
+// retryable, client errors are permanent.
+func checkResponse(response *http.Response, url string) (*http.Response, error) {
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return response, nil
+	}
+	response.Body.Close()
+
+	err := errors.Errorf("HTTP error %s for %s", response.Status, url)
+	switch {
+	case response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests:
+		return nil, WithCategory(err, ErrorCategoryNetwork)
+	case response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden:
+		return nil, retry.Permanent(WithCategory(err, ErrorCategoryAuth))
+	}
+	return nil, retry.Permanent(WithCategory(err, ErrorCategoryNetwork))
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDownloader() (*downloader, *[]time.Duration) {
+	waits := []time.Duration{}
+	d := newDownloader()
+	d.backoff.Retries = 3
+	d.backoff.MaxWait = 3 * time.Second
+	d.backoff.Sleep = func(wait time.Duration) { waits = append(waits, wait) }
+	return d, &waits
+}
+
+func TestDownloaderGetRetry(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte("v1.22.1")) // nolint: errcheck
+		},
+	))
+	defer server.Close()
+
+	sut, waits := newTestDownloader()
+	content, err := sut.Get(server.URL)
+	require.Nil(t, err)
+	require.Equal(t, "v1.22.1", string(content))
+	require.Equal(t, 3, requests)
+	require.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *waits)
+}
+
+func TestDownloaderGetRetriesExhausted(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	))
+	defer server.Close()
+
+	sut, waits := newTestDownloader()
+	_, err := sut.Get(server.URL)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "failed after 4 attempts")
+	require.Equal(t, ErrorCategoryNetwork, ErrorCategoryOf(err))
+	require.Equal(t, 4, requests)
+	require.Equal(t,
+		[]time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, *waits,
+	)
+}
+
+func TestDownloaderGetNotFound(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusNotFound)
+		},
+	))
+	defer server.Close()
+
+	sut, waits := newTestDownloader()
+	_, err := sut.Get(server.URL)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "404")
+	require.Equal(t, 1, requests)
+	require.Empty(t, *waits)
+}
+
+func TestDownloaderDownloadFileResume(t *testing.T) {
+	content := bytes.Repeat([]byte("kubeadm"), 1024)
+	ranges := []string{}
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			ranges = append(ranges, r.Header.Get("Range"))
+			if len(ranges) == 1 {
+				// Interrupt the first download halfway through
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				w.Write(content[:len(content)/2]) // nolint: errcheck
+				return
+			}
+			http.ServeContent(w, r, "kubeadm", time.Time{}, bytes.NewReader(content))
+		},
+	))
+	defer server.Close()
+
+	tempDir, err := os.MkdirTemp("", "kubepkg-download-")
+	require.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+	dst := filepath.Join(tempDir, "kubeadm")
+
+	sut, waits := newTestDownloader()
+	require.Nil(t, sut.DownloadFile(server.URL, dst))
+	require.Len(t, *waits, 1)
+	require.Equal(t,
+		[]string{"", "bytes=" + strconv.Itoa(len(content)/2) + "-"}, ranges,
+	)
+
+	downloaded, err := os.ReadFile(dst)
+	require.Nil(t, err)
+	require.Equal(t, content, downloaded)
+}
+
+func TestDownloaderDownloadFileRestart(t *testing.T) {
+	content := bytes.Repeat([]byte("kubectl"), 1024)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			if requests == 1 {
+				w.Write(content[:len(content)/2]) // nolint: errcheck
+				return
+			}
+			// Range requests are not supported
+			w.Write(content) // nolint: errcheck
+		},
+	))
+	defer server.Close()
+
+	tempDir, err := os.MkdirTemp("", "kubepkg-download-")
+	require.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+	dst := filepath.Join(tempDir, "kubectl")
+
+	sut, _ := newTestDownloader()
+	require.Nil(t, sut.DownloadFile(server.URL, dst))
+	require.Equal(t, 2, requests)
+
+	downloaded, err := os.ReadFile(dst)
+	require.Nil(t, err)
+	require.Equal(t, content, downloaded)
+}
+
+func TestDownloaderPostRetry(t *testing.T) {
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.Nil(t, err)
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			bodies = append(bodies, string(body))
+			if len(bodies) < 2 {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		},
+	))
+	defer server.Close()
+
+	sut, waits := newTestDownloader()
+	require.Nil(t, sut.Post(server.URL, []byte(`{"text":"done"}`)))
+	require.Equal(t, []string{`{"text":"done"}`, `{"text":"done"}`}, bodies)
+	require.Equal(t, []time.Duration{time.Second}, *waits)
+}
+
+func TestDownloaderPostForbidden(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusForbidden)
+		},
+	))
+	defer server.Close()
+
+	sut, waits := newTestDownloader()
+	err := sut.Post(server.URL, []byte("{}"))
+	require.NotNil(t, err)
+	require.Equal(t, ErrorCategoryAuth, ErrorCategoryOf(err))
+	require.Equal(t, 1, requests)
+	require.Empty(t, *waits)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// ErrorCategory classifies the errors returned by the client, which allows
+// automation to decide whether a failed run should be retried or alerted on.
+type ErrorCategory string
+
+const (
+	// ErrorCategoryUnknown is the category of all errors which have not been
+	// classified.
+	ErrorCategoryUnknown ErrorCategory = "unknown"
+
+	// ErrorCategoryValidation are invalid options, templates or packages,
+	// which require a change to succeed.
+	ErrorCategoryValidation ErrorCategory = "validation"
+
+	// ErrorCategoryNetwork are failed downloads and requests, which may
+	// succeed when being retried.
+	ErrorCategoryNetwork ErrorCategory = "network"
+
+	// ErrorCategoryAuth are requests and uploads which got rejected because
+	// of missing or insufficient credentials.
+	ErrorCategoryAuth ErrorCategory = "auth"
+
+	// ErrorCategoryBuild are failed package builds.
+	ErrorCategoryBuild ErrorCategory = "build"
+
+	// ErrorCategorySign are failed signatures of packages and files.
+	ErrorCategorySign ErrorCategory = "sign"
+
+	// ErrorCategoryPublish are failed uploads of packages and repositories.
+	ErrorCategoryPublish ErrorCategory = "publish"
+)
+
+// authFailureRegex matches the output of gsutil for rejected credentials.
+var authFailureRegex = regexp.MustCompile(
+	`AccessDeniedException|Anonymous caller|\b40[13]\b`,
+)
+
+// categorizedError is an error of a known category.
+type categorizedError struct {
+	error
+	category ErrorCategory
+}
+
+func (e categorizedError) Unwrap() error {
+	return e.error
+}
+
+func (e categorizedError) Cause() error {
+	return e.error
+}
+
+// WithCategory returns err classified by the provided category, which is nil
+// if err is nil. The message of the error does not change.
+func WithCategory(err error, category ErrorCategory) error {
+	if err == nil {
+		return nil
+	}
+	return categorizedError{error: err, category: category}
+}
+
+// ErrorCategoryOf returns the category of err. The innermost category of the
+// error chain wins, which means that a build failing because of a download
+// is a network error. Invalid options are always validation errors.
+func ErrorCategoryOf(err error) ErrorCategory {
+	category := ErrorCategoryUnknown
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch e := err.(type) {
+		case categorizedError:
+			category = e.category
+		case *options.ValidationError:
+			return ErrorCategoryValidation
+		}
+	}
+	return category
+}
+
+// publishError returns the error of a failed upload, which is an auth error
+// if the credentials got rejected.
+func publishError(err error) error {
+	if err != nil && authFailureRegex.MatchString(err.Error()) {
+		return WithCategory(err, ErrorCategoryAuth)
+	}
+	return WithCategory(err, ErrorCategoryPublish)
+}
+
+// aggregatedCategory returns the category shared by all provided errors,
+// which is the build category if they differ.
+func aggregatedCategory(errs []error) ErrorCategory {
+	category := ErrorCategoryBuild
+	for i, err := range errs {
+		if i == 0 {
+			category = ErrorCategoryOf(err)
+		} else if ErrorCategoryOf(err) != category {
+			return ErrorCategoryBuild
+		}
+	}
+	return category
+}
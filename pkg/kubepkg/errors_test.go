@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestErrorCategoryOf(t *testing.T) {
+	require.Nil(t, WithCategory(nil, ErrorCategoryBuild))
+	require.Equal(t, ErrorCategoryUnknown, ErrorCategoryOf(errors.New("error")))
+
+	// The innermost category wins
+	networkErr := WithCategory(errors.New("download failed"), ErrorCategoryNetwork)
+	buildErr := WithCategory(errors.Wrap(networkErr, "building kubeadm"), ErrorCategoryBuild)
+	require.Equal(t, "building kubeadm: download failed", buildErr.Error())
+	require.Equal(t, ErrorCategoryNetwork, ErrorCategoryOf(buildErr))
+	require.Equal(t, ErrorCategoryBuild, ErrorCategoryOf(
+		WithCategory(errors.New("build failed"), ErrorCategoryBuild),
+	))
+
+	invalidErr := options.New().WithConcurrency(0).Validate()
+	require.Equal(t, ErrorCategoryValidation, ErrorCategoryOf(errors.Wrap(invalidErr, "validating")))
+
+	require.Equal(t, ErrorCategoryAuth, ErrorCategoryOf(
+		publishError(errors.New("AccessDeniedException: 403 Forbidden")),
+	))
+	require.Equal(t, ErrorCategoryPublish, ErrorCategoryOf(
+		publishError(errors.New("connection reset")),
+	))
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// maxIssueErrorSize is the maximum number of bytes of the error message
+// quoted by a tracking issue, because failed builds contain the output of
+// the build tools.
+const maxIssueErrorSize = 8192
+
+// Stage is the part of a run which failed. Every stage of a build type has
+// its own tracking issue.
+type Stage string
+
+const (
+	// StageBuild are the package builds including all downloads.
+	StageBuild Stage = "build"
+
+	// StageSign are the signatures of the packages and checksums.
+	StageSign Stage = "sign"
+
+	// StagePublish is the upload of the packages.
+	StagePublish Stage = "publish"
+)
+
+// fileIssue opens a tracking issue for the failed stage in the configured
+// repository, or comments on it if it is still open. Like notifications,
+// tracking issues are best effort and do not fail the run.
+func (c *Client) fileIssue(stage Stage, walkErr error) {
+	if c.options.IssueRepo() == "" {
+		return
+	}
+	// Signing happens as part of the builds and before the upload
+	if ErrorCategoryOf(walkErr) == ErrorCategorySign {
+		stage = StageSign
+	}
+
+	// The repository has been validated to be in the form <owner>/<repo>
+	parts := strings.SplitN(c.options.IssueRepo(), "/", 2)
+	owner, repo := parts[0], parts[1]
+	title := IssueTitle(c.options.BuildType(), stage)
+	body := c.issueBody(stage, walkErr)
+
+	if c.options.DryRun() {
+		logrus.Infof(
+			"Dry run: would open or update issue %q in %s", title, c.options.IssueRepo(),
+		)
+		return
+	}
+
+	issues, err := c.impl.OpenIssues(owner, repo, c.options.IssueLabels())
+	if err != nil {
+		logrus.Warnf("Unable to list issues of %s: %v", c.options.IssueRepo(), err)
+		return
+	}
+	for _, issue := range issues {
+		if issue.GetTitle() != title {
+			continue
+		}
+		logrus.Infof("Commenting on tracking issue %s", issue.GetHTMLURL())
+		if err := c.impl.CommentOnIssue(owner, repo, issue.GetNumber(), body); err != nil {
+			logrus.Warnf("Unable to comment on tracking issue: %v", err)
+		}
+		return
+	}
+
+	logrus.Infof("Opening tracking issue %q in %s", title, c.options.IssueRepo())
+	issue, err := c.impl.CreateIssue(owner, repo, title, body, c.options.IssueLabels())
+	if err != nil {
+		logrus.Warnf("Unable to open tracking issue: %v", err)
+		return
+	}
+	logrus.Infof("Opened tracking issue %s", issue.GetHTMLURL())
+}
+
+// IssueTitle returns the title of the tracking issue of the build type and
+// stage, which identifies an open issue to be updated.
+func IssueTitle(buildType options.BuildType, stage Stage) string {
+	return fmt.Sprintf("kubepkg: %s packages fail in the %s stage", buildType, stage)
+}
+
+// issueBody returns the Markdown description of the failure, which contains
+// the failed builds, the link to the logs and the error.
+func (c *Client) issueBody(stage Stage, walkErr error) string {
+	n := c.newNotification(NotifyFailed, walkErr)
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%s\n\n", n.Message)
+	fmt.Fprintf(b, "- Stage: `%s`\n", stage)
+	fmt.Fprintf(b, "- Category: `%s`\n", ErrorCategoryOf(walkErr))
+	if n.LogsURL != "" {
+		fmt.Fprintf(b, "- Logs: %s\n", n.LogsURL)
+	}
+
+	failed := []string{}
+	for _, build := range n.Summary.Builds {
+		if build.Status == BuildFailed {
+			failed = append(failed, build.Name)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Fprintf(b, "\nFailed builds:\n")
+		for _, name := range failed {
+			fmt.Fprintf(b, "- `%s`\n", name)
+		}
+	}
+
+	message := n.Error
+	if len(message) > maxIssueErrorSize {
+		message = message[:maxIssueErrorSize] + "\n[truncated, see the logs]"
+	}
+	fmt.Fprintf(b, "\n<details><summary>Error</summary>\n\n```\n%s\n```\n</details>\n", message)
+	return b.String()
+}
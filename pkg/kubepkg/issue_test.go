@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"testing"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsIssueOpened(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithIssueRepo("kubernetes/release").
+		WithIssueLabels("kind/failing-test").
+		WithLogsURL("https://prow.k8s.io/job")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(true)
+	mock.RunSuccessWithWorkDirReturns(errors.New("dpkg-buildpackage failed"))
+	mock.OpenIssuesReturns([]*gogithub.Issue{
+		{Number: gogithub.Int(1), Title: gogithub.String("unrelated")},
+	}, nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.NotNil(t, sut.WalkBuilds(builds))
+
+	require.Equal(t, 1, mock.OpenIssuesCallCount())
+	owner, repo, labels := mock.OpenIssuesArgsForCall(0)
+	require.Equal(t, "kubernetes", owner)
+	require.Equal(t, "release", repo)
+	require.Equal(t, []string{"kind/failing-test"}, labels)
+
+	require.Equal(t, 0, mock.CommentOnIssueCallCount())
+	require.Equal(t, 1, mock.CreateIssueCallCount())
+	_, _, title, body, labels := mock.CreateIssueArgsForCall(0)
+	require.Equal(t, "kubepkg: deb packages fail in the build stage", title)
+	require.Equal(t, []string{"kind/failing-test"}, labels)
+	require.Contains(t, body, "kubepkg failed building deb packages")
+	require.Contains(t, body, "- Stage: `build`")
+	require.Contains(t, body, "- Logs: https://prow.k8s.io/job")
+	require.Contains(t, body, "- `kubeadm/release/amd64`")
+	require.Contains(t, body, "dpkg-buildpackage failed")
+}
+
+func TestWalkBuildsIssueUpdated(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithSignKey("keyring:ABCDEF12").
+		WithIssueRepo("kubernetes/release")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(true)
+
+	// The build succeeds, but signing the package fails
+	mock.RunSuccessWithWorkDirReturnsOnCall(1, errors.New("dpkg-sig failed"))
+	mock.OpenIssuesReturns([]*gogithub.Issue{{
+		Number: gogithub.Int(42),
+		Title:  gogithub.String(kubepkg.IssueTitle(options.BuildDeb, kubepkg.StageSign)),
+	}}, nil)
+
+	// Failing to update the issue does not change the error
+	mock.CommentOnIssueReturns(errors.New("forbidden"))
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	err = sut.WalkBuilds(builds)
+	require.NotNil(t, err)
+	require.Equal(t, kubepkg.ErrorCategorySign, kubepkg.ErrorCategoryOf(err))
+
+	require.Equal(t, 0, mock.CreateIssueCallCount())
+	require.Equal(t, 1, mock.CommentOnIssueCallCount())
+	_, _, number, body := mock.CommentOnIssueArgsForCall(0)
+	require.Equal(t, 42, number)
+	require.Contains(t, body, "- Stage: `sign`")
+	require.Contains(t, body, "dpkg-sig failed")
+}
+
+func TestWalkBuildsIssueSkipped(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		dryRun bool
+		err    error
+	}{
+		{name: "succeeded run"},
+		{name: "dry run", dryRun: true, err: errors.New("dpkg-buildpackage failed")},
+	} {
+		opts := options.New().
+			WithPackages("kubeadm").
+			WithChannels("release").
+			WithArchitectures("amd64").
+			WithIssueRepo("kubernetes/release").
+			WithDryRun(tc.dryRun)
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+		mock.AvailableReturns(true)
+		mock.RunSuccessWithWorkDirReturns(tc.err)
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err, tc.name)
+		require.Equal(t, tc.err != nil, sut.WalkBuilds(builds) != nil, tc.name)
+
+		require.Equal(t, 0, mock.OpenIssuesCallCount(), tc.name)
+		require.Equal(t, 0, mock.CreateIssueCallCount(), tc.name)
+		cleanup()
+	}
+}
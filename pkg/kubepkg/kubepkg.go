@@ -17,22 +17,32 @@ limitations under the License.
 package kubepkg
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 
-	"github.com/blang/semver"
 	gogithub "github.com/google/go-github/v37/github"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/git"
 	"k8s.io/release/pkg/github"
 	"k8s.io/release/pkg/kubepkg/options"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/object"
+	"k8s.io/release/pkg/output"
 	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/release-utils/command"
+	"sigs.k8s.io/release-utils/tar"
 	"sigs.k8s.io/release-utils/util"
 )
 
@@ -48,6 +58,17 @@ const (
 	MinimumCNIVersion        = "0.8.6"
 
 	kubeadmConf = "10-kubeadm.conf"
+
+	// The GitHub repositories of the CNI plugins and CRI tools, which get
+	// searched for their latest releases.
+	cniOwner      = "containernetworking"
+	cniRepo       = "plugins"
+	criToolsOwner = "kubernetes-sigs"
+	criToolsRepo  = "cri-tools"
+
+	// specsDir is the subdirectory of the output directory containing the
+	// specs if only specs get created.
+	specsDir = "specs"
 )
 
 var (
@@ -55,43 +76,140 @@ var (
 
 	buildArchMap = map[string]map[options.BuildType]string{
 		"amd64": {
-			"deb": "amd64",
-			"rpm": "x86_64",
+			"deb":       "amd64",
+			"rpm":       "x86_64",
+			"apk":       "x86_64",
+			"archlinux": "x86_64",
+			"snap":      "amd64",
+			"msi":       "x64",
 		},
 		"arm": {
-			"deb": "armhf",
-			"rpm": "armhfp",
+			"deb":       "armhf",
+			"rpm":       "armhfp",
+			"apk":       "armv7",
+			"archlinux": "armv7h",
+			"snap":      "armhf",
 		},
 		"arm64": {
-			"deb": "arm64",
-			"rpm": "aarch64",
+			"deb":       "arm64",
+			"rpm":       "aarch64",
+			"apk":       "aarch64",
+			"archlinux": "aarch64",
+			"snap":      "arm64",
+			"msi":       "arm64",
 		},
 		"ppc64le": {
-			"deb": "ppc64el",
-			"rpm": "ppc64le",
+			"deb":       "ppc64el",
+			"rpm":       "ppc64le",
+			"apk":       "ppc64le",
+			"archlinux": "powerpc64le",
+			"snap":      "ppc64el",
 		},
 		"s390x": {
-			"deb": "s390x",
-			"rpm": "s390x",
+			"deb":       "s390x",
+			"rpm":       "s390x",
+			"apk":       "s390x",
+			"archlinux": "s390x",
+			"snap":      "s390x",
+		},
+		"riscv64": {
+			"deb":       "riscv64",
+			"rpm":       "riscv64",
+			"apk":       "riscv64",
+			"archlinux": "riscv64",
+			"snap":      "riscv64",
 		},
 	}
 
+	// typePackages restricts the packages for build types which do not
+	// support all of them.
+	typePackages = map[options.BuildType][]string{
+		options.BuildSnap:       {"kubectl", "kubeadm"},
+		options.BuildChocolatey: {"kubectl", "kubeadm"},
+		options.BuildWinget:     {"kubectl", "kubeadm"},
+		options.BuildMsi:        {"kubelet", "kubeadm"},
+	}
+
 	builtins = map[string]interface{}{
 		"date": func() string {
 			return time.Now().Format(time.RFC1123Z)
 		},
+		"rpmDate": func() string {
+			return time.Now().Format("Mon Jan _2 2006")
+		},
+		"archLinuxVersion": archLinuxVersion,
+		"snapGrade":        snapGrade,
+		"msiVersion":       msiVersion,
 	}
 )
 
 type Client struct {
 	options *options.Options
 	impl    Impl
+
+	// kubeVersions are the Kubernetes versions to be built, where all
+	// version markers have been resolved by ConstructBuilds.
+	kubeVersions []string
+
+	// revision is the package revision, which gets derived from the source
+	// directory by ConstructBuilds if none has been set.
+	revision string
+
+	artifactsMu sync.Mutex
+	artifacts   []Artifact
+
+	templateFuncsMu sync.Mutex
+	templateFuncs   template.FuncMap
+
+	claimedBuildsMu sync.Mutex
+	claimedBuilds   map[string]bool
+
+	buildResultsMu sync.Mutex
+	buildResults   []BuildResult
+
+	releaseNotesMu    sync.Mutex
+	releaseNotesCache map[string]notes.ReleaseNotesByPR
+
+	dependenciesMu    sync.Mutex
+	dependenciesCache map[string]map[string]string
+
+	signerOnce sync.Once
+	signer     *signer
+	signerErr  error
+
+	pluginsMu      sync.RWMutex
+	builders       map[options.BuildType]Builder
+	preBuildHooks  []BuildHook
+	postBuildHooks []BuildHook
+
+	downloadsMu   sync.Mutex
+	downloads     map[string]*sharedDownload
+	downloadsDir  string
+	downloadSlots chan struct{}
+	prefetchWg    sync.WaitGroup
 }
 
 func New(o *options.Options) *Client {
+	// The JSON summary gets printed to stdout, which means that the build
+	// output must not pollute it. The build output is omitted if only
+	// warnings and errors get logged.
+	var stdout, stderr io.Writer = os.Stdout, os.Stderr
+	if o.OutputFormat() == options.OutputFormatJSON {
+		stdout = os.Stderr
+	}
+	if !logrus.IsLevelEnabled(logrus.InfoLevel) {
+		stdout, stderr = io.Discard, io.Discard
+	}
 	return &Client{
 		options: o,
-		impl:    &impl{},
+		impl: &impl{
+			stdout:   stdout,
+			stderr:   stderr,
+			versions: release.NewVersion(),
+		},
+		artifacts: []Artifact{},
+
+		buildResults: []BuildResult{},
 	}
 }
 
@@ -99,28 +217,76 @@ func (c *Client) SetImpl(impl Impl) {
 	c.impl = impl
 }
 
-type impl struct{}
+type impl struct {
+	// stdout receives the standard output of the build commands.
+	stdout io.Writer
+
+	// stderr receives the standard error of the build commands.
+	stderr io.Writer
+
+	// versions resolves the Kubernetes version markers, which are cached
+	// across all builds of a run.
+	versions *release.Version
+}
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate . Impl
 type Impl interface {
 	RunSuccessWithWorkDir(workDir, cmd string, args ...string) error
-	Releases(owner, repo string, includePrereleases bool) ([]*gogithub.RepositoryRelease, error)
+	LatestTag(owner, repo, constraint string) (string, error)
 	GetKubeVersion(versionType release.VersionType) (string, error)
+	GetURLResponse(url string, trim bool) (string, error)
+	PostURL(url string, body []byte) error
 	ReadFile(string) ([]byte, error)
 	WriteFile(string, []byte, os.FileMode) error
+	DownloadFile(url, dst string) error
+	Extract(tarball, dst string) error
+	Available(commands ...string) bool
+	RunOutputWithWorkDir(workDir, cmd string, args ...string) (string, error)
+	RsyncRecursive(src, dst string) error
+	DescribeRepo(dir string) (string, error)
+	OpenIssues(owner, repo string, labels []string) ([]*gogithub.Issue, error)
+	CreateIssue(owner, repo, title, body string, labels []string) (*gogithub.Issue, error)
+	CommentOnIssue(owner, repo string, number int, body string) error
 }
 
 func (i *impl) RunSuccessWithWorkDir(workDir, cmd string, args ...string) error {
-	return command.NewWithWorkDir(workDir, cmd, args...).RunSuccess()
+	// Package builds can produce a huge amount of output, which is why we
+	// only keep a limited amount of it in memory for error reporting.
+	buffer := output.NewLimitedBuffer(output.MaxSize())
+	c := exec.Command(cmd, args...)
+	c.Dir = workDir
+	c.Stdout = io.MultiWriter(i.stdout, buffer)
+	c.Stderr = io.MultiWriter(i.stderr, buffer)
+	if err := c.Run(); err != nil {
+		return errors.Wrapf(
+			err, "command %s did not succeed: %s", c.String(), buffer.String(),
+		)
+	}
+	return nil
 }
 
-func (i *impl) Releases(owner, repo string, includePrereleases bool) ([]*gogithub.RepositoryRelease, error) {
-	return github.New().Releases(owner, repo, includePrereleases)
+func (i *impl) LatestTag(owner, repo, constraint string) (string, error) {
+	return github.New().LatestTag(owner, repo, constraint)
 }
 
 func (i *impl) GetKubeVersion(versionType release.VersionType) (string, error) {
-	return release.NewVersion().GetKubeVersion(versionType)
+	return i.versions.GetKubeVersion(versionType)
+}
+
+func (i *impl) GetURLResponse(url string, trim bool) (string, error) {
+	content, err := newDownloader().Get(url)
+	if err != nil {
+		return "", err
+	}
+	if trim {
+		content = bytes.TrimSpace(content)
+	}
+	return string(content), nil
+}
+
+func (i *impl) PostURL(url string, body []byte) error {
+	return newDownloader().Post(url, body)
 }
 
 func (i *impl) ReadFile(filename string) ([]byte, error) {
@@ -131,11 +297,68 @@ func (i *impl) WriteFile(filename string, data []byte, perm os.FileMode) error {
 	return os.WriteFile(filename, data, perm)
 }
 
+func (i *impl) DownloadFile(url, dst string) error {
+	return newDownloader().DownloadFile(url, dst)
+}
+
+func (i *impl) Extract(tarball, dst string) error {
+	return tar.Extract(tarball, dst)
+}
+
+func (i *impl) Available(commands ...string) bool {
+	return command.Available(commands...)
+}
+
+func (i *impl) RunOutputWithWorkDir(workDir, cmd string, args ...string) (string, error) {
+	res, err := command.NewWithWorkDir(workDir, cmd, args...).RunSilentSuccessOutput()
+	if err != nil {
+		return "", err
+	}
+	return res.OutputTrimNL(), nil
+}
+
+func (i *impl) RsyncRecursive(src, dst string) error {
+	return object.NewGCS().RsyncRecursive(src, dst)
+}
+
+func (i *impl) DescribeRepo(dir string) (string, error) {
+	repo, err := git.OpenRepo(dir)
+	if err != nil {
+		return "", err
+	}
+	return repo.Describe(
+		git.NewDescribeOptions().WithTags().WithLong().WithAbbrev(revisionAbbrev),
+	)
+}
+
+func (i *impl) OpenIssues(owner, repo string, labels []string) ([]*gogithub.Issue, error) {
+	return github.New().ListOpenIssues(owner, repo, labels...)
+}
+
+func (i *impl) CreateIssue(owner, repo, title, body string, labels []string) (*gogithub.Issue, error) {
+	return github.New().CreateIssue(owner, repo, title, body, &github.NewIssueOptions{Labels: labels})
+}
+
+func (i *impl) CommentOnIssue(owner, repo string, number int, body string) error {
+	_, _, err := github.New().Client().CreateComment(context.Background(), owner, repo, number, body)
+	return err
+}
+
 type Build struct {
 	Type        options.BuildType
 	Package     string
 	Definitions []*PackageDefinition
 	TemplateDir string
+
+	// OverrideTemplateDirs are the package template directories of the
+	// extra template directories, whose files override the ones of
+	// TemplateDir.
+	OverrideTemplateDirs []string
+
+	// DistroTemplateDirs are the distro-specific package template
+	// directories by distribution, whose files override the ones of
+	// TemplateDir and OverrideTemplateDirs.
+	DistroTemplateDirs map[string][]string
 }
 
 type PackageDefinition struct {
@@ -145,14 +368,30 @@ type PackageDefinition struct {
 
 	Channel ChannelType
 
+	// ChannelName is the published name of the channel, which is the
+	// channel itself unless it has been renamed via the channel names.
+	ChannelName string
+
+	// Distro is the distribution of distro-specific packages, which is
+	// empty for the generic ones.
+	Distro string
+
 	KubernetesVersion string
 	Dependencies      map[string]string
 
+	// PinnedDependencies are the dependency versions pinned for the
+	// channel, which override the minimum versions of Dependencies.
+	PinnedDependencies options.DependencyVersions
+
 	DownloadLinkBase         string
 	KubeadmKubeletConfigFile string
 
 	CNIVersion      string
 	CNIDownloadLink string
+
+	// CNIPlugin is the plugin of individual CNI plugin packages, which get
+	// built from the kubernetes-cni sources.
+	CNIPlugin string
 }
 
 type buildConfig struct {
@@ -165,6 +404,50 @@ type buildConfig struct {
 	TemplateDir string
 	workspace   string
 	specOnly    bool
+
+	// started is the start time of the build.
+	started time.Time
+
+	// log is the logger of the build, which prefixes all messages with
+	// the build name.
+	log *logrus.Entry
+
+	// WindowsInstallers are the binaries of all selected architectures,
+	// which are only populated for Windows package types.
+	WindowsInstallers []WindowsInstaller
+
+	// LocalArtifactsDir is the directory relative to the spec directory,
+	// which contains the binary if it gets packaged from the binary
+	// directory instead of being downloaded.
+	LocalArtifactsDir string
+
+	// Systemd are the customizations of the kubelet systemd unit.
+	Systemd options.SystemdOptions
+
+	// Hooks are the snippets added to the maintainer scripts of the package.
+	Hooks options.PackageHooks
+
+	// ReleaseNotes are the changelog entries of the package, which are empty
+	// if the default changelog should be used.
+	ReleaseNotes []string
+
+	// localBinary is the path of the binary within the binary directory.
+	localBinary string
+
+	// builder is the registered builder of custom build types, which is nil
+	// for the builtin ones.
+	builder Builder
+
+	// lintFindings are the findings of lintian or rpmlint for the built
+	// package.
+	lintFindings []LintFinding
+
+	// overrideTemplateDirs are the template directories overriding
+	// individual files of the TemplateDir.
+	overrideTemplateDirs []string
+
+	// funcs are the functions available within the templates.
+	funcs template.FuncMap
 }
 
 func (c *Client) ConstructBuilds() ([]Build, error) {
@@ -172,37 +455,105 @@ func (c *Client) ConstructBuilds() ([]Build, error) {
 
 	builds := []Build{}
 
-	for _, pkg := range c.options.Packages() {
+	if err := c.loadTemplateFuncs(); err != nil {
+		return nil, errors.Wrap(err, "loading template functions")
+	}
+
+	kubeVersions, err := c.resolveKubeVersions()
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving Kubernetes versions")
+	}
+	c.kubeVersions = kubeVersions
+
+	revision, err := c.resolveRevision()
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving package revision")
+	}
+	c.revision = revision
+
+	cniVersion, err := c.resolveLatestVersion(
+		c.options.CNIVersion(), cniOwner, cniRepo, MinimumCNIVersion,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving CNI version")
+	}
+	criToolsVersion, err := c.resolveLatestVersion(
+		c.options.CRIToolsVersion(), criToolsOwner, criToolsRepo, minimumCRIToolsVersion,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving CRI tools version")
+	}
+
+	for _, pkg := range c.packages() {
+		source, templatePackage := pkg, pkg
+		cniPlugin := c.cniPlugin(pkg)
+		if cniPlugin != "" {
+			source, templatePackage = cniPackage, cniPluginTemplatePackage
+		}
+
+		if !isSupportedPackage(c.options.BuildType(), source) {
+			logrus.Infof(
+				"Skipping package %s, which is not available as %s",
+				pkg, c.options.BuildType(),
+			)
+			continue
+		}
+
 		// TODO: Get package directory for any version once package definitions are broken out
-		packageTemplateDir := filepath.Join(c.options.TemplateDir(), string(c.options.BuildType()), pkg)
-		if _, err := os.Stat(packageTemplateDir); err != nil {
+		packageTemplateDirs, err := c.packageTemplateDirs(templatePackage)
+		if err != nil {
 			return nil, errors.Wrap(err, "finding package template dir")
 		}
 
 		b := &Build{
-			Type:        c.options.BuildType(),
-			Package:     pkg,
-			TemplateDir: packageTemplateDir,
+			Type:                 c.options.BuildType(),
+			Package:              pkg,
+			TemplateDir:          packageTemplateDirs[0],
+			OverrideTemplateDirs: packageTemplateDirs[1:],
+			DistroTemplateDirs:   map[string][]string{},
 		}
 
-		for _, channel := range c.options.Channels() {
-			packageDef := &PackageDefinition{
-				Revision: c.options.Revision(),
-				Channel:  ChannelType(channel),
+		distros := c.options.Distros()
+		if len(distros) == 0 {
+			distros = []string{""}
+		} else {
+			for _, distro := range distros {
+				b.DistroTemplateDirs[distro] = c.distroTemplateDirs(distro, templatePackage)
 			}
+		}
 
-			packageDef.KubernetesVersion = c.options.KubeVersion()
+		kubeVersions := c.kubeVersions
+		if len(kubeVersions) == 0 {
+			// The version gets resolved per channel
+			kubeVersions = []string{""}
+		}
 
-			switch b.Package {
-			case "kubelet":
-				packageDef.CNIVersion = c.options.CNIVersion()
-			case "kubernetes-cni":
-				packageDef.Version = c.options.CNIVersion()
-			case "cri-tools":
-				packageDef.Version = c.options.CRIToolsVersion()
+		for _, channel := range c.options.Channels() {
+			for _, kubeVersion := range kubeVersions {
+				for _, distro := range distros {
+					packageDef := &PackageDefinition{
+						Revision:    c.revision,
+						Channel:     ChannelType(channel),
+						ChannelName: c.options.ChannelName(channel),
+						Distro:      distro,
+						CNIPlugin:   cniPlugin,
+					}
+
+					packageDef.KubernetesVersion = kubeVersion
+
+					switch source {
+					case "kubelet":
+						packageDef.CNIVersion = cniVersion
+					case "kubernetes-cni":
+						packageDef.Version = cniVersion
+						packageDef.CNIVersion = cniVersion
+					case "cri-tools":
+						packageDef.Version = criToolsVersion
+					}
+
+					b.Definitions = append(b.Definitions, packageDef)
+				}
 			}
-
-			b.Definitions = append(b.Definitions, packageDef)
 		}
 
 		builds = append(builds, *b)
@@ -214,58 +565,336 @@ func (c *Client) ConstructBuilds() ([]Build, error) {
 
 func (c *Client) WalkBuilds(builds []Build) (err error) {
 	logrus.Infof("Walking builds...")
+	c.notify(NotifyStarted, nil)
+	stage := StageBuild
+	defer func() {
+		c.notifyFinished(err)
+		if err != nil {
+			c.fileIssue(stage, err)
+		}
+	}()
 
 	workingDir := os.Getenv("KUBEPKG_WORKING_DIR")
-	if workingDir == "" {
+	if workingDir == "" && c.options.SpecOnly() {
+		workingDir = filepath.Join(c.options.OutputDir(), specsDir)
+		if err := os.MkdirAll(workingDir, os.FileMode(0o755)); err != nil {
+			return errors.Wrapf(err, "creating specs directory %s", workingDir)
+		}
+	} else if workingDir == "" {
 		workingDir, err = os.MkdirTemp("", "kubepkg")
 		if err != nil {
 			return err
 		}
 	}
 
-	for _, arch := range c.options.Architectures() {
-		for _, build := range builds {
-			for _, packageDef := range build.Definitions {
-				if err := c.buildPackage(build, packageDef, arch, workingDir); err != nil {
-					return err
-				}
-			}
-		}
+	if err := c.checkBinaryDir(); err != nil {
+		return err
+	}
+
+	c.claimedBuildsMu.Lock()
+	c.claimedBuilds = map[string]bool{}
+	c.claimedBuildsMu.Unlock()
+
+	defer c.cleanupSigner()
+	defer c.cleanupDownloads()
+	if err := c.runBuildJobs(c.buildJobs(builds), workingDir); err != nil {
+		return err
 	}
 	if c.options.SpecOnly() {
 		logrus.Infof("Package specs have been saved in %s", workingDir)
+	} else if err := c.writeChecksums(); err != nil {
+		return errors.Wrap(err, "writing checksums")
+	} else if err := c.upload(); err != nil {
+		stage = StagePublish
+		return errors.Wrap(err, "uploading packages")
 	}
 	logrus.Infof("Successfully walked builds")
 	return nil
 }
 
-func (c *Client) buildPackage(build Build, packageDef *PackageDefinition, arch, tmpDir string) error {
+// buildJob is a single entry of the packages × channels × Kubernetes
+// versions × architectures build matrix.
+type buildJob struct {
+	build      Build
+	packageDef *PackageDefinition
+	arch       string
+
+	// versioned indicates that multiple Kubernetes versions get built,
+	// which means that the version becomes part of the job name.
+	versioned bool
+}
+
+// newBuildJob returns the job for the provided build, package definition and
+// architecture.
+func (c *Client) newBuildJob(
+	build Build, packageDef *PackageDefinition, arch string,
+) buildJob {
+	return buildJob{
+		build:      build,
+		packageDef: packageDef,
+		arch:       arch,
+		versioned:  len(c.kubeVersions) > 1,
+	}
+}
+
+// String returns the name of the job, which is used as log prefix.
+func (j *buildJob) String() string {
+	channel := ""
+	if j.packageDef != nil {
+		channel = string(j.packageDef.Channel)
+		if j.packageDef.Distro != "" {
+			channel += "/" + j.packageDef.Distro
+		}
+	}
+	name := fmt.Sprintf("%s/%s/%s", j.build.Package, channel, j.arch)
+	if j.versioned && j.packageDef != nil {
+		name += "@" + j.packageDef.KubernetesVersion
+	}
+	return name
+}
+
+// buildJobs returns the build matrix for the provided builds.
+func (c *Client) buildJobs(builds []Build) []buildJob {
+	architectures := c.options.Architectures()
+	if isWindowsType(c.options.BuildType()) {
+		// Windows packages cover all architectures at once
+		architectures = []string{windowsAllArch}
+	}
+
+	jobs := []buildJob{}
+	for _, arch := range architectures {
+		for _, build := range builds {
+			for _, packageDef := range build.Definitions {
+				jobs = append(jobs, c.newBuildJob(build, packageDef, arch))
+			}
+		}
+	}
+	return jobs
+}
+
+// runBuildJobs builds all provided jobs by using a pool of workers, whose
+// size is controlled by the concurrency option. All jobs get prepared first,
+// which allows downloading the sources of all builds in parallel while the
+// packages are built. Failed builds do not stop the remaining ones, their
+// errors get aggregated instead.
+func (c *Client) runBuildJobs(jobs []buildJob, workingDir string) error {
+	workers := c.options.Concurrency()
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+	logrus.Infof("Running %d builds using %d worker(s)", len(jobs), workers)
+
+	// Every job writes its own slots, which keeps the aggregated errors in
+	// the same order as the build matrix.
+	jobErrors := make([]error, len(jobs))
+	results := make([]BuildResult, len(jobs))
+	configs := make([]*buildConfig, len(jobs))
+	durations := make([]time.Duration, len(jobs))
+	finish := func(i int, status BuildStatus, err error) {
+		job := &jobs[i]
+		results[i] = BuildResult{
+			Name:     job.String(),
+			Status:   status,
+			Duration: durations[i].Seconds(),
+		}
+		if err != nil {
+			logrus.WithField(buildField, job.String()).Errorf("Build failed: %v", err)
+			jobErrors[i] = WithCategory(errors.Wrapf(err, "build %s", job), ErrorCategoryBuild)
+			results[i].Error = err.Error()
+			results[i].Category = ErrorCategoryOf(jobErrors[i])
+		}
+	}
+
+	runWorkers(len(jobs), workers, func(i int) {
+		job := &jobs[i]
+		started := time.Now()
+		bc, status, err := c.prepareBuild(
+			job.build, job.packageDef, job.arch, workingDir,
+		)
+		durations[i] = time.Since(started)
+		if bc == nil || err != nil {
+			finish(i, status, err)
+			return
+		}
+		configs[i] = bc
+	})
+
+	c.prefetchSources(configs)
+
+	runWorkers(len(jobs), workers, func(i int) {
+		bc := configs[i]
+		if bc == nil {
+			return
+		}
+		started := time.Now()
+		status, err := c.buildPackage(bc)
+		durations[i] += time.Since(started)
+		finish(i, status, err)
+	})
+	c.addBuildResults(results)
+
+	failed := []error{}
+	for _, err := range jobErrors {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	switch len(failed) {
+	case 0:
+		return nil
+	case 1:
+		return failed[0]
+	}
+
+	messages := make([]string, 0, len(failed))
+	for _, err := range failed {
+		messages = append(messages, err.Error())
+	}
+	return WithCategory(errors.Errorf(
+		"%d of %d builds failed:\n%s",
+		len(failed), len(jobs), strings.Join(messages, "\n"),
+	), aggregatedCategory(failed))
+}
+
+// runWorkers calls fn for every index up to n by using the provided number of
+// parallel workers and waits until all calls are done.
+func runWorkers(n, workers int, fn func(i int)) {
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// prepareBuild resolves the build config of a single package. It returns a
+// nil build config together with the resulting status if the build has to be
+// skipped or failed.
+func (c *Client) prepareBuild(
+	build Build, packageDef *PackageDefinition, arch, tmpDir string,
+) (*buildConfig, BuildStatus, error) {
+	bc, err := c.newBuildConfig(build, packageDef, arch, tmpDir)
+	if err != nil {
+		return nil, BuildFailed, err
+	}
+	if bc == nil {
+		return nil, BuildSkipped, nil
+	}
+
+	// Multiple Kubernetes versions or channels can result in the same
+	// package, for example for kubernetes-cni, which gets built only once.
+	if !c.claimBuild(bc) {
+		bc.log.Infof(
+			"Skipping %s, which is already built by another job",
+			packageFileName(bc),
+		)
+		return nil, BuildSkipped, nil
+	}
+
+	existing, err := c.existingPackage(bc)
+	if err != nil {
+		return nil, BuildFailed, err
+	}
+	if existing {
+		return nil, BuildSkipped, nil
+	}
+	return bc, "", nil
+}
+
+// buildPackage builds a single prepared package and returns the resulting
+// status of the build.
+func (c *Client) buildPackage(bc *buildConfig) (BuildStatus, error) {
+	bc.started = time.Now()
+	bc.log.Infof("Building %s package for %s/%s architecture...", bc.Package, bc.GoArch, bc.BuildArch)
+	if err := c.run(bc); err != nil {
+		return BuildFailed, err
+	}
+	bc.log.Infof("Build finished in %s", roundDuration(time.Since(bc.started)))
+	return BuildSucceeded, nil
+}
+
+// claimBuild returns true if no other job of the current walk builds the
+// same package for the same channel.
+func (c *Client) claimBuild(bc *buildConfig) bool {
+	key := fmt.Sprintf("%s/%s/%s", bc.channelDir(), bc.GoArch, packageFileName(bc))
+
+	c.claimedBuildsMu.Lock()
+	defer c.claimedBuildsMu.Unlock()
+	if c.claimedBuilds == nil {
+		c.claimedBuilds = map[string]bool{}
+	}
+	if c.claimedBuilds[key] {
+		return false
+	}
+	c.claimedBuilds[key] = true
+	return true
+}
+
+// newBuildConfig resolves all versions and download locations of the
+// provided build. It returns a nil build config if the build has to be
+// skipped because the architecture is not available for the package type.
+func (c *Client) newBuildConfig(
+	build Build, packageDef *PackageDefinition, arch, tmpDir string,
+) (*buildConfig, error) {
 	if packageDef == nil {
-		return errors.New("package definition cannot be nil")
+		return nil, errors.New("package definition cannot be nil")
 	}
 
 	pd := &PackageDefinition{}
 	*pd = *packageDef
+	job := c.newBuildJob(build, packageDef, arch)
+
+	// Distro-specific templates take precedence over all generic ones
+	overrideTemplateDirs := append(
+		append([]string{}, build.OverrideTemplateDirs...),
+		build.DistroTemplateDirs[packageDef.Distro]...,
+	)
 
 	bc := &buildConfig{
-		PackageDefinition: pd,
-		Type:              build.Type,
-		Package:           build.Package,
-		GoArch:            arch,
-		TemplateDir:       build.TemplateDir,
-		workspace:         tmpDir,
-		specOnly:          c.options.SpecOnly(),
+		PackageDefinition:    pd,
+		Type:                 build.Type,
+		Package:              build.Package,
+		GoArch:               arch,
+		TemplateDir:          build.TemplateDir,
+		funcs:                c.templateFuncsMap(),
+		overrideTemplateDirs: overrideTemplateDirs,
+		workspace:            tmpDir,
+		specOnly:             c.options.SpecOnly(),
+		Systemd:              c.options.Systemd(),
+		started:              time.Now(),
+		builder:              c.builder(build.Type),
+		log: logrus.WithField(
+			buildField, job.String(),
+		),
 	}
 
 	bc.Name = build.Package
+	hooks := c.options.Hooks()[build.Package]
+	bc.Hooks = options.PackageHooks{
+		PostInstall: strings.TrimSpace(hooks.PostInstall),
+		PreRemove:   strings.TrimSpace(hooks.PreRemove),
+	}
 
 	var err error
 
 	if bc.KubernetesVersion != "" {
-		logrus.Infof("Checking if user-supplied Kubernetes version (%s) is valid semver...", bc.KubernetesVersion)
+		bc.log.Infof("Checking if user-supplied Kubernetes version (%s) is valid semver...", bc.KubernetesVersion)
 		kubeSemver, err := util.TagStringToSemver(bc.KubernetesVersion)
 		if err != nil {
-			return errors.Wrap(err, "user-supplied Kubernetes version is not valid semver")
+			return nil, errors.Wrap(err, "user-supplied Kubernetes version is not valid semver")
 		}
 
 		kubeVersionString := kubeSemver.String()
@@ -273,31 +902,36 @@ func (c *Client) buildPackage(build Build, packageDef *PackageDefinition, arch,
 
 		switch {
 		case len(kubeVersionParts) > 4:
-			logrus.Info("User-supplied Kubernetes version is a CI version")
-			logrus.Info("Setting channel to nightly")
+			bc.log.Info("User-supplied Kubernetes version is a CI version")
+			bc.log.Info("Setting channel to nightly")
 			bc.Channel = ChannelNightly
 		case len(kubeVersionParts) == 4:
-			logrus.Info("User-supplied Kubernetes version is a pre-release version")
-			logrus.Info("Setting channel to testing")
+			bc.log.Info("User-supplied Kubernetes version is a pre-release version")
+			bc.log.Info("Setting channel to testing")
 			bc.Channel = ChannelTesting
 		default:
-			logrus.Info("User-supplied Kubernetes version is a release version")
-			logrus.Info("Setting channel to release")
+			bc.log.Info("User-supplied Kubernetes version is a release version")
+			bc.log.Info("Setting channel to release")
 			bc.Channel = ChannelRelease
 		}
 	}
+	bc.ChannelName = c.options.ChannelName(string(bc.Channel))
+
+	c.pinDependencies(pd)
 
 	bc.KubernetesVersion, err = c.GetKubernetesVersion(pd)
 	if err != nil {
-		return errors.Wrap(err, "getting Kubernetes version")
+		return nil, errors.Wrap(err, "getting Kubernetes version")
 	}
 
 	bc.DownloadLinkBase, err = c.GetDownloadLinkBase(pd)
 	if err != nil {
-		return errors.Wrap(err, "getting Kubernetes download link base")
+		return nil, errors.Wrap(err, "getting Kubernetes download link base")
 	}
 
-	logrus.Infof("Kubernetes download link base: %s", bc.DownloadLinkBase)
+	bc.log.Infof("Kubernetes download link base: %s", bc.DownloadLinkBase)
+
+	c.resolveDependencyVersions(bc)
 
 	// For cases where a CI build version of Kubernetes is retrieved, replace instances
 	// of "+" with "-", so that we build with a valid Debian package version.
@@ -305,32 +939,84 @@ func (c *Client) buildPackage(build Build, packageDef *PackageDefinition, arch,
 
 	bc.Version, err = c.GetPackageVersion(pd)
 	if err != nil {
-		return errors.Wrap(err, "getting package version")
+		return nil, errors.Wrap(err, "getting package version")
 	}
 
-	logrus.Infof("%s package version: %s", bc.Name, bc.Version)
+	bc.log.Infof("%s package version: %s", bc.Name, bc.Version)
 
 	bc.Dependencies, err = GetDependencies(pd)
 	if err != nil {
-		return errors.Wrap(err, "getting dependencies")
+		return nil, errors.Wrap(err, "getting dependencies")
 	}
 
 	bc.KubeadmKubeletConfigFile = kubeadmConf
 
 	bc.BuildArch = getBuildArch(bc.GoArch, bc.Type)
+	if bc.builder != nil {
+		bc.BuildArch = bc.builder.BuildArch(bc.GoArch)
+	}
+	if isWindowsType(bc.Type) {
+		bc.BuildArch = windowsAllArch
+		bc.WindowsInstallers, err = c.windowsInstallers(bc)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting Windows installers")
+		}
+	}
+	if bc.BuildArch == "" {
+		bc.log.Infof(
+			"Skipping %s package for %s, which is not available as %s",
+			bc.Package, bc.GoArch, bc.Type,
+		)
+		return nil, nil
+	}
+
+	bc.localBinary, err = c.localBinary(bc.Package, bc.GoArch)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding local binary")
+	}
+	if bc.localBinary != "" || c.cachesDebBinary(bc) {
+		bc.LocalArtifactsDir = localArtifactsDir
+	}
+
+	bc.ReleaseNotes = c.releaseNotes(bc)
 
 	bc.CNIVersion, err = GetCNIVersion(pd)
 	if err != nil {
-		return errors.Wrap(err, "getting CNI version")
+		return nil, errors.Wrap(err, "getting CNI version")
 	}
 
 	bc.CNIDownloadLink, err = GetCNIDownloadLink(pd.Version, bc.GoArch)
 	if err != nil {
-		return errors.Wrap(err, "getting CNI download link")
+		return nil, errors.Wrap(err, "getting CNI download link")
+	}
+
+	return bc, nil
+}
+
+// pinDependencies applies the dependency versions pinned for the channel of
+// the provided package definition.
+func (c *Client) pinDependencies(pd *PackageDefinition) {
+	pinned, ok := c.options.ChannelDependencies()[string(pd.Channel)]
+	if !ok {
+		return
 	}
+	pd.PinnedDependencies = pinned
 
-	logrus.Infof("Building %s package for %s/%s architecture...", bc.Package, bc.GoArch, bc.BuildArch)
-	return c.run(bc)
+	switch sourcePackage(pd) {
+	case "kubelet":
+		if pinned.CNIVersion != "" {
+			pd.CNIVersion = pinned.CNIVersion
+		}
+	case "kubernetes-cni":
+		if pinned.CNIVersion != "" {
+			pd.Version = pinned.CNIVersion
+			pd.CNIVersion = pinned.CNIVersion
+		}
+	case "cri-tools":
+		if pinned.CRIToolsVersion != "" {
+			pd.Version = pinned.CRIToolsVersion
+		}
+	}
 }
 
 func (c *Client) run(bc *buildConfig) error {
@@ -339,7 +1025,7 @@ func (c *Client) run(bc *buildConfig) error {
 		return err
 	}
 
-	specDir := filepath.Join(bc.workspace, string(bc.Channel), bc.Package)
+	specDir := filepath.Join(bc.workspace, bc.channelDir(), bc.Package)
 	specDirWithArch := filepath.Join(specDir, bc.GoArch)
 
 	if err := os.MkdirAll(specDirWithArch, workspaceInfo.Mode()); err != nil {
@@ -351,21 +1037,80 @@ func (c *Client) run(bc *buildConfig) error {
 		defer os.RemoveAll(specDirWithArch)
 	}
 
-	if _, err := buildSpecs(bc, specDirWithArch); err != nil {
+	if err := bc.step("rendering specs", func() error {
+		_, err := buildSpecs(bc, specDirWithArch)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := bc.step("staging sources", func() error {
+		return c.stageSources(bc, specDirWithArch)
+	}); err != nil {
 		return err
 	}
 
 	if bc.specOnly {
-		logrus.Info("Spec-only mode was selected; kubepkg will now exit without building packages")
+		bc.log.Info("Spec-only mode was selected; kubepkg will now exit without building packages")
 		return nil
 	}
 
+	preBuildHooks, postBuildHooks := c.buildHooks()
+	if err := c.runBuildHooks(
+		bc, specDirWithArch, "running pre-build hooks", preBuildHooks,
+	); err != nil {
+		return err
+	}
+
+	if err := bc.step("building package", func() error {
+		return c.buildPackageFiles(bc, specDir, specDirWithArch)
+	}); err != nil {
+		return err
+	}
+
+	return c.runBuildHooks(
+		bc, specDirWithArch, "running post-build hooks", postBuildHooks,
+	)
+}
+
+// stageSources puts the binaries required by the package build into the
+// spec directory, unless the build tool retrieves them by itself.
+func (c *Client) stageSources(bc *buildConfig, specDirWithArch string) error {
+	switch {
+	case c.usesNfpm():
+		// nfpm retrieves all sources by itself
+	case bc.localBinary != "":
+		if err := c.stageLocalBinary(bc, specDirWithArch); err != nil {
+			return errors.Wrap(err, "staging local binary")
+		}
+	case c.cachesDebBinary(bc):
+		if err := c.download(
+			bc, sourceURL(bc), localArtifactPath(bc, specDirWithArch),
+		); err != nil {
+			return errors.Wrap(err, "downloading binary")
+		}
+	}
+	return nil
+}
+
+// buildPackageFiles runs the package build tool of the build type on the
+// rendered specs.
+func (c *Client) buildPackageFiles(bc *buildConfig, specDir, specDirWithArch string) error {
+	if bc.builder != nil {
+		return c.runBuilder(bc, specDirWithArch)
+	}
+	if c.usesNfpm() {
+		return c.runNfpm(bc, specDirWithArch)
+	}
+
 	// TODO: Move OS-specific logic into their own files
 	switch bc.Type {
 	case options.BuildDeb:
-		logrus.Infof("Running dpkg-buildpackage for %s (%s/%s)", bc.Package, bc.GoArch, bc.BuildArch)
+		bc.log.Infof("Running dpkg-buildpackage for %s (%s/%s)", bc.Package, bc.GoArch, bc.BuildArch)
 
-		if err := c.impl.RunSuccessWithWorkDir(
+		if err := c.runBuildCommand(
+			bc,
+			specDir,
 			specDirWithArch,
 			"dpkg-buildpackage",
 			"--unsigned-source",
@@ -377,47 +1122,194 @@ func (c *Client) run(bc *buildConfig) error {
 			return errors.Wrap(err, "running debian package build")
 		}
 
-		fileName := fmt.Sprintf(
-			"%s_%s-%s_%s.deb",
-			bc.Package,
-			bc.Version,
-			bc.Revision,
-			bc.BuildArch,
-		)
+		return c.copyPackage(bc, filepath.Join(specDir, packageFileName(bc)))
+	case options.BuildArchLinux:
+		return c.runMakepkg(bc, specDir, specDirWithArch)
+	case options.BuildSnap:
+		return c.runSnapcraft(bc, specDir, specDirWithArch)
+	case options.BuildChocolatey:
+		return c.runChocolatey(bc, specDir, specDirWithArch)
+	case options.BuildWinget:
+		return c.copyWingetManifests(bc, specDirWithArch)
+	case options.BuildMsi:
+		return c.runWixl(bc, specDir, specDirWithArch)
+	case options.BuildRpm:
+		bc.log.Info("Building rpms via kubepkg is not currently supported")
+	}
+
+	return nil
+}
 
-		dstPath := filepath.Join("bin", string(bc.Channel), fileName)
-		logrus.Infof("Using package destination path %s", dstPath)
+// outputPath returns the destination of the provided file of the build in
+// the output directory.
+func (c *Client) outputPath(bc *buildConfig, fileName string) string {
+	return filepath.Join(c.options.OutputDir(), bc.channelDir(), fileName)
+}
 
-		if err := os.MkdirAll(filepath.Dir(dstPath), os.FileMode(0o777)); err != nil {
-			return errors.Wrapf(err, "creating %s", filepath.Dir(dstPath))
-		}
+// channelDir returns the relative directory of the build's packages, which
+// is the published channel name for generic packages and channel/distro for
+// distro-specific ones.
+func (bc *buildConfig) channelDir() string {
+	channel := bc.ChannelName
+	if channel == "" {
+		channel = string(bc.Channel)
+	}
+	return filepath.Join(channel, bc.Distro)
+}
 
-		srcPath := filepath.Join(specDir, fileName)
-		input, err := c.impl.ReadFile(srcPath)
-		if err != nil {
-			return errors.Wrapf(err, "reading %s", srcPath)
-		}
+// existingPackage returns true if the package of the build already exists in
+// the output directory, which allows resuming a partially failed run. The
+// existing package gets recorded as artifact to keep the summary and the
+// checksums complete.
+func (c *Client) existingPackage(bc *buildConfig) (bool, error) {
+	// Winget builds produce multiple manifests instead of a single package
+	if c.options.Force() || bc.specOnly || bc.Type == options.BuildWinget {
+		return false, nil
+	}
 
-		err = c.impl.WriteFile(dstPath, input, os.FileMode(0o644))
-		if err != nil {
-			return errors.Wrapf(err, "writing file to %s", dstPath)
+	path := c.outputPath(bc, packageFileName(bc))
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
 		}
+		return false, errors.Wrapf(err, "checking for existing package %s", path)
+	}
 
-		logrus.Infof("Successfully built %s", dstPath)
-	case options.BuildRpm:
-		logrus.Info("Building rpms via kubepkg is not currently supported")
+	content, err := c.impl.ReadFile(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "reading existing package %s", path)
+	}
+	c.addArtifact(bc, path, content)
+
+	bc.log.Infof("Skipping build because %s already exists (use --force to rebuild it)", path)
+	return true, nil
+}
+
+// copyPackage copies the built package from srcPath into its destination
+// directory.
+func (c *Client) copyPackage(bc *buildConfig, srcPath string) error {
+	dstPath := c.outputPath(bc, filepath.Base(srcPath))
+	bc.log.Infof("Using package destination path %s", dstPath)
+
+	if err := os.MkdirAll(filepath.Dir(dstPath), os.FileMode(0o777)); err != nil {
+		return errors.Wrapf(err, "creating %s", filepath.Dir(dstPath))
+	}
+
+	if err := c.signPackage(bc, srcPath); err != nil {
+		return err
+	}
+
+	if err := c.validateBuiltPackage(bc, srcPath); err != nil {
+		return err
+	}
+
+	if err := c.lintBuiltPackage(bc, srcPath); err != nil {
+		return err
+	}
+
+	input, err := c.impl.ReadFile(srcPath)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", srcPath)
+	}
+
+	if err := c.impl.WriteFile(dstPath, input, os.FileMode(0o644)); err != nil {
+		return errors.Wrapf(err, "writing file to %s", dstPath)
+	}
+	c.addArtifact(bc, dstPath, input)
+
+	if err := c.writeProvenance(bc, dstPath, input); err != nil {
+		return err
 	}
 
+	bc.log.Infof("Successfully built %s", dstPath)
+	return nil
+}
+
+// copyFile copies src to dst, whereas the directory of dst gets created if
+// required.
+func (c *Client) copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "creating %s", filepath.Dir(dst))
+	}
+
+	content, err := c.impl.ReadFile(src)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", src)
+	}
+
+	if err := c.impl.WriteFile(dst, content, os.FileMode(0o644)); err != nil {
+		return errors.Wrapf(err, "writing %s", dst)
+	}
 	return nil
 }
 
+// packageFileName returns the file name of the package described by the
+// build config.
+func packageFileName(bc *buildConfig) string {
+	if bc.builder != nil {
+		return bc.builder.PackageFileName(newBuildContext(bc, ""))
+	}
+
+	switch bc.Type {
+	case options.BuildChocolatey:
+		return fmt.Sprintf("%s.%s.nupkg", bc.Package, bc.Version)
+	case options.BuildMsi:
+		return fmt.Sprintf(
+			"%s-%s-%s-%s.msi",
+			bc.Package,
+			bc.Version,
+			bc.Revision,
+			bc.BuildArch,
+		)
+	case options.BuildSnap:
+		return fmt.Sprintf(
+			"%s_%s_%s.snap",
+			bc.Package,
+			bc.Version,
+			bc.BuildArch,
+		)
+	case options.BuildArchLinux:
+		return fmt.Sprintf(
+			"%s-%s-%s-%s.pkg.tar.zst",
+			bc.Package,
+			archLinuxVersion(bc.Version),
+			bc.Revision,
+			bc.BuildArch,
+		)
+	case options.BuildApk:
+		return fmt.Sprintf(
+			"%s-%s-r%s.%s.apk",
+			bc.Package,
+			bc.Version,
+			bc.Revision,
+			bc.BuildArch,
+		)
+	case options.BuildRpm:
+		return fmt.Sprintf(
+			"%s-%s-%s.%s.rpm",
+			bc.Package,
+			bc.Version,
+			bc.Revision,
+			bc.BuildArch,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s_%s-%s_%s.deb",
+		bc.Package,
+		bc.Version,
+		bc.Revision,
+		bc.BuildArch,
+	)
+}
+
 func (c *Client) GetPackageVersion(packageDef *PackageDefinition) (string, error) {
 	if packageDef == nil {
 		return "", errors.New("package definition cannot be nil")
 	}
 
 	logrus.Infof("Setting version for %s package...", packageDef.Name)
-	switch packageDef.Name {
+	switch sourcePackage(packageDef) {
 	case "kubernetes-cni":
 		return GetCNIVersion(packageDef)
 	case "cri-tools":
@@ -431,6 +1323,40 @@ func (c *Client) GetPackageVersion(packageDef *PackageDefinition) (string, error
 	return util.TrimTagPrefix(packageDef.KubernetesVersion), nil
 }
 
+// resolveKubeVersions returns the Kubernetes versions to be built, where
+// version markers like stable-1.28 are replaced by the version they point to.
+// Markers resolving to the same version result in a single version.
+func (c *Client) resolveKubeVersions() ([]string, error) {
+	kubeVersions := []string{}
+	seen := map[string]bool{}
+	for _, kubeVersion := range c.options.KubeVersions() {
+		if options.IsKubeVersionMarker(kubeVersion) {
+			resolved, err := c.impl.GetKubeVersion(kubeVersionMarkerType(kubeVersion))
+			if err != nil {
+				return nil, errors.Wrapf(err, "resolving version marker %s", kubeVersion)
+			}
+			logrus.Infof("Resolved version marker %s to Kubernetes %s", kubeVersion, resolved)
+			kubeVersion = resolved
+		}
+		kubeVersion = util.TrimTagPrefix(kubeVersion)
+		if seen[kubeVersion] {
+			continue
+		}
+		seen[kubeVersion] = true
+		kubeVersions = append(kubeVersions, kubeVersion)
+	}
+	return kubeVersions, nil
+}
+
+// kubeVersionMarkerType returns the version type of the marker. Markers
+// without a bucket path like stable-1.28 refer to release versions.
+func kubeVersionMarkerType(marker string) release.VersionType {
+	if !strings.Contains(marker, "/") {
+		marker = "release/" + marker
+	}
+	return release.VersionType(marker)
+}
+
 func (c *Client) GetKubernetesVersion(packageDef *PackageDefinition) (string, error) {
 	if packageDef == nil {
 		return "", errors.New("package definition cannot be nil")
@@ -520,36 +1446,19 @@ func (c *Client) GetCRIToolsVersion(packageDef *PackageDefinition) (string, erro
 
 	criToolsVersion := fmt.Sprintf("%s.%s.0", criToolsMajor, criToolsMinor)
 
-	releases, err := c.impl.Releases("kubernetes-sigs", "cri-tools", false)
+	criToolsMinorInt, err := strconv.Atoi(criToolsMinor)
 	if err != nil {
 		return "", err
 	}
-
-	var tags []string
-	for _, release := range releases {
-		criToolsReleaseTag := util.TrimTagPrefix(*release.TagName)
-		criToolsReleaseVersionParts := strings.Split(criToolsReleaseTag, ".")
-		criToolsReleaseMinor := criToolsReleaseVersionParts[1]
-
-		if criToolsReleaseMinor == criToolsMinor {
-			tags = append(tags, criToolsReleaseTag)
-		}
+	latestTag, err := c.impl.LatestTag(
+		criToolsOwner, criToolsRepo,
+		fmt.Sprintf(">=%s <%s.%d.0", criToolsVersion, criToolsMajor, criToolsMinorInt+1),
+	)
+	if err != nil && !errors.Is(err, github.ErrNoMatchingTag) {
+		return "", errors.Wrap(err, "getting latest CRI tools release")
 	}
-
-	for _, tag := range tags {
-		tagSemver, err := semver.Parse(tag)
-		if err != nil {
-			return "", errors.Wrap(err, "could not parse tag semver")
-		}
-
-		criToolsSemver, err := semver.Parse(criToolsVersion)
-		if err != nil {
-			return "", errors.Wrap(err, "could not parse CRI tools semver")
-		}
-
-		if tagSemver.GTE(criToolsSemver) {
-			criToolsVersion = tag
-		}
+	if latestTag != "" {
+		criToolsVersion = util.TrimTagPrefix(latestTag)
 	}
 
 	logrus.Infof("Setting CRI tools version to %s", criToolsVersion)
@@ -614,9 +1523,37 @@ func GetDependencies(packageDef *PackageDefinition) (map[string]string, error) {
 		deps["cri-tools"] = minimumCRIToolsVersion
 	}
 
+	pinned := packageDef.PinnedDependencies
+	for dep, version := range map[string]string{
+		"kubernetes-cni": pinned.CNIVersion,
+		"cri-tools":      pinned.CRIToolsVersion,
+	} {
+		if _, ok := deps[dep]; ok && version != "" {
+			deps[dep] = util.TrimTagPrefix(version)
+		}
+	}
+	if packageDef.Name == "kubelet" && pinned.ConntrackVersion != "" {
+		deps["conntrack"] = util.TrimTagPrefix(pinned.ConntrackVersion)
+	}
+
 	return deps, nil
 }
 
+// isSupportedPackage returns true if the package can be built for the
+// provided build type.
+func isSupportedPackage(buildType options.BuildType, pkg string) bool {
+	packages, ok := typePackages[buildType]
+	if !ok {
+		return true
+	}
+	for _, p := range packages {
+		if pkg == p {
+			return true
+		}
+	}
+	return false
+}
+
 func getBuildArch(goArch string, buildType options.BuildType) string {
 	return buildArchMap[goArch][buildType]
 }
@@ -628,3 +1565,7 @@ func GetCNIDownloadLink(version, arch string) (string, error) {
 
 	return fmt.Sprintf("https://storage.googleapis.com/k8s-artifacts-cni/release/v%s/cni-plugins-linux-%s-v%s.tgz", version, arch, version), nil
 }
+
+func GetCRIToolsDownloadLink(version, arch string) string {
+	return fmt.Sprintf("https://storage.googleapis.com/k8s-artifacts-cri-tools/release/v%s/crictl-v%s-linux-%s.tar.gz", version, version, arch)
+}
@@ -19,11 +19,13 @@ package kubepkg_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
+	"k8s.io/release/pkg/github"
 	"k8s.io/release/pkg/kubepkg"
 	"k8s.io/release/pkg/kubepkg/kubepkgfakes"
 	"k8s.io/release/pkg/kubepkg/options"
@@ -39,6 +41,9 @@ func newSUT(opts *options.Options) (*kubepkg.Client, *kubepkgfakes.FakeImpl) {
 	sut := kubepkg.New(opts)
 
 	implMock := &kubepkgfakes.FakeImpl{}
+	implMock.DownloadFileStub = func(_, dst string) error {
+		return os.WriteFile(dst, []byte("download"), 0o644)
+	}
 	sut.SetImpl(implMock)
 
 	return sut, implMock
@@ -60,8 +65,12 @@ func sutWithTemplateDir(
 		WithKubeVersion("v1.18.0")
 	sut, mock = newSUT(opts)
 
+	typeDir := string(buildType)
+	if opts.Backend() == options.BackendNfpm || buildType == options.BuildApk {
+		typeDir = "nfpm"
+	}
 	for _, dir := range opts.Packages() {
-		pkgPath := filepath.Join(tempDir, string(buildType), dir)
+		pkgPath := filepath.Join(tempDir, typeDir, dir)
 		require.Nil(t, os.MkdirAll(pkgPath, 0755))
 	}
 	return sut, cleanup, mock
@@ -115,6 +124,355 @@ func TestWalkBuildsSuccessRPMSpecOnly(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestWalkBuildsSuccessOutputDir(t *testing.T) {
+	outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+	require.Nil(t, err)
+	defer os.RemoveAll(outputDir)
+
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithOutputDir(outputDir)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	dst, _, _ := mock.WriteFileArgsForCall(0)
+	require.Equal(t,
+		filepath.Join(outputDir, "release", "kubeadm_1.18.0-0_amd64.deb"), dst,
+	)
+}
+
+func TestWalkBuildsSuccessExistingPackage(t *testing.T) {
+	for _, force := range []bool{false, true} {
+		outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+		require.Nil(t, err)
+		defer os.RemoveAll(outputDir)
+
+		existing := filepath.Join(outputDir, "release", "kubeadm_1.18.0-0_amd64.deb")
+		require.Nil(t, os.MkdirAll(filepath.Dir(existing), os.FileMode(0o755)))
+		require.Nil(t, os.WriteFile(existing, []byte("content"), os.FileMode(0o644)))
+
+		opts := options.New().
+			WithPackages("kubeadm").
+			WithChannels("release").
+			WithArchitectures("amd64", "arm64").
+			WithOutputDir(outputDir).
+			WithForce(force)
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+		defer cleanup()
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		require.Nil(t, sut.WalkBuilds(builds))
+
+		summary := sut.Summary()
+		require.Len(t, summary.Artifacts, 2)
+		if force {
+			require.Equal(t, 2, mock.RunSuccessWithWorkDirCallCount())
+			require.Equal(t, kubepkg.BuildSucceeded, summary.Builds[0].Status)
+			continue
+		}
+
+		// Only the missing arm64 package gets built
+		require.Equal(t, 1, mock.RunSuccessWithWorkDirCallCount())
+		dst, _, _ := mock.WriteFileArgsForCall(0)
+		require.Equal(t,
+			filepath.Join(outputDir, "release", "kubeadm_1.18.0-0_arm64.deb"), dst,
+		)
+		require.Equal(t, kubepkg.BuildSkipped, summary.Builds[0].Status)
+		require.Equal(t, kubepkg.BuildSucceeded, summary.Builds[1].Status)
+		require.Equal(t, existing, summary.Artifacts[0].Path)
+	}
+}
+
+func TestWalkBuildsSuccessOutputDirSpecOnly(t *testing.T) {
+	outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+	require.Nil(t, err)
+	defer os.RemoveAll(outputDir)
+
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithOutputDir(outputDir).
+		WithSpecOnly(true)
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildRpm)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	require.DirExists(t, filepath.Join(outputDir, "specs", "release", "kubeadm"))
+}
+
+func TestWalkBuildsSuccessKubeVersions(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubelet", "kubernetes-cni").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	opts.WithKubeVersions("v1.18.0", "v1.18.1")
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Len(t, builds[0].Definitions, 2)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// kubernetes-cni does not depend on the Kubernetes version and gets
+	// built only once
+	require.Equal(t, 3, mock.RunSuccessWithWorkDirCallCount())
+	packages := []string{}
+	for i := 0; i < mock.ReadFileCallCount(); i++ {
+		packages = append(packages, filepath.Base(mock.ReadFileArgsForCall(i)))
+	}
+	require.ElementsMatch(t, []string{
+		"kubelet_1.18.0-0_amd64.deb",
+		"kubelet_1.18.1-0_amd64.deb",
+		"kubernetes-cni_0.8.6-0_amd64.deb",
+	}, packages)
+}
+
+func TestWalkBuildsSuccessChannelNames(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithChannelNames(map[string]string{
+			"release": "stable", "testing": "unstable",
+		})
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	opts.WithKubeVersions("v1.18.0", "v1.19.0-rc.1")
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// The pre-release gets published in the renamed testing channel
+	channels := map[kubepkg.ChannelType]string{}
+	for _, artifact := range sut.Summary().Artifacts {
+		channels[artifact.Channel] = filepath.Base(filepath.Dir(artifact.Path))
+	}
+	require.Equal(t, map[kubepkg.ChannelType]string{
+		"stable":   "stable",
+		"unstable": "unstable",
+	}, channels)
+}
+
+func TestConstructBuildsSuccessKubeVersionMarkers(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	opts.WithKubeVersions("stable-1.21", "v1.22.1", "stable")
+	mock.GetKubeVersionReturnsOnCall(0, "v1.21.14", nil)
+	mock.GetKubeVersionReturnsOnCall(1, "v1.22.1", nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Equal(t, 2, mock.GetKubeVersionCallCount())
+	require.EqualValues(t, "release/stable-1.21", mock.GetKubeVersionArgsForCall(0))
+	require.EqualValues(t, "release/stable", mock.GetKubeVersionArgsForCall(1))
+
+	// Markers resolving to an explicitly requested version are built once
+	require.Len(t, builds[0].Definitions, 2)
+	require.Equal(t, "1.21.14", builds[0].Definitions[0].KubernetesVersion)
+	require.Equal(t, "1.22.1", builds[0].Definitions[1].KubernetesVersion)
+}
+
+func TestConstructBuildsFailureKubeVersionMarker(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	opts.WithKubeVersions("ci/latest-1.99")
+	mock.GetKubeVersionReturns("", errors.New("not found"))
+
+	_, err := sut.ConstructBuilds()
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "resolving version marker ci/latest-1.99")
+	require.EqualValues(t, "ci/latest-1.99", mock.GetKubeVersionArgsForCall(0))
+}
+
+func TestWalkBuildsSuccessRiscv64(t *testing.T) {
+	for _, tc := range []struct {
+		buildType   options.BuildType
+		backend     options.Backend
+		expectedURL string
+		expected    string
+	}{
+		{
+			buildType: options.BuildDeb,
+			backend:   options.BackendNative,
+			expected:  "kubelet_1.18.0-0_riscv64.deb",
+		},
+		{
+			buildType:   options.BuildRpm,
+			backend:     options.BackendNfpm,
+			expectedURL: "https://dl.k8s.io/v1.18.0/bin/linux/riscv64/kubelet",
+			expected:    "kubelet-1.18.0-0.riscv64.rpm",
+		},
+	} {
+		opts := options.New().
+			WithBackend(tc.backend).
+			WithPackages("kubelet").
+			WithChannels("release").
+			WithArchitectures("riscv64")
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, tc.buildType)
+		defer cleanup()
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		require.Nil(t, sut.WalkBuilds(builds))
+
+		require.Equal(t, 1, mock.RunSuccessWithWorkDirCallCount())
+		if tc.expectedURL != "" {
+			url, _ := mock.DownloadFileArgsForCall(0)
+			require.Equal(t, tc.expectedURL, url)
+		} else {
+			_, _, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+			require.Equal(t, "riscv64", args[len(args)-1])
+		}
+		require.Equal(t, tc.expected, filepath.Base(mock.ReadFileArgsForCall(0)))
+	}
+}
+
+func TestWalkBuildsSuccessSystemd(t *testing.T) {
+	outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+	require.Nil(t, err)
+	defer os.RemoveAll(outputDir)
+
+	opts := options.New().
+		WithPackages("kubelet", "kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithOutputDir(outputDir).
+		WithSpecOnly(true).
+		WithSystemd(options.SystemdOptions{
+			CgroupDriver:     "systemd",
+			ExtraArgs:        []string{"--v=2"},
+			EnvironmentFiles: []string{"/etc/kubernetes/kubelet.env"},
+		})
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+
+	// Use the upstream templates
+	for _, file := range []string{
+		"kubelet/lib/systemd/system/kubelet.service",
+		"kubeadm/10-kubeadm.conf",
+	} {
+		template, err := os.ReadFile(filepath.Join(
+			"..", "..", "cmd", "kubepkg", "templates", "latest", "deb", file,
+		))
+		require.Nil(t, err)
+		dst := filepath.Join(opts.TemplateDir(), "deb", file)
+		require.Nil(t, os.MkdirAll(filepath.Dir(dst), 0o755))
+		require.Nil(t, os.WriteFile(dst, template, 0o644))
+	}
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	specDir := filepath.Join(outputDir, "specs", "release")
+	for file, expected := range map[string]string{
+		"kubelet/amd64/lib/systemd/system/kubelet.service": "[Service]\n" +
+			"EnvironmentFile=-/etc/kubernetes/kubelet.env\n" +
+			"ExecStart=/usr/bin/kubelet --cgroup-driver=systemd --v=2\n",
+		"kubeadm/amd64/10-kubeadm.conf": "EnvironmentFile=-/etc/default/kubelet\n" +
+			"EnvironmentFile=-/etc/kubernetes/kubelet.env\n" +
+			"ExecStart=\n" +
+			"ExecStart=/usr/bin/kubelet $KUBELET_KUBECONFIG_ARGS $KUBELET_CONFIG_ARGS " +
+			"$KUBELET_KUBEADM_ARGS --cgroup-driver=systemd --v=2 $KUBELET_EXTRA_ARGS",
+	} {
+		content, err := os.ReadFile(filepath.Join(specDir, file))
+		require.Nil(t, err)
+		require.Contains(t, string(content), expected, file)
+	}
+}
+
+func TestWalkBuildsSuccessHooks(t *testing.T) {
+	for _, tc := range []struct {
+		buildType options.BuildType
+		templates []string
+		expected  map[string][]string
+	}{
+		{
+			buildType: options.BuildDeb,
+			templates: []string{"debian/postinst", "debian/prerm"},
+			expected: map[string][]string{
+				"kubelet/amd64/debian/postinst": {"    configure)\nload-module\n    ;;"},
+				"kubelet/amd64/debian/prerm":    {"    remove|upgrade|deconfigure)\n    ;;"},
+				"kubectl/amd64/debian/postinst": {"    configure)\n    ;;"},
+				"kubectl/amd64/debian/prerm":    {"    remove|upgrade|deconfigure)\nunload-module\n    ;;"},
+			},
+		},
+		{
+			buildType: options.BuildRpm,
+			templates: []string{"%s.spec"},
+			expected: map[string][]string{
+				"kubelet/amd64/kubelet.spec": {"\n%post\nload-module\n\n%changelog"},
+				"kubectl/amd64/kubectl.spec": {"\n%preun\nunload-module\n\n%changelog"},
+			},
+		},
+	} {
+		outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+		require.Nil(t, err)
+		defer os.RemoveAll(outputDir)
+
+		opts := options.New().
+			WithPackages("kubelet", "kubectl").
+			WithChannels("release").
+			WithArchitectures("amd64").
+			WithOutputDir(outputDir).
+			WithSpecOnly(true).
+			WithHooks(map[string]options.PackageHooks{
+				"kubelet": {PostInstall: "load-module\n"},
+				"kubectl": {PreRemove: "unload-module"},
+			})
+		sut, cleanup, _ := sutWithTemplateDir(t, opts, tc.buildType)
+		defer cleanup()
+
+		// Use the upstream templates
+		for _, pkg := range opts.Packages() {
+			for _, file := range tc.templates {
+				file = strings.ReplaceAll(file, "%s", pkg)
+				template, err := os.ReadFile(filepath.Join(
+					"..", "..", "cmd", "kubepkg", "templates", "latest",
+					string(tc.buildType), pkg, file,
+				))
+				require.Nil(t, err)
+				dst := filepath.Join(opts.TemplateDir(), string(tc.buildType), pkg, file)
+				require.Nil(t, os.MkdirAll(filepath.Dir(dst), 0o755))
+				require.Nil(t, os.WriteFile(dst, template, 0o644))
+			}
+		}
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		require.Nil(t, sut.WalkBuilds(builds))
+
+		for file, expected := range tc.expected {
+			content, err := os.ReadFile(filepath.Join(outputDir, "specs", "release", file))
+			require.Nil(t, err)
+			for _, e := range expected {
+				require.Contains(t, string(content), e, file)
+			}
+		}
+	}
+}
+
 func TestWalkBuildsSuccessDeb(t *testing.T) {
 	sut, cleanup, _ := sutWithTemplateDir(t, nil, options.BuildDeb)
 	defer cleanup()
@@ -162,6 +520,68 @@ func TestWalkBuildsFailureDebDPKGFailed(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestWalkBuildsSuccessConcurrency(t *testing.T) {
+	opts := options.New().WithChannels("release").WithConcurrency(4)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.Nil(t, err)
+
+	// 5 packages for 5 architectures, plus SHA256SUMS and SHA512SUMS
+	require.Equal(t, 25, mock.RunSuccessWithWorkDirCallCount())
+	require.Equal(t, 27, mock.WriteFileCallCount())
+}
+
+func TestWalkBuildsFailureConcurrencyAggregatesErrors(t *testing.T) {
+	opts := options.New().
+		WithChannels("release").
+		WithArchitectures("amd64", "arm64").
+		WithConcurrency(3)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.RunSuccessWithWorkDirReturns(errors.New("build error"))
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "10 of 10 builds failed")
+	require.Contains(t, err.Error(), "build kubelet/release/amd64")
+	require.Contains(t, err.Error(), "build cri-tools/release/arm64")
+	require.Equal(t, kubepkg.ErrorCategoryBuild, kubepkg.ErrorCategoryOf(err))
+	require.Equal(t, kubepkg.ErrorCategoryBuild, sut.Summary().Builds[0].Category)
+
+	// Failed builds do not stop the remaining ones
+	require.Equal(t, 10, mock.RunSuccessWithWorkDirCallCount())
+}
+
+func TestWalkBuildsDryRun(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithUploadBucket("bucket").
+		WithNotifyURL("https://example.com/hook").
+		WithDryRun(true)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(true)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// The packages get built, but neither uploaded nor announced
+	require.Len(t, sut.Summary().Artifacts, 1)
+	require.Zero(t, mock.RsyncRecursiveCallCount())
+	require.Zero(t, mock.PostURLCallCount())
+}
+
 func TestConstructBuildsFailedInvalidTemplateDir(t *testing.T) {
 	sut, _ := newSUT(nil)
 	builds, err := sut.ConstructBuilds()
@@ -328,6 +748,7 @@ func TestGetCRIToolsVersionSuccess(t *testing.T) {
 		name        string
 		version     string
 		kubeVersion string
+		latestTag   string
 		expected    string
 	}{
 		{
@@ -340,10 +761,17 @@ func TestGetCRIToolsVersionSuccess(t *testing.T) {
 			kubeVersion: "1.18.0-alpha.1",
 			expected:    "1.17.0",
 		},
+		{
+			name:        "Latest CRI tools release of the minor version",
+			kubeVersion: "1.22.3",
+			latestTag:   "v1.22.1",
+			expected:    "1.22.1",
+		},
 	}
 
-	sut, _ := newSUT(nil)
+	sut, mock := newSUT(nil)
 	for _, tc := range testcases {
+		mock.LatestTagReturns(tc.latestTag, nil)
 		actual, err := sut.GetCRIToolsVersion(
 			&kubepkg.PackageDefinition{
 				Version:           tc.version,
@@ -357,9 +785,25 @@ func TestGetCRIToolsVersionSuccess(t *testing.T) {
 }
 
 func TestGetCRIToolsVersionFailure(t *testing.T) {
-	sut, _ := newSUT(nil)
+	sut, mock := newSUT(nil)
 	_, err := sut.GetCRIToolsVersion(nil)
 	require.NotNil(t, err)
+
+	mock.LatestTagReturns("", errors.New("error"))
+	_, err = sut.GetCRIToolsVersion(&kubepkg.PackageDefinition{KubernetesVersion: "1.22.3"})
+	require.NotNil(t, err)
+}
+
+func TestGetCRIToolsVersionNoMatchingRelease(t *testing.T) {
+	sut, mock := newSUT(nil)
+	mock.LatestTagReturns("", errors.Wrap(github.ErrNoMatchingTag, "cri-tools"))
+
+	version, err := sut.GetCRIToolsVersion(&kubepkg.PackageDefinition{KubernetesVersion: "1.22.3"})
+	require.Nil(t, err)
+	require.Equal(t, "1.22.0", version)
+
+	_, _, constraint := mock.LatestTagArgsForCall(0)
+	require.Equal(t, ">=1.22.0 <1.23.0", constraint)
 }
 
 func TestGetDownloadLinkBaseSuccess(t *testing.T) {
@@ -506,6 +950,31 @@ func TestGetDependenciesSuccess(t *testing.T) {
 	}
 }
 
+func TestGetDependenciesSuccessPinned(t *testing.T) {
+	pinned := options.DependencyVersions{
+		CNIVersion:       "v1.0.1",
+		CRIToolsVersion:  "1.23.0",
+		ConntrackVersion: "1.4.6",
+	}
+
+	kubelet, err := kubepkg.GetDependencies(&kubepkg.PackageDefinition{
+		Name: "kubelet", PinnedDependencies: pinned,
+	})
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{
+		"kubernetes-cni": "1.0.1",
+		"conntrack":      "1.4.6",
+	}, kubelet)
+
+	kubeadm, err := kubepkg.GetDependencies(&kubepkg.PackageDefinition{
+		Name: "kubeadm", PinnedDependencies: pinned,
+	})
+	require.Nil(t, err)
+	require.Equal(t, "1.0.1", kubeadm["kubernetes-cni"])
+	require.Equal(t, "1.23.0", kubeadm["cri-tools"])
+	require.NotContains(t, kubeadm, "conntrack")
+}
+
 func TestGetDependenciesFailure(t *testing.T) {
 	_, err := kubepkg.GetDependencies(nil)
 	require.NotNil(t, err)
@@ -524,6 +993,12 @@ func TestGetCNIDownloadLinkSuccess(t *testing.T) {
 			arch:     "amd64",
 			expected: "https://storage.googleapis.com/k8s-artifacts-cni/release/v0.8.6/cni-plugins-linux-amd64-v0.8.6.tgz",
 		},
+		{
+			name:     "riscv64",
+			version:  "1.2.0",
+			arch:     "riscv64",
+			expected: "https://storage.googleapis.com/k8s-artifacts-cni/release/v1.2.0/cni-plugins-linux-riscv64-v1.2.0.tgz",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -18,7 +18,7 @@ limitations under the License.
 package kubepkgfakes
 
 import (
-	"io/fs"
+	"os"
 	"sync"
 
 	"github.com/google/go-github/v37/github"
@@ -27,6 +27,85 @@ import (
 )
 
 type FakeImpl struct {
+	AvailableStub        func(...string) bool
+	availableMutex       sync.RWMutex
+	availableArgsForCall []struct {
+		arg1 []string
+	}
+	availableReturns struct {
+		result1 bool
+	}
+	availableReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	CommentOnIssueStub        func(string, string, int, string) error
+	commentOnIssueMutex       sync.RWMutex
+	commentOnIssueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}
+	commentOnIssueReturns struct {
+		result1 error
+	}
+	commentOnIssueReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateIssueStub        func(string, string, string, string, []string) (*github.Issue, error)
+	createIssueMutex       sync.RWMutex
+	createIssueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []string
+	}
+	createIssueReturns struct {
+		result1 *github.Issue
+		result2 error
+	}
+	createIssueReturnsOnCall map[int]struct {
+		result1 *github.Issue
+		result2 error
+	}
+	DescribeRepoStub        func(string) (string, error)
+	describeRepoMutex       sync.RWMutex
+	describeRepoArgsForCall []struct {
+		arg1 string
+	}
+	describeRepoReturns struct {
+		result1 string
+		result2 error
+	}
+	describeRepoReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	DownloadFileStub        func(string, string) error
+	downloadFileMutex       sync.RWMutex
+	downloadFileArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	downloadFileReturns struct {
+		result1 error
+	}
+	downloadFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExtractStub        func(string, string) error
+	extractMutex       sync.RWMutex
+	extractArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	extractReturns struct {
+		result1 error
+	}
+	extractReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetKubeVersionStub        func(release.VersionType) (string, error)
 	getKubeVersionMutex       sync.RWMutex
 	getKubeVersionArgsForCall []struct {
@@ -40,6 +119,62 @@ type FakeImpl struct {
 		result1 string
 		result2 error
 	}
+	GetURLResponseStub        func(string, bool) (string, error)
+	getURLResponseMutex       sync.RWMutex
+	getURLResponseArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	getURLResponseReturns struct {
+		result1 string
+		result2 error
+	}
+	getURLResponseReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	LatestTagStub        func(string, string, string) (string, error)
+	latestTagMutex       sync.RWMutex
+	latestTagArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	latestTagReturns struct {
+		result1 string
+		result2 error
+	}
+	latestTagReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	OpenIssuesStub        func(string, string, []string) ([]*github.Issue, error)
+	openIssuesMutex       sync.RWMutex
+	openIssuesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}
+	openIssuesReturns struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	openIssuesReturnsOnCall map[int]struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	PostURLStub        func(string, []byte) error
+	postURLMutex       sync.RWMutex
+	postURLArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	postURLReturns struct {
+		result1 error
+	}
+	postURLReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReadFileStub        func(string) ([]byte, error)
 	readFileMutex       sync.RWMutex
 	readFileArgsForCall []struct {
@@ -53,19 +188,31 @@ type FakeImpl struct {
 		result1 []byte
 		result2 error
 	}
-	ReleasesStub        func(string, string, bool) ([]*github.RepositoryRelease, error)
-	releasesMutex       sync.RWMutex
-	releasesArgsForCall []struct {
+	RsyncRecursiveStub        func(string, string) error
+	rsyncRecursiveMutex       sync.RWMutex
+	rsyncRecursiveArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	rsyncRecursiveReturns struct {
+		result1 error
+	}
+	rsyncRecursiveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RunOutputWithWorkDirStub        func(string, string, ...string) (string, error)
+	runOutputWithWorkDirMutex       sync.RWMutex
+	runOutputWithWorkDirArgsForCall []struct {
 		arg1 string
 		arg2 string
-		arg3 bool
+		arg3 []string
 	}
-	releasesReturns struct {
-		result1 []*github.RepositoryRelease
+	runOutputWithWorkDirReturns struct {
+		result1 string
 		result2 error
 	}
-	releasesReturnsOnCall map[int]struct {
-		result1 []*github.RepositoryRelease
+	runOutputWithWorkDirReturnsOnCall map[int]struct {
+		result1 string
 		result2 error
 	}
 	RunSuccessWithWorkDirStub        func(string, string, ...string) error
@@ -81,12 +228,12 @@ type FakeImpl struct {
 	runSuccessWithWorkDirReturnsOnCall map[int]struct {
 		result1 error
 	}
-	WriteFileStub        func(string, []byte, fs.FileMode) error
+	WriteFileStub        func(string, []byte, os.FileMode) error
 	writeFileMutex       sync.RWMutex
 	writeFileArgsForCall []struct {
 		arg1 string
 		arg2 []byte
-		arg3 fs.FileMode
+		arg3 os.FileMode
 	}
 	writeFileReturns struct {
 		result1 error
@@ -98,6 +245,397 @@ type FakeImpl struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeImpl) Available(arg1 ...string) bool {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.availableMutex.Lock()
+	ret, specificReturn := fake.availableReturnsOnCall[len(fake.availableArgsForCall)]
+	fake.availableArgsForCall = append(fake.availableArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.AvailableStub
+	fakeReturns := fake.availableReturns
+	fake.recordInvocation("Available", []interface{}{arg1Copy})
+	fake.availableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) AvailableCallCount() int {
+	fake.availableMutex.RLock()
+	defer fake.availableMutex.RUnlock()
+	return len(fake.availableArgsForCall)
+}
+
+func (fake *FakeImpl) AvailableCalls(stub func(...string) bool) {
+	fake.availableMutex.Lock()
+	defer fake.availableMutex.Unlock()
+	fake.AvailableStub = stub
+}
+
+func (fake *FakeImpl) AvailableArgsForCall(i int) []string {
+	fake.availableMutex.RLock()
+	defer fake.availableMutex.RUnlock()
+	argsForCall := fake.availableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) AvailableReturns(result1 bool) {
+	fake.availableMutex.Lock()
+	defer fake.availableMutex.Unlock()
+	fake.AvailableStub = nil
+	fake.availableReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeImpl) AvailableReturnsOnCall(i int, result1 bool) {
+	fake.availableMutex.Lock()
+	defer fake.availableMutex.Unlock()
+	fake.AvailableStub = nil
+	if fake.availableReturnsOnCall == nil {
+		fake.availableReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.availableReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeImpl) CommentOnIssue(arg1 string, arg2 string, arg3 int, arg4 string) error {
+	fake.commentOnIssueMutex.Lock()
+	ret, specificReturn := fake.commentOnIssueReturnsOnCall[len(fake.commentOnIssueArgsForCall)]
+	fake.commentOnIssueArgsForCall = append(fake.commentOnIssueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CommentOnIssueStub
+	fakeReturns := fake.commentOnIssueReturns
+	fake.recordInvocation("CommentOnIssue", []interface{}{arg1, arg2, arg3, arg4})
+	fake.commentOnIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CommentOnIssueCallCount() int {
+	fake.commentOnIssueMutex.RLock()
+	defer fake.commentOnIssueMutex.RUnlock()
+	return len(fake.commentOnIssueArgsForCall)
+}
+
+func (fake *FakeImpl) CommentOnIssueCalls(stub func(string, string, int, string) error) {
+	fake.commentOnIssueMutex.Lock()
+	defer fake.commentOnIssueMutex.Unlock()
+	fake.CommentOnIssueStub = stub
+}
+
+func (fake *FakeImpl) CommentOnIssueArgsForCall(i int) (string, string, int, string) {
+	fake.commentOnIssueMutex.RLock()
+	defer fake.commentOnIssueMutex.RUnlock()
+	argsForCall := fake.commentOnIssueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) CommentOnIssueReturns(result1 error) {
+	fake.commentOnIssueMutex.Lock()
+	defer fake.commentOnIssueMutex.Unlock()
+	fake.CommentOnIssueStub = nil
+	fake.commentOnIssueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CommentOnIssueReturnsOnCall(i int, result1 error) {
+	fake.commentOnIssueMutex.Lock()
+	defer fake.commentOnIssueMutex.Unlock()
+	fake.CommentOnIssueStub = nil
+	if fake.commentOnIssueReturnsOnCall == nil {
+		fake.commentOnIssueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.commentOnIssueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreateIssue(arg1 string, arg2 string, arg3 string, arg4 string, arg5 []string) (*github.Issue, error) {
+	var arg5Copy []string
+	if arg5 != nil {
+		arg5Copy = make([]string, len(arg5))
+		copy(arg5Copy, arg5)
+	}
+	fake.createIssueMutex.Lock()
+	ret, specificReturn := fake.createIssueReturnsOnCall[len(fake.createIssueArgsForCall)]
+	fake.createIssueArgsForCall = append(fake.createIssueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5Copy})
+	stub := fake.CreateIssueStub
+	fakeReturns := fake.createIssueReturns
+	fake.recordInvocation("CreateIssue", []interface{}{arg1, arg2, arg3, arg4, arg5Copy})
+	fake.createIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) CreateIssueCallCount() int {
+	fake.createIssueMutex.RLock()
+	defer fake.createIssueMutex.RUnlock()
+	return len(fake.createIssueArgsForCall)
+}
+
+func (fake *FakeImpl) CreateIssueCalls(stub func(string, string, string, string, []string) (*github.Issue, error)) {
+	fake.createIssueMutex.Lock()
+	defer fake.createIssueMutex.Unlock()
+	fake.CreateIssueStub = stub
+}
+
+func (fake *FakeImpl) CreateIssueArgsForCall(i int) (string, string, string, string, []string) {
+	fake.createIssueMutex.RLock()
+	defer fake.createIssueMutex.RUnlock()
+	argsForCall := fake.createIssueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeImpl) CreateIssueReturns(result1 *github.Issue, result2 error) {
+	fake.createIssueMutex.Lock()
+	defer fake.createIssueMutex.Unlock()
+	fake.CreateIssueStub = nil
+	fake.createIssueReturns = struct {
+		result1 *github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) CreateIssueReturnsOnCall(i int, result1 *github.Issue, result2 error) {
+	fake.createIssueMutex.Lock()
+	defer fake.createIssueMutex.Unlock()
+	fake.CreateIssueStub = nil
+	if fake.createIssueReturnsOnCall == nil {
+		fake.createIssueReturnsOnCall = make(map[int]struct {
+			result1 *github.Issue
+			result2 error
+		})
+	}
+	fake.createIssueReturnsOnCall[i] = struct {
+		result1 *github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) DescribeRepo(arg1 string) (string, error) {
+	fake.describeRepoMutex.Lock()
+	ret, specificReturn := fake.describeRepoReturnsOnCall[len(fake.describeRepoArgsForCall)]
+	fake.describeRepoArgsForCall = append(fake.describeRepoArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DescribeRepoStub
+	fakeReturns := fake.describeRepoReturns
+	fake.recordInvocation("DescribeRepo", []interface{}{arg1})
+	fake.describeRepoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) DescribeRepoCallCount() int {
+	fake.describeRepoMutex.RLock()
+	defer fake.describeRepoMutex.RUnlock()
+	return len(fake.describeRepoArgsForCall)
+}
+
+func (fake *FakeImpl) DescribeRepoCalls(stub func(string) (string, error)) {
+	fake.describeRepoMutex.Lock()
+	defer fake.describeRepoMutex.Unlock()
+	fake.DescribeRepoStub = stub
+}
+
+func (fake *FakeImpl) DescribeRepoArgsForCall(i int) string {
+	fake.describeRepoMutex.RLock()
+	defer fake.describeRepoMutex.RUnlock()
+	argsForCall := fake.describeRepoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) DescribeRepoReturns(result1 string, result2 error) {
+	fake.describeRepoMutex.Lock()
+	defer fake.describeRepoMutex.Unlock()
+	fake.DescribeRepoStub = nil
+	fake.describeRepoReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) DescribeRepoReturnsOnCall(i int, result1 string, result2 error) {
+	fake.describeRepoMutex.Lock()
+	defer fake.describeRepoMutex.Unlock()
+	fake.DescribeRepoStub = nil
+	if fake.describeRepoReturnsOnCall == nil {
+		fake.describeRepoReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.describeRepoReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) DownloadFile(arg1 string, arg2 string) error {
+	fake.downloadFileMutex.Lock()
+	ret, specificReturn := fake.downloadFileReturnsOnCall[len(fake.downloadFileArgsForCall)]
+	fake.downloadFileArgsForCall = append(fake.downloadFileArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DownloadFileStub
+	fakeReturns := fake.downloadFileReturns
+	fake.recordInvocation("DownloadFile", []interface{}{arg1, arg2})
+	fake.downloadFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) DownloadFileCallCount() int {
+	fake.downloadFileMutex.RLock()
+	defer fake.downloadFileMutex.RUnlock()
+	return len(fake.downloadFileArgsForCall)
+}
+
+func (fake *FakeImpl) DownloadFileCalls(stub func(string, string) error) {
+	fake.downloadFileMutex.Lock()
+	defer fake.downloadFileMutex.Unlock()
+	fake.DownloadFileStub = stub
+}
+
+func (fake *FakeImpl) DownloadFileArgsForCall(i int) (string, string) {
+	fake.downloadFileMutex.RLock()
+	defer fake.downloadFileMutex.RUnlock()
+	argsForCall := fake.downloadFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) DownloadFileReturns(result1 error) {
+	fake.downloadFileMutex.Lock()
+	defer fake.downloadFileMutex.Unlock()
+	fake.DownloadFileStub = nil
+	fake.downloadFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) DownloadFileReturnsOnCall(i int, result1 error) {
+	fake.downloadFileMutex.Lock()
+	defer fake.downloadFileMutex.Unlock()
+	fake.DownloadFileStub = nil
+	if fake.downloadFileReturnsOnCall == nil {
+		fake.downloadFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.downloadFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Extract(arg1 string, arg2 string) error {
+	fake.extractMutex.Lock()
+	ret, specificReturn := fake.extractReturnsOnCall[len(fake.extractArgsForCall)]
+	fake.extractArgsForCall = append(fake.extractArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ExtractStub
+	fakeReturns := fake.extractReturns
+	fake.recordInvocation("Extract", []interface{}{arg1, arg2})
+	fake.extractMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) ExtractCallCount() int {
+	fake.extractMutex.RLock()
+	defer fake.extractMutex.RUnlock()
+	return len(fake.extractArgsForCall)
+}
+
+func (fake *FakeImpl) ExtractCalls(stub func(string, string) error) {
+	fake.extractMutex.Lock()
+	defer fake.extractMutex.Unlock()
+	fake.ExtractStub = stub
+}
+
+func (fake *FakeImpl) ExtractArgsForCall(i int) (string, string) {
+	fake.extractMutex.RLock()
+	defer fake.extractMutex.RUnlock()
+	argsForCall := fake.extractArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) ExtractReturns(result1 error) {
+	fake.extractMutex.Lock()
+	defer fake.extractMutex.Unlock()
+	fake.ExtractStub = nil
+	fake.extractReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) ExtractReturnsOnCall(i int, result1 error) {
+	fake.extractMutex.Lock()
+	defer fake.extractMutex.Unlock()
+	fake.ExtractStub = nil
+	if fake.extractReturnsOnCall == nil {
+		fake.extractReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.extractReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImpl) GetKubeVersion(arg1 release.VersionType) (string, error) {
 	fake.getKubeVersionMutex.Lock()
 	ret, specificReturn := fake.getKubeVersionReturnsOnCall[len(fake.getKubeVersionArgsForCall)]
@@ -162,6 +700,275 @@ func (fake *FakeImpl) GetKubeVersionReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
+func (fake *FakeImpl) GetURLResponse(arg1 string, arg2 bool) (string, error) {
+	fake.getURLResponseMutex.Lock()
+	ret, specificReturn := fake.getURLResponseReturnsOnCall[len(fake.getURLResponseArgsForCall)]
+	fake.getURLResponseArgsForCall = append(fake.getURLResponseArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.GetURLResponseStub
+	fakeReturns := fake.getURLResponseReturns
+	fake.recordInvocation("GetURLResponse", []interface{}{arg1, arg2})
+	fake.getURLResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GetURLResponseCallCount() int {
+	fake.getURLResponseMutex.RLock()
+	defer fake.getURLResponseMutex.RUnlock()
+	return len(fake.getURLResponseArgsForCall)
+}
+
+func (fake *FakeImpl) GetURLResponseCalls(stub func(string, bool) (string, error)) {
+	fake.getURLResponseMutex.Lock()
+	defer fake.getURLResponseMutex.Unlock()
+	fake.GetURLResponseStub = stub
+}
+
+func (fake *FakeImpl) GetURLResponseArgsForCall(i int) (string, bool) {
+	fake.getURLResponseMutex.RLock()
+	defer fake.getURLResponseMutex.RUnlock()
+	argsForCall := fake.getURLResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) GetURLResponseReturns(result1 string, result2 error) {
+	fake.getURLResponseMutex.Lock()
+	defer fake.getURLResponseMutex.Unlock()
+	fake.GetURLResponseStub = nil
+	fake.getURLResponseReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetURLResponseReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getURLResponseMutex.Lock()
+	defer fake.getURLResponseMutex.Unlock()
+	fake.GetURLResponseStub = nil
+	if fake.getURLResponseReturnsOnCall == nil {
+		fake.getURLResponseReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getURLResponseReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) LatestTag(arg1 string, arg2 string, arg3 string) (string, error) {
+	fake.latestTagMutex.Lock()
+	ret, specificReturn := fake.latestTagReturnsOnCall[len(fake.latestTagArgsForCall)]
+	fake.latestTagArgsForCall = append(fake.latestTagArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.LatestTagStub
+	fakeReturns := fake.latestTagReturns
+	fake.recordInvocation("LatestTag", []interface{}{arg1, arg2, arg3})
+	fake.latestTagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) LatestTagCallCount() int {
+	fake.latestTagMutex.RLock()
+	defer fake.latestTagMutex.RUnlock()
+	return len(fake.latestTagArgsForCall)
+}
+
+func (fake *FakeImpl) LatestTagCalls(stub func(string, string, string) (string, error)) {
+	fake.latestTagMutex.Lock()
+	defer fake.latestTagMutex.Unlock()
+	fake.LatestTagStub = stub
+}
+
+func (fake *FakeImpl) LatestTagArgsForCall(i int) (string, string, string) {
+	fake.latestTagMutex.RLock()
+	defer fake.latestTagMutex.RUnlock()
+	argsForCall := fake.latestTagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) LatestTagReturns(result1 string, result2 error) {
+	fake.latestTagMutex.Lock()
+	defer fake.latestTagMutex.Unlock()
+	fake.LatestTagStub = nil
+	fake.latestTagReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) LatestTagReturnsOnCall(i int, result1 string, result2 error) {
+	fake.latestTagMutex.Lock()
+	defer fake.latestTagMutex.Unlock()
+	fake.LatestTagStub = nil
+	if fake.latestTagReturnsOnCall == nil {
+		fake.latestTagReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.latestTagReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) OpenIssues(arg1 string, arg2 string, arg3 []string) ([]*github.Issue, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.openIssuesMutex.Lock()
+	ret, specificReturn := fake.openIssuesReturnsOnCall[len(fake.openIssuesArgsForCall)]
+	fake.openIssuesArgsForCall = append(fake.openIssuesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.OpenIssuesStub
+	fakeReturns := fake.openIssuesReturns
+	fake.recordInvocation("OpenIssues", []interface{}{arg1, arg2, arg3Copy})
+	fake.openIssuesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) OpenIssuesCallCount() int {
+	fake.openIssuesMutex.RLock()
+	defer fake.openIssuesMutex.RUnlock()
+	return len(fake.openIssuesArgsForCall)
+}
+
+func (fake *FakeImpl) OpenIssuesCalls(stub func(string, string, []string) ([]*github.Issue, error)) {
+	fake.openIssuesMutex.Lock()
+	defer fake.openIssuesMutex.Unlock()
+	fake.OpenIssuesStub = stub
+}
+
+func (fake *FakeImpl) OpenIssuesArgsForCall(i int) (string, string, []string) {
+	fake.openIssuesMutex.RLock()
+	defer fake.openIssuesMutex.RUnlock()
+	argsForCall := fake.openIssuesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) OpenIssuesReturns(result1 []*github.Issue, result2 error) {
+	fake.openIssuesMutex.Lock()
+	defer fake.openIssuesMutex.Unlock()
+	fake.OpenIssuesStub = nil
+	fake.openIssuesReturns = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) OpenIssuesReturnsOnCall(i int, result1 []*github.Issue, result2 error) {
+	fake.openIssuesMutex.Lock()
+	defer fake.openIssuesMutex.Unlock()
+	fake.OpenIssuesStub = nil
+	if fake.openIssuesReturnsOnCall == nil {
+		fake.openIssuesReturnsOnCall = make(map[int]struct {
+			result1 []*github.Issue
+			result2 error
+		})
+	}
+	fake.openIssuesReturnsOnCall[i] = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) PostURL(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.postURLMutex.Lock()
+	ret, specificReturn := fake.postURLReturnsOnCall[len(fake.postURLArgsForCall)]
+	fake.postURLArgsForCall = append(fake.postURLArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.PostURLStub
+	fakeReturns := fake.postURLReturns
+	fake.recordInvocation("PostURL", []interface{}{arg1, arg2Copy})
+	fake.postURLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) PostURLCallCount() int {
+	fake.postURLMutex.RLock()
+	defer fake.postURLMutex.RUnlock()
+	return len(fake.postURLArgsForCall)
+}
+
+func (fake *FakeImpl) PostURLCalls(stub func(string, []byte) error) {
+	fake.postURLMutex.Lock()
+	defer fake.postURLMutex.Unlock()
+	fake.PostURLStub = stub
+}
+
+func (fake *FakeImpl) PostURLArgsForCall(i int) (string, []byte) {
+	fake.postURLMutex.RLock()
+	defer fake.postURLMutex.RUnlock()
+	argsForCall := fake.postURLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) PostURLReturns(result1 error) {
+	fake.postURLMutex.Lock()
+	defer fake.postURLMutex.Unlock()
+	fake.PostURLStub = nil
+	fake.postURLReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) PostURLReturnsOnCall(i int, result1 error) {
+	fake.postURLMutex.Lock()
+	defer fake.postURLMutex.Unlock()
+	fake.PostURLStub = nil
+	if fake.postURLReturnsOnCall == nil {
+		fake.postURLReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.postURLReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
 	fake.readFileMutex.Lock()
 	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
@@ -226,20 +1033,87 @@ func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) Releases(arg1 string, arg2 string, arg3 bool) ([]*github.RepositoryRelease, error) {
-	fake.releasesMutex.Lock()
-	ret, specificReturn := fake.releasesReturnsOnCall[len(fake.releasesArgsForCall)]
-	fake.releasesArgsForCall = append(fake.releasesArgsForCall, struct {
+func (fake *FakeImpl) RsyncRecursive(arg1 string, arg2 string) error {
+	fake.rsyncRecursiveMutex.Lock()
+	ret, specificReturn := fake.rsyncRecursiveReturnsOnCall[len(fake.rsyncRecursiveArgsForCall)]
+	fake.rsyncRecursiveArgsForCall = append(fake.rsyncRecursiveArgsForCall, struct {
 		arg1 string
 		arg2 string
-		arg3 bool
-	}{arg1, arg2, arg3})
-	stub := fake.ReleasesStub
-	fakeReturns := fake.releasesReturns
-	fake.recordInvocation("Releases", []interface{}{arg1, arg2, arg3})
-	fake.releasesMutex.Unlock()
+	}{arg1, arg2})
+	stub := fake.RsyncRecursiveStub
+	fakeReturns := fake.rsyncRecursiveReturns
+	fake.recordInvocation("RsyncRecursive", []interface{}{arg1, arg2})
+	fake.rsyncRecursiveMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) RsyncRecursiveCallCount() int {
+	fake.rsyncRecursiveMutex.RLock()
+	defer fake.rsyncRecursiveMutex.RUnlock()
+	return len(fake.rsyncRecursiveArgsForCall)
+}
+
+func (fake *FakeImpl) RsyncRecursiveCalls(stub func(string, string) error) {
+	fake.rsyncRecursiveMutex.Lock()
+	defer fake.rsyncRecursiveMutex.Unlock()
+	fake.RsyncRecursiveStub = stub
+}
+
+func (fake *FakeImpl) RsyncRecursiveArgsForCall(i int) (string, string) {
+	fake.rsyncRecursiveMutex.RLock()
+	defer fake.rsyncRecursiveMutex.RUnlock()
+	argsForCall := fake.rsyncRecursiveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) RsyncRecursiveReturns(result1 error) {
+	fake.rsyncRecursiveMutex.Lock()
+	defer fake.rsyncRecursiveMutex.Unlock()
+	fake.RsyncRecursiveStub = nil
+	fake.rsyncRecursiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) RsyncRecursiveReturnsOnCall(i int, result1 error) {
+	fake.rsyncRecursiveMutex.Lock()
+	defer fake.rsyncRecursiveMutex.Unlock()
+	fake.RsyncRecursiveStub = nil
+	if fake.rsyncRecursiveReturnsOnCall == nil {
+		fake.rsyncRecursiveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rsyncRecursiveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) RunOutputWithWorkDir(arg1 string, arg2 string, arg3 ...string) (string, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.runOutputWithWorkDirMutex.Lock()
+	ret, specificReturn := fake.runOutputWithWorkDirReturnsOnCall[len(fake.runOutputWithWorkDirArgsForCall)]
+	fake.runOutputWithWorkDirArgsForCall = append(fake.runOutputWithWorkDirArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.RunOutputWithWorkDirStub
+	fakeReturns := fake.runOutputWithWorkDirReturns
+	fake.recordInvocation("RunOutputWithWorkDir", []interface{}{arg1, arg2, arg3Copy})
+	fake.runOutputWithWorkDirMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -247,62 +1121,67 @@ func (fake *FakeImpl) Releases(arg1 string, arg2 string, arg3 bool) ([]*github.R
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeImpl) ReleasesCallCount() int {
-	fake.releasesMutex.RLock()
-	defer fake.releasesMutex.RUnlock()
-	return len(fake.releasesArgsForCall)
+func (fake *FakeImpl) RunOutputWithWorkDirCallCount() int {
+	fake.runOutputWithWorkDirMutex.RLock()
+	defer fake.runOutputWithWorkDirMutex.RUnlock()
+	return len(fake.runOutputWithWorkDirArgsForCall)
 }
 
-func (fake *FakeImpl) ReleasesCalls(stub func(string, string, bool) ([]*github.RepositoryRelease, error)) {
-	fake.releasesMutex.Lock()
-	defer fake.releasesMutex.Unlock()
-	fake.ReleasesStub = stub
+func (fake *FakeImpl) RunOutputWithWorkDirCalls(stub func(string, string, ...string) (string, error)) {
+	fake.runOutputWithWorkDirMutex.Lock()
+	defer fake.runOutputWithWorkDirMutex.Unlock()
+	fake.RunOutputWithWorkDirStub = stub
 }
 
-func (fake *FakeImpl) ReleasesArgsForCall(i int) (string, string, bool) {
-	fake.releasesMutex.RLock()
-	defer fake.releasesMutex.RUnlock()
-	argsForCall := fake.releasesArgsForCall[i]
+func (fake *FakeImpl) RunOutputWithWorkDirArgsForCall(i int) (string, string, []string) {
+	fake.runOutputWithWorkDirMutex.RLock()
+	defer fake.runOutputWithWorkDirMutex.RUnlock()
+	argsForCall := fake.runOutputWithWorkDirArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeImpl) ReleasesReturns(result1 []*github.RepositoryRelease, result2 error) {
-	fake.releasesMutex.Lock()
-	defer fake.releasesMutex.Unlock()
-	fake.ReleasesStub = nil
-	fake.releasesReturns = struct {
-		result1 []*github.RepositoryRelease
+func (fake *FakeImpl) RunOutputWithWorkDirReturns(result1 string, result2 error) {
+	fake.runOutputWithWorkDirMutex.Lock()
+	defer fake.runOutputWithWorkDirMutex.Unlock()
+	fake.RunOutputWithWorkDirStub = nil
+	fake.runOutputWithWorkDirReturns = struct {
+		result1 string
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) ReleasesReturnsOnCall(i int, result1 []*github.RepositoryRelease, result2 error) {
-	fake.releasesMutex.Lock()
-	defer fake.releasesMutex.Unlock()
-	fake.ReleasesStub = nil
-	if fake.releasesReturnsOnCall == nil {
-		fake.releasesReturnsOnCall = make(map[int]struct {
-			result1 []*github.RepositoryRelease
+func (fake *FakeImpl) RunOutputWithWorkDirReturnsOnCall(i int, result1 string, result2 error) {
+	fake.runOutputWithWorkDirMutex.Lock()
+	defer fake.runOutputWithWorkDirMutex.Unlock()
+	fake.RunOutputWithWorkDirStub = nil
+	if fake.runOutputWithWorkDirReturnsOnCall == nil {
+		fake.runOutputWithWorkDirReturnsOnCall = make(map[int]struct {
+			result1 string
 			result2 error
 		})
 	}
-	fake.releasesReturnsOnCall[i] = struct {
-		result1 []*github.RepositoryRelease
+	fake.runOutputWithWorkDirReturnsOnCall[i] = struct {
+		result1 string
 		result2 error
 	}{result1, result2}
 }
 
 func (fake *FakeImpl) RunSuccessWithWorkDir(arg1 string, arg2 string, arg3 ...string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.runSuccessWithWorkDirMutex.Lock()
 	ret, specificReturn := fake.runSuccessWithWorkDirReturnsOnCall[len(fake.runSuccessWithWorkDirArgsForCall)]
 	fake.runSuccessWithWorkDirArgsForCall = append(fake.runSuccessWithWorkDirArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 []string
-	}{arg1, arg2, arg3})
+	}{arg1, arg2, arg3Copy})
 	stub := fake.RunSuccessWithWorkDirStub
 	fakeReturns := fake.runSuccessWithWorkDirReturns
-	fake.recordInvocation("RunSuccessWithWorkDir", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("RunSuccessWithWorkDir", []interface{}{arg1, arg2, arg3Copy})
 	fake.runSuccessWithWorkDirMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2, arg3...)
@@ -355,7 +1234,7 @@ func (fake *FakeImpl) RunSuccessWithWorkDirReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte, arg3 fs.FileMode) error {
+func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte, arg3 os.FileMode) error {
 	var arg2Copy []byte
 	if arg2 != nil {
 		arg2Copy = make([]byte, len(arg2))
@@ -366,7 +1245,7 @@ func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte, arg3 fs.FileMode) erro
 	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
 		arg1 string
 		arg2 []byte
-		arg3 fs.FileMode
+		arg3 os.FileMode
 	}{arg1, arg2Copy, arg3})
 	stub := fake.WriteFileStub
 	fakeReturns := fake.writeFileReturns
@@ -387,13 +1266,13 @@ func (fake *FakeImpl) WriteFileCallCount() int {
 	return len(fake.writeFileArgsForCall)
 }
 
-func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte, fs.FileMode) error) {
+func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte, os.FileMode) error) {
 	fake.writeFileMutex.Lock()
 	defer fake.writeFileMutex.Unlock()
 	fake.WriteFileStub = stub
 }
 
-func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte, fs.FileMode) {
+func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte, os.FileMode) {
 	fake.writeFileMutex.RLock()
 	defer fake.writeFileMutex.RUnlock()
 	argsForCall := fake.writeFileArgsForCall[i]
@@ -426,16 +1305,6 @@ func (fake *FakeImpl) WriteFileReturnsOnCall(i int, result1 error) {
 func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.getKubeVersionMutex.RLock()
-	defer fake.getKubeVersionMutex.RUnlock()
-	fake.readFileMutex.RLock()
-	defer fake.readFileMutex.RUnlock()
-	fake.releasesMutex.RLock()
-	defer fake.releasesMutex.RUnlock()
-	fake.runSuccessWithWorkDirMutex.RLock()
-	defer fake.runSuccessWithWorkDirMutex.RUnlock()
-	fake.writeFileMutex.RLock()
-	defer fake.writeFileMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
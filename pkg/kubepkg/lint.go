@@ -0,0 +1,286 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// noValue is rendered by text/template for missing values.
+const noValue = "<no value>"
+
+// lintRule describes a rendered spec file and the metadata fields it has to
+// contain.
+type lintRule struct {
+	// file is the path relative to the spec directory, where %s gets
+	// replaced by the package name.
+	file string
+
+	// fields are the regular expressions of the required metadata fields.
+	fields []string
+}
+
+// lintRules are the rules per template directory type.
+var lintRules = map[string][]lintRule{
+	string(options.BuildDeb): {
+		{"debian/control", []string{
+			`(?m)^Source: \S+`,
+			`(?m)^Maintainer: \S+`,
+			`(?m)^Package: \S+`,
+			`(?m)^Architecture: \S+`,
+			`(?m)^Description: \S+`,
+		}},
+		{"debian/changelog", []string{
+			`^\S+ \(\S+-\S+\) \S+; urgency=\S+`,
+		}},
+		{"debian/rules", nil},
+	},
+	string(options.BuildRpm): {
+		{"%s.spec", []string{
+			`(?m)^Name: +\S+`,
+			`(?m)^Version: +\S+`,
+			`(?m)^Release: +\S+`,
+			`(?m)^Summary: +\S+`,
+			`(?m)^License: +\S+`,
+			`(?m)^%description`,
+		}},
+	},
+	nfpmTemplateDir: {
+		{nfpmConfig, []string{
+			`(?m)^name: \S+`,
+			`(?m)^arch: \S+`,
+			`(?m)^version: \S+`,
+			`(?m)^maintainer: \S+`,
+			`(?m)^description: \S+`,
+		}},
+	},
+	string(options.BuildArchLinux): {
+		{"PKGBUILD", []string{
+			`(?m)^pkgname=\S+`,
+			`(?m)^pkgver=\S+`,
+			`(?m)^pkgrel=\S+`,
+			`(?m)^pkgdesc=\S+`,
+			`(?m)^arch=\S+`,
+		}},
+	},
+	string(options.BuildSnap): {
+		{"snapcraft.yaml", []string{
+			`(?m)^name: \S+`,
+			`(?m)^version: \S+`,
+			`(?m)^summary: \S+`,
+			`(?m)^description: \S+`,
+			`(?m)^grade: \S+`,
+		}},
+	},
+	string(options.BuildChocolatey): {
+		{"%s.nuspec", []string{
+			`<id>[^<]+</id>`,
+			`<version>[^<]+</version>`,
+			`<authors>[^<]+</authors>`,
+			`<description>[^<]+</description>`,
+		}},
+	},
+	string(options.BuildWinget): {
+		{"Kubernetes.%s.yaml", []string{
+			`(?m)^PackageIdentifier: \S+`,
+			`(?m)^PackageVersion: \S+`,
+		}},
+		{"Kubernetes.%s.installer.yaml", []string{
+			`(?m)^\s+InstallerUrl: \S+`,
+			`(?m)^\s+InstallerSha256: \S+`,
+		}},
+	},
+	string(options.BuildMsi): {
+		{"%s.wxs", []string{
+			`Version="\d+\.\d+\.\d+"`,
+			`UpgradeCode="[0-9A-F-]{36}"`,
+			`Manufacturer="[^"]+"`,
+		}},
+	},
+}
+
+// LintIssue is a single problem found while linting the templates.
+type LintIssue struct {
+	// Build is the name of the build, like kubelet/release/amd64.
+	Build string `json:"build"`
+
+	// File is the template or spec file containing the issue, if any.
+	File string `json:"file,omitempty"`
+
+	// Message describes the issue.
+	Message string `json:"message"`
+}
+
+func (l *LintIssue) String() string {
+	if l.File == "" {
+		return fmt.Sprintf("%s: %s", l.Build, l.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", l.Build, l.File, l.Message)
+}
+
+// Lint renders the specs of every build into a temporary directory and
+// verifies that they contain all required metadata fields. An error is only
+// returned if linting could not be done at all, for example if the versions
+// cannot be resolved.
+func (c *Client) Lint(builds []Build) ([]LintIssue, error) {
+	logrus.Infof("Linting builds...")
+
+	tempDir, err := os.MkdirTemp("", "kubepkg-lint-")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temporary lint directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	issues := []LintIssue{}
+	for i := range builds {
+		issues = append(issues, lintTemplateDir(&builds[i])...)
+	}
+
+	rules := lintRules[c.templateDirType()]
+	for i, job := range c.buildJobs(builds) {
+		bc, err := c.newBuildConfig(job.build, job.packageDef, job.arch, tempDir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving build %s", job.String())
+		}
+		if bc == nil {
+			continue
+		}
+
+		specDir := filepath.Join(tempDir, fmt.Sprint(i))
+		if err := os.MkdirAll(specDir, os.FileMode(0o755)); err != nil {
+			return nil, errors.Wrapf(err, "creating %s", specDir)
+		}
+		if _, err := buildSpecs(bc, specDir); err != nil {
+			issues = append(issues, LintIssue{
+				Build:   job.String(),
+				Message: fmt.Sprintf("rendering specs failed: %v", err),
+			})
+			continue
+		}
+
+		issues = append(issues, lintSpecDir(job.String(), bc.Package, specDir, rules)...)
+	}
+
+	logrus.Infof("Found %d lint issues", len(issues))
+	return issues, nil
+}
+
+// templateDirType returns the template sub directory used for the selected
+// build type and backend.
+func (c *Client) templateDirType() string {
+	if c.usesNfpm() {
+		return nfpmTemplateDir
+	}
+	return string(c.options.BuildType())
+}
+
+// lintTemplateDir verifies that the template directory of the build contains
+// any templates.
+func lintTemplateDir(build *Build) []LintIssue {
+	templates, err := collectTemplates(
+		append([]string{build.TemplateDir}, build.OverrideTemplateDirs...),
+	)
+	if err != nil {
+		return []LintIssue{{
+			Build:   build.Package,
+			File:    build.TemplateDir,
+			Message: fmt.Sprintf("walking template directory failed: %v", err),
+		}}
+	}
+
+	files := 0
+	for _, tf := range templates {
+		if !tf.info.IsDir() {
+			files++
+		}
+	}
+	if files == 0 {
+		return []LintIssue{{
+			Build:   build.Package,
+			File:    build.TemplateDir,
+			Message: "template directory does not contain any templates",
+		}}
+	}
+	return nil
+}
+
+// lintSpecDir verifies the rendered specs of a single build.
+func lintSpecDir(name, pkg, specDir string, rules []lintRule) []LintIssue {
+	issues := []LintIssue{}
+
+	// Rendering succeeded, but values could still be missing
+	if err := filepath.Walk(specDir, func(path string, f os.FileInfo, err error) error {
+		if err != nil || f.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(content), noValue) {
+			rel, _ := filepath.Rel(specDir, path)
+			issues = append(issues, LintIssue{
+				Build:   name,
+				File:    rel,
+				Message: fmt.Sprintf("rendered spec contains %q", noValue),
+			})
+		}
+		return nil
+	}); err != nil {
+		return append(issues, LintIssue{
+			Build:   name,
+			Message: fmt.Sprintf("walking spec directory failed: %v", err),
+		})
+	}
+
+	for _, rule := range rules {
+		file := rule.file
+		if strings.Contains(file, "%s") {
+			file = fmt.Sprintf(file, pkg)
+		}
+
+		content, err := os.ReadFile(filepath.Join(specDir, file))
+		if err != nil {
+			issues = append(issues, LintIssue{
+				Build:   name,
+				File:    file,
+				Message: "required spec file is missing",
+			})
+			continue
+		}
+
+		for _, field := range rule.fields {
+			if !regexp.MustCompile(field).Match(content) {
+				issues = append(issues, LintIssue{
+					Build:   name,
+					File:    file,
+					Message: fmt.Sprintf("required field %q not found", field),
+				})
+			}
+		}
+	}
+	return issues
+}
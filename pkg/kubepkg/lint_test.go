@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestLintSuccess(t *testing.T) {
+	for _, buildType := range []options.BuildType{
+		options.BuildDeb, options.BuildRpm, options.BuildApk, options.BuildMsi,
+	} {
+		opts := options.New().
+			WithBuildType(buildType).
+			WithTemplateDir(filepath.Join("..", "..", "cmd", "kubepkg", "templates", "latest")).
+			WithKubeVersion("v1.22.1").
+			WithCRIToolsVersion("1.22.0").
+			WithChannels("release").
+			WithArchitectures("amd64")
+		sut, mock := newSUT(opts)
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+
+		issues, err := sut.Lint(builds)
+		require.Nil(t, err)
+		require.Empty(t, issues, "%s: %v", buildType, issues)
+		require.Zero(t, mock.RunSuccessWithWorkDirCallCount())
+	}
+}
+
+func TestLintIssues(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm", "kubectl", "kubelet").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+
+	// kubectl has an empty template dir, kubelet cannot be rendered and
+	// kubeadm misses metadata
+	require.Nil(t, os.WriteFile(
+		filepath.Join(opts.TemplateDir(), "deb", "kubelet", "rules"),
+		[]byte("{{ .Dependencies.missing }}"), 0o644,
+	))
+	debianDir := filepath.Join(opts.TemplateDir(), "deb", "kubeadm", "debian")
+	require.Nil(t, os.MkdirAll(debianDir, 0o755))
+	for file, content := range map[string]string{
+		"control":   "Source: kubeadm\nPackage: kubeadm\nArchitecture: {{ .BuildArch }}\n",
+		"changelog": "kubeadm ({{ .Version }}-{{ .Revision }}) {{ .Channel }}; urgency=medium\n",
+		"rules":     "",
+	} {
+		require.Nil(t, os.WriteFile(filepath.Join(debianDir, file), []byte(content), 0o644))
+	}
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	issues, err := sut.Lint(builds)
+	require.Nil(t, err)
+
+	messages := []string{}
+	for i := range issues {
+		messages = append(messages, issues[i].String())
+	}
+	require.Contains(t, messages,
+		"kubectl: "+filepath.Join(opts.TemplateDir(), "deb", "kubectl")+
+			": template directory does not contain any templates",
+	)
+	require.Contains(t, messages[len(messages)-1], "kubelet/release/amd64: rendering specs failed")
+	require.Contains(t, messages,
+		"kubeadm/release/amd64: debian/control: required field \"(?m)^Maintainer: \\\\S+\" not found",
+	)
+	require.Contains(t, messages,
+		"kubectl/release/amd64: debian/control: required spec file is missing",
+	)
+}
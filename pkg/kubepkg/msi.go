@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+const (
+	wixlExecutable = "wixl"
+
+	// msiSourceDir is the directory within the spec directory where the
+	// Windows binaries get downloaded to.
+	msiSourceDir = "bin"
+)
+
+// runWixl downloads the Windows binary and builds the WiX source rendered
+// into specDirWithArch by using wixl. Since wixl is not commonly available,
+// the build always runs inside a container.
+func (c *Client) runWixl(bc *buildConfig, specDir, specDirWithArch string) error {
+	sourceDir := filepath.Join(specDirWithArch, msiSourceDir)
+	if err := os.MkdirAll(sourceDir, os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "creating %s", sourceDir)
+	}
+
+	url := windowsBinaryURL(bc, bc.GoArch)
+	dst := filepath.Join(sourceDir, bc.Package+".exe")
+	if err := c.download(bc, url, dst); err != nil {
+		return errors.Wrap(err, "downloading Windows binary")
+	}
+
+	bc.log.Infof("Running wixl for %s (%s/%s)", bc.Package, bc.GoArch, bc.BuildArch)
+	fileName := packageFileName(bc)
+	if err := c.runBuildCommand(
+		bc,
+		specDir,
+		specDirWithArch,
+		wixlExecutable,
+		"--arch", bc.BuildArch,
+		"--output", fileName,
+		bc.Package+".wxs",
+	); err != nil {
+		return errors.Wrap(err, "running Windows Installer package build")
+	}
+
+	return c.copyPackage(bc, filepath.Join(specDirWithArch, fileName))
+}
+
+// msiVersion converts the provided version into a Windows Installer product
+// version, which only supports numeric major, minor and build fields.
+func msiVersion(version string) (string, error) {
+	sv, err := util.TagStringToSemver(version)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing version %s", version)
+	}
+	return fmt.Sprintf("%d.%d.%d", sv.Major, sv.Minor, sv.Patch), nil
+}
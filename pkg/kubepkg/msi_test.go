@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessMsi(t *testing.T) {
+	opts := options.New().WithChannels("release")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildMsi)
+	defer cleanup()
+	mock.AvailableReturns(true)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Len(t, builds, 2)
+
+	err = sut.WalkBuilds(builds)
+	require.Nil(t, err)
+
+	// Only amd64 and arm64 are available on Windows
+	require.Equal(t, 4, mock.DownloadFileCallCount())
+	require.Equal(t, 4, mock.RunSuccessWithWorkDirCallCount())
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+	require.Equal(t, "docker", cmd)
+	require.Contains(t, args, kubepkg.DefaultMsiBuildImage)
+	require.Contains(t, args, "wixl")
+	require.Contains(t, args, "x64")
+	require.Contains(t, args, "kubelet.wxs")
+	require.Equal(t,
+		"kubelet-1.18.0-0-x64.msi",
+		filepath.Base(mock.ReadFileArgsForCall(0)),
+	)
+}
+
+func TestWalkBuildsFailureMsiNoContainerRuntime(t *testing.T) {
+	opts := options.New().WithChannels("release")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildMsi)
+	defer cleanup()
+	mock.AvailableReturns(false)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+const (
+	nfpmExecutable = "nfpm"
+	nfpmConfig     = "nfpm.yaml"
+
+	// nfpmTemplateDir is the template sub directory used for the nfpm
+	// backend, which is shared between all package types.
+	nfpmTemplateDir = "nfpm"
+
+	// nfpmSourceDir is the directory within the spec directory where the
+	// package sources get downloaded to.
+	nfpmSourceDir = "bin"
+)
+
+// usesNfpm returns true if the packages have to be built by using nfpm, which
+// is the case for the nfpm backend as well as for package types which are not
+// supported by the native backend.
+func (c *Client) usesNfpm() bool {
+	return c.options.Backend() == options.BackendNfpm ||
+		c.options.BuildType() == options.BuildApk
+}
+
+// runNfpm builds the package for the provided build config by using nfpm,
+// which does not require dpkg-buildpackage or rpmbuild on the host.
+func (c *Client) runNfpm(bc *buildConfig, specDir string) error {
+	if err := c.downloadNfpmSources(bc, specDir); err != nil {
+		return errors.Wrap(err, "downloading package sources")
+	}
+
+	fileName := packageFileName(bc)
+	bc.log.Infof("Running nfpm for %s (%s/%s)", bc.Package, bc.GoArch, bc.BuildArch)
+	if err := c.impl.RunSuccessWithWorkDir(
+		specDir,
+		nfpmExecutable,
+		"package",
+		"--config", nfpmConfig,
+		"--packager", string(bc.Type),
+		"--target", fileName,
+	); err != nil {
+		return errors.Wrap(err, "running nfpm package build")
+	}
+
+	return c.copyPackage(bc, filepath.Join(specDir, fileName))
+}
+
+// downloadNfpmSources retrieves the binaries to be packaged into the source
+// directory of the provided spec directory.
+func (c *Client) downloadNfpmSources(bc *buildConfig, specDir string) error {
+	sourceDir := filepath.Join(specDir, nfpmSourceDir)
+	if err := os.MkdirAll(sourceDir, os.FileMode(0o755)); err != nil {
+		return errors.Wrapf(err, "creating %s", sourceDir)
+	}
+
+	if bc.localBinary != "" {
+		bc.log.Infof("Using local binary %s", bc.localBinary)
+		return c.copyBinary(bc.localBinary, filepath.Join(sourceDir, bc.Package))
+	}
+
+	url := sourceURL(bc)
+	if !isSourceTarball(bc) {
+		return c.download(bc, url, filepath.Join(sourceDir, bc.Package))
+	}
+
+	tarball := url
+	dst := filepath.Join(specDir, filepath.Base(tarball))
+	if err := c.download(bc, tarball, dst); err != nil {
+		return err
+	}
+	defer os.RemoveAll(dst)
+
+	bc.log.Infof("Extracting %s to %s", dst, sourceDir)
+	return c.impl.Extract(dst, sourceDir)
+}
+
+// sourceURL returns the download location of the Linux sources of the
+// package, which is either a binary or a tarball.
+func sourceURL(bc *buildConfig) string {
+	switch sourcePackage(bc.PackageDefinition) {
+	case "kubernetes-cni":
+		return bc.CNIDownloadLink
+	case "cri-tools":
+		return GetCRIToolsDownloadLink(bc.Version, bc.GoArch)
+	}
+	return fmt.Sprintf(
+		"%s/bin/linux/%s/%s", bc.DownloadLinkBase, bc.GoArch, bc.Package,
+	)
+}
+
+// isSourceTarball returns true if the sources of the package are distributed
+// as tarball.
+func isSourceTarball(bc *buildConfig) bool {
+	pkg := sourcePackage(bc.PackageDefinition)
+	return pkg == cniPackage || pkg == "cri-tools"
+}
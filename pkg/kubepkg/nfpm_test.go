@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessNfpm(t *testing.T) {
+	for _, buildType := range []options.BuildType{
+		options.BuildDeb, options.BuildRpm,
+	} {
+		opts := options.New().
+			WithBackend(options.BackendNfpm).
+			WithPackages("kubectl", "kubernetes-cni").
+			WithChannels("release").
+			WithArchitectures("amd64")
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, buildType)
+		defer cleanup()
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+
+		err = sut.WalkBuilds(builds)
+		require.Nil(t, err)
+
+		require.Equal(t, 2, mock.DownloadFileCallCount())
+		require.Equal(t, 1, mock.ExtractCallCount())
+		require.Equal(t, 2, mock.RunSuccessWithWorkDirCallCount())
+		_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+		require.Equal(t, "nfpm", cmd)
+		require.Contains(t, args, string(buildType))
+	}
+}
+
+func TestWalkBuildsSuccessApk(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("arm64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildApk)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.Nil(t, err)
+
+	require.Equal(t, 1, mock.RunSuccessWithWorkDirCallCount())
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+	require.Equal(t, "nfpm", cmd)
+	require.Contains(t, args, "apk")
+	require.Contains(t, args, "kubeadm-1.18.0-r0.aarch64.apk")
+}
+
+func TestWalkBuildsFailureNfpmDownloadFailed(t *testing.T) {
+	opts := options.New().WithBackend(options.BackendNfpm)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	mock.DownloadFileReturns(err)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.NotNil(t, err)
+}
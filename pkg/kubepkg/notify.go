@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// maxNotifiedFailures is the maximum number of failed builds listed by a
+// Slack notification.
+const maxNotifiedFailures = 10
+
+// NotifyEvent is the stage of a run a notification is sent for.
+type NotifyEvent string
+
+const (
+	// NotifyStarted is sent before the first package gets built.
+	NotifyStarted NotifyEvent = "started"
+
+	// NotifySucceeded is sent after all packages have been built and
+	// uploaded.
+	NotifySucceeded NotifyEvent = "succeeded"
+
+	// NotifyFailed is sent if any build or the upload failed.
+	NotifyFailed NotifyEvent = "failed"
+)
+
+// Notification is the payload of the webhook notifications.
+type Notification struct {
+	Event              NotifyEvent       `json:"event"`
+	BuildType          options.BuildType `json:"buildType"`
+	KubernetesVersions []string          `json:"kubernetesVersions"`
+
+	// Message is the human readable description of the event.
+	Message string `json:"message"`
+
+	// Error is the error message of a failed run.
+	Error string `json:"error,omitempty"`
+
+	// LogsURL is the location of the build logs.
+	LogsURL string `json:"logsURL,omitempty"`
+
+	// ArtifactsURL is the location of the uploaded packages, which is only
+	// set if an upload bucket has been configured.
+	ArtifactsURL string `json:"artifactsURL,omitempty"`
+
+	// Summary are the results of all builds, which is only set for finished
+	// runs.
+	Summary *Summary `json:"summary,omitempty"`
+}
+
+// notify sends a notification for the event to the configured webhook.
+// Notifications are best effort, which means that failing to send them does
+// not fail the run.
+func (c *Client) notify(event NotifyEvent, walkErr error) {
+	if c.options.NotifyURL() == "" {
+		return
+	}
+
+	payload, err := c.newNotification(event, walkErr).Payload(c.options.NotifyFormat())
+	if err != nil {
+		logrus.Warnf("Unable to create %s notification: %v", event, err)
+		return
+	}
+
+	if c.options.DryRun() {
+		logrus.Infof("Dry run: would send %s notification", event)
+		return
+	}
+
+	// The URL is not logged, because webhook URLs usually contain a secret
+	logrus.Infof("Sending %s notification", event)
+	if err := c.impl.PostURL(c.options.NotifyURL(), payload); err != nil {
+		logrus.Warnf("Unable to send %s notification: %v", event, err)
+	}
+}
+
+// notifyFinished sends the notification of a finished run.
+func (c *Client) notifyFinished(walkErr error) {
+	if walkErr != nil {
+		c.notify(NotifyFailed, walkErr)
+		return
+	}
+	c.notify(NotifySucceeded, nil)
+}
+
+// newNotification returns the notification for the event.
+func (c *Client) newNotification(event NotifyEvent, walkErr error) *Notification {
+	n := &Notification{
+		Event:              event,
+		BuildType:          c.options.BuildType(),
+		KubernetesVersions: c.kubeVersions,
+		LogsURL:            c.options.LogsURL(),
+	}
+	subject := fmt.Sprintf("%s packages", n.BuildType)
+	if len(n.KubernetesVersions) > 0 {
+		subject += " for Kubernetes " + strings.Join(n.KubernetesVersions, ", ")
+	}
+
+	if event == NotifyStarted {
+		n.Message = fmt.Sprintf("kubepkg started building %s", subject)
+		return n
+	}
+
+	n.Summary = c.Summary()
+	counts := map[BuildStatus]int{}
+	for _, build := range n.Summary.Builds {
+		counts[build.Status]++
+	}
+	results := fmt.Sprintf(
+		"%d succeeded, %d failed, %d skipped",
+		counts[BuildSucceeded], counts[BuildFailed], counts[BuildSkipped],
+	)
+
+	if walkErr != nil {
+		n.Error = walkErr.Error()
+		n.Message = fmt.Sprintf("kubepkg failed building %s (%s)", subject, results)
+		return n
+	}
+
+	n.Message = fmt.Sprintf("kubepkg built %s (%s)", subject, results)
+	if c.options.UploadBucket() != "" {
+		if uploadPath, err := c.uploadPath(); err == nil {
+			n.ArtifactsURL = fmt.Sprintf(
+				"https://storage.googleapis.com/%s/%s/",
+				strings.TrimPrefix(c.options.UploadBucket(), "gs://"),
+				strings.Trim(uploadPath, "/"),
+			)
+		}
+	}
+	return n
+}
+
+// Payload returns the request body of the notification in the provided
+// format.
+func (n *Notification) Payload(format options.NotifyFormat) ([]byte, error) {
+	var payload interface{} = n
+	if format == options.NotifyFormatSlack {
+		payload = map[string]string{"text": n.SlackText()}
+	}
+	res, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling notification")
+	}
+	return res, nil
+}
+
+// SlackText returns the notification formatted as Slack message, which lists
+// the failed builds and links the artifacts and logs.
+func (n *Notification) SlackText() string {
+	b := &strings.Builder{}
+	icon := map[NotifyEvent]string{
+		NotifyStarted:   ":package:",
+		NotifySucceeded: ":white_check_mark:",
+		NotifyFailed:    ":x:",
+	}[n.Event]
+	fmt.Fprintf(b, "%s %s", icon, n.Message)
+
+	failed := []string{}
+	if n.Summary != nil {
+		for _, build := range n.Summary.Builds {
+			if build.Status == BuildFailed {
+				failed = append(failed, build.Name)
+			}
+		}
+	}
+	for i, name := range failed {
+		if i == maxNotifiedFailures {
+			fmt.Fprintf(b, "\n• and %d more", len(failed)-i)
+			break
+		}
+		fmt.Fprintf(b, "\n• `%s` failed", name)
+	}
+	// Errors apart from failed builds, like a failed upload, get shown as is
+	if n.Error != "" && len(failed) == 0 {
+		fmt.Fprintf(b, "\n```%s```", n.Error)
+	}
+
+	links := []string{}
+	if n.ArtifactsURL != "" {
+		links = append(links, fmt.Sprintf("<%s|Artifacts>", n.ArtifactsURL))
+	}
+	if n.LogsURL != "" {
+		links = append(links, fmt.Sprintf("<%s|Logs>", n.LogsURL))
+	}
+	if len(links) > 0 {
+		fmt.Fprintf(b, "\n%s", strings.Join(links, " | "))
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsNotifyWebhook(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithUploadBucket("bucket").
+		WithNotifyURL("https://example.com/hook").
+		WithLogsURL("https://prow.k8s.io/job")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(true)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	require.Equal(t, 2, mock.PostURLCallCount())
+	notifications := []kubepkg.Notification{}
+	for i := 0; i < mock.PostURLCallCount(); i++ {
+		url, body := mock.PostURLArgsForCall(i)
+		require.Equal(t, "https://example.com/hook", url)
+		notification := kubepkg.Notification{}
+		require.Nil(t, json.Unmarshal(body, &notification))
+		notifications = append(notifications, notification)
+	}
+
+	require.Equal(t, kubepkg.NotifyStarted, notifications[0].Event)
+	require.Nil(t, notifications[0].Summary)
+	require.Equal(t, kubepkg.NotifySucceeded, notifications[1].Event)
+	require.Equal(t, []string{"1.18.0"}, notifications[1].KubernetesVersions)
+	require.Equal(t, "https://prow.k8s.io/job", notifications[1].LogsURL)
+	require.Equal(t,
+		"https://storage.googleapis.com/bucket/stage/v1.18.0/packages/",
+		notifications[1].ArtifactsURL,
+	)
+	require.Len(t, notifications[1].Summary.Artifacts, 1)
+	require.Contains(t, notifications[1].Message, "1 succeeded, 0 failed")
+}
+
+func TestWalkBuildsNotifySlackFailure(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithNotifyURL("https://hooks.slack.com/services/secret").
+		WithNotifyFormat(options.NotifyFormatSlack).
+		WithLogsURL("https://prow.k8s.io/job")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(true)
+	mock.RunSuccessWithWorkDirReturns(errors.New("dpkg-buildpackage failed"))
+
+	// Failing to notify does not fail the run
+	mock.PostURLReturnsOnCall(0, errors.New("slack unavailable"))
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.NotNil(t, sut.WalkBuilds(builds))
+
+	require.Equal(t, 2, mock.PostURLCallCount())
+	_, body := mock.PostURLArgsForCall(1)
+	message := map[string]string{}
+	require.Nil(t, json.Unmarshal(body, &message))
+	require.Contains(t, message["text"], ":x: kubepkg failed building deb packages")
+	require.Contains(t, message["text"], "`kubeadm/release/amd64` failed")
+	require.Contains(t, message["text"], "<https://prow.k8s.io/job|Logs>")
+	require.NotContains(t, message["text"], "Artifacts")
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the file representation of the kubepkg options. It can be
+// written in either YAML or JSON. Unset fields keep their current value when
+// applied via WithConfig.
+type Config struct {
+	Backend Backend `json:"backend,omitempty"`
+
+	Packages      []string `json:"packages,omitempty"`
+	Channels      []string `json:"channels,omitempty"`
+	Architectures []string `json:"architectures,omitempty"`
+	CNIPlugins    []string `json:"cniPlugins,omitempty"`
+	Distros       []string `json:"distros,omitempty"`
+
+	KubeVersion     string   `json:"kubeVersion,omitempty"`
+	KubeVersions    []string `json:"kubeVersions,omitempty"`
+	Revision        string   `json:"revision,omitempty"`
+	CNIVersion      string   `json:"cniVersion,omitempty"`
+	CRIToolsVersion string   `json:"criToolsVersion,omitempty"`
+
+	ChannelDependencies map[string]DependencyVersions `json:"channelDependencies,omitempty"`
+	ChannelNames        map[string]string             `json:"channelNames,omitempty"`
+
+	ReleaseDownloadLinkBase string `json:"releaseDownloadLinkBase,omitempty"`
+
+	Systemd SystemdOptions          `json:"systemd,omitempty"`
+	Hooks   map[string]PackageHooks `json:"hooks,omitempty"`
+
+	TemplateDir       string   `json:"templateDir,omitempty"`
+	ExtraTemplateDirs []string `json:"extraTemplateDirs,omitempty"`
+	SpecOnly          *bool    `json:"specOnly,omitempty"`
+	ReleaseNotes      *bool    `json:"releaseNotes,omitempty"`
+	OutputDir         string   `json:"outputDir,omitempty"`
+	Force             *bool    `json:"force,omitempty"`
+	BinaryDir         string   `json:"binaryDir,omitempty"`
+	CacheDir          string   `json:"cacheDir,omitempty"`
+	SourceDir         string   `json:"sourceDir,omitempty"`
+
+	BuildInContainer *bool             `json:"buildInContainer,omitempty"`
+	ContainerRuntime string            `json:"containerRuntime,omitempty"`
+	ContainerImage   string            `json:"containerImage,omitempty"`
+	ContainerImages  map[string]string `json:"containerImages,omitempty"`
+	SetupQEMU        *bool             `json:"setupQEMU,omitempty"`
+
+	Concurrency         int          `json:"concurrency,omitempty"`
+	DownloadConcurrency int          `json:"downloadConcurrency,omitempty"`
+	OutputFormat        OutputFormat `json:"outputFormat,omitempty"`
+
+	SignKey          string `json:"signKey,omitempty"`
+	Provenance       *bool  `json:"provenance,omitempty"`
+	ValidatePackages *bool  `json:"validatePackages,omitempty"`
+
+	UploadBucket string `json:"uploadBucket,omitempty"`
+	UploadPath   string `json:"uploadPath,omitempty"`
+
+	LintPackages     *bool        `json:"lintPackages,omitempty"`
+	LintFailSeverity LintSeverity `json:"lintFailSeverity,omitempty"`
+
+	NotifyURL    string       `json:"notifyURL,omitempty"`
+	NotifyFormat NotifyFormat `json:"notifyFormat,omitempty"`
+	LogsURL      string       `json:"logsURL,omitempty"`
+
+	IssueRepo   string   `json:"issueRepo,omitempty"`
+	IssueLabels []string `json:"issueLabels,omitempty"`
+
+	DryRun *bool `json:"dryRun,omitempty"`
+}
+
+// LoadConfig reads the YAML or JSON config file from the provided path.
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading config file %s", path)
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, errors.Wrapf(err, "parsing config file %s", path)
+	}
+	return config, nil
+}
+
+// WithConfig applies all set values of the provided config to the options.
+func (o *Options) WithConfig(config *Config) *Options {
+	if config == nil {
+		return o
+	}
+	if config.Backend != "" {
+		o.backend = config.Backend
+	}
+	if len(config.Packages) > 0 {
+		o.packages = config.Packages
+	}
+	if len(config.Channels) > 0 {
+		o.channels = config.Channels
+	}
+	if len(config.Architectures) > 0 {
+		o.architectures = config.Architectures
+	}
+	if len(config.CNIPlugins) > 0 {
+		o.cniPlugins = config.CNIPlugins
+	}
+	if len(config.Distros) > 0 {
+		o.distros = config.Distros
+	}
+	if config.KubeVersion != "" {
+		o.kubeVersions = []string{config.KubeVersion}
+	}
+	if len(config.KubeVersions) > 0 {
+		o.kubeVersions = config.KubeVersions
+	}
+	if config.Revision != "" {
+		o.revision = config.Revision
+	}
+	if config.CNIVersion != "" {
+		o.cniVersion = config.CNIVersion
+	}
+	if config.CRIToolsVersion != "" {
+		o.criToolsVersion = config.CRIToolsVersion
+	}
+	if len(config.ChannelDependencies) > 0 {
+		o.channelDependencies = config.ChannelDependencies
+	}
+	if len(config.ChannelNames) > 0 {
+		o.channelNames = config.ChannelNames
+	}
+	if config.ReleaseDownloadLinkBase != "" {
+		o.releaseDownloadLinkBase = config.ReleaseDownloadLinkBase
+	}
+	if config.Systemd.CgroupDriver != "" {
+		o.systemd.CgroupDriver = config.Systemd.CgroupDriver
+	}
+	if len(config.Systemd.ExtraArgs) > 0 {
+		o.systemd.ExtraArgs = config.Systemd.ExtraArgs
+	}
+	if len(config.Systemd.EnvironmentFiles) > 0 {
+		o.systemd.EnvironmentFiles = config.Systemd.EnvironmentFiles
+	}
+	if len(config.Hooks) > 0 {
+		o.hooks = config.Hooks
+	}
+	if config.TemplateDir != "" {
+		o.templateDir = config.TemplateDir
+	}
+	if len(config.ExtraTemplateDirs) > 0 {
+		o.extraTemplateDirs = config.ExtraTemplateDirs
+	}
+	if config.SpecOnly != nil {
+		o.specOnly = *config.SpecOnly
+	}
+	if config.ReleaseNotes != nil {
+		o.releaseNotes = *config.ReleaseNotes
+	}
+	if config.OutputDir != "" {
+		o.outputDir = config.OutputDir
+	}
+	if config.Force != nil {
+		o.force = *config.Force
+	}
+	if config.BinaryDir != "" {
+		o.binaryDir = config.BinaryDir
+	}
+	if config.CacheDir != "" {
+		o.cacheDir = config.CacheDir
+	}
+	if config.SourceDir != "" {
+		o.sourceDir = config.SourceDir
+	}
+	if config.BuildInContainer != nil {
+		o.buildInContainer = *config.BuildInContainer
+	}
+	if config.ContainerRuntime != "" {
+		o.containerRuntime = config.ContainerRuntime
+	}
+	if config.ContainerImage != "" {
+		o.containerImage = config.ContainerImage
+	}
+	if len(config.ContainerImages) > 0 {
+		o.containerImages = config.ContainerImages
+	}
+	if config.SetupQEMU != nil {
+		o.setupQEMU = *config.SetupQEMU
+	}
+	if config.Concurrency != 0 {
+		o.concurrency = config.Concurrency
+	}
+	if config.DownloadConcurrency != 0 {
+		o.downloadConcurrency = config.DownloadConcurrency
+	}
+	if config.OutputFormat != "" {
+		o.outputFormat = config.OutputFormat
+	}
+	if config.SignKey != "" {
+		o.signKey = config.SignKey
+	}
+	if config.Provenance != nil {
+		o.provenance = *config.Provenance
+	}
+	if config.ValidatePackages != nil {
+		o.validatePackages = *config.ValidatePackages
+	}
+	if config.UploadBucket != "" {
+		o.uploadBucket = config.UploadBucket
+	}
+	if config.UploadPath != "" {
+		o.uploadPath = config.UploadPath
+	}
+	if config.LintPackages != nil {
+		o.lintPackages = *config.LintPackages
+	}
+	if config.LintFailSeverity != "" {
+		o.lintFailSeverity = config.LintFailSeverity
+	}
+	if config.NotifyURL != "" {
+		o.notifyURL = config.NotifyURL
+	}
+	if config.NotifyFormat != "" {
+		o.notifyFormat = config.NotifyFormat
+	}
+	if config.LogsURL != "" {
+		o.logsURL = config.LogsURL
+	}
+	if config.IssueRepo != "" {
+		o.issueRepo = config.IssueRepo
+	}
+	if len(config.IssueLabels) > 0 {
+		o.issueLabels = config.IssueLabels
+	}
+	if config.DryRun != nil {
+		o.dryRun = *config.DryRun
+	}
+	return o
+}
+
+// Config returns the file representation of all options, including the
+// defaults. Applying it via WithConfig results in the same options.
+func (o *Options) Config() *Config {
+	boolPtr := func(b bool) *bool { return &b }
+	return &Config{
+		Backend:                 o.backend,
+		Packages:                o.packages,
+		Channels:                o.channels,
+		Architectures:           o.architectures,
+		CNIPlugins:              o.cniPlugins,
+		Distros:                 o.distros,
+		KubeVersions:            o.kubeVersions,
+		Revision:                o.Revision(),
+		CNIVersion:              o.cniVersion,
+		CRIToolsVersion:         o.criToolsVersion,
+		ChannelDependencies:     o.channelDependencies,
+		ChannelNames:            o.channelNames,
+		ReleaseDownloadLinkBase: o.releaseDownloadLinkBase,
+		Systemd:                 o.systemd,
+		Hooks:                   o.hooks,
+		TemplateDir:             o.templateDir,
+		ExtraTemplateDirs:       o.extraTemplateDirs,
+		SpecOnly:                boolPtr(o.specOnly),
+		ReleaseNotes:            boolPtr(o.releaseNotes),
+		OutputDir:               o.outputDir,
+		Force:                   boolPtr(o.force),
+		BinaryDir:               o.binaryDir,
+		CacheDir:                o.cacheDir,
+		SourceDir:               o.sourceDir,
+		BuildInContainer:        boolPtr(o.buildInContainer),
+		ContainerRuntime:        o.containerRuntime,
+		ContainerImage:          o.containerImage,
+		ContainerImages:         o.containerImages,
+		SetupQEMU:               boolPtr(o.setupQEMU),
+		Concurrency:             o.concurrency,
+		DownloadConcurrency:     o.downloadConcurrency,
+		OutputFormat:            o.outputFormat,
+		SignKey:                 o.signKey,
+		Provenance:              boolPtr(o.provenance),
+		ValidatePackages:        boolPtr(o.validatePackages),
+		UploadBucket:            o.uploadBucket,
+		UploadPath:              o.uploadPath,
+		LintPackages:            boolPtr(o.lintPackages),
+		LintFailSeverity:        o.lintFailSeverity,
+		NotifyURL:               o.notifyURL,
+		NotifyFormat:            o.notifyFormat,
+		LogsURL:                 o.logsURL,
+		IssueRepo:               o.issueRepo,
+		IssueLabels:             o.issueLabels,
+		DryRun:                  boolPtr(o.dryRun),
+	}
+}
+
+// YAML returns the YAML representation of the config.
+func (c *Config) YAML() ([]byte, error) {
+	res, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling config")
+	}
+	return res, nil
+}
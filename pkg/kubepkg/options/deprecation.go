@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Deprecation marks a flag as deprecated.
+type Deprecation struct {
+	// Flag is the name of the deprecated flag, like kube-version.
+	Flag string
+
+	// Replacement is the flag to be used instead, if any.
+	Replacement string
+
+	// RemovalVersion is the kubepkg release which removes the flag.
+	RemovalVersion string
+
+	// Hint is an additional migration advice.
+	Hint string
+}
+
+// deprecations are all deprecated flags. Entries have to be kept until their
+// removal version has been released, for example:
+//
+//	{Flag: "old-flag", Replacement: "new-flag", RemovalVersion: "v1.0.0"},
+var deprecations = []Deprecation{
+	{
+		Flag:        "container-image",
+		Replacement: "container-images",
+		Hint:        "it overrides the images of all distros and build types, which can be selected individually now",
+	},
+}
+
+// Deprecations returns all deprecated flags.
+func Deprecations() []Deprecation {
+	return append([]Deprecation{}, deprecations...)
+}
+
+// Message returns the human readable description of the deprecation.
+func (d *Deprecation) Message() string {
+	msg := fmt.Sprintf("--%s is deprecated", d.Flag)
+	if d.RemovalVersion != "" {
+		msg += fmt.Sprintf(" and will be removed in %s", d.RemovalVersion)
+	}
+	if d.Replacement != "" {
+		msg += fmt.Sprintf(", use --%s instead", d.Replacement)
+	}
+	if d.Hint != "" {
+		msg += ": " + d.Hint
+	}
+	return msg
+}
+
+// Warn logs the deprecation as warning, whose fields allow automation to
+// detect the use of deprecated flags.
+func (d *Deprecation) Warn() {
+	logrus.WithFields(logrus.Fields{
+		"deprecatedFlag": d.Flag,
+		"replacement":    d.Replacement,
+		"removalVersion": d.RemovalVersion,
+	}).Warn(d.Message())
+}
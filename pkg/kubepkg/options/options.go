@@ -17,8 +17,12 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
 	"path/filepath"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -27,57 +31,327 @@ import (
 )
 
 type Options struct {
-	buildType BuildType
+	buildType        BuildType
+	backend          Backend
+	customBuildTypes []BuildType
 
 	revision        string
-	kubeVersion     string
+	kubeVersions    []string
 	cniVersion      string
 	criToolsVersion string
 
+	channelDependencies map[string]DependencyVersions
+	channelNames        map[string]string
+
 	packages      []string
 	channels      []string
 	architectures []string
+	distros       []string
+	cniPlugins    []string
 
 	releaseDownloadLinkBase string
 
-	templateDir string
-	specOnly    bool
+	systemd SystemdOptions
+	hooks   map[string]PackageHooks
+
+	templateDir       string
+	extraTemplateDirs []string
+	specOnly          bool
+	releaseNotes      bool
+	outputDir         string
+	force             bool
+	binaryDir         string
+	cacheDir          string
+	sourceDir         string
+
+	buildInContainer bool
+	containerRuntime string
+	containerImage   string
+	containerImages  map[string]string
+	setupQEMU        bool
+
+	concurrency         int
+	downloadConcurrency int
+	outputFormat        OutputFormat
+
+	signKey          string
+	provenance       bool
+	validatePackages bool
+
+	uploadBucket string
+	uploadPath   string
+
+	lintPackages     bool
+	lintFailSeverity LintSeverity
+
+	notifyURL    string
+	notifyFormat NotifyFormat
+	logsURL      string
+
+	issueRepo   string
+	issueLabels []string
+
+	dryRun bool
 }
 
 type BuildType string
 
+// SystemdOptions customize the kubelet systemd unit and the kubeadm drop-in
+// of it.
+type SystemdOptions struct {
+	// CgroupDriver is the cgroup driver of the kubelet, either "systemd" or
+	// "cgroupfs". The kubelet default is used if empty.
+	CgroupDriver string `json:"cgroupDriver,omitempty"`
+
+	// ExtraArgs are additional arguments of the kubelet.
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// EnvironmentFiles are additional optional environment files of the
+	// kubelet, which take precedence over the default ones.
+	EnvironmentFiles []string `json:"environmentFiles,omitempty"`
+}
+
+// PackageHooks are shell snippets added to the maintainer scripts of a
+// package.
+type PackageHooks struct {
+	// PostInstall is run after installing or upgrading the package, which
+	// is the configure step of the debian postinst and the rpm %post.
+	PostInstall string `json:"postInstall,omitempty"`
+
+	// PreRemove is run before removing or upgrading the package, which is
+	// the debian prerm and the rpm %preun.
+	PreRemove string `json:"preRemove,omitempty"`
+}
+
+// KubeletArgs returns the kubelet arguments to be added to the systemd unit.
+func (s SystemdOptions) KubeletArgs() []string {
+	args := []string{}
+	if s.CgroupDriver != "" {
+		args = append(args, "--cgroup-driver="+s.CgroupDriver)
+	}
+	return append(args, s.ExtraArgs...)
+}
+
+// DependencyVersions are the pinned versions of the package dependencies.
+// Empty versions are not pinned.
+type DependencyVersions struct {
+	CNIVersion       string `json:"cniVersion,omitempty"`
+	CRIToolsVersion  string `json:"criToolsVersion,omitempty"`
+	ConntrackVersion string `json:"conntrackVersion,omitempty"`
+}
+
+// OutputFormat is the format of the build summary printed after walking the
+// builds.
+type OutputFormat string
+
+const (
+	// OutputFormatText prints a table of all build results including their
+	// status and duration to stdout.
+	OutputFormatText OutputFormat = "text"
+
+	// OutputFormatJSON prints a machine readable summary of all built
+	// artifacts and build results to stdout.
+	OutputFormatJSON OutputFormat = "json"
+)
+
+// NotifyFormat is the payload format of the build notifications.
+type NotifyFormat string
+
+const (
+	// NotifyFormatWebhook posts the notification including the build
+	// summary as JSON object.
+	NotifyFormatWebhook NotifyFormat = "webhook"
+
+	// NotifyFormatSlack posts the notification as message of a Slack
+	// incoming webhook.
+	NotifyFormatSlack NotifyFormat = "slack"
+)
+
+// LintSeverity is the severity of a finding of lintian or rpmlint.
+type LintSeverity string
+
+const (
+	// LintSeverityError are violations of the packaging policy.
+	LintSeverityError LintSeverity = "error"
+
+	// LintSeverityWarning are likely issues of the package.
+	LintSeverityWarning LintSeverity = "warning"
+
+	// LintSeverityInfo are informational, pedantic or experimental
+	// findings.
+	LintSeverityInfo LintSeverity = "info"
+)
+
+// lintSeverityLevels orders the lint severities.
+var lintSeverityLevels = map[LintSeverity]int{
+	LintSeverityInfo:    1,
+	LintSeverityWarning: 2,
+	LintSeverityError:   3,
+}
+
+// AtLeast returns true if the severity is equal to or higher than the
+// provided one.
+func (s LintSeverity) AtLeast(severity LintSeverity) bool {
+	return lintSeverityLevels[s] >= lintSeverityLevels[severity]
+}
+
+// SignKeyType is the source of the GPG key used for signing packages.
+type SignKeyType string
+
+const (
+	// SignKeyFile imports an armored secret key from a file into a
+	// temporary keyring.
+	SignKeyFile SignKeyType = "file"
+
+	// SignKeyKeyring uses a key of the default GPG keyring.
+	SignKeyKeyring SignKeyType = "keyring"
+
+	// SignKeyKMS uses a key of the default GPG keyring, whose secret part
+	// is provided by a KMS-backed smartcard daemon.
+	SignKeyKMS SignKeyType = "kms"
+)
+
+// Backend is the tool used for building the packages.
+type Backend string
+
 const (
 	BuildDeb BuildType = "deb"
 	BuildRpm BuildType = "rpm"
+	BuildApk BuildType = "apk"
+
+	// BuildArchLinux generates Arch Linux PKGBUILDs and builds the packages
+	// via makepkg.
+	BuildArchLinux BuildType = "archlinux"
+
+	// BuildSnap builds snaps via snapcraft.
+	BuildSnap BuildType = "snap"
+
+	// BuildChocolatey builds Chocolatey packages for Windows.
+	BuildChocolatey BuildType = "chocolatey"
+
+	// BuildWinget generates winget manifests for Windows.
+	BuildWinget BuildType = "winget"
+
+	// BuildMsi builds Windows Installer packages for Windows nodes.
+	BuildMsi BuildType = "msi"
+
 	BuildAll BuildType = "all"
 
+	// BackendNative builds packages by using the distribution tooling
+	// (dpkg-buildpackage, rpmbuild) available on the host.
+	BackendNative Backend = "native"
+
+	// BackendNfpm builds packages by using nfpm, which works on any host
+	// operating system.
+	BackendNfpm Backend = "nfpm"
+
 	DefaultReleaseDownloadLinkBase = "https://dl.k8s.io"
 
+	// DefaultOutputDir is the default directory for built packages and
+	// specs.
+	DefaultOutputDir = "bin"
+
+	// LatestVersion can be used as CNI or CRI tools version to build the
+	// newest release of the project.
+	LatestVersion = "latest"
+
 	defaultRevision = "0"
 	templateRootDir = "templates"
+
+	defaultDownloadConcurrency = 4
 )
 
 var (
 	supportedPackages = []string{
 		"kubelet", "kubectl", "kubeadm", "kubernetes-cni", "cri-tools",
 	}
+	// packagePresets are named package sets which can be selected instead
+	// of listing the individual packages. The minimal preset is meant for
+	// appliance-style node images which only run the kubelet.
+	packagePresets = map[string][]string{
+		"all":     supportedPackages,
+		"minimal": {"kubelet", "kubernetes-cni"},
+	}
+	// supportedCNIPlugins are the plugin binaries of the CNI plugins
+	// release tarballs.
+	supportedCNIPlugins = []string{
+		"bandwidth", "bridge", "dhcp", "firewall", "flannel", "host-device",
+		"host-local", "ipvlan", "loopback", "macvlan", "portmap", "ptp", "sbr",
+		"static", "tuning", "vlan", "vrf",
+	}
+	supportedCgroupDrivers = []string{
+		"systemd", "cgroupfs",
+	}
 	supportedChannels = []string{
 		"release", "testing", "nightly",
 	}
 	supportedArchitectures = []string{
+		"amd64", "arm", "arm64", "ppc64le", "s390x", "riscv64",
+	}
+	// defaultArchitectures excludes riscv64, because its binaries are not
+	// available for older Kubernetes versions.
+	defaultArchitectures = []string{
 		"amd64", "arm", "arm64", "ppc64le", "s390x",
 	}
+	// distroBuildTypes are the supported distributions by the build type
+	// of their packages.
+	distroBuildTypes = map[string]BuildType{
+		"debian-bullseye": BuildDeb,
+		"debian-bookworm": BuildDeb,
+		"ubuntu-focal":    BuildDeb,
+		"ubuntu-jammy":    BuildDeb,
+		"el8":             BuildRpm,
+		"el9":             BuildRpm,
+		"fc39":            BuildRpm,
+		"fc40":            BuildRpm,
+	}
+	supportedBuildTypes = []string{
+		string(BuildDeb), string(BuildRpm), string(BuildApk),
+		string(BuildArchLinux), string(BuildSnap), string(BuildChocolatey),
+		string(BuildWinget), string(BuildMsi),
+	}
+	supportedBackends = []string{
+		string(BackendNative), string(BackendNfpm),
+	}
+	supportedContainerRuntimes = []string{
+		"docker", "podman",
+	}
+	supportedOutputFormats = []string{
+		string(OutputFormatText), string(OutputFormatJSON),
+	}
+	supportedNotifyFormats = []string{
+		string(NotifyFormatWebhook), string(NotifyFormatSlack),
+	}
 	latestTemplateDir = filepath.Join(templateRootDir, "latest")
+
+	// channelNameRegex matches the valid published channel names, which
+	// are used as directory names and deb distributions.
+	channelNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+	// issueRepoRegex matches GitHub repositories in the form <owner>/<repo>.
+	issueRepoRegex = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
+	// kubeVersionMarkerRegex matches the published version markers like
+	// stable, stable-1.28, latest or ci/latest.
+	kubeVersionMarkerRegex = regexp.MustCompile(
+		`^((release|ci)/)?(stable|latest)(-\d+\.\d+)?$`,
+	)
 )
 
 func New() *Options {
 	return &Options{
-		revision:                defaultRevision,
+		backend:                 BackendNative,
 		packages:                supportedPackages,
 		channels:                supportedChannels,
-		architectures:           supportedArchitectures,
+		architectures:           defaultArchitectures,
 		releaseDownloadLinkBase: DefaultReleaseDownloadLinkBase,
 		templateDir:             latestTemplateDir,
+		outputDir:               DefaultOutputDir,
+		concurrency:             1,
+		downloadConcurrency:     defaultDownloadConcurrency,
+		outputFormat:            OutputFormatText,
+		lintFailSeverity:        LintSeverityError,
+		notifyFormat:            NotifyFormatWebhook,
 	}
 }
 
@@ -86,26 +360,76 @@ func (o *Options) WithBuildType(buildType BuildType) *Options {
 	return o
 }
 
+// WithCustomBuildTypes sets the build types which are provided by builder
+// plugins in addition to the builtin ones.
+func (o *Options) WithCustomBuildTypes(buildTypes ...BuildType) *Options {
+	o.customBuildTypes = buildTypes
+	return o
+}
+
+func (o *Options) WithBackend(backend Backend) *Options {
+	o.backend = backend
+	return o
+}
+
+// WithRevision sets the package revision. An empty revision gets derived
+// from the git state of the source directory if one has been set.
 func (o *Options) WithRevision(revision string) *Options {
 	o.revision = revision
 	return o
 }
 
 func (o *Options) WithKubeVersion(kubeVersion string) *Options {
-	o.kubeVersion = kubeVersion
+	if kubeVersion == "" {
+		return o.WithKubeVersions()
+	}
+	return o.WithKubeVersions(kubeVersion)
+}
+
+// WithKubeVersions sets multiple Kubernetes versions to be built. Every
+// version can also be a range of patch versions like 1.22.0-1.22.3, which
+// gets expanded on Validate, or a version marker like stable-1.28, which
+// gets resolved when constructing the builds.
+func (o *Options) WithKubeVersions(kubeVersions ...string) *Options {
+	o.kubeVersions = kubeVersions
 	return o
 }
 
+// WithCNIVersion sets the CNI version to build, which can be LatestVersion
+// to build the newest release of the CNI plugins.
 func (o *Options) WithCNIVersion(cniVersion string) *Options {
 	o.cniVersion = cniVersion
 	return o
 }
 
+// WithCRIToolsVersion sets the CRI tools version to build, which can be
+// LatestVersion to build the newest release of the CRI tools.
 func (o *Options) WithCRIToolsVersion(criToolsVersion string) *Options {
 	o.criToolsVersion = criToolsVersion
 	return o
 }
 
+// WithChannelDependencies pins the dependency versions per channel, for
+// example to require a newer CNI version for the nightly channel.
+func (o *Options) WithChannelDependencies(
+	channelDependencies map[string]DependencyVersions,
+) *Options {
+	o.channelDependencies = channelDependencies
+	return o
+}
+
+// WithChannelNames sets the published names of the channels, for example
+// release=stable and nightly=unstable. The channels determine the Kubernetes
+// versions of their packages: release versions for release, pre-releases for
+// testing and CI builds for nightly. Multiple channels can share a name, which
+// publishes their packages in the same channel.
+func (o *Options) WithChannelNames(channelNames map[string]string) *Options {
+	o.channelNames = channelNames
+	return o
+}
+
+// WithPackages sets the packages to build. Every package can also be a
+// preset like minimal, which gets expanded on Validate.
 func (o *Options) WithPackages(packages ...string) *Options {
 	o.packages = packages
 	return o
@@ -121,31 +445,229 @@ func (o *Options) WithArchitectures(architectures ...string) *Options {
 	return o
 }
 
+func (o *Options) WithDistros(distros ...string) *Options {
+	o.distros = distros
+	return o
+}
+
+// WithCNIPlugins sets the CNI plugins to build individual packages for, in
+// addition to the kubernetes-cni package.
+func (o *Options) WithCNIPlugins(cniPlugins ...string) *Options {
+	o.cniPlugins = cniPlugins
+	return o
+}
+
 func (o *Options) WithReleaseDownloadLinkBase(releaseDownloadLinkBase string) *Options {
 	o.releaseDownloadLinkBase = releaseDownloadLinkBase
 	return o
 }
 
+// WithSystemd sets the customizations of the kubelet systemd unit.
+func (o *Options) WithSystemd(systemd SystemdOptions) *Options {
+	o.systemd = systemd
+	return o
+}
+
+// WithHooks sets the maintainer script hooks per package.
+func (o *Options) WithHooks(hooks map[string]PackageHooks) *Options {
+	o.hooks = hooks
+	return o
+}
+
 func (o *Options) WithTemplateDir(templateDir string) *Options {
 	o.templateDir = templateDir
 	return o
 }
 
+func (o *Options) WithExtraTemplateDirs(extraTemplateDirs ...string) *Options {
+	o.extraTemplateDirs = extraTemplateDirs
+	return o
+}
+
 func (o *Options) WithSpecOnly(specOnly bool) *Options {
 	o.specOnly = specOnly
 	return o
 }
 
+func (o *Options) WithReleaseNotes(releaseNotes bool) *Options {
+	o.releaseNotes = releaseNotes
+	return o
+}
+
+func (o *Options) WithOutputDir(outputDir string) *Options {
+	o.outputDir = outputDir
+	return o
+}
+
+func (o *Options) WithForce(force bool) *Options {
+	o.force = force
+	return o
+}
+
+func (o *Options) WithBinaryDir(binaryDir string) *Options {
+	o.binaryDir = binaryDir
+	return o
+}
+
+// WithSourceDir sets the git repository the package revision gets derived
+// from if no revision has been set.
+func (o *Options) WithSourceDir(sourceDir string) *Options {
+	o.sourceDir = sourceDir
+	return o
+}
+
+func (o *Options) WithCacheDir(cacheDir string) *Options {
+	o.cacheDir = cacheDir
+	return o
+}
+
+func (o *Options) WithBuildInContainer(buildInContainer bool) *Options {
+	o.buildInContainer = buildInContainer
+	return o
+}
+
+func (o *Options) WithContainerRuntime(containerRuntime string) *Options {
+	o.containerRuntime = containerRuntime
+	return o
+}
+
+func (o *Options) WithContainerImage(containerImage string) *Options {
+	o.containerImage = containerImage
+	return o
+}
+
+// WithContainerImages sets the container images for building in containers
+// by distribution, like el9, or by build type, like deb, for generic
+// packages.
+func (o *Options) WithContainerImages(containerImages map[string]string) *Options {
+	o.containerImages = containerImages
+	return o
+}
+
+func (o *Options) WithSetupQEMU(setupQEMU bool) *Options {
+	o.setupQEMU = setupQEMU
+	return o
+}
+
+func (o *Options) WithConcurrency(concurrency int) *Options {
+	o.concurrency = concurrency
+	return o
+}
+
+// WithDownloadConcurrency sets the maximum number of files which get
+// downloaded in parallel across all builds.
+func (o *Options) WithDownloadConcurrency(downloadConcurrency int) *Options {
+	o.downloadConcurrency = downloadConcurrency
+	return o
+}
+
+func (o *Options) WithOutputFormat(outputFormat OutputFormat) *Options {
+	o.outputFormat = outputFormat
+	return o
+}
+
+func (o *Options) WithSignKey(signKey string) *Options {
+	o.signKey = signKey
+	return o
+}
+
+func (o *Options) WithProvenance(provenance bool) *Options {
+	o.provenance = provenance
+	return o
+}
+
+func (o *Options) WithValidatePackages(validatePackages bool) *Options {
+	o.validatePackages = validatePackages
+	return o
+}
+
+func (o *Options) WithUploadBucket(uploadBucket string) *Options {
+	o.uploadBucket = uploadBucket
+	return o
+}
+
+func (o *Options) WithUploadPath(uploadPath string) *Options {
+	o.uploadPath = uploadPath
+	return o
+}
+
+func (o *Options) WithNotifyURL(notifyURL string) *Options {
+	o.notifyURL = notifyURL
+	return o
+}
+
+func (o *Options) WithNotifyFormat(notifyFormat NotifyFormat) *Options {
+	o.notifyFormat = notifyFormat
+	return o
+}
+
+func (o *Options) WithLogsURL(logsURL string) *Options {
+	o.logsURL = logsURL
+	return o
+}
+
+// WithIssueRepo sets the GitHub repository in the form <owner>/<repo>, in
+// which failed runs open or update a tracking issue.
+func (o *Options) WithIssueRepo(issueRepo string) *Options {
+	o.issueRepo = issueRepo
+	return o
+}
+
+func (o *Options) WithIssueLabels(issueLabels ...string) *Options {
+	o.issueLabels = issueLabels
+	return o
+}
+
+func (o *Options) WithDryRun(dryRun bool) *Options {
+	o.dryRun = dryRun
+	return o
+}
+
+func (o *Options) WithLintPackages(lintPackages bool) *Options {
+	o.lintPackages = lintPackages
+	return o
+}
+
+func (o *Options) WithLintFailSeverity(lintFailSeverity LintSeverity) *Options {
+	o.lintFailSeverity = lintFailSeverity
+	return o
+}
+
 func (o *Options) BuildType() BuildType {
 	return o.buildType
 }
 
+// CustomBuildTypes returns the build types which are provided by builder
+// plugins in addition to the builtin ones.
+func (o *Options) CustomBuildTypes() []BuildType {
+	return o.customBuildTypes
+}
+
+func (o *Options) Backend() Backend {
+	return o.backend
+}
+
+// Revision returns the package revision. It defaults to 0 unless a source
+// directory has been set, where an empty revision indicates that it gets
+// derived from the git state of the source directory.
 func (o *Options) Revision() string {
+	if o.revision == "" && o.sourceDir == "" {
+		return defaultRevision
+	}
 	return o.revision
 }
 
 func (o *Options) KubeVersion() string {
-	return o.kubeVersion
+	if len(o.kubeVersions) == 0 {
+		return ""
+	}
+	return o.kubeVersions[0]
+}
+
+// KubeVersions returns all Kubernetes versions to be built. An empty list
+// indicates that the version gets resolved per channel.
+func (o *Options) KubeVersions() []string {
+	return o.kubeVersions
 }
 
 func (o *Options) CNIVersion() string {
@@ -156,6 +678,25 @@ func (o *Options) CRIToolsVersion() string {
 	return o.criToolsVersion
 }
 
+// ChannelDependencies returns the dependency versions pinned per channel.
+func (o *Options) ChannelDependencies() map[string]DependencyVersions {
+	return o.channelDependencies
+}
+
+// ChannelNames returns the published names by channel.
+func (o *Options) ChannelNames() map[string]string {
+	return o.channelNames
+}
+
+// ChannelName returns the published name of the channel, which is the
+// channel itself unless it has been renamed.
+func (o *Options) ChannelName(channel string) string {
+	if name, ok := o.channelNames[channel]; ok {
+		return name
+	}
+	return channel
+}
+
 func (o *Options) Packages() []string {
 	return o.packages
 }
@@ -168,34 +709,632 @@ func (o *Options) Architectures() []string {
 	return o.architectures
 }
 
+// Distros returns the distributions to build distro-specific package
+// variants for. The generic packages get built if it is empty.
+func (o *Options) Distros() []string {
+	return o.distros
+}
+
+// CNIPlugins returns the CNI plugins to build individual packages for. They
+// only get built if the kubernetes-cni package is selected.
+func (o *Options) CNIPlugins() []string {
+	return o.cniPlugins
+}
+
 func (o *Options) ReleaseDownloadLinkBase() string {
 	return o.releaseDownloadLinkBase
 }
 
+// Systemd returns the customizations of the kubelet systemd unit.
+func (o *Options) Systemd() SystemdOptions {
+	return o.systemd
+}
+
+// Hooks returns the maintainer script hooks per package.
+func (o *Options) Hooks() map[string]PackageHooks {
+	return o.hooks
+}
+
 func (o *Options) TemplateDir() string {
 	return o.templateDir
 }
 
+// ExtraTemplateDirs returns additional template directories, which use the
+// layout of the template directory. Their files override the ones of the
+// template directory with the same path, whereas later directories take
+// precedence.
+func (o *Options) ExtraTemplateDirs() []string {
+	return o.extraTemplateDirs
+}
+
 func (o *Options) SpecOnly() bool {
 	return o.specOnly
 }
 
-// Validate verifies if all set options are valid
+// ReleaseNotes returns true if the changelogs of the Kubernetes packages
+// should contain the release notes of the packaged version.
+func (o *Options) ReleaseNotes() bool {
+	return o.releaseNotes
+}
+
+// OutputDir returns the directory where packages get written to, one
+// subdirectory per channel. Specs get written to its specs subdirectory if
+// only specs are created.
+func (o *Options) OutputDir() string {
+	return o.outputDir
+}
+
+// Force returns true if packages should be rebuilt even if they already exist
+// in the output directory.
+func (o *Options) Force() bool {
+	return o.force
+}
+
+// BinaryDir returns the local Kubernetes _output tree, which contains the
+// binaries to be packaged instead of downloading them. An empty string
+// indicates that the binaries should be downloaded.
+func (o *Options) BinaryDir() string {
+	return o.binaryDir
+}
+
+// SourceDir returns the git repository the package revision gets derived
+// from if no revision has been set.
+func (o *Options) SourceDir() string {
+	return o.sourceDir
+}
+
+// CacheDir returns the directory for caching downloads, which get verified
+// against their published checksums. An empty string disables the cache.
+func (o *Options) CacheDir() string {
+	return o.cacheDir
+}
+
+func (o *Options) BuildInContainer() bool {
+	return o.buildInContainer
+}
+
+// ContainerRuntime returns the container runtime to be used for building in
+// containers. An empty string indicates that the runtime should be detected
+// automatically.
+func (o *Options) ContainerRuntime() string {
+	return o.containerRuntime
+}
+
+// ContainerImage returns the image to be used for building all packages in
+// containers. An empty string indicates that the default image for the
+// distribution or build type should be used.
+//
+// Deprecated: use ContainerImages instead.
+func (o *Options) ContainerImage() string {
+	return o.containerImage
+}
+
+// ContainerImages returns the custom container images for building in
+// containers by distribution or build type. Images of a distribution take
+// precedence over the ones of its build type.
+func (o *Options) ContainerImages() map[string]string {
+	return o.containerImages
+}
+
+// SetupQEMU returns true if QEMU user emulation should be registered on the
+// host if it is required for running binaries of foreign architectures.
+func (o *Options) SetupQEMU() bool {
+	return o.setupQEMU
+}
+
+// Concurrency returns the maximum number of packages which get built in
+// parallel.
+func (o *Options) Concurrency() int {
+	return o.concurrency
+}
+
+// DownloadConcurrency returns the maximum number of files which get
+// downloaded in parallel across all builds.
+func (o *Options) DownloadConcurrency() int {
+	return o.downloadConcurrency
+}
+
+// OutputFormat returns the format of the build summary.
+func (o *Options) OutputFormat() OutputFormat {
+	return o.outputFormat
+}
+
+// SignKey returns the key used for signing packages. An empty string
+// indicates that packages should not be signed.
+func (o *Options) SignKey() string {
+	return o.signKey
+}
+
+// Provenance returns true if an in-toto SLSA provenance statement should be
+// written next to every built package.
+func (o *Options) Provenance() bool {
+	return o.provenance
+}
+
+// ValidatePackages returns true if the metadata of every built deb and rpm
+// should be validated before it gets copied to the output directory.
+func (o *Options) ValidatePackages() bool {
+	return o.validatePackages
+}
+
+// UploadBucket returns the GCS bucket the packages, checksums and build
+// summary get uploaded to after a successful run. An empty string indicates
+// that nothing should be uploaded.
+func (o *Options) UploadBucket() string {
+	return o.uploadBucket
+}
+
+// UploadPath returns the path within the upload bucket. An empty string
+// indicates that the release staging path of the built Kubernetes version
+// should be used.
+func (o *Options) UploadPath() string {
+	return o.uploadPath
+}
+
+// NotifyURL returns the webhook URL, which gets notified when walking the
+// builds starts and finishes. An empty string disables the notifications.
+func (o *Options) NotifyURL() string {
+	return o.notifyURL
+}
+
+// NotifyFormat returns the payload format of the build notifications.
+func (o *Options) NotifyFormat() NotifyFormat {
+	return o.notifyFormat
+}
+
+// LogsURL returns the location of the build logs, for example the URL of the
+// CI job, which gets linked by the build notifications.
+func (o *Options) LogsURL() string {
+	return o.logsURL
+}
+
+// IssueRepo returns the GitHub repository in the form <owner>/<repo>, in
+// which failed runs open or update a tracking issue. An empty string disables
+// the tracking issues.
+func (o *Options) IssueRepo() string {
+	return o.issueRepo
+}
+
+// IssueLabels returns the labels of the tracking issues, which are also used
+// to find the existing issue of a failure.
+func (o *Options) IssueLabels() []string {
+	return o.issueLabels
+}
+
+// DryRun returns true if the run must not modify any remote locations,
+// which means that uploads, publishing, notifications and tracking issues only
+// get logged.
+func (o *Options) DryRun() bool {
+	return o.dryRun
+}
+
+// LintPackages returns true if every built deb and rpm should be checked by
+// lintian or rpmlint inside the container image of its build type.
+func (o *Options) LintPackages() bool {
+	return o.lintPackages
+}
+
+// LintFailSeverity returns the lowest severity of lint findings which fails
+// the build.
+func (o *Options) LintFailSeverity() LintSeverity {
+	return o.lintFailSeverity
+}
+
+// Validate verifies if all set options are valid. It checks all options up
+// front and reports every problem at once as ValidationError, instead of
+// failing on the first one.
 func (o *Options) Validate() error {
-	if ok := isSupported(o.packages, supportedPackages); !ok {
-		return errors.New("package selections are not supported")
+	errs := []string{}
+	invalid := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
 	}
-	if ok := isSupported(o.channels, supportedChannels); !ok {
-		return errors.New("channel selections are not supported")
+	checkSupported := func(name string, values, supported []string) {
+		if unsupported := unsupportedValues(values, supported); len(unsupported) > 0 {
+			invalid(
+				"%s not supported: %s (use any of: %s)", name,
+				strings.Join(unsupported, ", "), strings.Join(supported, ", "),
+			)
+		}
 	}
-	if ok := isSupported(o.architectures, supportedArchitectures); !ok {
-		return errors.New("architectures selections are not supported")
+	checkVersion := func(name, version string) {
+		if _, err := util.TagStringToSemver(version); err != nil {
+			invalid("%s %q is not valid semver: %v", name, version, err)
+		}
 	}
 
-	// Replace the "+" with a "-" to make it semver-compliant
-	o.kubeVersion = util.TrimTagPrefix(o.kubeVersion)
+	o.packages = ExpandPackages(o.packages)
+	checkSupported("packages", o.packages, supportedPackages)
+	for channel, name := range o.channelNames {
+		if ok := isSupported([]string{channel}, supportedChannels); !ok {
+			invalid("renamed channel %q is not supported", channel)
+		}
+		if !channelNameRegex.MatchString(name) {
+			invalid("name %q of channel %s is invalid", name, channel)
+		}
+	}
+	o.channels = o.expandChannelNames(o.channels)
+	checkSupported("channels", o.channels, supportedChannels)
+	checkSupported("architectures", o.architectures, supportedArchitectures)
+	checkSupported("CNI plugins", o.cniPlugins, supportedCNIPlugins)
+	if o.buildType != "" && !o.isCustomBuildType(o.buildType) {
+		if ok := isSupported([]string{string(o.buildType)}, supportedBuildTypes); !ok {
+			invalid("build type %q is not supported", o.buildType)
+		}
+	}
+	for _, distro := range o.distros {
+		buildType, ok := distroBuildTypes[distro]
+		if !ok {
+			invalid(
+				"distro %q is not supported, use one of: %s",
+				distro, strings.Join(SupportedDistros(), ", "),
+			)
+			continue
+		}
+		if o.buildType != "" && o.buildType != buildType {
+			invalid("distro %q requires %s packages, got %s", distro, buildType, o.buildType)
+		}
+	}
+	if ok := isSupported([]string{string(o.backend)}, supportedBackends); !ok {
+		invalid("backend %q is not supported", o.backend)
+	}
+	if o.containerRuntime != "" {
+		if ok := isSupported([]string{o.containerRuntime}, supportedContainerRuntimes); !ok {
+			invalid("container runtime %q is not supported", o.containerRuntime)
+		}
+	}
+	for key := range o.containerImages {
+		if _, ok := distroBuildTypes[key]; !ok && !isSupported([]string{key}, supportedBuildTypes) {
+			invalid("container image for %q is neither for a supported distro nor build type", key)
+		}
+	}
+	if o.buildInContainer && o.backend != BackendNative {
+		invalid("building in containers requires the %q backend", BackendNative)
+	}
+	if o.outputDir == "" {
+		invalid("output directory must not be empty")
+	}
+	if o.systemd.CgroupDriver != "" {
+		if ok := isSupported([]string{o.systemd.CgroupDriver}, supportedCgroupDrivers); !ok {
+			invalid("cgroup driver %q is not supported", o.systemd.CgroupDriver)
+		}
+	}
+	for pkg := range o.hooks {
+		if !isSupportedHookPackage(pkg) {
+			invalid("package %q of the hooks is not supported", pkg)
+		}
+	}
+	for _, file := range o.systemd.EnvironmentFiles {
+		if !filepath.IsAbs(file) {
+			invalid("environment file %s has to be an absolute path", file)
+		}
+	}
+	if o.cniVersion != "" && o.cniVersion != LatestVersion {
+		checkVersion("CNI version", o.cniVersion)
+	}
+	if o.criToolsVersion != "" && o.criToolsVersion != LatestVersion {
+		checkVersion("CRI tools version", o.criToolsVersion)
+	}
+	for channel, versions := range o.channelDependencies {
+		if ok := isSupported([]string{channel}, supportedChannels); !ok {
+			invalid("channel %q of the dependency versions is not supported", channel)
+		}
+		for _, version := range []string{
+			versions.CNIVersion, versions.CRIToolsVersion, versions.ConntrackVersion,
+		} {
+			if version != "" {
+				checkVersion("dependency version of channel "+channel, version)
+			}
+		}
+	}
+	if o.binaryDir != "" && len(o.kubeVersions) == 0 {
+		invalid("a Kubernetes version is required when using a binary directory")
+	}
+	if o.concurrency < 1 {
+		invalid("concurrency has to be at least 1, got %d", o.concurrency)
+	}
+	if o.downloadConcurrency < 1 {
+		invalid("download concurrency has to be at least 1, got %d", o.downloadConcurrency)
+	}
+	if ok := isSupported([]string{string(o.outputFormat)}, supportedOutputFormats); !ok {
+		invalid("output format %q is not supported", o.outputFormat)
+	}
+	if o.notifyURL != "" {
+		if !strings.HasPrefix(o.notifyURL, "http://") && !strings.HasPrefix(o.notifyURL, "https://") {
+			invalid("notify URL %q has to be an HTTP or HTTPS URL", o.notifyURL)
+		}
+		if ok := isSupported([]string{string(o.notifyFormat)}, supportedNotifyFormats); !ok {
+			invalid("notify format %q is not supported", o.notifyFormat)
+		}
+	}
+	if o.issueRepo != "" && !issueRepoRegex.MatchString(o.issueRepo) {
+		invalid("issue repository %q has to be in the form <owner>/<repo>", o.issueRepo)
+	}
+	if _, ok := lintSeverityLevels[o.lintFailSeverity]; !ok {
+		invalid("lint fail severity %q is not supported", o.lintFailSeverity)
+	}
+	if o.signKey != "" {
+		if _, _, err := ParseSignKey(o.signKey); err != nil {
+			invalid("parsing sign key: %v", err)
+		}
+	}
+
+	kubeVersions := []string{}
+	for _, kubeVersion := range o.kubeVersions {
+		if IsKubeVersionMarker(kubeVersion) {
+			kubeVersions = append(kubeVersions, kubeVersion)
+			continue
+		}
+		expanded, err := ExpandKubeVersion(kubeVersion)
+		if err != nil {
+			invalid("expanding Kubernetes version %s: %v", kubeVersion, err)
+			continue
+		}
+		for _, v := range expanded {
+			checkVersion("Kubernetes version", v)
+			// Replace the "+" with a "-" to make it semver-compliant
+			kubeVersions = append(kubeVersions, util.TrimTagPrefix(v))
+		}
+	}
+	if len(o.kubeVersions) > 0 {
+		o.kubeVersions = kubeVersions
+	}
+
+	// Options acting on the built packages are mutually exclusive with only
+	// creating the specs
+	if o.specOnly {
+		for _, option := range []struct {
+			name string
+			set  bool
+		}{
+			{"uploading packages", o.uploadBucket != ""},
+			{"signing packages", o.signKey != ""},
+			{"writing provenance", o.provenance},
+			{"validating packages", o.validatePackages},
+			{"linting packages", o.lintPackages},
+		} {
+			if option.set {
+				invalid("%s is not possible if only specs get created", option.name)
+			}
+		}
+	}
+	if o.uploadBucket != "" && o.uploadPath == "" && len(o.kubeVersions) != 1 {
+		invalid("an upload path is required unless a single Kubernetes version gets built")
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: errs}
+}
+
+// ValidationError is returned by Validate and contains every problem of the
+// options.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0]
+	}
+	return fmt.Sprintf("%d invalid options:\n%s", len(e.Problems), strings.Join(e.Problems, "\n"))
+}
+
+// ParseSignKey splits the provided sign key into its type and value. Keys
+// without a type prefix refer to the default keyring, for example:
+//
+//	file:/path/to/key.asc
+//	keyring:0xABCDEF12 or 0xABCDEF12
+//	kms:0xABCDEF12
+func ParseSignKey(signKey string) (SignKeyType, string, error) {
+	parts := strings.SplitN(signKey, ":", 2)
+	if len(parts) == 1 {
+		if parts[0] == "" {
+			return "", "", errors.New("sign key is empty")
+		}
+		return SignKeyKeyring, parts[0], nil
+	}
+
+	keyType, value := SignKeyType(parts[0]), parts[1]
+	switch keyType {
+	case SignKeyFile, SignKeyKeyring, SignKeyKMS:
+	default:
+		return "", "", errors.Errorf("sign key type %q is not supported", keyType)
+	}
+	if value == "" {
+		return "", "", errors.Errorf("sign key of type %q is empty", keyType)
+	}
+	return keyType, value, nil
+}
+
+// isSupportedHookPackage returns true if the provided package can have hooks,
+// which are all supported packages including the individual CNI plugin
+// packages.
+func isSupportedHookPackage(pkg string) bool {
+	if isSupported([]string{pkg}, supportedPackages) {
+		return true
+	}
+	plugin := strings.TrimPrefix(pkg, "kubernetes-cni-")
+	return plugin != pkg && isSupported([]string{plugin}, supportedCNIPlugins)
+}
+
+// expandChannelNames replaces the published channel names within channels by
+// all channels having that name, while keeping their order and dropping
+// duplicates.
+func (o *Options) expandChannelNames(channels []string) []string {
+	res := []string{}
+	seen := map[string]bool{}
+	add := func(channel string) {
+		if !seen[channel] {
+			seen[channel] = true
+			res = append(res, channel)
+		}
+	}
+	for _, channel := range channels {
+		if isSupported([]string{channel}, supportedChannels) {
+			add(channel)
+			continue
+		}
+		renamed := false
+		for _, supported := range supportedChannels {
+			if o.ChannelName(supported) == channel {
+				add(supported)
+				renamed = true
+			}
+		}
+		if !renamed {
+			add(channel)
+		}
+	}
+	return res
+}
+
+// isCustomBuildType returns true if the build type is provided by a builder
+// plugin.
+func (o *Options) isCustomBuildType(buildType BuildType) bool {
+	for _, custom := range o.customBuildTypes {
+		if custom == buildType {
+			return true
+		}
+	}
+	return false
+}
+
+// PackagePresets returns the sorted names of the package presets.
+func PackagePresets() []string {
+	res := make([]string, 0, len(packagePresets))
+	for preset := range packagePresets {
+		res = append(res, preset)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// ExpandPackages replaces the package presets like minimal with their
+// packages. Duplicates get removed while keeping the order of the packages.
+func ExpandPackages(packages []string) []string {
+	res := []string{}
+	seen := map[string]bool{}
+	for _, pkg := range packages {
+		expanded, ok := packagePresets[pkg]
+		if !ok {
+			expanded = []string{pkg}
+		}
+		for _, p := range expanded {
+			if !seen[p] {
+				seen[p] = true
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
+
+// ExpandKubeVersion returns all versions of the provided patch version range
+// like 1.22.0-1.22.3. Versions which are not a range, including pre-releases
+// like 1.22.0-rc.0, are returned unchanged.
+func ExpandKubeVersion(kubeVersion string) ([]string, error) {
+	parts := strings.SplitN(kubeVersion, "-", 2)
+	if len(parts) != 2 {
+		return []string{kubeVersion}, nil
+	}
+
+	from, fromErr := util.TagStringToSemver(parts[0])
+	to, toErr := util.TagStringToSemver(parts[1])
+	if fromErr != nil || toErr != nil || len(to.Pre) > 0 || len(to.Build) > 0 {
+		return []string{kubeVersion}, nil
+	}
+
+	if from.Major != to.Major || from.Minor != to.Minor {
+		return nil, errors.Errorf(
+			"version range %s has to be within the same minor version", kubeVersion,
+		)
+	}
+	if from.Patch > to.Patch {
+		return nil, errors.Errorf(
+			"version range %s has to start with the lower version", kubeVersion,
+		)
+	}
+
+	versions := []string{}
+	for patch := from.Patch; patch <= to.Patch; patch++ {
+		versions = append(versions, fmt.Sprintf("%d.%d.%d", from.Major, from.Minor, patch))
+	}
+	return versions, nil
+}
+
+// IsKubeVersionMarker returns true if the provided Kubernetes version is a
+// version marker like stable, stable-1.28, latest or ci/latest instead of an
+// actual version.
+func IsKubeVersionMarker(kubeVersion string) bool {
+	return kubeVersionMarkerRegex.MatchString(kubeVersion)
+}
 
-	return nil
+// SupportedPackages returns all packages which can be selected.
+func SupportedPackages() []string {
+	return append([]string{}, supportedPackages...)
+}
+
+// SupportedChannels returns all channels which can be selected.
+func SupportedChannels() []string {
+	return append([]string{}, supportedChannels...)
+}
+
+// SupportedArchitectures returns all architectures which can be selected,
+// including the ones which are not built by default.
+func SupportedArchitectures() []string {
+	return append([]string{}, supportedArchitectures...)
+}
+
+// SupportedCNIPlugins returns all CNI plugins which can be packaged
+// individually.
+func SupportedCNIPlugins() []string {
+	return append([]string{}, supportedCNIPlugins...)
+}
+
+// SupportedBuildTypes returns all build types which can be selected.
+func SupportedBuildTypes() []BuildType {
+	res := make([]BuildType, 0, len(supportedBuildTypes))
+	for _, buildType := range supportedBuildTypes {
+		res = append(res, BuildType(buildType))
+	}
+	return res
+}
+
+// SupportedDistros returns all distributions which can be selected, sorted
+// by their name.
+func SupportedDistros() []string {
+	res := make([]string, 0, len(distroBuildTypes))
+	for distro := range distroBuildTypes {
+		res = append(res, distro)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// DistroBuildType returns the build type of the packages of the provided
+// distribution, which is empty if the distribution is not supported.
+func DistroBuildType(distro string) BuildType {
+	return distroBuildTypes[distro]
+}
+
+// unsupportedValues returns all values of input which are not part of
+// expected.
+func unsupportedValues(input, expected []string) []string {
+	res := []string{}
+	for _, i := range input {
+		found := false
+		for _, j := range expected {
+			if i == j {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, i)
+		}
+	}
+	return res
 }
 
 func isSupported(input, expected []string) bool {
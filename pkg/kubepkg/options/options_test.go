@@ -17,6 +17,7 @@ limitations under the License.
 package options
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,7 @@ func TestOptions(t *testing.T) {
 	sut := New()
 
 	require.Equal(t, BuildDeb, sut.WithBuildType(BuildDeb).BuildType())
+	require.Equal(t, BackendNfpm, sut.WithBackend(BackendNfpm).Backend())
 	require.Equal(t, str, sut.WithRevision(str).Revision())
 	require.Equal(t, str, sut.WithKubeVersion(str).KubeVersion())
 	require.Equal(t, str, sut.WithCNIVersion(str).CNIVersion())
@@ -36,9 +38,34 @@ func TestOptions(t *testing.T) {
 	require.Equal(t, slice, sut.WithPackages(slice...).Packages())
 	require.Equal(t, slice, sut.WithChannels(slice...).Channels())
 	require.Equal(t, slice, sut.WithArchitectures(slice...).Architectures())
+	require.Equal(t, slice, sut.WithDistros(slice...).Distros())
 	require.Equal(t, str, sut.WithReleaseDownloadLinkBase(str).ReleaseDownloadLinkBase())
 	require.Equal(t, str, sut.WithTemplateDir(str).TemplateDir())
+	require.Equal(t, slice, sut.WithExtraTemplateDirs(slice...).ExtraTemplateDirs())
 	require.Equal(t, true, sut.WithSpecOnly(true).SpecOnly())
+	require.Equal(t, str, sut.WithOutputDir(str).OutputDir())
+	require.Equal(t, str, sut.WithBinaryDir(str).BinaryDir())
+	require.Equal(t, str, sut.WithCacheDir(str).CacheDir())
+	require.Equal(t, str, sut.WithSourceDir(str).SourceDir())
+	require.Equal(t, true, sut.WithBuildInContainer(true).BuildInContainer())
+	require.Equal(t, str, sut.WithContainerRuntime(str).ContainerRuntime())
+	require.Equal(t, str, sut.WithContainerImage(str).ContainerImage())
+	require.Equal(t, map[string]string{"el9": str}, sut.WithContainerImages(map[string]string{"el9": str}).ContainerImages())
+	require.Equal(t, 4, sut.WithConcurrency(4).Concurrency())
+	require.Equal(t, 2, sut.WithDownloadConcurrency(2).DownloadConcurrency())
+	require.Equal(t, str, sut.WithNotifyURL(str).NotifyURL())
+	require.Equal(t, NotifyFormatSlack, sut.WithNotifyFormat(NotifyFormatSlack).NotifyFormat())
+	require.Equal(t, str, sut.WithLogsURL(str).LogsURL())
+	require.Equal(t, str, sut.WithIssueRepo(str).IssueRepo())
+	require.Equal(t, slice, sut.WithIssueLabels(slice...).IssueLabels())
+	require.Equal(t, OutputFormatJSON, sut.WithOutputFormat(OutputFormatJSON).OutputFormat())
+	require.Equal(t, str, sut.WithSignKey(str).SignKey())
+	require.Equal(t, true, sut.WithProvenance(true).Provenance())
+	require.Equal(t, true, sut.WithValidatePackages(true).ValidatePackages())
+	require.Equal(t, str, sut.WithUploadBucket(str).UploadBucket())
+	require.Equal(t, str, sut.WithUploadPath(str).UploadPath())
+	require.Equal(t, true, sut.WithLintPackages(true).LintPackages())
+	require.Equal(t, LintSeverityWarning, sut.WithLintFailSeverity(LintSeverityWarning).LintFailSeverity())
 }
 
 func TestValidateSuccess(t *testing.T) {
@@ -49,14 +76,124 @@ func TestValidateFailureWrongPackage(t *testing.T) {
 	require.NotNil(t, New().WithPackages("wrong").Validate())
 }
 
+func TestValidateSuccessPackagePresets(t *testing.T) {
+	opts := New().WithPackages("minimal", "cri-tools", "kubelet")
+	require.Nil(t, opts.Validate())
+	require.Equal(t, []string{"kubelet", "kubernetes-cni", "cri-tools"}, opts.Packages())
+
+	opts = New().WithPackages("all")
+	require.Nil(t, opts.Validate())
+	require.Equal(t, supportedPackages, opts.Packages())
+	require.Equal(t, []string{"all", "minimal"}, PackagePresets())
+}
+
+func TestRevision(t *testing.T) {
+	require.Equal(t, defaultRevision, New().Revision())
+	require.Equal(t, defaultRevision, New().WithRevision("").Revision())
+	require.Empty(t, New().WithSourceDir(".").Revision())
+	require.Equal(t, "1", New().WithSourceDir(".").WithRevision("1").Revision())
+}
+
 func TestValidateFailureWrongChannel(t *testing.T) {
 	require.NotNil(t, New().WithChannels("wrong").Validate())
 }
 
+func TestValidateSuccessChannelNames(t *testing.T) {
+	opts := New().
+		WithChannelNames(map[string]string{
+			"release": "stable", "testing": "unstable", "nightly": "unstable",
+		}).
+		WithChannels("unstable", "stable", "nightly")
+	require.Nil(t, opts.Validate())
+	require.Equal(t, []string{"testing", "nightly", "release"}, opts.Channels())
+	require.Equal(t, "stable", opts.ChannelName("release"))
+	require.Equal(t, "unstable", opts.ChannelName("nightly"))
+	require.Equal(t, "release", New().ChannelName("release"))
+}
+
+func TestValidateFailureWrongChannelNames(t *testing.T) {
+	for _, channelNames := range []map[string]string{
+		{"wrong": "stable"},
+		{"release": ""},
+		{"release": "../stable"},
+	} {
+		require.NotNil(t, New().WithChannelNames(channelNames).Validate())
+	}
+}
+
 func TestValidateFailureWrongArchitecture(t *testing.T) {
 	require.NotNil(t, New().WithArchitectures("wrong").Validate())
 }
 
+func TestValidateFailureWrongCNIPlugin(t *testing.T) {
+	require.Nil(t, New().WithCNIPlugins("bridge", "host-local").Validate())
+	require.NotNil(t, New().WithCNIPlugins("wrong").Validate())
+}
+
+func TestValidateSuccessRiscv64(t *testing.T) {
+	require.Nil(t, New().WithArchitectures("riscv64").Validate())
+	require.NotContains(t, New().Architectures(), "riscv64")
+}
+
+func TestValidateFailureWrongBuildType(t *testing.T) {
+	require.NotNil(t, New().WithBuildType("wrong").Validate())
+	require.Nil(t, New().WithBuildType(BuildMsi).Validate())
+}
+
+func TestValidateFailureWrongDistro(t *testing.T) {
+	require.Nil(t, New().WithDistros("debian-bookworm", "ubuntu-jammy").WithBuildType(BuildDeb).Validate())
+	require.Nil(t, New().WithDistros("el9").WithBuildType(BuildRpm).Validate())
+	require.NotNil(t, New().WithDistros("wrong").Validate())
+	require.NotNil(t, New().WithDistros("el9").WithBuildType(BuildDeb).Validate())
+}
+
+func TestSupportedDistros(t *testing.T) {
+	require.Contains(t, SupportedDistros(), "ubuntu-jammy")
+	require.Equal(t, BuildRpm, DistroBuildType("fc39"))
+	require.Empty(t, DistroBuildType("wrong"))
+}
+
+func TestSupportedBuildTypes(t *testing.T) {
+	require.Contains(t, SupportedBuildTypes(), BuildDeb)
+	require.NotContains(t, SupportedBuildTypes(), BuildAll)
+}
+
+func TestSupportedValues(t *testing.T) {
+	require.Contains(t, SupportedPackages(), "kubelet")
+	require.Equal(t, []string{"release", "testing", "nightly"}, SupportedChannels())
+	require.Contains(t, SupportedArchitectures(), "riscv64")
+	require.Contains(t, SupportedCNIPlugins(), "bridge")
+
+	// The returned values are copies
+	SupportedChannels()[0] = "wrong"
+	require.Equal(t, "release", SupportedChannels()[0])
+}
+
+func TestValidateFailureWrongBackend(t *testing.T) {
+	require.NotNil(t, New().WithBackend("wrong").Validate())
+}
+
+func TestValidateFailureWrongContainerRuntime(t *testing.T) {
+	require.NotNil(t, New().WithContainerRuntime("wrong").Validate())
+}
+
+func TestValidateContainerImages(t *testing.T) {
+	require.Nil(t, New().WithContainerImages(map[string]string{
+		"el9": "image", "deb": "image",
+	}).Validate())
+	require.NotNil(t, New().WithContainerImages(map[string]string{
+		"wrong": "image",
+	}).Validate())
+}
+
+func TestValidateFailureContainerWithNfpm(t *testing.T) {
+	require.NotNil(t, New().
+		WithBuildInContainer(true).
+		WithBackend(BackendNfpm).
+		Validate(),
+	)
+}
+
 func TestIsSupportedSuccess(t *testing.T) {
 	testcases := []struct {
 		name     string
@@ -120,3 +257,319 @@ func TestIsSupportedFailure(t *testing.T) {
 		require.NotEqual(t, tc.expected, actual)
 	}
 }
+
+func TestValidateFailureWrongConcurrency(t *testing.T) {
+	require.NotNil(t, New().WithConcurrency(0).Validate())
+}
+
+func TestValidateFailureWrongDownloadConcurrency(t *testing.T) {
+	require.NotNil(t, New().WithDownloadConcurrency(0).Validate())
+}
+
+func TestValidateFailureWrongNotifyURL(t *testing.T) {
+	require.NotNil(t, New().WithNotifyURL("hooks.slack.com").Validate())
+}
+
+func TestValidateFailureWrongNotifyFormat(t *testing.T) {
+	require.NotNil(t, New().
+		WithNotifyURL("https://example.com").
+		WithNotifyFormat("wrong").
+		Validate(),
+	)
+}
+
+func TestValidateIssueRepo(t *testing.T) {
+	require.Nil(t, New().WithIssueRepo("kubernetes/release").Validate())
+	require.NotNil(t, New().WithIssueRepo("kubernetes").Validate())
+	require.NotNil(t, New().WithIssueRepo("https://github.com/kubernetes/release").Validate())
+}
+
+func TestValidateFailureEmptyOutputDir(t *testing.T) {
+	require.NotNil(t, New().WithOutputDir("").Validate())
+}
+
+func TestValidateFailureBinaryDirWithoutKubeVersion(t *testing.T) {
+	require.NotNil(t, New().WithBinaryDir("_output").Validate())
+	require.Nil(t, New().WithBinaryDir("_output").WithKubeVersion("v1.22.0").Validate())
+}
+
+func TestValidateSuccessKubeVersions(t *testing.T) {
+	opts := New().WithKubeVersions("v1.22.0", "1.23.0-1.23.2", "v1.24.0-rc.0")
+	require.Nil(t, opts.Validate())
+	require.Equal(t,
+		[]string{"1.22.0", "1.23.0", "1.23.1", "1.23.2", "1.24.0-rc.0"},
+		opts.KubeVersions(),
+	)
+	require.Equal(t, "1.22.0", opts.KubeVersion())
+}
+
+func TestValidateSuccessKubeVersionMarkers(t *testing.T) {
+	opts := New().WithKubeVersions("stable", "stable-1.28", "ci/latest", "v1.22.0")
+	require.Nil(t, opts.Validate())
+	require.Equal(t,
+		[]string{"stable", "stable-1.28", "ci/latest", "1.22.0"},
+		opts.KubeVersions(),
+	)
+}
+
+func TestIsKubeVersionMarker(t *testing.T) {
+	for _, marker := range []string{
+		"stable", "latest", "stable-1.28", "latest-1.28", "release/stable",
+		"release/latest-1.28", "ci/latest", "ci/latest-1.28",
+	} {
+		require.True(t, IsKubeVersionMarker(marker), marker)
+	}
+	for _, version := range []string{
+		"", "1.28.0", "v1.28.0", "1.28.0-rc.0", "stable-1", "foo/stable", "stable1.28",
+	} {
+		require.False(t, IsKubeVersionMarker(version), version)
+	}
+}
+
+func TestValidateFailureWrongKubeVersionRange(t *testing.T) {
+	require.NotNil(t, New().WithKubeVersions("1.23.0-1.24.0").Validate())
+	require.NotNil(t, New().WithKubeVersions("1.23.2-1.23.0").Validate())
+}
+
+func TestValidateFailureWrongChannelDependencies(t *testing.T) {
+	require.Nil(t, New().WithChannelDependencies(map[string]DependencyVersions{
+		"nightly": {CNIVersion: "v1.0.1", ConntrackVersion: "1.4.6"},
+	}).Validate())
+	require.NotNil(t, New().WithChannelDependencies(map[string]DependencyVersions{
+		"wrong": {CNIVersion: "1.0.1"},
+	}).Validate())
+	require.NotNil(t, New().WithChannelDependencies(map[string]DependencyVersions{
+		"nightly": {CRIToolsVersion: "wrong"},
+	}).Validate())
+}
+
+func TestValidateFailureWrongSystemd(t *testing.T) {
+	require.Nil(t, New().WithSystemd(SystemdOptions{
+		CgroupDriver:     "systemd",
+		EnvironmentFiles: []string{"/etc/kubernetes/kubelet.env"},
+	}).Validate())
+	require.NotNil(t, New().WithSystemd(SystemdOptions{
+		CgroupDriver: "wrong",
+	}).Validate())
+	require.NotNil(t, New().WithSystemd(SystemdOptions{
+		EnvironmentFiles: []string{"kubelet.env"},
+	}).Validate())
+}
+
+func TestValidateFailureWrongHooksPackage(t *testing.T) {
+	require.Nil(t, New().WithHooks(map[string]PackageHooks{
+		"kubelet":               {PostInstall: "true"},
+		"kubernetes-cni-bridge": {PreRemove: "true"},
+	}).Validate())
+	require.NotNil(t, New().WithHooks(map[string]PackageHooks{
+		"wrong": {PostInstall: "true"},
+	}).Validate())
+	require.NotNil(t, New().WithHooks(map[string]PackageHooks{
+		"kubernetes-cni-wrong": {PostInstall: "true"},
+	}).Validate())
+}
+
+func TestSystemdKubeletArgs(t *testing.T) {
+	require.Empty(t, SystemdOptions{}.KubeletArgs())
+	require.Equal(t,
+		[]string{"--cgroup-driver=cgroupfs", "--v=2"},
+		SystemdOptions{CgroupDriver: "cgroupfs", ExtraArgs: []string{"--v=2"}}.KubeletArgs(),
+	)
+}
+
+func TestValidateFailureWrongOutputFormat(t *testing.T) {
+	require.NotNil(t, New().WithOutputFormat("wrong").Validate())
+}
+
+func TestValidateFailureWrongLintFailSeverity(t *testing.T) {
+	require.NotNil(t, New().WithLintFailSeverity("wrong").Validate())
+}
+
+func TestLintSeverityAtLeast(t *testing.T) {
+	require.True(t, LintSeverityError.AtLeast(LintSeverityWarning))
+	require.True(t, LintSeverityWarning.AtLeast(LintSeverityWarning))
+	require.False(t, LintSeverityInfo.AtLeast(LintSeverityWarning))
+}
+
+func TestValidateFailureAggregated(t *testing.T) {
+	err := New().
+		WithChannels("release", "wrong").
+		WithArchitectures("amd64", "sparc").
+		WithKubeVersion("1.22.x").
+		WithConcurrency(0).
+		Validate()
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "4 invalid options")
+	require.Contains(t, err.Error(), "channels not supported: wrong")
+	require.Contains(t, err.Error(), "architectures not supported: sparc")
+	require.Contains(t, err.Error(), `Kubernetes version "1.22.x" is not valid semver`)
+	require.Contains(t, err.Error(), "concurrency has to be at least 1")
+
+	validationErr, ok := err.(*ValidationError)
+	require.True(t, ok)
+	require.Len(t, validationErr.Problems, 4)
+}
+
+func TestValidateFailureWrongVersions(t *testing.T) {
+	require.NotNil(t, New().WithCNIVersion("wrong").Validate())
+	require.NotNil(t, New().WithCRIToolsVersion("wrong").Validate())
+	require.Nil(t, New().WithCNIVersion("1.0.1").WithCRIToolsVersion("v1.22.0").Validate())
+	require.Nil(t, New().WithCNIVersion(LatestVersion).WithCRIToolsVersion(LatestVersion).Validate())
+}
+
+func TestValidateFailureSpecOnly(t *testing.T) {
+	for _, opts := range []*Options{
+		New().WithSignKey("keyring:ABCDEF12"),
+		New().WithProvenance(true),
+		New().WithValidatePackages(true),
+		New().WithLintPackages(true),
+	} {
+		require.Nil(t, opts.Validate())
+		err := opts.WithSpecOnly(true).Validate()
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "not possible if only specs get created")
+	}
+}
+
+func TestValidateFailureUpload(t *testing.T) {
+	require.NotNil(t, New().WithUploadBucket("bucket").Validate())
+	require.NotNil(t, New().WithUploadBucket("bucket").WithKubeVersion("v1.22.0").WithSpecOnly(true).Validate())
+	require.NotNil(t, New().WithUploadBucket("bucket").WithKubeVersion("1.22.0-1.22.1").Validate())
+	require.Nil(t, New().WithUploadBucket("bucket").WithKubeVersion("v1.22.0").Validate())
+	require.Nil(t, New().WithUploadBucket("bucket").WithUploadPath("packages").Validate())
+}
+
+func TestValidateFailureWrongSignKey(t *testing.T) {
+	require.NotNil(t, New().WithSignKey("wrong:key").Validate())
+	require.Nil(t, New().WithSignKey("file:/key.asc").Validate())
+}
+
+func TestParseSignKey(t *testing.T) {
+	for _, tc := range []struct {
+		signKey       string
+		expectedType  SignKeyType
+		expectedValue string
+		shouldError   bool
+	}{
+		{signKey: "ABCDEF12", expectedType: SignKeyKeyring, expectedValue: "ABCDEF12"},
+		{signKey: "keyring:ABCDEF12", expectedType: SignKeyKeyring, expectedValue: "ABCDEF12"},
+		{signKey: "file:/path/key.asc", expectedType: SignKeyFile, expectedValue: "/path/key.asc"},
+		{signKey: "kms:ABCDEF12", expectedType: SignKeyKMS, expectedValue: "ABCDEF12"},
+		{signKey: "", shouldError: true},
+		{signKey: "file:", shouldError: true},
+		{signKey: "wrong:ABCDEF12", shouldError: true},
+	} {
+		keyType, value, err := ParseSignKey(tc.signKey)
+		if tc.shouldError {
+			require.NotNil(t, err, tc.signKey)
+			continue
+		}
+		require.Nil(t, err, tc.signKey)
+		require.Equal(t, tc.expectedType, keyType)
+		require.Equal(t, tc.expectedValue, value)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		content     string
+		shouldError bool
+	}{
+		{
+			name: "yaml",
+			content: `packages: [kubelet, kubeadm]
+channels: [testing]
+kubeVersion: v1.22.0
+specOnly: true
+channelDependencies:
+  testing:
+    cniVersion: 1.0.1
+`,
+		},
+		{
+			name:    "json",
+			content: `{"packages": ["kubelet", "kubeadm"], "channels": ["testing"], "kubeVersion": "v1.22.0", "specOnly": true, "channelDependencies": {"testing": {"cniVersion": "1.0.1"}}}`,
+		},
+		{
+			name:        "unknown field",
+			content:     "wrong: true",
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			file, err := os.CreateTemp("", "kubepkg-config-")
+			require.Nil(t, err)
+			defer os.Remove(file.Name())
+			_, err = file.WriteString(tc.content)
+			require.Nil(t, err)
+
+			config, err := LoadConfig(file.Name())
+			if tc.shouldError {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+
+			sut := New().WithConfig(config)
+			require.Equal(t, []string{"kubelet", "kubeadm"}, sut.Packages())
+			require.Equal(t, []string{"testing"}, sut.Channels())
+			require.Equal(t, defaultArchitectures, sut.Architectures())
+			require.Equal(t, "v1.22.0", sut.KubeVersion())
+			require.Equal(t, defaultRevision, sut.Revision())
+			require.True(t, sut.SpecOnly())
+			require.Equal(t,
+				map[string]DependencyVersions{"testing": {CNIVersion: "1.0.1"}},
+				sut.ChannelDependencies(),
+			)
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	opts := New().
+		WithPackages("kubelet").
+		WithKubeVersions("v1.22.0", "v1.21.3").
+		WithRevision("1").
+		WithSpecOnly(true).
+		WithDryRun(true).
+		WithChannelNames(map[string]string{"release": "stable"})
+
+	config := opts.Config()
+	content, err := config.YAML()
+	require.Nil(t, err)
+	require.Contains(t, string(content), "specOnly: true")
+	require.Contains(t, string(content), "force: false")
+	require.Contains(t, string(content), "dryRun: true")
+
+	require.Equal(t, opts, New().WithConfig(config))
+}
+
+func TestLoadConfigFailureNotExisting(t *testing.T) {
+	_, err := LoadConfig("/not/existing")
+	require.NotNil(t, err)
+}
+
+func TestDeprecationMessage(t *testing.T) {
+	for _, tc := range []struct {
+		deprecation Deprecation
+		expected    string
+	}{
+		{
+			deprecation: Deprecation{Flag: "old"},
+			expected:    "--old is deprecated",
+		},
+		{
+			deprecation: Deprecation{
+				Flag: "old", Replacement: "new", RemovalVersion: "v1.0.0",
+			},
+			expected: "--old is deprecated and will be removed in v1.0.0, use --new instead",
+		},
+		{
+			deprecation: Deprecation{Flag: "old", Hint: "it has no effect anymore"},
+			expected:    "--old is deprecated: it has no effect anymore",
+		},
+	} {
+		require.Equal(t, tc.expected, tc.deprecation.Message())
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// contentsCommands are the commands listing the files of a package.
+var contentsCommands = map[options.BuildType][]string{
+	options.BuildDeb: {dpkgDebExecutable, "--contents"},
+	options.BuildRpm: {rpmExecutable, "--query", "--list", "--package"},
+}
+
+// MetadataChange is a metadata field which differs between two packages.
+type MetadataChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// PackageDiff is the difference of the file lists and metadata of two
+// versions of a package.
+type PackageDiff struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+
+	// Metadata are the changed metadata fields including the version.
+	Metadata []MetadataChange `json:"metadata"`
+
+	// Added are the files only contained in the new package.
+	Added []string `json:"added"`
+
+	// Removed are the files only contained in the old package.
+	Removed []string `json:"removed"`
+}
+
+// ComparePackages compares the file lists and the name, version,
+// architecture, maintainer and dependencies of two debs or rpms, for example
+// kubeadm 1.22.0 and 1.22.1. The packages are inspected via dpkg-deb or rpm.
+func (c *Client) ComparePackages(oldPath, newPath string) (*PackageDiff, error) {
+	buildType := options.BuildType(strings.TrimPrefix(filepath.Ext(oldPath), "."))
+	if newType := filepath.Ext(newPath); newType != filepath.Ext(oldPath) {
+		return nil, errors.Errorf(
+			"unable to compare %s and %s packages", buildType, strings.TrimPrefix(newType, "."),
+		)
+	}
+
+	diff := &PackageDiff{
+		Old:      oldPath,
+		New:      newPath,
+		Metadata: []MetadataChange{},
+	}
+
+	oldMetadata, err := c.packageMetadata(buildType, oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newMetadata, err := c.packageMetadata(buildType, newPath)
+	if err != nil {
+		return nil, err
+	}
+	diff.Metadata = compareMetadata(oldMetadata, newMetadata)
+
+	oldFiles, err := c.packageFiles(buildType, oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newFiles, err := c.packageFiles(buildType, newPath)
+	if err != nil {
+		return nil, err
+	}
+	diff.Added = subtractFiles(newFiles, oldFiles)
+	diff.Removed = subtractFiles(oldFiles, newFiles)
+
+	logrus.Infof(
+		"Compared %s with %s: %d metadata changes, %d added and %d removed files",
+		filepath.Base(oldPath), filepath.Base(newPath),
+		len(diff.Metadata), len(diff.Added), len(diff.Removed),
+	)
+	return diff, nil
+}
+
+// packageFiles returns the paths of all files and links contained in the
+// package, whereas directories are omitted.
+func (c *Client) packageFiles(buildType options.BuildType, pkgPath string) (map[string]bool, error) {
+	command, ok := contentsCommands[buildType]
+	if !ok {
+		return nil, errors.Errorf("comparing %s packages is not supported", buildType)
+	}
+	if !c.impl.Available(command[0]) {
+		return nil, errors.Errorf(
+			"comparing %s packages requires %s", buildType, command[0],
+		)
+	}
+
+	output, err := c.impl.RunOutputWithWorkDir(
+		"", command[0], append(command[1:], pkgPath)...,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing files of %s", pkgPath)
+	}
+
+	files := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		file := strings.TrimSpace(line)
+		if buildType == options.BuildDeb {
+			// -rwxr-xr-x root/root 44 2021-08-19 12:00 ./usr/bin/kubeadm
+			fields := strings.Fields(line)
+			if len(fields) < 6 || strings.HasPrefix(fields[0], "d") {
+				continue
+			}
+			file = strings.SplitN(strings.Join(fields[5:], " "), " -> ", 2)[0]
+		}
+		if file == "" || file == "(contains no files)" {
+			continue
+		}
+		files[path.Clean("/"+file)] = true
+	}
+	return files, nil
+}
+
+// compareMetadata returns the changed fields between the old and new package
+// metadata. Dependencies are compared one by one.
+func compareMetadata(oldMetadata, newMetadata *PackageMetadata) []MetadataChange {
+	changes := []MetadataChange{}
+	for _, field := range []struct{ name, oldValue, newValue string }{
+		{"name", oldMetadata.Name, newMetadata.Name},
+		{"version", oldMetadata.Version, newMetadata.Version},
+		{"architecture", oldMetadata.Arch, newMetadata.Arch},
+		{"maintainer", oldMetadata.Maintainer, newMetadata.Maintainer},
+	} {
+		if field.oldValue != field.newValue {
+			changes = append(changes, MetadataChange{field.name, field.oldValue, field.newValue})
+		}
+	}
+
+	deps := map[string]bool{}
+	for dep := range oldMetadata.Dependencies {
+		deps[dep] = true
+	}
+	for dep := range newMetadata.Dependencies {
+		deps[dep] = true
+	}
+	sortedDeps := make([]string, 0, len(deps))
+	for dep := range deps {
+		sortedDeps = append(sortedDeps, dep)
+	}
+	sort.Strings(sortedDeps)
+
+	describe := func(dependencies map[string]string, dep string) string {
+		constraint, ok := dependencies[dep]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(dep + " " + constraint)
+	}
+	for _, dep := range sortedDeps {
+		oldDep := describe(oldMetadata.Dependencies, dep)
+		newDep := describe(newMetadata.Dependencies, dep)
+		if oldDep != newDep {
+			changes = append(changes, MetadataChange{"dependency " + dep, oldDep, newDep})
+		}
+	}
+	return changes
+}
+
+// subtractFiles returns all sorted files of a which are not part of b.
+func subtractFiles(a, b map[string]bool) []string {
+	res := []string{}
+	for file := range a {
+		if !b[file] {
+			res = append(res, file)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// FilesChanged returns true if files have been added or removed.
+func (d *PackageDiff) FilesChanged() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0
+}
+
+// JSON returns the indented JSON representation of the diff.
+func (d *PackageDiff) JSON() ([]byte, error) {
+	res, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling package diff")
+	}
+	return res, nil
+}
+
+// Write writes the human readable report of the diff to w.
+func (d *PackageDiff) Write(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "--- %s\n+++ %s\n", d.Old, d.New)
+	for _, change := range d.Metadata {
+		fmt.Fprintf(b, "%s: %q -> %q\n", change.Field, change.Old, change.New)
+	}
+	for _, file := range d.Removed {
+		fmt.Fprintf(b, "- %s\n", file)
+	}
+	for _, file := range d.Added {
+		fmt.Fprintf(b, "+ %s\n", file)
+	}
+	if len(d.Metadata) == 0 && !d.FilesChanged() {
+		fmt.Fprintln(b, "no differences")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+)
+
+func TestComparePackages(t *testing.T) {
+	sut, mock := newSUT(nil)
+	mock.AvailableReturns(true)
+	mock.RunOutputWithWorkDirCalls(func(_, cmd string, args ...string) (string, error) {
+		pkg := args[len(args)-1]
+		switch {
+		case args[0] == "--show" && pkg == "old.deb":
+			return kubeadmDebMetadata, nil
+		case args[0] == "--show":
+			return strings.NewReplacer("1.18.0-0", "1.18.1-0", "cri-tools (>= 1.13.0)", "cri-tools (>= 1.18.0)").
+				Replace(kubeadmDebMetadata), nil
+		case pkg == "old.deb":
+			return "drwxr-xr-x root/root         0 2021-08-19 12:00 ./\n" +
+				"-rwxr-xr-x root/root  39464960 2021-08-19 12:00 ./usr/bin/kubeadm\n" +
+				"-rw-r--r-- root/root       100 2021-08-19 12:00 ./usr/share/doc/kubeadm/README\n", nil
+		}
+		return "-rwxr-xr-x root/root  39464960 2021-08-19 12:00 ./usr/bin/kubeadm\n" +
+			"lrwxrwxrwx root/root         0 2021-08-19 12:00 ./usr/local/bin/kubeadm -> /usr/bin/kubeadm\n", nil
+	})
+
+	diff, err := sut.ComparePackages("old.deb", "new.deb")
+	require.Nil(t, err)
+	require.True(t, diff.FilesChanged())
+	require.Equal(t, []string{"/usr/local/bin/kubeadm"}, diff.Added)
+	require.Equal(t, []string{"/usr/share/doc/kubeadm/README"}, diff.Removed)
+	require.Equal(t, []kubepkg.MetadataChange{
+		{Field: "version", Old: "1.18.0-0", New: "1.18.1-0"},
+		{Field: "dependency cri-tools", Old: "cri-tools >= 1.13.0", New: "cri-tools >= 1.18.0"},
+	}, diff.Metadata)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, diff.Write(buf))
+	require.Contains(t, buf.String(), "- /usr/share/doc/kubeadm/README\n+ /usr/local/bin/kubeadm\n")
+
+	res, err := diff.JSON()
+	require.Nil(t, err)
+	require.Contains(t, string(res), `"field": "version"`)
+}
+
+func TestComparePackagesFailure(t *testing.T) {
+	sut, mock := newSUT(nil)
+	_, err := sut.ComparePackages("old.deb", "new.rpm")
+	require.NotNil(t, err)
+
+	_, err = sut.ComparePackages("old.snap", "new.snap")
+	require.NotNil(t, err)
+
+	mock.AvailableReturns(false)
+	_, err = sut.ComparePackages("old.rpm", "new.rpm")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+var (
+	// packageLinters are the tools checking the built packages by build
+	// type.
+	packageLinters = map[options.BuildType]string{
+		options.BuildDeb: "lintian",
+		options.BuildRpm: "rpmlint",
+	}
+
+	// lintFindingRegex matches the findings of lintian, like
+	// `E: kubeadm: some-tag details`, and rpmlint, like
+	// `kubeadm.x86_64: W: some-tag details`.
+	lintFindingRegex = regexp.MustCompile(`^(?:\S+: )?([EWIPX]): (.+)$`)
+
+	// lintSeverities maps the finding codes of the linters to severities.
+	lintSeverities = map[string]options.LintSeverity{
+		"E": options.LintSeverityError,
+		"W": options.LintSeverityWarning,
+		"I": options.LintSeverityInfo,
+		"P": options.LintSeverityInfo,
+		"X": options.LintSeverityInfo,
+	}
+)
+
+// LintFinding is a single issue reported by lintian or rpmlint.
+type LintFinding struct {
+	// Severity is the severity of the finding.
+	Severity options.LintSeverity `json:"severity"`
+
+	// Message is the tag of the finding including its details.
+	Message string `json:"message"`
+}
+
+// lintBuiltPackage runs the linter of the build type on the package at path
+// inside the container image of the build, if package linting is
+// enabled. The findings get recorded for the summary and the build fails if
+// any finding reaches the configured severity.
+func (c *Client) lintBuiltPackage(bc *buildConfig, path string) error {
+	if !c.options.LintPackages() {
+		return nil
+	}
+	linter, ok := packageLinters[bc.Type]
+	if !ok {
+		bc.log.Debugf("Skipping linting of %s packages", bc.Type)
+		return nil
+	}
+
+	runtime, err := c.containerRuntime()
+	if err != nil {
+		return errors.Wrap(err, "linting package")
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return errors.Wrapf(err, "getting absolute path of %s", path)
+	}
+	image := c.containerImage(bc)
+	bc.log.Infof("Linting %s using %s in container image %s", filepath.Base(path), linter, image)
+
+	// Both linters exit with a non-zero code if they report errors, which is
+	// why only failures to run them are treated as errors.
+	output, err := c.impl.RunOutputWithWorkDir(
+		dir, runtime,
+		"run",
+		"--rm",
+		"--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+		"--volume", fmt.Sprintf("%s:%s:Z", dir, containerWorkspace),
+		"--workdir", containerWorkspace,
+		"--entrypoint", "sh",
+		image,
+		"-c", `"$0" "$1"; test $? -lt 126`, linter, filepath.Base(path),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "running %s", linter)
+	}
+
+	bc.lintFindings = parseLintFindings(output)
+	failing := []string{}
+	for _, finding := range bc.lintFindings {
+		if finding.Severity.AtLeast(c.options.LintFailSeverity()) {
+			failing = append(failing, fmt.Sprintf("%s: %s", finding.Severity, finding.Message))
+			continue
+		}
+		bc.log.Warnf("%s %s: %s", linter, finding.Severity, finding.Message)
+	}
+	if len(failing) > 0 {
+		return errors.Errorf(
+			"%s reported %d findings of severity %s or higher for %s:\n%s",
+			linter, len(failing), c.options.LintFailSeverity(),
+			filepath.Base(path), strings.Join(failing, "\n"),
+		)
+	}
+	return nil
+}
+
+// parseLintFindings parses the output of lintian or rpmlint. Notes,
+// overrides and summary lines get ignored.
+func parseLintFindings(output string) []LintFinding {
+	findings := []LintFinding{}
+	for _, line := range strings.Split(output, "\n") {
+		match := lintFindingRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		findings = append(findings, LintFinding{
+			Severity: lintSeverities[match[1]],
+			Message:  match[2],
+		})
+	}
+	return findings
+}
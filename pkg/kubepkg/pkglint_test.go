@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsLintPackages(t *testing.T) {
+	for _, tc := range []struct {
+		output           string
+		severity         options.LintSeverity
+		expectedFindings []kubepkg.LintFinding
+		shouldError      bool
+	}{
+		{
+			output:   "W: kubeadm: binary-without-manpage usr/bin/kubeadm\nN: some note\nI: kubeadm: info-tag\n",
+			severity: options.LintSeverityError,
+			expectedFindings: []kubepkg.LintFinding{
+				{Severity: options.LintSeverityWarning, Message: "kubeadm: binary-without-manpage usr/bin/kubeadm"},
+				{Severity: options.LintSeverityInfo, Message: "kubeadm: info-tag"},
+			},
+		},
+		{
+			output:      "W: kubeadm: binary-without-manpage usr/bin/kubeadm\n",
+			severity:    options.LintSeverityWarning,
+			shouldError: true,
+		},
+		{
+			output:      "E: kubeadm: no-copyright-file\n",
+			severity:    options.LintSeverityError,
+			shouldError: true,
+		},
+	} {
+		opts := options.New().
+			WithPackages("kubeadm").
+			WithChannels("release").
+			WithArchitectures("amd64").
+			WithLintPackages(true).
+			WithLintFailSeverity(tc.severity)
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+		defer cleanup()
+		mock.AvailableReturns(true)
+		mock.RunOutputWithWorkDirReturns(tc.output, nil)
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		err = sut.WalkBuilds(builds)
+
+		require.Equal(t, 1, mock.RunOutputWithWorkDirCallCount())
+		_, cmd, args := mock.RunOutputWithWorkDirArgsForCall(0)
+		require.Equal(t, "docker", cmd)
+		require.Equal(t, []string{"lintian", "kubeadm_1.18.0-0_amd64.deb"}, args[len(args)-2:])
+		if tc.shouldError {
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "lintian reported 1 findings")
+			continue
+		}
+		require.Nil(t, err)
+
+		summary := sut.Summary()
+		require.Len(t, summary.Artifacts, 1)
+		require.Equal(t, tc.expectedFindings, summary.Artifacts[0].LintFindings)
+		require.Contains(t, summary.Table(), "2 lint findings: 0 errors, 1 warnings, 1 infos")
+	}
+}
+
+func TestLintPackagesSkipsOtherTypes(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubectl").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithLintPackages(true)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildSnap)
+	defer cleanup()
+	mock.AvailableReturns(true)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+	require.Zero(t, mock.RunOutputWithWorkDirCallCount())
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// PlannedBuild is a single package build including all resolved versions and
+// download locations.
+type PlannedBuild struct {
+	Package           string            `json:"package"`
+	Type              options.BuildType `json:"type"`
+	Channel           ChannelType       `json:"channel"`
+	Distro            string            `json:"distro,omitempty"`
+	Arch              string            `json:"arch"`
+	BuildArch         string            `json:"buildArch"`
+	Version           string            `json:"version"`
+	Revision          string            `json:"revision"`
+	KubernetesVersion string            `json:"kubernetesVersion"`
+
+	// FileName is the name of the resulting package, which is empty if the
+	// build type does not produce a single package file.
+	FileName string `json:"fileName,omitempty"`
+
+	// DownloadURLs are the locations of the binaries or tarballs which get
+	// packaged.
+	DownloadURLs []string `json:"downloadURLs"`
+}
+
+// Plan is the full build matrix of the client.
+type Plan struct {
+	Builds []PlannedBuild `json:"builds"`
+}
+
+// Plan resolves all versions and download locations of the provided builds
+// without building anything.
+func (c *Client) Plan(builds []Build) (*Plan, error) {
+	logrus.Infof("Planning builds...")
+
+	plan := &Plan{Builds: []PlannedBuild{}}
+	for _, job := range c.buildJobs(builds) {
+		bc, err := c.newBuildConfig(job.build, job.packageDef, job.arch, "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "planning build %s", job.String())
+		}
+		if bc == nil {
+			continue
+		}
+
+		planned := PlannedBuild{
+			Package:           bc.Package,
+			Type:              bc.Type,
+			Channel:           ChannelType(bc.ChannelName),
+			Distro:            bc.Distro,
+			Arch:              bc.GoArch,
+			BuildArch:         bc.BuildArch,
+			Version:           bc.Version,
+			Revision:          bc.Revision,
+			KubernetesVersion: bc.KubernetesVersion,
+			DownloadURLs:      downloadURLs(bc),
+		}
+		if bc.Type != options.BuildWinget {
+			planned.FileName = packageFileName(bc)
+		}
+		plan.Builds = append(plan.Builds, planned)
+	}
+
+	logrus.Infof("Successfully planned %d builds", len(plan.Builds))
+	return plan, nil
+}
+
+// downloadURLs returns the locations of all sources of the build. Binaries of
+// the binary directory are returned as local paths.
+func downloadURLs(bc *buildConfig) []string {
+	switch {
+	case bc.localBinary != "":
+		return []string{bc.localBinary}
+	case isWindowsType(bc.Type):
+		urls := []string{}
+		for _, installer := range bc.WindowsInstallers {
+			urls = append(urls, installer.URL)
+		}
+		return urls
+	case bc.Type == options.BuildMsi:
+		return []string{windowsBinaryURL(bc, bc.GoArch)}
+	}
+	return []string{sourceURL(bc)}
+}
+
+// JSON returns the indented JSON representation of the plan.
+func (p *Plan) JSON() ([]byte, error) {
+	res, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling build plan")
+	}
+	return res, nil
+}
+
+// Write writes the human readable table representation of the plan to w.
+func (p *Plan) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PACKAGE\tTYPE\tCHANNEL\tARCH\tVERSION\tFILE\tDOWNLOADS")
+	for i := range p.Builds {
+		b := &p.Builds[i]
+		channel := string(b.Channel)
+		if b.Distro != "" {
+			channel += "/" + b.Distro
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s/%s\t%s-%s\t%s\t%s\n",
+			b.Package, b.Type, channel, b.Arch, b.BuildArch,
+			b.Version, b.Revision, b.FileName,
+			strings.Join(b.DownloadURLs, ","),
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestPlan(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm", "kubernetes-cni").
+		WithChannels("release").
+		WithArchitectures("amd64", "arm64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildRpm)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	plan, err := sut.Plan(builds)
+	require.Nil(t, err)
+	require.Len(t, plan.Builds, 4)
+	require.Zero(t, mock.RunSuccessWithWorkDirCallCount())
+	require.Zero(t, mock.DownloadFileCallCount())
+
+	kubeadm := plan.Builds[0]
+	require.Equal(t, "kubeadm", kubeadm.Package)
+	require.Equal(t, "aarch64", plan.Builds[2].BuildArch)
+	require.Equal(t, "kubeadm-1.18.0-0.x86_64.rpm", kubeadm.FileName)
+	require.Equal(t,
+		[]string{"https://dl.k8s.io/v1.18.0/bin/linux/amd64/kubeadm"},
+		kubeadm.DownloadURLs,
+	)
+	require.Equal(t,
+		[]string{"https://storage.googleapis.com/k8s-artifacts-cni/release/v0.8.6/cni-plugins-linux-amd64-v0.8.6.tgz"},
+		plan.Builds[1].DownloadURLs,
+	)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, plan.Write(buf))
+	require.Contains(t, buf.String(), "kubeadm-1.18.0-0.x86_64.rpm")
+
+	res, err := plan.JSON()
+	require.Nil(t, err)
+	require.Contains(t, string(res), `"fileName": "kubeadm-1.18.0-0.aarch64.rpm"`)
+}
+
+func TestPlanFailure(t *testing.T) {
+	opts := options.New()
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	opts.WithKubeVersion("wrong")
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	_, err = sut.Plan(builds)
+	require.NotNil(t, err)
+}
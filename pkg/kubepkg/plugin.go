@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// Builder builds the packages of a custom build type, which can be added
+// via RegisterBuilder. The specs of its packages get rendered from the
+// <template-dir>/<build-type>/<package> templates like for the builtin types.
+type Builder interface {
+	// BuildArch returns the architecture name of the package type for the
+	// provided Go architecture. Architectures resulting in an empty string
+	// are skipped.
+	BuildArch(goArch string) string
+
+	// PackageFileName returns the file name of the package described by the
+	// build context.
+	PackageFileName(ctx *BuildContext) string
+
+	// Build builds the package from the rendered specs within the spec
+	// directory of the build context and returns the path of the built
+	// package, which gets copied into the output directory afterwards.
+	// Relative paths are resolved against the spec directory and the file
+	// name has to match PackageFileName.
+	Build(ctx *BuildContext) (string, error)
+}
+
+// BuildHook is a custom step which runs before or after every package build.
+type BuildHook func(ctx *BuildContext) error
+
+// BuildContext describes a single package build for builders and build
+// hooks.
+type BuildContext struct {
+	*PackageDefinition
+
+	Type      options.BuildType
+	Package   string
+	GoArch    string
+	BuildArch string
+
+	// SpecDir is the directory containing the rendered specs of the
+	// architecture.
+	SpecDir string
+
+	// PackagePath is the path of the package within the output directory,
+	// which is empty when passed to Builder.PackageFileName.
+	PackagePath string
+
+	// Log is the logger of the build.
+	Log *logrus.Entry
+}
+
+// RegisterBuilder adds a custom build type, whose packages get built by the
+// provided builder. The build type gets added to the custom build types of
+// the options, which means that it passes their validation. Builtin build
+// types cannot be overridden.
+func (c *Client) RegisterBuilder(buildType options.BuildType, builder Builder) error {
+	if builder == nil {
+		return errors.New("builder cannot be nil")
+	}
+	for _, builtin := range options.SupportedBuildTypes() {
+		if buildType == builtin {
+			return errors.Errorf("build type %s is a builtin", buildType)
+		}
+	}
+
+	c.pluginsMu.Lock()
+	defer c.pluginsMu.Unlock()
+	if c.builders == nil {
+		c.builders = map[options.BuildType]Builder{}
+	}
+	if _, ok := c.builders[buildType]; !ok {
+		c.options.WithCustomBuildTypes(
+			append(c.options.CustomBuildTypes(), buildType)...,
+		)
+	}
+	c.builders[buildType] = builder
+	return nil
+}
+
+// AddPreBuildHook registers a step which runs before building every
+// package, after its specs have been rendered. Hooks run in the order of
+// their registration and a failing hook fails the build.
+func (c *Client) AddPreBuildHook(hook BuildHook) {
+	c.pluginsMu.Lock()
+	defer c.pluginsMu.Unlock()
+	c.preBuildHooks = append(c.preBuildHooks, hook)
+}
+
+// AddPostBuildHook registers a step which runs after every package has been
+// built successfully. Hooks run in the order of their registration and a
+// failing hook fails the build.
+func (c *Client) AddPostBuildHook(hook BuildHook) {
+	c.pluginsMu.Lock()
+	defer c.pluginsMu.Unlock()
+	c.postBuildHooks = append(c.postBuildHooks, hook)
+}
+
+// builder returns the registered builder of the build type, which is nil for
+// the builtin build types.
+func (c *Client) builder(buildType options.BuildType) Builder {
+	c.pluginsMu.RLock()
+	defer c.pluginsMu.RUnlock()
+	return c.builders[buildType]
+}
+
+// runBuildHooks runs the provided hooks for the build as a step of the
+// provided name.
+func (c *Client) runBuildHooks(
+	bc *buildConfig, specDir, name string, hooks []BuildHook,
+) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+	return bc.step(name, func() error {
+		ctx := c.buildContext(bc, specDir)
+		for _, hook := range hooks {
+			if err := hook(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// buildHooks returns a copy of the registered pre and post build hooks.
+func (c *Client) buildHooks() (pre, post []BuildHook) {
+	c.pluginsMu.RLock()
+	defer c.pluginsMu.RUnlock()
+	return append([]BuildHook{}, c.preBuildHooks...),
+		append([]BuildHook{}, c.postBuildHooks...)
+}
+
+// buildContext returns the build context of the build config including the
+// path of its package within the output directory.
+func (c *Client) buildContext(bc *buildConfig, specDir string) *BuildContext {
+	ctx := newBuildContext(bc, specDir)
+	ctx.PackagePath = c.outputPath(bc, packageFileName(bc))
+	return ctx
+}
+
+// newBuildContext returns the build context of the build config without the
+// package path.
+func newBuildContext(bc *buildConfig, specDir string) *BuildContext {
+	return &BuildContext{
+		PackageDefinition: bc.PackageDefinition,
+		Type:              bc.Type,
+		Package:           bc.Package,
+		GoArch:            bc.GoArch,
+		BuildArch:         bc.BuildArch,
+		SpecDir:           specDir,
+		Log:               bc.log,
+	}
+}
+
+// runBuilder builds the package of a custom build type by using its
+// registered builder.
+func (c *Client) runBuilder(bc *buildConfig, specDirWithArch string) error {
+	bc.log.Infof("Running %s builder for %s (%s/%s)", bc.Type, bc.Package, bc.GoArch, bc.BuildArch)
+	path, err := bc.builder.Build(c.buildContext(bc, specDirWithArch))
+	if err != nil {
+		return errors.Wrapf(err, "running %s builder", bc.Type)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(specDirWithArch, path)
+	}
+	return c.copyPackage(bc, path)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+type testBuilder struct {
+	built []string
+	err   error
+}
+
+func (b *testBuilder) BuildArch(goArch string) string {
+	if goArch == "arm" {
+		return ""
+	}
+	return goArch
+}
+
+func (b *testBuilder) PackageFileName(ctx *kubepkg.BuildContext) string {
+	return fmt.Sprintf("%s-%s.%s.acme", ctx.Package, ctx.Version, ctx.BuildArch)
+}
+
+func (b *testBuilder) Build(ctx *kubepkg.BuildContext) (string, error) {
+	b.built = append(b.built, ctx.SpecDir)
+	return b.PackageFileName(ctx), b.err
+}
+
+func TestWalkBuildsCustomBuilder(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubectl").
+		WithChannels("release").
+		WithArchitectures("amd64", "arm")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, "acme")
+	defer cleanup()
+
+	builder := &testBuilder{}
+	require.NotNil(t, sut.RegisterBuilder(options.BuildDeb, builder))
+	require.Nil(t, sut.RegisterBuilder("acme", builder))
+	require.Nil(t, opts.Validate())
+	require.Equal(t, []options.BuildType{"acme"}, opts.CustomBuildTypes())
+
+	hooks := []string{}
+	sut.AddPreBuildHook(func(ctx *kubepkg.BuildContext) error {
+		hooks = append(hooks, "pre "+filepath.Base(ctx.PackagePath))
+		return nil
+	})
+	sut.AddPostBuildHook(func(ctx *kubepkg.BuildContext) error {
+		hooks = append(hooks, "post "+filepath.Base(ctx.PackagePath))
+		return nil
+	})
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// arm is not supported by the builder
+	require.Len(t, builder.built, 1)
+	require.Equal(t, []string{
+		"pre kubectl-1.18.0.amd64.acme", "post kubectl-1.18.0.amd64.acme",
+	}, hooks)
+	artifacts := sut.Summary().Artifacts
+	require.Len(t, artifacts, 1)
+	require.Equal(t, "kubectl-1.18.0.amd64.acme", filepath.Base(artifacts[0].Path))
+	require.Zero(t, mock.RunSuccessWithWorkDirCallCount())
+}
+
+func TestWalkBuildsCustomBuilderFailure(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubectl").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, "acme")
+	defer cleanup()
+	require.Nil(t, sut.RegisterBuilder("acme", &testBuilder{err: errors.New("no tooling")}))
+
+	postHookCalled := false
+	sut.AddPostBuildHook(func(*kubepkg.BuildContext) error {
+		postHookCalled = true
+		return nil
+	})
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	err = sut.WalkBuilds(builds)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "running acme builder: no tooling")
+	require.False(t, postHookCalled)
+}
+
+func TestWalkBuildsPreBuildHookFailure(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubectl").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	sut.AddPreBuildHook(func(*kubepkg.BuildContext) error {
+		return errors.New("hook failed")
+	})
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	err = sut.WalkBuilds(builds)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "hook failed")
+	require.Zero(t, mock.RunSuccessWithWorkDirCallCount())
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// sharedDownload is a file which gets downloaded once per walk and is shared
+// by all builds requiring it, for example the CNI plugins tarball of the
+// kubernetes-cni packages of all channels.
+type sharedDownload struct {
+	once sync.Once
+	path string
+	err  error
+}
+
+// fetch returns the local path of url, which gets downloaded by the first
+// build requiring it. Concurrent callers wait for that download and share
+// its result, including a failure.
+func (c *Client) fetch(bc *buildConfig, url string) (string, error) {
+	c.downloadsMu.Lock()
+	if c.downloads == nil {
+		c.downloads = map[string]*sharedDownload{}
+	}
+	download, ok := c.downloads[url]
+	if !ok {
+		download = &sharedDownload{}
+		c.downloads[url] = download
+	}
+	c.downloadsMu.Unlock()
+
+	download.once.Do(func() {
+		if c.options.CacheDir() != "" {
+			download.path, download.err = c.fetchCached(bc, url)
+			return
+		}
+		download.path, download.err = c.fetchUncached(bc, url)
+	})
+	return download.path, download.err
+}
+
+// fetchUncached downloads url into a temporary directory, which gets removed
+// at the end of the walk.
+func (c *Client) fetchUncached(bc *buildConfig, url string) (string, error) {
+	downloadDir, err := c.downloadDir()
+	if err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp(downloadDir, "")
+	if err != nil {
+		return "", errors.Wrap(err, "creating download directory")
+	}
+
+	dst := filepath.Join(dir, path.Base(url))
+	bc.log.Infof("Downloading %s to %s", url, dst)
+	if err := c.downloadFile(url, dst); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// downloadDir returns the temporary directory of the downloads of the
+// current walk, which gets created on first use.
+func (c *Client) downloadDir() (string, error) {
+	c.downloadsMu.Lock()
+	defer c.downloadsMu.Unlock()
+	if c.downloadsDir == "" {
+		dir, err := os.MkdirTemp("", "kubepkg-downloads-")
+		if err != nil {
+			return "", errors.Wrap(err, "creating downloads directory")
+		}
+		c.downloadsDir = dir
+	}
+	return c.downloadsDir, nil
+}
+
+// downloadFile downloads url to dst, whereas the number of parallel
+// downloads across all builds is limited by the download concurrency.
+func (c *Client) downloadFile(url, dst string) error {
+	c.downloadsMu.Lock()
+	if c.downloadSlots == nil {
+		slots := c.options.DownloadConcurrency()
+		if slots < 1 {
+			slots = 1
+		}
+		c.downloadSlots = make(chan struct{}, slots)
+	}
+	slots := c.downloadSlots
+	c.downloadsMu.Unlock()
+
+	slots <- struct{}{}
+	defer func() { <-slots }()
+	return c.impl.DownloadFile(url, dst)
+}
+
+// prefetchSources starts downloading the sources of all provided builds in
+// the background, which means that the binaries of all architectures get
+// downloaded in parallel while the first builds are already running.
+// Failures are reported by the builds requiring the files.
+func (c *Client) prefetchSources(configs []*buildConfig) {
+	for _, bc := range configs {
+		if bc == nil {
+			continue
+		}
+		for _, url := range c.sourceURLs(bc) {
+			c.prefetchWg.Add(1)
+			go func(bc *buildConfig, url string) {
+				defer c.prefetchWg.Done()
+				if _, err := c.fetch(bc, url); err != nil {
+					bc.log.Debugf("Prefetching %s failed: %v", url, err)
+				}
+			}(bc, url)
+		}
+	}
+}
+
+// sourceURLs returns the files which get downloaded by kubepkg itself for
+// the build. Builds using the distribution tooling without a download cache
+// retrieve their sources on their own.
+func (c *Client) sourceURLs(bc *buildConfig) []string {
+	switch {
+	case bc.specOnly || bc.builder != nil:
+		return nil
+	case bc.Type == options.BuildMsi:
+		return []string{windowsBinaryURL(bc, bc.GoArch)}
+	case bc.localBinary != "":
+		return nil
+	case c.usesNfpm() || c.cachesDebBinary(bc):
+		return []string{sourceURL(bc)}
+	}
+	return nil
+}
+
+// cleanupDownloads waits for all prefetched downloads and removes the
+// downloads of the current walk.
+func (c *Client) cleanupDownloads() {
+	c.prefetchWg.Wait()
+
+	c.downloadsMu.Lock()
+	defer c.downloadsMu.Unlock()
+	if c.downloadsDir != "" {
+		if err := os.RemoveAll(c.downloadsDir); err != nil {
+			logrus.Warnf("Unable to remove downloads directory %s: %v", c.downloadsDir, err)
+		}
+	}
+	c.downloadsDir = ""
+	c.downloads = nil
+}
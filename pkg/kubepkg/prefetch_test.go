@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessDownloadConcurrency(t *testing.T) {
+	opts := options.New().
+		WithBackend(options.BackendNfpm).
+		WithPackages("kubectl", "kubelet").
+		WithChannels("release", "testing").
+		WithArchitectures("amd64", "arm64").
+		WithConcurrency(4).
+		WithDownloadConcurrency(1)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildRpm)
+	defer cleanup()
+
+	mu := sync.Mutex{}
+	running, maxRunning := 0, 0
+	mock.DownloadFileStub = func(_, dst string) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+		return os.WriteFile(dst, []byte("download"), 0o644)
+	}
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// Both channels share the binaries of every architecture
+	require.Equal(t, 4, mock.DownloadFileCallCount())
+	require.Equal(t, 1, maxRunning)
+	urls := []string{}
+	for i := 0; i < mock.DownloadFileCallCount(); i++ {
+		url, _ := mock.DownloadFileArgsForCall(i)
+		urls = append(urls, url)
+	}
+	require.ElementsMatch(t, []string{
+		"https://dl.k8s.io/v1.18.0/bin/linux/amd64/kubectl",
+		"https://dl.k8s.io/v1.18.0/bin/linux/arm64/kubectl",
+		"https://dl.k8s.io/v1.18.0/bin/linux/amd64/kubelet",
+		"https://dl.k8s.io/v1.18.0/bin/linux/arm64/kubelet",
+	}, urls)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// buildField is the log field which identifies the build of a log entry.
+const buildField = "build"
+
+// LogPrefixHook is a logrus hook which prefixes the message of every log
+// entry belonging to a build with the name of the build, for example
+// `[kubeadm/stable/arm64]`. This keeps the output of parallel builds
+// readable.
+type LogPrefixHook struct{}
+
+// Levels returns all log levels, because every entry should be prefixed.
+func (*LogPrefixHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire prefixes the message of the entry if it belongs to a build.
+func (*LogPrefixHook) Fire(entry *logrus.Entry) error {
+	build, ok := entry.Data[buildField]
+	if !ok {
+		return nil
+	}
+	prefix := fmt.Sprintf("[%v] ", build)
+	if !strings.HasPrefix(entry.Message, prefix) {
+		entry.Message = prefix + entry.Message
+	}
+	return nil
+}
+
+// step runs a single step of the build and logs how long it took.
+func (bc *buildConfig) step(name string, fn func() error) error {
+	started := time.Now()
+	bc.log.Debugf("Started %s", name)
+	err := fn()
+	if err != nil {
+		bc.log.Debugf("Failed %s after %s", name, roundDuration(time.Since(started)))
+		return err
+	}
+	bc.log.Infof("Finished %s in %s", name, roundDuration(time.Since(started)))
+	return nil
+}
+
+// roundDuration rounds the duration for being displayed to the user.
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
+
+// addBuildResults records the results of finished build jobs for the summary.
+func (c *Client) addBuildResults(results []BuildResult) {
+	c.buildResultsMu.Lock()
+	defer c.buildResultsMu.Unlock()
+	c.buildResults = append(c.buildResults, results...)
+}
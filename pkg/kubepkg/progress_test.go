@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+)
+
+func TestLogPrefixHook(t *testing.T) {
+	sut := &kubepkg.LogPrefixHook{}
+
+	entry := logrus.WithField("build", "kubeadm/stable/arm64")
+	entry.Message = "Building package"
+	require.Nil(t, sut.Fire(entry))
+	require.Equal(t, "[kubeadm/stable/arm64] Building package", entry.Message)
+
+	// Prefixing is idempotent
+	require.Nil(t, sut.Fire(entry))
+	require.Equal(t, "[kubeadm/stable/arm64] Building package", entry.Message)
+
+	entry = logrus.NewEntry(logrus.StandardLogger())
+	entry.Message = "Walking builds..."
+	require.Nil(t, sut.Fire(entry))
+	require.Equal(t, "Walking builds...", entry.Message)
+}
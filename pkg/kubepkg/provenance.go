@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/release-utils/hash"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/version"
+)
+
+const (
+	// ProvenanceSuffix is appended to the file name of a package for the
+	// file containing its provenance statement.
+	ProvenanceSuffix = ".intoto.json"
+
+	inTotoStatementType = "https://in-toto.io/Statement/v0.1"
+	slsaPredicateType   = "https://slsa.dev/provenance/v0.2"
+	kubepkgBuilderID    = "https://github.com/kubernetes/release/tree/master/cmd/kubepkg"
+	kubepkgBuildType    = "https://github.com/kubernetes/release/tree/master/cmd/kubepkg/%s@v1"
+)
+
+// sourceRepositories are the git repositories the packaged binaries are built
+// from by their source package.
+var sourceRepositories = map[string]string{
+	"kubelet":        "https://github.com/kubernetes/kubernetes",
+	"kubectl":        "https://github.com/kubernetes/kubernetes",
+	"kubeadm":        "https://github.com/kubernetes/kubernetes",
+	"kubernetes-cni": "https://github.com/containernetworking/plugins",
+	"cri-tools":      "https://github.com/kubernetes-sigs/cri-tools",
+}
+
+// ProvenanceStatement is an in-toto statement containing the SLSA
+// provenance of a single package.
+type ProvenanceStatement struct {
+	Type          string              `json:"_type"`
+	PredicateType string              `json:"predicateType"`
+	Subject       []ProvenanceSubject `json:"subject"`
+	Predicate     ProvenancePredicate `json:"predicate"`
+}
+
+// ProvenanceSubject is an artifact described by the provenance.
+type ProvenanceSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// ProvenancePredicate is the SLSA provenance of a package build.
+type ProvenancePredicate struct {
+	Builder    ProvenanceBuilder    `json:"builder"`
+	BuildType  string               `json:"buildType"`
+	Invocation ProvenanceInvocation `json:"invocation"`
+	Metadata   ProvenanceMetadata   `json:"metadata"`
+	Materials  []ProvenanceMaterial `json:"materials"`
+}
+
+// ProvenanceBuilder identifies the tool which built the package.
+type ProvenanceBuilder struct {
+	ID string `json:"id"`
+}
+
+// ProvenanceInvocation contains the parameters of the package build.
+type ProvenanceInvocation struct {
+	Parameters map[string]string `json:"parameters"`
+}
+
+// ProvenanceMetadata contains the time range of the package build.
+type ProvenanceMetadata struct {
+	BuildStartedOn  time.Time `json:"buildStartedOn"`
+	BuildFinishedOn time.Time `json:"buildFinishedOn"`
+}
+
+// ProvenanceMaterial is an input of the package build, like the source
+// repository or a downloaded binary.
+type ProvenanceMaterial struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// writeProvenance writes the provenance statement of the package at path
+// next to it if provenance has been enabled. The statement gets signed into
+// a detached .asc signature if a sign key has been configured.
+func (c *Client) writeProvenance(bc *buildConfig, path string, content []byte) error {
+	if !c.options.Provenance() {
+		return nil
+	}
+
+	sum256 := sha256.Sum256(content)
+	statement := c.provenance(bc, filepath.Base(path), hex.EncodeToString(sum256[:]))
+	statementJSON, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshaling provenance")
+	}
+
+	provenancePath := path + ProvenanceSuffix
+	bc.log.Infof("Writing provenance to %s", provenancePath)
+	if err := c.impl.WriteFile(
+		provenancePath, append(statementJSON, '\n'), os.FileMode(0o644),
+	); err != nil {
+		return errors.Wrapf(err, "writing %s", provenancePath)
+	}
+
+	if c.options.SignKey() != "" {
+		return c.signFile(provenancePath, provenancePath+".asc", false)
+	}
+	return nil
+}
+
+// provenance returns the provenance statement of the package with the
+// provided file name and SHA256 checksum.
+func (c *Client) provenance(bc *buildConfig, fileName, digest string) *ProvenanceStatement {
+	builderID := kubepkgBuilderID
+	if gitVersion := version.Get().GitVersion; gitVersion != "" {
+		builderID += "@" + gitVersion
+	}
+
+	return &ProvenanceStatement{
+		Type:          inTotoStatementType,
+		PredicateType: slsaPredicateType,
+		Subject: []ProvenanceSubject{{
+			Name:   fileName,
+			Digest: map[string]string{"sha256": digest},
+		}},
+		Predicate: ProvenancePredicate{
+			Builder:   ProvenanceBuilder{ID: builderID},
+			BuildType: fmt.Sprintf(kubepkgBuildType, bc.Type),
+			Invocation: ProvenanceInvocation{
+				Parameters: map[string]string{
+					"package":           bc.Package,
+					"version":           bc.Version,
+					"revision":          bc.Revision,
+					"channel":           bc.ChannelName,
+					"arch":              bc.GoArch,
+					"buildArch":         bc.BuildArch,
+					"kubernetesVersion": bc.KubernetesVersion,
+					"cniVersion":        bc.CNIVersion,
+					"backend":           string(c.options.Backend()),
+				},
+			},
+			Metadata: ProvenanceMetadata{
+				BuildStartedOn:  bc.started.UTC(),
+				BuildFinishedOn: time.Now().UTC(),
+			},
+			Materials: c.provenanceMaterials(bc),
+		},
+	}
+}
+
+// provenanceMaterials returns the source tag and all binaries or tarballs
+// packaged by the build including their checksums, if available.
+func (c *Client) provenanceMaterials(bc *buildConfig) []ProvenanceMaterial {
+	materials := []ProvenanceMaterial{}
+
+	// CI builds do not have a tag
+	source := sourcePackage(bc.PackageDefinition)
+	if repo, ok := sourceRepositories[source]; ok && bc.Channel != ChannelNightly {
+		materials = append(materials, ProvenanceMaterial{
+			URI: fmt.Sprintf("git+%s@refs/tags/%s", repo, util.AddTagPrefix(bc.Version)),
+		})
+	}
+
+	windowsSHA256 := map[string]string{}
+	for _, installer := range bc.WindowsInstallers {
+		windowsSHA256[installer.URL] = installer.SHA256
+	}
+
+	for _, url := range downloadURLs(bc) {
+		material := ProvenanceMaterial{URI: url}
+		var digest string
+		var err error
+		switch {
+		case url == bc.localBinary:
+			material.URI = "file://" + url
+			digest, err = hash.SHA256ForFile(url)
+		case windowsSHA256[url] != "":
+			digest = windowsSHA256[url]
+		default:
+			digest, err = c.publishedSHA256(url)
+		}
+		if err != nil {
+			bc.log.Warnf("Unable to get checksum of %s for provenance: %v", url, err)
+		} else if digest != "" {
+			material.Digest = map[string]string{"sha256": digest}
+		}
+		materials = append(materials, material)
+	}
+	return materials
+}
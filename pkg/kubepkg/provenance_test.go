@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessProvenance(t *testing.T) {
+	outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+	require.Nil(t, err)
+	defer os.RemoveAll(outputDir)
+
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithOutputDir(outputDir).
+		WithProvenance(true)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.GetURLResponseStub = func(url string, _ bool) (string, error) {
+		if strings.HasSuffix(url, ".sha256") {
+			return "0123  kubeadm", nil
+		}
+		return "", nil
+	}
+	mock.ReadFileReturns([]byte("content"), nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// The package is followed by its provenance
+	dst, content, _ := mock.WriteFileArgsForCall(1)
+	require.Equal(t,
+		filepath.Join(outputDir, "release", "kubeadm_1.18.0-0_amd64.deb"+kubepkg.ProvenanceSuffix), dst,
+	)
+
+	statement := &kubepkg.ProvenanceStatement{}
+	require.Nil(t, json.Unmarshal(content, statement))
+	require.Equal(t, "kubeadm_1.18.0-0_amd64.deb", statement.Subject[0].Name)
+	require.Equal(t,
+		// sha256 of "content"
+		"ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+		statement.Subject[0].Digest["sha256"],
+	)
+	require.Equal(t, "1.18.0", statement.Predicate.Invocation.Parameters["version"])
+	require.Equal(t, "amd64", statement.Predicate.Invocation.Parameters["arch"])
+	require.Equal(t, []kubepkg.ProvenanceMaterial{
+		{URI: "git+https://github.com/kubernetes/kubernetes@refs/tags/v1.18.0"},
+		{
+			URI:    "https://dl.k8s.io/v1.18.0/bin/linux/amd64/kubeadm",
+			Digest: map[string]string{"sha256": "0123"},
+		},
+	}, statement.Predicate.Materials)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/object"
+)
+
+const (
+	// DefaultPublishStagingBucket is the default bucket for staging
+	// package repositories before their promotion.
+	DefaultPublishStagingBucket = "k8s-staging-releng"
+
+	// DefaultPublishStagingPath is the default path within the staging
+	// bucket.
+	DefaultPublishStagingPath = "packages"
+
+	aptReleaseFile      = "Release"
+	aptInReleaseFile    = "InRelease"
+	aptReleaseSignature = "Release.gpg"
+)
+
+// PublishOptions are the settings for publishing package repositories.
+type PublishOptions struct {
+	// RepoDir is the local root directory of the package repositories, for
+	// example created by CreateAptRepo or CreateRpmRepo.
+	RepoDir string
+
+	// StagingBucket is the GCS bucket the repositories get uploaded to.
+	StagingBucket string
+
+	// StagingPath is the path within the staging bucket.
+	StagingPath string
+
+	// Promote indicates if the staged repositories should be promoted to
+	// the production bucket after their upload.
+	Promote bool
+
+	// ProductionBucket is the GCS bucket the repositories get promoted to.
+	ProductionBucket string
+
+	// ProductionPath is the path within the production bucket.
+	ProductionPath string
+
+	// AllowUnsigned skips the check for signed repository metadata.
+	AllowUnsigned bool
+}
+
+// Publish uploads the package repositories of the repository directory to
+// the staging bucket and optionally promotes them to the production bucket
+// afterwards. The packages are part of the repositories, which means that
+// the whole directory gets synced. Repository metadata has to be signed
+// unless AllowUnsigned is set.
+func (c *Client) Publish(o *PublishOptions) error {
+	if o.Promote && o.ProductionBucket == "" {
+		return errors.New("production bucket is required for promotion")
+	}
+
+	if err := checkRepoDir(o.RepoDir, o.AllowUnsigned); err != nil {
+		return WithCategory(
+			errors.Wrapf(err, "checking repository directory %s", o.RepoDir),
+			ErrorCategoryValidation,
+		)
+	}
+
+	gcs := object.NewGCS()
+	stagingPath, err := gcs.NormalizePath(o.StagingBucket, o.StagingPath)
+	if err != nil {
+		return errors.Wrap(err, "normalizing staging path")
+	}
+
+	dryRun := c.options.DryRun()
+	if err := c.rsync(o.RepoDir, stagingPath, dryRun); err != nil {
+		return errors.Wrap(err, "staging package repositories")
+	}
+
+	if !o.Promote {
+		logrus.Infof("Staged package repositories in %s", stagingPath)
+		return nil
+	}
+
+	productionPath, err := gcs.NormalizePath(o.ProductionBucket, o.ProductionPath)
+	if err != nil {
+		return errors.Wrap(err, "normalizing production path")
+	}
+
+	if err := c.rsync(stagingPath, productionPath, dryRun); err != nil {
+		return errors.Wrap(err, "promoting package repositories")
+	}
+
+	logrus.Infof("Promoted package repositories to %s", productionPath)
+	return nil
+}
+
+func (c *Client) rsync(src, dst string, dryRun bool) error {
+	if dryRun {
+		logrus.Infof("Dry run: would sync %s to %s", src, dst)
+		return nil
+	}
+
+	logrus.Infof("Syncing %s to %s", src, dst)
+	return publishError(c.impl.RsyncRecursive(src, dst))
+}
+
+// checkRepoDir verifies that the directory contains at least one APT or yum
+// repository and that the metadata of all repositories is signed.
+func checkRepoDir(repoDir string, allowUnsigned bool) error {
+	repos := 0
+	if err := filepath.Walk(repoDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		signatures := []string{}
+		switch info.Name() {
+		case aptReleaseFile:
+			signatures = append(signatures, aptReleaseSignature, aptInReleaseFile)
+		case repomdXML:
+			signatures = append(signatures, repomdXML+".asc")
+		default:
+			return nil
+		}
+		repos++
+
+		if allowUnsigned {
+			return nil
+		}
+		for _, signature := range signatures {
+			signaturePath := filepath.Join(filepath.Dir(path), signature)
+			if _, err := os.Stat(signaturePath); err != nil {
+				return errors.Errorf(
+					"repository metadata %s is not signed: %s is missing",
+					path, signaturePath,
+				)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if repos == 0 {
+		return errors.New("no APT or yum repositories found")
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func newRepoDir(t *testing.T, files ...string) string {
+	repoDir, err := os.MkdirTemp("", "kubepkg-repo-")
+	require.Nil(t, err)
+	for _, file := range files {
+		path := filepath.Join(repoDir, file)
+		require.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.Nil(t, os.WriteFile(path, nil, 0o644))
+	}
+	return repoDir
+}
+
+func TestPublish(t *testing.T) {
+	repoDir := newRepoDir(t,
+		"apt/dists/kubernetes-xenial/Release",
+		"apt/dists/kubernetes-xenial/Release.gpg",
+		"apt/dists/kubernetes-xenial/InRelease",
+		"rpm/x86_64/repodata/repomd.xml",
+		"rpm/x86_64/repodata/repomd.xml.asc",
+	)
+	defer os.RemoveAll(repoDir)
+
+	for _, tc := range []struct {
+		name          string
+		opts          kubepkg.PublishOptions
+		expectedSyncs [][]string
+	}{
+		{
+			name: "stage only",
+			opts: kubepkg.PublishOptions{
+				StagingBucket: "staging",
+				StagingPath:   "packages/v1.22.1",
+			},
+			expectedSyncs: [][]string{
+				{repoDir, "gs://staging/packages/v1.22.1"},
+			},
+		},
+		{
+			name: "stage and promote",
+			opts: kubepkg.PublishOptions{
+				StagingBucket:    "staging",
+				StagingPath:      "packages/v1.22.1",
+				Promote:          true,
+				ProductionBucket: "gs://production",
+			},
+			expectedSyncs: [][]string{
+				{repoDir, "gs://staging/packages/v1.22.1"},
+				{"gs://staging/packages/v1.22.1", "gs://production"},
+			},
+		},
+	} {
+		sut, mock := newSUT(nil)
+		tc.opts.RepoDir = repoDir
+		require.Nil(t, sut.Publish(&tc.opts), tc.name)
+		require.Equal(t, len(tc.expectedSyncs), mock.RsyncRecursiveCallCount(), tc.name)
+		for i, expected := range tc.expectedSyncs {
+			src, dst := mock.RsyncRecursiveArgsForCall(i)
+			require.Equal(t, expected, []string{src, dst}, tc.name)
+		}
+	}
+}
+
+func TestPublishDryRun(t *testing.T) {
+	repoDir := newRepoDir(t,
+		"apt/dists/kubernetes-xenial/Release",
+		"apt/dists/kubernetes-xenial/Release.gpg",
+		"apt/dists/kubernetes-xenial/InRelease",
+	)
+	defer os.RemoveAll(repoDir)
+
+	sut, mock := newSUT(options.New().WithDryRun(true))
+	require.Nil(t, sut.Publish(&kubepkg.PublishOptions{
+		RepoDir:          repoDir,
+		StagingBucket:    "staging",
+		Promote:          true,
+		ProductionBucket: "production",
+	}))
+	require.Zero(t, mock.RsyncRecursiveCallCount())
+}
+
+func TestPublishFailure(t *testing.T) {
+	unsignedRepoDir := newRepoDir(t,
+		"apt/dists/kubernetes-xenial/Release",
+		"apt/dists/kubernetes-xenial/InRelease",
+	)
+	defer os.RemoveAll(unsignedRepoDir)
+	emptyRepoDir := newRepoDir(t)
+	defer os.RemoveAll(emptyRepoDir)
+
+	for _, tc := range []struct {
+		name     string
+		opts     kubepkg.PublishOptions
+		rsyncErr error
+	}{
+		{
+			name: "unsigned repository",
+			opts: kubepkg.PublishOptions{
+				RepoDir:       unsignedRepoDir,
+				StagingBucket: "staging",
+			},
+		},
+		{
+			name: "empty repository directory",
+			opts: kubepkg.PublishOptions{
+				RepoDir:       emptyRepoDir,
+				StagingBucket: "staging",
+				AllowUnsigned: true,
+			},
+		},
+		{
+			name: "missing production bucket",
+			opts: kubepkg.PublishOptions{
+				RepoDir:       unsignedRepoDir,
+				StagingBucket: "staging",
+				AllowUnsigned: true,
+				Promote:       true,
+			},
+		},
+		{
+			name: "sync failed",
+			opts: kubepkg.PublishOptions{
+				RepoDir:       unsignedRepoDir,
+				StagingBucket: "staging",
+				AllowUnsigned: true,
+			},
+			rsyncErr: err,
+		},
+	} {
+		sut, mock := newSUT(nil)
+		mock.RsyncRecursiveReturns(tc.rsyncErr)
+		require.NotNil(t, sut.Publish(&tc.opts), tc.name)
+	}
+}
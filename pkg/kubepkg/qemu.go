@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// binfmtMiscDir contains the registered binfmt_misc handlers of the
+	// host, for example qemu-aarch64.
+	binfmtMiscDir = "/proc/sys/fs/binfmt_misc"
+
+	// BinfmtImage is the container image used for registering the QEMU
+	// user emulation binfmt_misc handlers.
+	BinfmtImage = "docker.io/tonistiigi/binfmt:latest"
+)
+
+// qemuArchitectures maps the Go architectures to the QEMU ones, which are
+// used for naming the binfmt_misc handlers.
+var qemuArchitectures = map[string]string{
+	"amd64":   "x86_64",
+	"arm":     "arm",
+	"arm64":   "aarch64",
+	"ppc64le": "ppc64le",
+	"s390x":   "s390x",
+	"riscv64": "riscv64",
+}
+
+// ensureEmulation makes sure that binaries of the provided architecture can
+// be run on the host. Foreign architectures require a QEMU user emulation
+// binfmt_misc handler, which gets registered if the QEMU setup has been
+// enabled. Otherwise an error explains how to register it, instead of
+// failing later with exec format errors.
+func (c *Client) ensureEmulation(containerRuntime, arch string) error {
+	if arch == runtime.GOARCH {
+		return nil
+	}
+
+	qemuArch, ok := qemuArchitectures[arch]
+	if !ok {
+		return errors.Errorf("emulating architecture %s is not supported", arch)
+	}
+	if c.binfmtRegistered(qemuArch) {
+		logrus.Infof("Using QEMU user emulation for %s", arch)
+		return nil
+	}
+
+	setupArgs := []string{"run", "--rm", "--privileged", BinfmtImage, "--install", arch}
+	if !c.options.SetupQEMU() {
+		return errors.Errorf(
+			"running %s binaries on a %s host requires QEMU user emulation, "+
+				"but no binfmt_misc handler is registered for %s: "+
+				"use --setup-qemu or register it manually by running `%s %s`",
+			arch, runtime.GOARCH, qemuArch,
+			containerRuntime, strings.Join(setupArgs, " "),
+		)
+	}
+
+	logrus.Infof("Registering QEMU user emulation for %s", arch)
+	if err := c.impl.RunSuccessWithWorkDir("", containerRuntime, setupArgs...); err != nil {
+		return errors.Wrapf(err, "registering QEMU user emulation for %s", arch)
+	}
+	if !c.binfmtRegistered(qemuArch) {
+		return errors.Errorf(
+			"QEMU user emulation for %s is still not available after registering it", arch,
+		)
+	}
+	return nil
+}
+
+// binfmtRegistered returns true if an enabled QEMU binfmt_misc handler
+// exists for the provided QEMU architecture.
+func (c *Client) binfmtRegistered(qemuArch string) bool {
+	content, err := c.impl.ReadFile(filepath.Join(binfmtMiscDir, "qemu-"+qemuArch))
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(string(content), "enabled")
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/kubepkg/options"
+	"k8s.io/release/pkg/notes"
+)
+
+// releaseNotesFile is the release notes JSON published alongside the
+// binaries of every Kubernetes release.
+const releaseNotesFile = "release-notes.json"
+
+// releaseNotes returns the changelog entries of the provided build, which are
+// the release notes of the packaged Kubernetes version ordered by pull
+// request. An empty list is returned if the release notes are disabled, not
+// available for the package or not published (yet).
+func (c *Client) releaseNotes(bc *buildConfig) []string {
+	if !c.options.ReleaseNotes() ||
+		!isKubernetesBinary(bc.Package) ||
+		bc.Channel == ChannelNightly {
+		return nil
+	}
+
+	url := fmt.Sprintf("%s/%s", bc.DownloadLinkBase, releaseNotesFile)
+	notesByPR, err := c.fetchReleaseNotes(url)
+	if err != nil {
+		bc.log.Warnf("Unable to retrieve release notes, using default changelog: %v", err)
+		return nil
+	}
+
+	prs := []int{}
+	for pr := range notesByPR {
+		prs = append(prs, pr)
+	}
+	sort.Ints(prs)
+
+	entries := []string{}
+	for _, pr := range prs {
+		note := notesByPR[pr]
+		if note == nil {
+			continue
+		}
+		// Changelog entries have to be single lines
+		text := strings.Join(strings.Fields(note.Text), " ")
+		if text == "" {
+			continue
+		}
+		entry := fmt.Sprintf("%s (#%d)", text, pr)
+		if bc.Type == options.BuildRpm {
+			// Macros get expanded within the whole spec
+			entry = strings.ReplaceAll(entry, "%", "%%")
+		}
+		entries = append(entries, entry)
+	}
+	bc.log.Infof("Using %d release notes as changelog", len(entries))
+	return entries
+}
+
+// fetchReleaseNotes retrieves the release notes from the provided URL. They
+// are shared between all packages of the same version and therefore fetched
+// only once.
+func (c *Client) fetchReleaseNotes(url string) (notes.ReleaseNotesByPR, error) {
+	c.releaseNotesMu.Lock()
+	defer c.releaseNotesMu.Unlock()
+
+	if notesByPR, ok := c.releaseNotesCache[url]; ok {
+		return notesByPR, nil
+	}
+
+	response, err := c.impl.GetURLResponse(url, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching %s", url)
+	}
+
+	notesByPR := notes.ReleaseNotesByPR{}
+	if err := json.Unmarshal([]byte(response), &notesByPR); err != nil {
+		return nil, errors.Wrapf(err, "parsing release notes of %s", url)
+	}
+
+	if c.releaseNotesCache == nil {
+		c.releaseNotesCache = map[string]notes.ReleaseNotesByPR{}
+	}
+	c.releaseNotesCache[url] = notesByPR
+	return notesByPR, nil
+}
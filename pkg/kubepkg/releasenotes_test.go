@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessReleaseNotes(t *testing.T) {
+	const releaseNotes = `{
+		"2": {"text": "Fixed 100% of\nthe bugs"},
+		"1": {"text": "Added a feature"}
+	}`
+
+	for _, tc := range []struct {
+		buildType    options.BuildType
+		template     string
+		response     string
+		responseErr  error
+		expectedFile string
+		expected     []string
+	}{
+		{
+			buildType:    options.BuildDeb,
+			template:     "debian/changelog",
+			response:     releaseNotes,
+			expectedFile: "debian/changelog",
+			expected: []string{
+				"urgency=medium\n\n  * Added a feature (#1)\n  * Fixed 100% of the bugs (#2)\n\n -- ",
+			},
+		},
+		{
+			buildType:    options.BuildDeb,
+			template:     "debian/changelog",
+			responseErr:  errors.New("not found"),
+			expectedFile: "debian/changelog",
+			expected: []string{
+				"urgency=medium\n\n  * https://git.k8s.io/kubernetes/CHANGELOG/README.md\n\n -- ",
+			},
+		},
+		{
+			buildType:    options.BuildRpm,
+			template:     "kubelet.spec",
+			response:     releaseNotes,
+			expectedFile: "kubelet.spec",
+			expected: []string{
+				"Kubernetes Authors <kubernetes-dev@googlegroups.com> - 1.18.0-0\n" +
+					"- Added a feature (#1)\n- Fixed 100%% of the bugs (#2)\n\n* Mon Jun 22 2020",
+			},
+		},
+		{
+			buildType:    options.BuildRpm,
+			template:     "kubelet.spec",
+			response:     "wrong",
+			expectedFile: "kubelet.spec",
+			expected:     []string{"%changelog\n* Mon Jun 22 2020"},
+		},
+	} {
+		outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+		require.Nil(t, err)
+		defer os.RemoveAll(outputDir)
+
+		opts := options.New().
+			WithPackages("kubelet").
+			WithChannels("release").
+			WithArchitectures("amd64").
+			WithOutputDir(outputDir).
+			WithSpecOnly(true).
+			WithReleaseNotes(true)
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, tc.buildType)
+		defer cleanup()
+		mock.GetURLResponseReturns(tc.response, tc.responseErr)
+
+		// Use the upstream templates
+		template, err := os.ReadFile(filepath.Join(
+			"..", "..", "cmd", "kubepkg", "templates", "latest",
+			string(tc.buildType), "kubelet", tc.template,
+		))
+		require.Nil(t, err)
+		dst := filepath.Join(opts.TemplateDir(), string(tc.buildType), "kubelet", tc.template)
+		require.Nil(t, os.MkdirAll(filepath.Dir(dst), 0o755))
+		require.Nil(t, os.WriteFile(dst, template, 0o644))
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		require.Nil(t, sut.WalkBuilds(builds))
+
+		// The build dependencies of the Kubernetes version get fetched first
+		require.Equal(t, 2, mock.GetURLResponseCallCount())
+		url, _ := mock.GetURLResponseArgsForCall(1)
+		require.Equal(t, "https://dl.k8s.io/v1.18.0/release-notes.json", url)
+
+		content, err := os.ReadFile(filepath.Join(
+			outputDir, "specs", "release", "kubelet", "amd64", tc.expectedFile,
+		))
+		require.Nil(t, err)
+		for _, expected := range tc.expected {
+			require.Contains(t, string(content), expected)
+		}
+	}
+}
+
+func TestWalkBuildsSuccessReleaseNotesDisabled(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubelet", "cri-tools").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithSpecOnly(true)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	defer os.RemoveAll(opts.OutputDir())
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// Only the build dependencies of the Kubernetes version get fetched
+	require.Equal(t, 1, mock.GetURLResponseCallCount())
+	url, _ := mock.GetURLResponseArgsForCall(0)
+	require.True(t, strings.HasSuffix(url, "/v1.18.0/build/dependencies.yaml"), url)
+
+	// cri-tools is not part of the Kubernetes release notes
+	opts.WithReleaseNotes(true)
+	builds, err = sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+	require.Equal(t, 2, mock.GetURLResponseCallCount())
+	url, _ = mock.GetURLResponseArgsForCall(1)
+	require.Equal(t, "https://dl.k8s.io/v1.18.0/release-notes.json", url)
+}
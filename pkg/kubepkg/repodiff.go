@@ -0,0 +1,383 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// RepoDiffStatus is the state of a planned package compared to the
+// repository.
+type RepoDiffStatus string
+
+const (
+	// RepoDiffMissing indicates that the repository does not serve any
+	// version of the package for the architecture.
+	RepoDiffMissing RepoDiffStatus = "missing"
+
+	// RepoDiffOutdated indicates that the repository only serves versions
+	// older than the planned one.
+	RepoDiffOutdated RepoDiffStatus = "outdated"
+
+	// RepoDiffNewer indicates that the repository serves a version newer
+	// than the planned one.
+	RepoDiffNewer RepoDiffStatus = "newer"
+
+	// RepoDiffCurrent indicates that the repository serves the planned
+	// version.
+	RepoDiffCurrent RepoDiffStatus = "current"
+)
+
+// RepoDiffOptions are the settings for comparing the planned packages with a
+// published repository.
+type RepoDiffOptions struct {
+	// RepoURL is the base URL of the APT repository or of the yum
+	// repositories, which contain one repository per architecture like
+	// created by CreateRpmRepo.
+	RepoURL string
+
+	// Suite is the distribution name of the APT repository.
+	Suite string
+
+	// Component is the component of the APT repository.
+	Component string
+}
+
+// RepoDiffEntry is the comparison of a single planned package with the
+// repository.
+type RepoDiffEntry struct {
+	Package   string      `json:"package"`
+	Channel   ChannelType `json:"channel"`
+	BuildArch string      `json:"buildArch"`
+
+	// Planned is the full version of the planned package including the
+	// revision.
+	Planned string `json:"planned"`
+
+	// Published is the newest version of the package served by the
+	// repository, which is empty if the package is missing.
+	Published string `json:"published,omitempty"`
+
+	Status RepoDiffStatus `json:"status"`
+}
+
+// RepoDiff is the comparison of all planned packages with the repository.
+type RepoDiff struct {
+	Entries []RepoDiffEntry `json:"entries"`
+}
+
+// publishedPackages are the versions served by a repository by package name
+// and architecture.
+type publishedPackages map[string]map[string][]string
+
+func (p publishedPackages) add(name, arch, version string) {
+	if p[name] == nil {
+		p[name] = map[string][]string{}
+	}
+	p[name][arch] = append(p[name][arch], version)
+}
+
+// DiffRepo plans the provided builds and compares the resulting deb or rpm
+// versions with the newest versions served by the repository.
+func (c *Client) DiffRepo(builds []Build, o *RepoDiffOptions) (*RepoDiff, error) {
+	buildType := c.options.BuildType()
+	if buildType != options.BuildDeb && buildType != options.BuildRpm {
+		return nil, errors.Errorf("comparing %s packages is not supported", buildType)
+	}
+
+	plan, err := c.Plan(builds)
+	if err != nil {
+		return nil, errors.Wrap(err, "planning builds")
+	}
+
+	published := publishedPackages{}
+	fetched := map[string]bool{}
+	for i := range plan.Builds {
+		arch := plan.Builds[i].BuildArch
+		if fetched[arch] {
+			continue
+		}
+		fetched[arch] = true
+
+		if buildType == options.BuildDeb {
+			err = c.fetchAptPackages(o, arch, published)
+		} else {
+			err = c.fetchRpmPackages(o, arch, published)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetching packages for %s", arch)
+		}
+	}
+
+	diff := &RepoDiff{Entries: []RepoDiffEntry{}}
+	for i := range plan.Builds {
+		b := &plan.Builds[i]
+		entry := RepoDiffEntry{
+			Package:   b.Package,
+			Channel:   b.Channel,
+			BuildArch: b.BuildArch,
+			Planned:   fmt.Sprintf("%s-%s", b.Version, b.Revision),
+			Status:    RepoDiffMissing,
+		}
+
+		for _, version := range published[b.Package][b.BuildArch] {
+			if entry.Published == "" || comparePackageVersions(version, entry.Published) > 0 {
+				entry.Published = version
+			}
+		}
+		if entry.Published != "" {
+			switch cmp := comparePackageVersions(entry.Published, entry.Planned); {
+			case cmp < 0:
+				entry.Status = RepoDiffOutdated
+			case cmp > 0:
+				entry.Status = RepoDiffNewer
+			default:
+				entry.Status = RepoDiffCurrent
+			}
+		}
+		diff.Entries = append(diff.Entries, entry)
+	}
+	return diff, nil
+}
+
+// fetchAptPackages adds all packages of the Packages index of the
+// architecture to published.
+func (c *Client) fetchAptPackages(
+	o *RepoDiffOptions, arch string, published publishedPackages,
+) error {
+	url := fmt.Sprintf(
+		"%s/dists/%s/%s/binary-%s/Packages",
+		strings.TrimSuffix(o.RepoURL, "/"), o.Suite, o.Component, arch,
+	)
+	logrus.Infof("Fetching package index %s", url)
+	index, err := c.impl.GetURLResponse(url, false)
+	if err != nil {
+		return errors.Wrapf(err, "fetching %s", url)
+	}
+
+	name, version, pkgArch := "", "", ""
+	flush := func() {
+		if name != "" && version != "" {
+			published.add(name, pkgArch, version)
+		}
+		name, version, pkgArch = "", "", ""
+	}
+	scanner := bufio.NewScanner(strings.NewReader(index))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(parts[1])
+		switch parts[0] {
+		case "Package":
+			name = value
+		case "Version":
+			version = value
+		case "Architecture":
+			pkgArch = value
+		}
+	}
+	flush()
+	return errors.Wrap(scanner.Err(), "parsing package index")
+}
+
+// rpmRepomd is the part of the repomd.xml pointing to the package list.
+type rpmRepomd struct {
+	Data []struct {
+		Type     string `xml:"type,attr"`
+		Location struct {
+			Href string `xml:"href,attr"`
+		} `xml:"location"`
+	} `xml:"data"`
+}
+
+// rpmPrimary is the part of the primary.xml describing the packages.
+type rpmPrimary struct {
+	Packages []struct {
+		Name    string `xml:"name"`
+		Arch    string `xml:"arch"`
+		Version struct {
+			Ver string `xml:"ver,attr"`
+			Rel string `xml:"rel,attr"`
+		} `xml:"version"`
+	} `xml:"package"`
+}
+
+// fetchRpmPackages adds all packages of the yum repository of the
+// architecture to published.
+func (c *Client) fetchRpmPackages(
+	o *RepoDiffOptions, arch string, published publishedPackages,
+) error {
+	repoURL := fmt.Sprintf("%s/%s", strings.TrimSuffix(o.RepoURL, "/"), arch)
+	repomdURL := fmt.Sprintf("%s/repodata/%s", repoURL, repomdXML)
+	logrus.Infof("Fetching repository metadata %s", repomdURL)
+	repomdContent, err := c.impl.GetURLResponse(repomdURL, false)
+	if err != nil {
+		return errors.Wrapf(err, "fetching %s", repomdURL)
+	}
+
+	repomd := &rpmRepomd{}
+	if err := xml.Unmarshal([]byte(repomdContent), repomd); err != nil {
+		return errors.Wrapf(err, "parsing %s", repomdURL)
+	}
+	primaryURL := ""
+	for _, data := range repomd.Data {
+		if data.Type == "primary" {
+			primaryURL = fmt.Sprintf("%s/%s", repoURL, data.Location.Href)
+		}
+	}
+	if primaryURL == "" {
+		return errors.Errorf("no primary package list found in %s", repomdURL)
+	}
+
+	logrus.Infof("Fetching package list %s", primaryURL)
+	primaryContent, err := c.impl.GetURLResponse(primaryURL, false)
+	if err != nil {
+		return errors.Wrapf(err, "fetching %s", primaryURL)
+	}
+	var reader io.Reader = strings.NewReader(primaryContent)
+	if strings.HasSuffix(primaryURL, ".gz") {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return errors.Wrapf(err, "decompressing %s", primaryURL)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	primary := &rpmPrimary{}
+	if err := xml.NewDecoder(reader).Decode(primary); err != nil {
+		return errors.Wrapf(err, "parsing %s", primaryURL)
+	}
+	for _, pkg := range primary.Packages {
+		published.add(pkg.Name, pkg.Arch, pkg.Version.Ver+"-"+pkg.Version.Rel)
+	}
+	return nil
+}
+
+// comparePackageVersions compares two versions of the form
+// <version>-<revision> and returns -1, 0 or 1 if a is older, equal or newer
+// than b. The versions are compared as semver, whereas the revisions get
+// compared by their dot separated numeric parts.
+func comparePackageVersions(a, b string) int {
+	versionA, revisionA := splitPackageVersion(a)
+	versionB, revisionB := splitPackageVersion(b)
+
+	semverA, errA := util.TagStringToSemver(versionA)
+	semverB, errB := util.TagStringToSemver(versionB)
+	if errA == nil && errB == nil {
+		if cmp := semverA.Compare(semverB); cmp != 0 {
+			return cmp
+		}
+	} else if cmp := strings.Compare(versionA, versionB); cmp != 0 {
+		return cmp
+	}
+
+	partsA := strings.Split(revisionA, ".")
+	partsB := strings.Split(revisionB, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		if i >= len(partsA) {
+			return -1
+		}
+		if i >= len(partsB) {
+			return 1
+		}
+		numA, errA := strconv.Atoi(partsA[i])
+		numB, errB := strconv.Atoi(partsB[i])
+		cmp := 0
+		if errA == nil && errB == nil {
+			cmp = numA - numB
+		} else {
+			cmp = strings.Compare(partsA[i], partsB[i])
+		}
+		switch {
+		case cmp < 0:
+			return -1
+		case cmp > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+// splitPackageVersion splits the full package version at its last dash into
+// the upstream version and the revision. Pre-release versions like
+// 1.22.0-rc.0-0 keep their dash.
+func splitPackageVersion(version string) (upstream, revision string) {
+	dash := strings.LastIndex(version, "-")
+	if dash < 0 {
+		return version, ""
+	}
+	return version[:dash], version[dash+1:]
+}
+
+// JSON returns the indented JSON representation of the diff.
+func (d *RepoDiff) JSON() ([]byte, error) {
+	res, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling repository diff")
+	}
+	return res, nil
+}
+
+// Write writes the human readable table representation of the diff to w.
+func (d *RepoDiff) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PACKAGE\tCHANNEL\tARCH\tPLANNED\tPUBLISHED\tSTATUS")
+	for i := range d.Entries {
+		e := &d.Entries[i]
+		published := e.Published
+		if published == "" {
+			published = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Package, e.Channel, e.BuildArch, e.Planned, published, e.Status,
+		)
+	}
+	return tw.Flush()
+}
+
+// Differs returns true if any planned package is not served in its planned
+// version by the repository.
+func (d *RepoDiff) Differs() bool {
+	for i := range d.Entries {
+		if d.Entries[i].Status != RepoDiffCurrent {
+			return true
+		}
+	}
+	return false
+}
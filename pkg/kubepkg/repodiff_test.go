@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestDiffRepoDeb(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm", "kubectl", "kubelet").
+		WithChannels("release").
+		WithArchitectures("amd64", "arm64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.GetURLResponseCalls(func(url string, _ bool) (string, error) {
+		switch url {
+		case "https://repo/dists/kubernetes-xenial/main/binary-amd64/Packages":
+			return "Package: kubeadm\nVersion: 1.17.9-0\nArchitecture: amd64\n\n" +
+				"Package: kubeadm\nVersion: 1.18.0-0\nArchitecture: amd64\n\n" +
+				"Package: kubectl\nVersion: 1.17.2-00\nArchitecture: amd64\n\n" +
+				"Package: kubelet\nVersion: 1.18.0-1.1\nArchitecture: amd64\n", nil
+		case "https://repo/dists/kubernetes-xenial/main/binary-arm64/Packages":
+			return "", nil
+		}
+		return "", errors.New("not found")
+	})
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	diff, err := sut.DiffRepo(builds, &kubepkg.RepoDiffOptions{
+		RepoURL: "https://repo/", Suite: "kubernetes-xenial", Component: "main",
+	})
+	require.Nil(t, err)
+	require.True(t, diff.Differs())
+
+	statuses := map[string]kubepkg.RepoDiffStatus{}
+	for _, entry := range diff.Entries {
+		statuses[entry.Package+"/"+entry.BuildArch] = entry.Status
+	}
+	require.Equal(t, map[string]kubepkg.RepoDiffStatus{
+		"kubeadm/amd64": kubepkg.RepoDiffCurrent,
+		"kubectl/amd64": kubepkg.RepoDiffOutdated,
+		"kubelet/amd64": kubepkg.RepoDiffNewer,
+		"kubeadm/arm64": kubepkg.RepoDiffMissing,
+		"kubectl/arm64": kubepkg.RepoDiffMissing,
+		"kubelet/arm64": kubepkg.RepoDiffMissing,
+	}, statuses)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, diff.Write(buf))
+	require.Contains(t, buf.String(), "1.17.2-00")
+
+	res, err := diff.JSON()
+	require.Nil(t, err)
+	require.Contains(t, string(res), `"status": "newer"`)
+}
+
+func TestDiffRepoRpm(t *testing.T) {
+	primary := &bytes.Buffer{}
+	w := gzip.NewWriter(primary)
+	_, err := w.Write([]byte(`<metadata><package type="rpm"><name>kubeadm</name><arch>x86_64</arch>` +
+		`<version epoch="0" ver="1.18.0" rel="0"/></package></metadata>`))
+	require.Nil(t, err)
+	require.Nil(t, w.Close())
+
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildRpm)
+	defer cleanup()
+	mock.GetURLResponseCalls(func(url string, _ bool) (string, error) {
+		switch url {
+		case "https://repo/x86_64/repodata/repomd.xml":
+			return `<repomd><data type="primary"><location href="repodata/abc-primary.xml.gz"/></data></repomd>`, nil
+		case "https://repo/x86_64/repodata/abc-primary.xml.gz":
+			return primary.String(), nil
+		}
+		return "", errors.New("not found")
+	})
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	diff, err := sut.DiffRepo(builds, &kubepkg.RepoDiffOptions{RepoURL: "https://repo"})
+	require.Nil(t, err)
+	require.False(t, diff.Differs())
+	require.Len(t, diff.Entries, 1)
+	require.Equal(t, "1.18.0-0", diff.Entries[0].Published)
+}
+
+func TestDiffRepoFailure(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildRpm)
+	defer cleanup()
+	mock.GetURLResponseReturns("", errors.New("not found"))
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	_, err = sut.DiffRepo(builds, &kubepkg.RepoDiffOptions{RepoURL: "https://repo"})
+	require.NotNil(t, err)
+
+	sut, cleanup, _ = sutWithTemplateDir(t, opts, options.BuildSnap)
+	defer cleanup()
+	_, err = sut.DiffRepo(builds, &kubepkg.RepoDiffOptions{RepoURL: "https://repo"})
+	require.NotNil(t, err)
+}
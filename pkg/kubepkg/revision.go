@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// revisionAbbrev is the length of the abbreviated commit within derived
+// revisions. It is fixed, because the git default depends on the size of the
+// repository.
+const revisionAbbrev = 12
+
+// describeRegex matches the long git description of a commit, like
+// v1.22.0-42-g0123456789ab.
+var describeRegex = regexp.MustCompile(`^.+-(\d+)-g([0-9a-f]+)$`)
+
+// resolveRevision returns the configured package revision. If it is empty,
+// the revision gets derived from the distance to the latest tag and the
+// abbreviated commit of the source directory, like 42.g0123456789ab. Those
+// revisions increase with every commit after the tag and are reproducible for
+// the same commit.
+func (c *Client) resolveRevision() (string, error) {
+	if c.options.Revision() != "" {
+		return c.options.Revision(), nil
+	}
+
+	description, err := c.impl.DescribeRepo(c.options.SourceDir())
+	if err != nil {
+		return "", errors.Wrapf(err, "describing git repository %s", c.options.SourceDir())
+	}
+	match := describeRegex.FindStringSubmatch(description)
+	if match == nil {
+		return "", errors.Errorf("unable to parse git description %q", description)
+	}
+
+	revision := fmt.Sprintf("%s.g%s", match[1], match[2])
+	logrus.Infof(
+		"Derived revision %s from %s of %s", revision, description, c.options.SourceDir(),
+	)
+	return revision, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestConstructBuildsSuccessRevisionFromSourceDir(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("nightly").
+		WithArchitectures("amd64").
+		WithSourceDir("/src/release")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.DescribeRepoReturns("v0.10.0-42-g0123456789ab", nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Equal(t, "/src/release", mock.DescribeRepoArgsForCall(0))
+	require.Equal(t, "42.g0123456789ab", builds[0].Definitions[0].Revision)
+
+	// An explicitly set revision takes precedence
+	opts.WithRevision("1")
+	builds, err = sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Equal(t, 1, mock.DescribeRepoCallCount())
+	require.Equal(t, "1", builds[0].Definitions[0].Revision)
+}
+
+func TestConstructBuildsFailureRevisionFromSourceDir(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		err         error
+		expected    string
+	}{
+		{"v0.10.0", nil, "unable to parse git description"},
+		{"", errors.New("no tags"), "describing git repository"},
+	} {
+		opts := options.New().
+			WithPackages("kubeadm").
+			WithChannels("nightly").
+			WithArchitectures("amd64").
+			WithSourceDir("/src/release")
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+		mock.DescribeRepoReturns(tc.description, tc.err)
+
+		_, err := sut.ConstructBuilds()
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), tc.expected)
+		cleanup()
+	}
+}
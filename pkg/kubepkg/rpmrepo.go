@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+const (
+	rpmNoArch = "noarch"
+	repomdXML = "repomd.xml"
+)
+
+// createRepoExecutables are the supported tools for generating the rpm
+// repository metadata in order of preference.
+var createRepoExecutables = []string{"createrepo_c", "createrepo"}
+
+// RpmRepoOptions are the settings for creating a yum repository.
+type RpmRepoOptions struct {
+	// PackageDir is the directory containing the rpms.
+	PackageDir string
+
+	// RepoDir is the root directory of the repositories, which contains
+	// one repository per architecture.
+	RepoDir string
+}
+
+// CreateRpmRepo assembles all rpms of the package directory into one yum
+// repository per architecture below the repository directory, for example
+// <repo-dir>/x86_64. Architecture independent rpms become part of every
+// repository. The repomd.xml of every repository gets signed into
+// repomd.xml.asc if a sign key has been configured.
+func (c *Client) CreateRpmRepo(o *RpmRepoOptions) error {
+	createRepo := ""
+	for _, executable := range createRepoExecutables {
+		if c.impl.Available(executable) {
+			createRepo = executable
+			break
+		}
+	}
+	if createRepo == "" {
+		return errors.Errorf(
+			"one of %v is required for creating yum repositories",
+			createRepoExecutables,
+		)
+	}
+
+	rpms, err := filepath.Glob(filepath.Join(o.PackageDir, "*.rpm"))
+	if err != nil {
+		return errors.Wrapf(err, "finding rpms in %s", o.PackageDir)
+	}
+	if len(rpms) == 0 {
+		return errors.Errorf("no rpms found in %s", o.PackageDir)
+	}
+
+	rpmsByArch := map[string][]string{}
+	for _, rpm := range rpms {
+		pkg, arch := parsePackageFileName(options.BuildRpm, filepath.Base(rpm))
+		if pkg == "" {
+			return errors.Errorf("unable to parse rpm file name %s", rpm)
+		}
+		rpmsByArch[arch] = append(rpmsByArch[arch], rpm)
+	}
+
+	architectures := []string{}
+	for arch := range rpmsByArch {
+		if arch != rpmNoArch {
+			architectures = append(architectures, arch)
+		}
+	}
+	if len(architectures) == 0 {
+		return errors.Errorf("no architecture specific rpms found in %s", o.PackageDir)
+	}
+	sort.Strings(architectures)
+
+	if c.options.SignKey() != "" {
+		defer c.cleanupSigner()
+	} else {
+		logrus.Warn("No sign key provided, the yum repositories will be unsigned")
+	}
+
+	for _, arch := range architectures {
+		archDir := filepath.Join(o.RepoDir, arch)
+		archRpms := append(rpmsByArch[arch], rpmsByArch[rpmNoArch]...)
+		logrus.Infof("Creating yum repository in %s from %d rpms", archDir, len(archRpms))
+
+		for _, rpm := range archRpms {
+			if err := c.copyFile(rpm, filepath.Join(archDir, filepath.Base(rpm))); err != nil {
+				return err
+			}
+		}
+
+		if err := c.impl.RunSuccessWithWorkDir(
+			archDir, createRepo, "--update", ".",
+		); err != nil {
+			return errors.Wrapf(err, "creating yum repository metadata for %s", arch)
+		}
+
+		if c.options.SignKey() != "" {
+			repomd := filepath.Join(archDir, "repodata", repomdXML)
+			if err := c.signFile(repomd, repomd+".asc", false); err != nil {
+				return err
+			}
+		}
+	}
+
+	logrus.Infof(
+		"Successfully created yum repositories for %v in %s",
+		architectures, o.RepoDir,
+	)
+	return nil
+}
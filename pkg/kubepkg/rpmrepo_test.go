@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestCreateRpmRepo(t *testing.T) {
+	packageDir, err := os.MkdirTemp("", "kubepkg-packages-")
+	require.Nil(t, err)
+	defer os.RemoveAll(packageDir)
+	repoDir, err := os.MkdirTemp("", "kubepkg-repo-")
+	require.Nil(t, err)
+	defer os.RemoveAll(repoDir)
+
+	for _, rpm := range []string{
+		"kubeadm-1.22.1-0.x86_64.rpm",
+		"kubeadm-1.22.1-0.aarch64.rpm",
+		"kubernetes-cni-0.8.7-0.x86_64.rpm",
+		"kubectl-completion-1.22.1-0.noarch.rpm",
+	} {
+		require.Nil(t, os.WriteFile(filepath.Join(packageDir, rpm), []byte(rpm), 0o644))
+	}
+
+	sut, mock := newSUT(options.New().WithSignKey("ABCDEF12"))
+	mock.AvailableReturns(true)
+	mock.ReadFileStub = os.ReadFile
+	mock.WriteFileStub = os.WriteFile
+
+	require.Nil(t, sut.CreateRpmRepo(&kubepkg.RpmRepoOptions{
+		PackageDir: packageDir,
+		RepoDir:    repoDir,
+	}))
+
+	for _, file := range []string{
+		"aarch64/kubeadm-1.22.1-0.aarch64.rpm",
+		"aarch64/kubectl-completion-1.22.1-0.noarch.rpm",
+		"x86_64/kubeadm-1.22.1-0.x86_64.rpm",
+		"x86_64/kubernetes-cni-0.8.7-0.x86_64.rpm",
+		"x86_64/kubectl-completion-1.22.1-0.noarch.rpm",
+	} {
+		require.FileExists(t, filepath.Join(repoDir, file))
+	}
+	require.NoFileExists(t, filepath.Join(repoDir, "aarch64/kubeadm-1.22.1-0.x86_64.rpm"))
+	require.NoDirExists(t, filepath.Join(repoDir, "noarch"))
+
+	// createrepo_c and gpg for every architecture
+	require.Equal(t, 4, mock.RunSuccessWithWorkDirCallCount())
+	workDir, cmd, _ := mock.RunSuccessWithWorkDirArgsForCall(0)
+	require.Equal(t, "createrepo_c", cmd)
+	require.Equal(t, filepath.Join(repoDir, "aarch64"), workDir)
+
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(3)
+	require.Equal(t, "gpg", cmd)
+	require.Contains(t, args, "--detach-sign")
+	require.Contains(t, args, filepath.Join(repoDir, "x86_64/repodata/repomd.xml.asc"))
+}
+
+func TestCreateRpmRepoFailure(t *testing.T) {
+	packageDir, err := os.MkdirTemp("", "kubepkg-packages-")
+	require.Nil(t, err)
+	defer os.RemoveAll(packageDir)
+
+	// No createrepo available
+	sut, mock := newSUT(nil)
+	mock.AvailableReturns(false)
+	require.NotNil(t, sut.CreateRpmRepo(&kubepkg.RpmRepoOptions{PackageDir: packageDir}))
+
+	// No rpms available
+	mock.AvailableReturns(true)
+	require.NotNil(t, sut.CreateRpmRepo(&kubepkg.RpmRepoOptions{PackageDir: packageDir}))
+
+	// createrepo_c fails
+	require.Nil(t, os.WriteFile(
+		filepath.Join(packageDir, "kubeadm-1.22.1-0.x86_64.rpm"), nil, 0o644,
+	))
+	repoDir, err := os.MkdirTemp("", "kubepkg-repo-")
+	require.Nil(t, err)
+	defer os.RemoveAll(repoDir)
+	mock.ReadFileStub = os.ReadFile
+	mock.WriteFileStub = os.WriteFile
+	mock.RunSuccessWithWorkDirReturns(errors.New(""))
+	require.NotNil(t, sut.CreateRpmRepo(&kubepkg.RpmRepoOptions{
+		PackageDir: packageDir,
+		RepoDir:    repoDir,
+	}))
+}
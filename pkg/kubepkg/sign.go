@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+const (
+	gpgExecutable     = "gpg"
+	dpkgSigExecutable = "dpkg-sig"
+	rpmSignExecutable = "rpmsign"
+)
+
+// signer contains the resolved GPG key used for signing packages.
+type signer struct {
+	// keyID is the fingerprint or ID of the key.
+	keyID string
+
+	// gnupgHome is the temporary GPG home directory for file based keys,
+	// which is empty if the default keyring should be used.
+	gnupgHome string
+}
+
+// isSignableType returns true if packages of the provided build type can be
+// signed.
+func isSignableType(buildType options.BuildType) bool {
+	return buildType == options.BuildDeb || buildType == options.BuildRpm
+}
+
+// getSigner returns the signer for the configured sign key, which gets set up
+// only once per client.
+func (c *Client) getSigner() (*signer, error) {
+	c.signerOnce.Do(func() {
+		c.signer, c.signerErr = c.newSigner()
+	})
+	return c.signer, c.signerErr
+}
+
+func (c *Client) newSigner() (*signer, error) {
+	keyType, value, err := options.ParseSignKey(c.options.SignKey())
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing sign key")
+	}
+
+	if !c.impl.Available(gpgExecutable) {
+		return nil, errors.Errorf("%s is required for signing packages", gpgExecutable)
+	}
+
+	switch keyType {
+	case options.SignKeyFile:
+		return c.importSignKey(value)
+	case options.SignKeyKMS:
+		// The KMS-backed key is exposed as smartcard to gpg, which
+		// therefore has to be available.
+		if _, err := c.impl.RunOutputWithWorkDir("", gpgExecutable, "--batch", "--card-status"); err != nil {
+			return nil, errors.Wrap(err, "checking KMS-backed gpg smartcard")
+		}
+	}
+
+	if _, err := c.impl.RunOutputWithWorkDir(
+		"", gpgExecutable, "--batch", "--list-secret-keys", value,
+	); err != nil {
+		return nil, errors.Wrapf(err, "finding secret key %s", value)
+	}
+
+	logrus.Infof("Using %s key %s for signing packages", keyType, value)
+	return &signer{keyID: value}, nil
+}
+
+// importSignKey imports the secret key file into a temporary GPG home
+// directory.
+func (c *Client) importSignKey(keyFile string) (*signer, error) {
+	gnupgHome, err := os.MkdirTemp("", "kubepkg-gnupg-")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temporary GPG home directory")
+	}
+
+	if _, err := c.impl.RunOutputWithWorkDir(
+		"", gpgExecutable, "--batch", "--homedir", gnupgHome, "--import", keyFile,
+	); err != nil {
+		os.RemoveAll(gnupgHome)
+		return nil, errors.Wrapf(err, "importing sign key %s", keyFile)
+	}
+
+	output, err := c.impl.RunOutputWithWorkDir(
+		"", gpgExecutable, "--batch", "--homedir", gnupgHome,
+		"--with-colons", "--list-secret-keys",
+	)
+	if err != nil {
+		os.RemoveAll(gnupgHome)
+		return nil, errors.Wrap(err, "listing imported secret keys")
+	}
+
+	keyID := fingerprint(output)
+	if keyID == "" {
+		os.RemoveAll(gnupgHome)
+		return nil, errors.Errorf("no secret key found in %s", keyFile)
+	}
+
+	logrus.Infof("Using key %s from %s for signing packages", keyID, keyFile)
+	return &signer{keyID: keyID, gnupgHome: gnupgHome}, nil
+}
+
+// fingerprint returns the first fingerprint of the provided gpg colon
+// listing.
+func fingerprint(listing string) string {
+	for _, line := range strings.Split(listing, "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) > 9 && fields[0] == "fpr" {
+			return fields[9]
+		}
+	}
+	return ""
+}
+
+// cleanupSigner removes the temporary GPG home directory if required.
+func (c *Client) cleanupSigner() {
+	if c.signer != nil && c.signer.gnupgHome != "" {
+		os.RemoveAll(c.signer.gnupgHome)
+	}
+}
+
+// signPackage signs the package at path if a sign key has been configured.
+func (c *Client) signPackage(bc *buildConfig, path string) error {
+	if c.options.SignKey() == "" {
+		return nil
+	}
+	if !isSignableType(bc.Type) {
+		bc.log.Warnf("Signing %s packages is not supported, skipping", bc.Type)
+		return nil
+	}
+
+	s, err := c.getSigner()
+	if err != nil {
+		return WithCategory(errors.Wrap(err, "setting up signer"), ErrorCategorySign)
+	}
+
+	args := []string{}
+	if s.gnupgHome != "" {
+		args = append(args, "GNUPGHOME="+s.gnupgHome)
+	}
+
+	bc.log.Infof("Signing %s using key %s", filepath.Base(path), s.keyID)
+	switch bc.Type {
+	case options.BuildDeb:
+		args = append(args, dpkgSigExecutable, "--sign", "builder", "-k", s.keyID, path)
+	case options.BuildRpm:
+		args = append(args, rpmSignExecutable, "--addsign", "--define", "_gpg_name "+s.keyID, path)
+	}
+
+	if err := c.impl.RunSuccessWithWorkDir(
+		filepath.Dir(path), "env", args...,
+	); err != nil {
+		return WithCategory(errors.Wrapf(err, "signing %s", path), ErrorCategorySign)
+	}
+	return nil
+}
+
+// signFile creates an armored signature of src at dst, which is either a
+// clear text signature or a detached one.
+func (c *Client) signFile(src, dst string, clearsign bool) error {
+	s, err := c.getSigner()
+	if err != nil {
+		return WithCategory(errors.Wrap(err, "setting up signer"), ErrorCategorySign)
+	}
+
+	args := []string{"--batch", "--yes"}
+	if s.gnupgHome != "" {
+		args = append(args, "--homedir", s.gnupgHome)
+	}
+	args = append(args, "--local-user", s.keyID, "--armor")
+	if clearsign {
+		args = append(args, "--clearsign")
+	} else {
+		args = append(args, "--detach-sign")
+	}
+	args = append(args, "--output", dst, src)
+
+	logrus.Infof("Signing %s to %s using key %s", src, dst, s.keyID)
+	if err := c.impl.RunSuccessWithWorkDir(filepath.Dir(src), gpgExecutable, args...); err != nil {
+		return WithCategory(errors.Wrapf(err, "signing %s", src), ErrorCategorySign)
+	}
+	return nil
+}
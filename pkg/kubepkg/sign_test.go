@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessSignKeyring(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithSignKey("keyring:ABCDEF12")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(true)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// Build, package signature and checksum signatures
+	require.Equal(t, 4, mock.RunSuccessWithWorkDirCallCount())
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(1)
+	require.Equal(t, "env", cmd)
+	require.Equal(t, []string{
+		"dpkg-sig", "--sign", "builder", "-k", "ABCDEF12",
+		args[len(args)-1],
+	}, args)
+	require.Equal(t, "kubeadm_1.18.0-0_amd64.deb", filepath.Base(args[len(args)-1]))
+
+	_, _, gpgArgs := mock.RunOutputWithWorkDirArgsForCall(0)
+	require.Contains(t, gpgArgs, "--list-secret-keys")
+}
+
+func TestWalkBuildsSuccessSignFile(t *testing.T) {
+	opts := options.New().
+		WithBackend(options.BackendNfpm).
+		WithPackages("kubeadm", "kubectl").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithSignKey("file:/key.asc")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildRpm)
+	defer cleanup()
+	mock.AvailableReturns(true)
+	mock.RunOutputWithWorkDirReturnsOnCall(1, "sec:u:255:22:ABCDEF12:1::::::scESC:\nfpr:::::::::0123ABCDEF12:\n", nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	// The key only gets imported once
+	require.Equal(t, 2, mock.RunOutputWithWorkDirCallCount())
+	_, _, importArgs := mock.RunOutputWithWorkDirArgsForCall(0)
+	require.Contains(t, importArgs, "/key.asc")
+
+	// Builds, package signatures and checksum signatures
+	require.Equal(t, 6, mock.RunSuccessWithWorkDirCallCount())
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(1)
+	require.Equal(t, "env", cmd)
+	require.Contains(t, args[0], "GNUPGHOME=")
+	require.Contains(t, args, "rpmsign")
+	require.Contains(t, args, "_gpg_name 0123ABCDEF12")
+}
+
+func TestWalkBuildsFailureSignNoKeyFound(t *testing.T) {
+	opts := options.New().WithSignKey("file:/key.asc")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(true)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.NotNil(t, sut.WalkBuilds(builds))
+}
+
+func TestWalkBuildsFailureSignNoGPG(t *testing.T) {
+	opts := options.New().WithSignKey("ABCDEF12")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(false)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.NotNil(t, sut.WalkBuilds(builds))
+}
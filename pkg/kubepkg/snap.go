@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+// snapRisks maps the kubepkg channels to the snap store risk levels.
+var snapRisks = map[ChannelType]string{
+	ChannelRelease: "stable",
+	ChannelTesting: "candidate",
+	ChannelNightly: "edge",
+}
+
+// runSnapcraft builds the snap from the snapcraft.yaml rendered into
+// specDirWithArch.
+func (c *Client) runSnapcraft(bc *buildConfig, specDir, specDirWithArch string) error {
+	bc.log.Infof("Running snapcraft for %s (%s/%s)", bc.Package, bc.GoArch, bc.BuildArch)
+
+	fileName := packageFileName(bc)
+	if err := c.runBuildCommand(
+		bc,
+		specDir,
+		specDirWithArch,
+		"snapcraft",
+		"snap",
+		"--destructive-mode",
+		"--enable-experimental-target-arch",
+		"--target-arch", bc.BuildArch,
+		"--output", fileName,
+	); err != nil {
+		return errors.Wrap(err, "running snap build")
+	}
+
+	channel, err := SnapChannel(bc.Channel, bc.Version)
+	if err != nil {
+		return errors.Wrap(err, "getting snap channel")
+	}
+	bc.log.Infof("Snap %s targets the snap store channel %s", fileName, channel)
+
+	return c.copyPackage(bc, filepath.Join(specDirWithArch, fileName))
+}
+
+// SnapChannel returns the snap store channel (`<track>/<risk>`) for the
+// provided kubepkg channel and package version, for example `1.22/stable`.
+func SnapChannel(channel ChannelType, version string) (string, error) {
+	risk, ok := snapRisks[channel]
+	if !ok {
+		return "", errors.Errorf("unknown channel %q", channel)
+	}
+
+	semver, err := util.TagStringToSemver(version)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing version %s", version)
+	}
+
+	return fmt.Sprintf("%d.%d/%s", semver.Major, semver.Minor, risk), nil
+}
+
+// snapGrade returns the snapcraft grade for the provided channel. Only
+// release channel snaps are allowed to be published as stable.
+func snapGrade(channel ChannelType) string {
+	if channel == ChannelRelease {
+		return "stable"
+	}
+	return "devel"
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessSnap(t *testing.T) {
+	opts := options.New().
+		WithChannels("testing").
+		WithArchitectures("amd64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildSnap)
+	defer cleanup()
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Len(t, builds, 2)
+	require.Equal(t, "kubectl", builds[0].Package)
+	require.Equal(t, "kubeadm", builds[1].Package)
+
+	err = sut.WalkBuilds(builds)
+	require.Nil(t, err)
+
+	require.Equal(t, 2, mock.RunSuccessWithWorkDirCallCount())
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+	require.Equal(t, "snapcraft", cmd)
+	require.Contains(t, args, "kubectl_1.18.0_amd64.snap")
+}
+
+func TestSnapChannel(t *testing.T) {
+	for _, tc := range []struct {
+		channel     kubepkg.ChannelType
+		version     string
+		expected    string
+		shouldError bool
+	}{
+		{kubepkg.ChannelRelease, "1.22.1", "1.22/stable", false},
+		{kubepkg.ChannelTesting, "1.23.0-rc.0", "1.23/candidate", false},
+		{kubepkg.ChannelNightly, "v1.23.0-alpha.0.12-abc", "1.23/edge", false},
+		{"wrong", "1.22.1", "", true},
+		{kubepkg.ChannelRelease, "wrong", "", true},
+	} {
+		res, err := kubepkg.SnapChannel(tc.channel, tc.version)
+		if tc.shouldError {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, res)
+	}
+}
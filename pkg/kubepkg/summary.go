@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// Artifact is a single file produced by a package build.
+type Artifact struct {
+	// Package is the name of the package, like kubelet.
+	Package string `json:"package"`
+
+	// Version is the version of the package.
+	Version string `json:"version"`
+
+	// Revision is the package revision.
+	Revision string `json:"revision"`
+
+	// Channel is the published name of the channel the package has been
+	// built for.
+	Channel ChannelType `json:"channel"`
+
+	// Distro is the distribution of distro-specific packages.
+	Distro string `json:"distro,omitempty"`
+
+	// Type is the package type, like deb or rpm.
+	Type options.BuildType `json:"type"`
+
+	// Arch is the Go architecture of the package, like amd64.
+	Arch string `json:"arch"`
+
+	// BuildArch is the architecture name used by the package type, like
+	// x86_64.
+	BuildArch string `json:"buildArch"`
+
+	// Path is the absolute location of the artifact.
+	Path string `json:"path"`
+
+	// SHA256 is the hex encoded SHA256 checksum of the artifact.
+	SHA256 string `json:"sha256"`
+
+	// SHA512 is the hex encoded SHA512 checksum of the artifact.
+	SHA512 string `json:"sha512"`
+
+	// Duration is the amount of seconds it took to build the artifact.
+	Duration float64 `json:"duration"`
+
+	// LintFindings are the findings of lintian or rpmlint, which are only
+	// populated if linting packages is enabled.
+	LintFindings []LintFinding `json:"lintFindings,omitempty"`
+}
+
+// BuildStatus is the outcome of a single build job.
+type BuildStatus string
+
+const (
+	// BuildSucceeded indicates that the package has been built.
+	BuildSucceeded BuildStatus = "succeeded"
+
+	// BuildFailed indicates that the package build returned an error.
+	BuildFailed BuildStatus = "failed"
+
+	// BuildSkipped indicates that the package has not been built, for example
+	// because the architecture is not supported, another job already built
+	// the same package or the package already exists in the output directory.
+	BuildSkipped BuildStatus = "skipped"
+)
+
+// BuildResult is the result of a single build job.
+type BuildResult struct {
+	// Name identifies the build, for example `kubeadm/stable/arm64`.
+	Name string `json:"name"`
+
+	// Status is the outcome of the build.
+	Status BuildStatus `json:"status"`
+
+	// Duration is the amount of seconds the build took.
+	Duration float64 `json:"duration"`
+
+	// Error is the error message of a failed build.
+	Error string `json:"error,omitempty"`
+
+	// Category classifies the error of a failed build.
+	Category ErrorCategory `json:"category,omitempty"`
+}
+
+// Summary contains all artifacts built by the client.
+type Summary struct {
+	Artifacts []Artifact    `json:"artifacts"`
+	Builds    []BuildResult `json:"builds"`
+}
+
+// Summary returns the summary of all artifacts built so far, sorted by their
+// path, as well as the results of all builds in the order of the build
+// matrix.
+func (c *Client) Summary() *Summary {
+	c.artifactsMu.Lock()
+	artifacts := make([]Artifact, len(c.artifacts))
+	copy(artifacts, c.artifacts)
+	c.artifactsMu.Unlock()
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Path < artifacts[j].Path
+	})
+
+	c.buildResultsMu.Lock()
+	builds := make([]BuildResult, len(c.buildResults))
+	copy(builds, c.buildResults)
+	c.buildResultsMu.Unlock()
+
+	return &Summary{Artifacts: artifacts, Builds: builds}
+}
+
+// Table returns a human readable table of all build results, followed by the
+// total amount of succeeded, failed and skipped builds.
+func (s *Summary) Table() string {
+	buf := &bytes.Buffer{}
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BUILD\tSTATUS\tDURATION")
+
+	counts := map[BuildStatus]int{}
+	for _, build := range s.Builds {
+		counts[build.Status]++
+		duration := roundDuration(
+			time.Duration(build.Duration * float64(time.Second)),
+		)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", build.Name, build.Status, duration)
+	}
+	w.Flush()
+
+	fmt.Fprintf(
+		buf, "%d succeeded, %d failed, %d skipped\n",
+		counts[BuildSucceeded], counts[BuildFailed], counts[BuildSkipped],
+	)
+
+	findings := map[options.LintSeverity]int{}
+	total := 0
+	for _, artifact := range s.Artifacts {
+		for _, finding := range artifact.LintFindings {
+			findings[finding.Severity]++
+			total++
+		}
+	}
+	if total > 0 {
+		fmt.Fprintf(
+			buf, "%d lint findings: %d errors, %d warnings, %d infos\n", total,
+			findings[options.LintSeverityError],
+			findings[options.LintSeverityWarning],
+			findings[options.LintSeverityInfo],
+		)
+	}
+	return buf.String()
+}
+
+// JSON returns the indented JSON representation of the summary.
+func (s *Summary) JSON() ([]byte, error) {
+	res, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling build summary")
+	}
+	return res, nil
+}
+
+// addArtifact records the artifact with the provided content written to path
+// for the summary.
+func (c *Client) addArtifact(bc *buildConfig, path string, content []byte) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		absPath = path
+	}
+	sum256 := sha256.Sum256(content)
+	sum512 := sha512.Sum512(content)
+
+	c.artifactsMu.Lock()
+	defer c.artifactsMu.Unlock()
+	c.artifacts = append(c.artifacts, Artifact{
+		Package:   bc.Package,
+		Version:   bc.Version,
+		Revision:  bc.Revision,
+		Channel:   ChannelType(bc.ChannelName),
+		Distro:    bc.Distro,
+		Type:      bc.Type,
+		Arch:      bc.GoArch,
+		BuildArch: bc.BuildArch,
+		Path:      absPath,
+		SHA256:    hex.EncodeToString(sum256[:]),
+		SHA512:    hex.EncodeToString(sum512[:]),
+		Duration:  time.Since(bc.started).Seconds(),
+
+		LintFindings: bc.lintFindings,
+	})
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestSummary(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64", "arm64").
+		WithConcurrency(2)
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.ReadFileReturns([]byte("content"), nil)
+
+	require.Empty(t, sut.Summary().Artifacts)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	summary := sut.Summary()
+	require.Len(t, summary.Artifacts, 2)
+	artifact := summary.Artifacts[0]
+	require.Equal(t, "kubeadm", artifact.Package)
+	require.Equal(t, "1.18.0", artifact.Version)
+	require.Equal(t, kubepkg.ChannelRelease, artifact.Channel)
+	require.Equal(t, options.BuildDeb, artifact.Type)
+	require.Equal(t, "amd64", artifact.Arch)
+	require.True(t, filepath.IsAbs(artifact.Path))
+	require.Equal(t, "kubeadm_1.18.0-0_amd64.deb", filepath.Base(artifact.Path))
+	require.Equal(t,
+		"ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+		artifact.SHA256,
+	)
+	require.Equal(t, "arm64", summary.Artifacts[1].Arch)
+
+	res, err := summary.JSON()
+	require.Nil(t, err)
+	require.Contains(t, string(res), `"sha256": "ed7002b4`)
+}
+
+func TestSummaryBuilds(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64", "arm64", "arm64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.RunSuccessWithWorkDirReturnsOnCall(1, errors.New("build error"))
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.NotNil(t, sut.WalkBuilds(builds))
+
+	summary := sut.Summary()
+	require.Len(t, summary.Builds, 3)
+	require.Equal(t, "kubeadm/release/amd64", summary.Builds[0].Name)
+	require.Equal(t, kubepkg.BuildSucceeded, summary.Builds[0].Status)
+	require.Empty(t, summary.Builds[0].Error)
+	require.Equal(t, "kubeadm/release/arm64", summary.Builds[1].Name)
+	require.Equal(t, kubepkg.BuildFailed, summary.Builds[1].Status)
+	require.Contains(t, summary.Builds[1].Error, "build error")
+	require.Equal(t, kubepkg.BuildSkipped, summary.Builds[2].Status)
+
+	table := summary.Table()
+	require.Regexp(t, `BUILD +STATUS +DURATION`, table)
+	require.Regexp(t, `kubeadm/release/amd64 +succeeded +\d`, table)
+	require.Regexp(t, `kubeadm/release/arm64 +failed +\d`, table)
+	require.Contains(t, table, "1 succeeded, 1 failed, 1 skipped")
+
+	res, err := summary.JSON()
+	require.Nil(t, err)
+	require.Contains(t, string(res), `"status": "failed"`)
+}
@@ -20,12 +20,17 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"sort"
 	"text/template"
 
 	"github.com/pkg/errors"
-	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
 )
 
+// templateFuncsFile is the optional file within an extra template directory,
+// which defines additional template functions as lookup tables.
+const templateFuncsFile = "functions.yaml"
+
 type work struct {
 	src  string
 	dst  string
@@ -33,36 +38,81 @@ type work struct {
 	info os.FileInfo
 }
 
-func buildSpecs(bc *buildConfig, specDir string) (workItems []work, err error) {
-	if err := filepath.Walk(bc.TemplateDir, func(templateFile string, f os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		specFile := filepath.Join(specDir, templateFile[len(bc.TemplateDir):])
-		if specFile == specDir {
+// templateFile is a single file or directory of the package templates.
+type templateFile struct {
+	path string
+	info os.FileInfo
+}
+
+// collectTemplates returns all files and directories of the template
+// directories keyed by their relative path. Files of later directories
+// override the ones of earlier directories with the same relative path.
+func collectTemplates(templateDirs []string) (map[string]templateFile, error) {
+	templates := map[string]templateFile{}
+	for _, templateDir := range templateDirs {
+		if err := filepath.Walk(templateDir, func(path string, f os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(templateDir, path)
+			if err != nil {
+				return err
+			}
+			if rel == "." {
+				return nil
+			}
+			templates[rel] = templateFile{path: path, info: f}
 			return nil
+		}); err != nil {
+			return nil, err
 		}
-		if f.IsDir() {
-			return os.Mkdir(specFile, f.Mode())
+	}
+	return templates, nil
+}
+
+func buildSpecs(bc *buildConfig, specDir string) (workItems []work, err error) {
+	templates, err := collectTemplates(
+		append([]string{bc.TemplateDir}, bc.overrideTemplateDirs...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sorting ensures that parent directories get created first
+	paths := make([]string, 0, len(templates))
+	for rel := range templates {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+
+	funcs := bc.funcs
+	if funcs == nil {
+		funcs = builtins
+	}
+
+	for _, rel := range paths {
+		tf := templates[rel]
+		specFile := filepath.Join(specDir, rel)
+		if tf.info.IsDir() {
+			if err := os.MkdirAll(specFile, tf.info.Mode()); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		t, err := template.
 			New("").
-			Funcs(builtins).
+			Funcs(funcs).
 			Option("missingkey=error").
-			ParseFiles(templateFile)
+			ParseFiles(tf.path)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		workItems = append(workItems, work{
-			src:  templateFile,
+			src:  tf.path,
 			dst:  specFile,
 			t:    t.Templates()[0],
-			info: f,
+			info: tf.info,
 		})
-
-		return nil
-	}); err != nil {
-		return nil, err
 	}
 
 	for _, item := range workItems {
@@ -78,7 +128,105 @@ func buildSpecs(bc *buildConfig, specDir string) (workItems []work, err error) {
 		}
 	}
 
-	logrus.Info("Package specs have successfully been built")
+	bc.log.Info("Package specs have successfully been built")
 
 	return workItems, nil
 }
+
+// AddTemplateFuncs registers additional functions, which can be used by all
+// package templates. Builtin functions cannot be overridden.
+func (c *Client) AddTemplateFuncs(funcs template.FuncMap) error {
+	c.templateFuncsMu.Lock()
+	defer c.templateFuncsMu.Unlock()
+
+	for name, fn := range funcs {
+		if _, ok := builtins[name]; ok {
+			return errors.Errorf("template function %s is a builtin", name)
+		}
+		if c.templateFuncs == nil {
+			c.templateFuncs = template.FuncMap{}
+		}
+		c.templateFuncs[name] = fn
+	}
+	return nil
+}
+
+// loadTemplateFuncs registers the lookup table functions of the extra
+// template directories. Every top level key of their functions.yaml becomes
+// a function, which maps its single argument by using the table of the key.
+// Unknown arguments are returned unchanged, for example:
+//
+//	# functions.yaml
+//	rpmDependency:
+//	  iptables: iptables-legacy
+//
+//	# template
+//	Requires: {{ rpmDependency "iptables" }}
+func (c *Client) loadTemplateFuncs() error {
+	for _, dir := range c.options.ExtraTemplateDirs() {
+		path := filepath.Join(dir, templateFuncsFile)
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", path)
+		}
+
+		tables := map[string]map[string]string{}
+		if err := yaml.UnmarshalStrict(content, &tables); err != nil {
+			return errors.Wrapf(err, "parsing %s", path)
+		}
+
+		funcs := template.FuncMap{}
+		for name, table := range tables {
+			table := table
+			funcs[name] = func(key string) string {
+				if value, ok := table[key]; ok {
+					return value
+				}
+				return key
+			}
+		}
+		if err := c.AddTemplateFuncs(funcs); err != nil {
+			return errors.Wrapf(err, "registering functions of %s", path)
+		}
+	}
+	return nil
+}
+
+// packageTemplateDirs returns the existing template directories of the
+// package, starting with the one of the template directory followed by the
+// ones of the extra template directories.
+func (c *Client) packageTemplateDirs(pkg string) ([]string, error) {
+	dirs := []string{}
+	for _, dir := range append(
+		[]string{c.options.TemplateDir()}, c.options.ExtraTemplateDirs()...,
+	) {
+		packageTemplateDir := filepath.Join(dir, c.templateDirType(), pkg)
+		if _, err := os.Stat(packageTemplateDir); err == nil {
+			dirs = append(dirs, packageTemplateDir)
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, errors.Errorf(
+			"no template directory found for package %s", pkg,
+		)
+	}
+	return dirs, nil
+}
+
+// templateFuncsMap returns the builtin and all registered template functions.
+func (c *Client) templateFuncsMap() template.FuncMap {
+	c.templateFuncsMu.Lock()
+	defer c.templateFuncsMu.Unlock()
+
+	funcs := template.FuncMap{}
+	for name, fn := range builtins {
+		funcs[name] = fn
+	}
+	for name, fn := range c.templateFuncs {
+		funcs[name] = fn
+	}
+	return funcs
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessExtraTemplateDirs(t *testing.T) {
+	extraTemplateDir, err := os.MkdirTemp("", "kubepkg-extra-templates-")
+	require.Nil(t, err)
+	defer os.RemoveAll(extraTemplateDir)
+	outputDir, err := os.MkdirTemp("", "kubepkg-output-")
+	require.Nil(t, err)
+	defer os.RemoveAll(outputDir)
+
+	opts := options.New().
+		WithPackages("kubelet").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithExtraTemplateDirs(extraTemplateDir).
+		WithOutputDir(outputDir).
+		WithSpecOnly(true)
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+
+	for dir, files := range map[string]map[string]string{
+		filepath.Join(opts.TemplateDir(), "deb", "kubelet"): {
+			"control":  "base {{ .Package }}",
+			"postinst": "base",
+		},
+		filepath.Join(extraTemplateDir, "deb", "kubelet"): {
+			"postinst": `override {{ depName "iptables" }} {{ depName "conntrack" }}`,
+		},
+		filepath.Join(extraTemplateDir, "deb", "kubectl"): {
+			"control": "extra {{ .Package }}",
+		},
+		extraTemplateDir: {
+			"functions.yaml": "depName:\n  iptables: iptables-legacy\n",
+		},
+	} {
+		require.Nil(t, os.MkdirAll(dir, 0o755))
+		for file, content := range files {
+			require.Nil(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644))
+		}
+	}
+
+	// kubectl only exists in the extra template directory
+	opts.WithPackages("kubelet", "kubectl")
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Len(t, builds, 2)
+	require.Nil(t, sut.WalkBuilds(builds))
+
+	specDir := filepath.Join(outputDir, "specs", "release")
+	for file, expected := range map[string]string{
+		"kubelet/amd64/control":  "base kubelet",
+		"kubelet/amd64/postinst": "override iptables-legacy conntrack",
+		"kubectl/amd64/control":  "extra kubectl",
+	} {
+		content, err := os.ReadFile(filepath.Join(specDir, file))
+		require.Nil(t, err)
+		require.Equal(t, expected, string(content))
+	}
+}
+
+func TestConstructBuildsFailureExtraTemplateFuncs(t *testing.T) {
+	extraTemplateDir, err := os.MkdirTemp("", "kubepkg-extra-templates-")
+	require.Nil(t, err)
+	defer os.RemoveAll(extraTemplateDir)
+
+	opts := options.New().WithExtraTemplateDirs(extraTemplateDir)
+	sut, cleanup, _ := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+
+	for _, content := range []string{
+		"depName: [wrong]",
+		"date:\n  key: value\n",
+	} {
+		require.Nil(t, os.WriteFile(
+			filepath.Join(extraTemplateDir, "functions.yaml"), []byte(content), 0o644,
+		))
+		_, err = sut.ConstructBuilds()
+		require.NotNil(t, err, content)
+	}
+}
+
+func TestAddTemplateFuncs(t *testing.T) {
+	sut, _ := newSUT(nil)
+	require.Nil(t, sut.AddTemplateFuncs(map[string]interface{}{
+		"upper": strings.ToUpper,
+	}))
+	require.NotNil(t, sut.AddTemplateFuncs(map[string]interface{}{
+		"date": strings.ToUpper,
+	}))
+}
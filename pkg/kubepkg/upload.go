@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/object"
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-utils/util"
+)
+
+const (
+	// SummaryFile is the name of the JSON build summary uploaded next to
+	// the packages.
+	SummaryFile = "summary.json"
+
+	// uploadPackagesDir is the subdirectory of the release staging directory
+	// of a Kubernetes version which contains the packages.
+	uploadPackagesDir = "packages"
+)
+
+// upload pushes all artifacts of the current run including their checksums,
+// signatures and provenance statements as well as the JSON build summary to
+// the upload bucket. The files keep their layout relative to the output
+// directory. Nothing gets uploaded if no upload bucket has been configured.
+func (c *Client) upload() error {
+	if c.options.UploadBucket() == "" {
+		return nil
+	}
+
+	uploadPath, err := c.uploadPath()
+	if err != nil {
+		return err
+	}
+
+	stagingDir, err := os.MkdirTemp("", "kubepkg-upload-")
+	if err != nil {
+		return errors.Wrap(err, "creating upload staging directory")
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := c.stageUpload(stagingDir); err != nil {
+		return errors.Wrap(err, "staging files for upload")
+	}
+
+	dst, err := object.NewGCS().NormalizePath(c.options.UploadBucket(), uploadPath)
+	if err != nil {
+		return errors.Wrap(err, "normalizing upload path")
+	}
+
+	if c.options.DryRun() {
+		logrus.Infof("Dry run: would upload packages to %s", dst)
+		return nil
+	}
+
+	logrus.Infof("Uploading packages to %s", dst)
+	if err := c.impl.RsyncRecursive(stagingDir, dst); err != nil {
+		return publishError(errors.Wrapf(err, "uploading packages to %s", dst))
+	}
+	logrus.Infof("Successfully uploaded packages to %s", dst)
+	return nil
+}
+
+// uploadPath returns the path within the upload bucket. It defaults to the
+// packages directory of the release staging layout, for example
+// stage/v1.22.1/packages, if a single Kubernetes version has been built.
+func (c *Client) uploadPath() (string, error) {
+	if c.options.UploadPath() != "" {
+		return c.options.UploadPath(), nil
+	}
+	if len(c.kubeVersions) != 1 {
+		return "", errors.New(
+			"an upload path is required unless a single Kubernetes version gets built",
+		)
+	}
+	return filepath.Join(
+		release.StagePath, util.AddTagPrefix(c.kubeVersions[0]), uploadPackagesDir,
+	), nil
+}
+
+// stageUpload copies the files to be uploaded into the staging directory.
+func (c *Client) stageUpload(stagingDir string) error {
+	outputDir, err := filepath.Abs(c.options.OutputDir())
+	if err != nil {
+		return errors.Wrapf(err, "getting absolute path of %s", c.options.OutputDir())
+	}
+
+	summary := c.Summary()
+	files := map[string]bool{}
+	for _, artifact := range summary.Artifacts {
+		files[artifact.Path] = true
+		dir := filepath.Dir(artifact.Path)
+		files[filepath.Join(dir, SHA256SumsFile)] = true
+		files[filepath.Join(dir, SHA512SumsFile)] = true
+	}
+
+	// Provenance statements and signatures only exist if they are enabled
+	optional := []string{}
+	for file := range files {
+		optional = append(optional, file+".asc", file+ProvenanceSuffix, file+ProvenanceSuffix+".asc")
+	}
+	for _, file := range optional {
+		if _, err := os.Stat(file); err == nil {
+			files[file] = true
+		}
+	}
+
+	for file := range files {
+		rel, err := filepath.Rel(outputDir, file)
+		if err != nil {
+			return errors.Wrapf(err, "getting relative path of %s", file)
+		}
+		if err := c.copyFile(file, filepath.Join(stagingDir, rel)); err != nil {
+			return err
+		}
+	}
+
+	summaryJSON, err := summary.JSON()
+	if err != nil {
+		return err
+	}
+	summaryPath := filepath.Join(stagingDir, SummaryFile)
+	if err := c.impl.WriteFile(
+		summaryPath, append(summaryJSON, '\n'), os.FileMode(0o644),
+	); err != nil {
+		return errors.Wrapf(err, "writing %s", summaryPath)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsUpload(t *testing.T) {
+	for _, tc := range []struct {
+		uploadPath, expectedDst string
+	}{
+		{expectedDst: "gs://bucket/stage/v1.18.0/packages"},
+		{uploadPath: "custom/path", expectedDst: "gs://bucket/custom/path"},
+	} {
+		opts := options.New().
+			WithPackages("kubeadm").
+			WithChannels("release").
+			WithArchitectures("amd64").
+			WithUploadBucket("bucket").
+			WithUploadPath(tc.uploadPath)
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+		defer cleanup()
+		mock.AvailableReturns(true)
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		require.Nil(t, sut.WalkBuilds(builds))
+
+		require.Equal(t, 1, mock.RsyncRecursiveCallCount())
+		src, dst := mock.RsyncRecursiveArgsForCall(0)
+		require.Equal(t, tc.expectedDst, dst)
+
+		uploaded := []string{}
+		for i := 0; i < mock.WriteFileCallCount(); i++ {
+			path, _, _ := mock.WriteFileArgsForCall(i)
+			if rel, err := filepath.Rel(src, path); err == nil && !strings.HasPrefix(rel, "..") {
+				uploaded = append(uploaded, rel)
+			}
+		}
+		require.ElementsMatch(t, []string{
+			filepath.Join("release", "kubeadm_1.18.0-0_amd64.deb"),
+			filepath.Join("release", kubepkg.SHA256SumsFile),
+			filepath.Join("release", kubepkg.SHA512SumsFile),
+			kubepkg.SummaryFile,
+		}, uploaded)
+	}
+}
+
+func TestWalkBuildsUploadFailure(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubeadm").
+		WithChannels("release").
+		WithArchitectures("amd64").
+		WithUploadBucket("bucket")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+	defer cleanup()
+	mock.AvailableReturns(true)
+	mock.RsyncRecursiveReturns(errors.New("gsutil failed"))
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	err = sut.WalkBuilds(builds)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "uploading packages")
+}
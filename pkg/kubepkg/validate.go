@@ -0,0 +1,304 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+const (
+	// packageMaintainerName is the name of the maintainer all packages
+	// have to be published with.
+	packageMaintainerName = "Kubernetes Authors"
+
+	dpkgDebExecutable = "dpkg-deb"
+	rpmExecutable     = "rpm"
+)
+
+var (
+	// metadataCommands are the commands printing the name, version,
+	// architecture, maintainer and dependencies of a package on one line
+	// each.
+	metadataCommands = map[options.BuildType][]string{
+		options.BuildDeb: {
+			dpkgDebExecutable, "--show",
+			"--showformat=${Package}\\n${Version}\\n${Architecture}\\n${Maintainer}\\n${Depends}\\n",
+		},
+		options.BuildRpm: {
+			rpmExecutable, "--query", "--package",
+			"--queryformat=%{NAME}\\n%{VERSION}-%{RELEASE}\\n%{ARCH}\\n%{PACKAGER}\\n[%{REQUIRENAME} %{REQUIREFLAGS:depflags} %{REQUIREVERSION}, ]\\n",
+		},
+	}
+)
+
+// PackageMetadata are the fields of a deb or rpm, which get validated.
+type PackageMetadata struct {
+	// Name is the package name, like kubelet.
+	Name string
+
+	// Version is the full version including the revision, like 1.22.1-0.
+	Version string
+
+	// Arch is the architecture name of the package type, like x86_64.
+	Arch string
+
+	// Maintainer is the maintainer of a deb or the packager of an rpm.
+	Maintainer string
+
+	// Dependencies are the version constraints by the names of the
+	// required packages. Dependencies without a version have an empty
+	// constraint, like "" or ">= 1.4.21".
+	Dependencies map[string]string
+}
+
+// FileName returns the file name the package should have for the provided
+// build type.
+func (m *PackageMetadata) FileName(buildType options.BuildType) string {
+	if buildType == options.BuildRpm {
+		return fmt.Sprintf("%s-%s.%s.rpm", m.Name, m.Version, m.Arch)
+	}
+	return fmt.Sprintf("%s_%s_%s.deb", m.Name, m.Version, m.Arch)
+}
+
+// ValidateResult is the result of validating a single package.
+type ValidateResult struct {
+	// Path is the location of the package.
+	Path string `json:"path"`
+
+	// Err is the validation failure, which is nil on success.
+	Err error `json:"-"`
+}
+
+// MarshalJSON returns the JSON representation of the result, which contains
+// the message of the validation failure as error.
+func (r ValidateResult) MarshalJSON() ([]byte, error) {
+	type result ValidateResult
+	res := struct {
+		result
+		Error string `json:"error,omitempty"`
+	}{result: result(r)}
+	if r.Err != nil {
+		res.Error = r.Err.Error()
+	}
+	return json.Marshal(res)
+}
+
+// ValidatePackages inspects the provided debs and rpms and checks that their
+// name, version and architecture match the file name, that they are
+// maintained by the Kubernetes Authors and that they depend on all required
+// packages. An error is returned if any package is invalid.
+func (c *Client) ValidatePackages(paths ...string) ([]ValidateResult, error) {
+	results := []ValidateResult{}
+	failed := 0
+	for _, path := range paths {
+		result := ValidateResult{Path: path, Err: c.validatePackageFile(path)}
+		if result.Err != nil {
+			logrus.Errorf("Validation of %s failed: %v", path, result.Err)
+			failed++
+		}
+		results = append(results, result)
+	}
+	if failed > 0 {
+		return results, WithCategory(errors.Errorf(
+			"validation failed for %d of %d packages", failed, len(paths),
+		), ErrorCategoryValidation)
+	}
+	return results, nil
+}
+
+// validatePackageFile validates a single package, whose expectations are
+// derived from its file name.
+func (c *Client) validatePackageFile(path string) error {
+	buildType := options.BuildType(strings.TrimPrefix(filepath.Ext(path), "."))
+	metadata, err := c.packageMetadata(buildType, path)
+	if err != nil {
+		return err
+	}
+
+	// Only the names of the dependencies are known without the build
+	deps, err := GetDependencies(&PackageDefinition{Name: metadata.Name})
+	if err != nil {
+		return errors.Wrap(err, "getting dependencies")
+	}
+	expected := &PackageMetadata{
+		Name:         metadata.Name,
+		Version:      metadata.Version,
+		Arch:         metadata.Arch,
+		Dependencies: map[string]string{},
+	}
+	for dep := range deps {
+		expected.Dependencies[dep] = ""
+	}
+
+	mismatches := validateMetadata(expected, metadata)
+	if fileName := metadata.FileName(buildType); fileName != filepath.Base(path) {
+		mismatches = append(mismatches, fmt.Sprintf(
+			"file name: expected %s, got %s", fileName, filepath.Base(path),
+		))
+	}
+	return mismatchesError(path, mismatches)
+}
+
+// validateBuiltPackage validates the deb or rpm built at path against the
+// build config if package validation is enabled.
+func (c *Client) validateBuiltPackage(bc *buildConfig, path string) error {
+	if !c.options.ValidatePackages() {
+		return nil
+	}
+	if _, ok := metadataCommands[bc.Type]; !ok {
+		bc.log.Debugf("Skipping validation of %s packages", bc.Type)
+		return nil
+	}
+
+	bc.log.Infof("Validating %s", filepath.Base(path))
+	metadata, err := c.packageMetadata(bc.Type, path)
+	if err != nil {
+		return err
+	}
+
+	expected := &PackageMetadata{
+		Name:         bc.Package,
+		Version:      fmt.Sprintf("%s-%s", bc.Version, bc.Revision),
+		Arch:         bc.BuildArch,
+		Dependencies: map[string]string{},
+	}
+	for dep, version := range bc.Dependencies {
+		expected.Dependencies[bc.DependencyName(dep)] = ">= " + version
+	}
+	return mismatchesError(path, validateMetadata(expected, metadata))
+}
+
+// packageMetadata reads the metadata of the package at path by using the
+// tooling of the build type.
+func (c *Client) packageMetadata(buildType options.BuildType, path string) (*PackageMetadata, error) {
+	command, ok := metadataCommands[buildType]
+	if !ok {
+		return nil, errors.Errorf("validating %s packages is not supported", buildType)
+	}
+	if !c.impl.Available(command[0]) {
+		return nil, errors.Errorf(
+			"validating %s packages requires %s", buildType, command[0],
+		)
+	}
+
+	output, err := c.impl.RunOutputWithWorkDir(
+		"", command[0], append(command[1:], path)...,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading metadata of %s", path)
+	}
+	return parsePackageMetadata(buildType, output)
+}
+
+// parsePackageMetadata parses the output of the metadata command of the
+// build type.
+func parsePackageMetadata(buildType options.BuildType, output string) (*PackageMetadata, error) {
+	lines := strings.SplitN(output, "\n", 5)
+	if len(lines) < 4 {
+		return nil, errors.Errorf("unexpected package metadata: %q", output)
+	}
+	for len(lines) < 5 {
+		lines = append(lines, "")
+	}
+
+	metadata := &PackageMetadata{
+		Name:         strings.TrimSpace(lines[0]),
+		Version:      strings.TrimSpace(lines[1]),
+		Arch:         strings.TrimSpace(lines[2]),
+		Maintainer:   strings.TrimSpace(lines[3]),
+		Dependencies: map[string]string{},
+	}
+
+	for _, dep := range strings.Split(lines[4], ",") {
+		// Only the first alternative of a deb dependency is considered
+		dep = strings.TrimSpace(strings.Split(dep, "|")[0])
+		if buildType == options.BuildDeb {
+			dep = strings.NewReplacer("(", "", ")", "").Replace(dep)
+		}
+		fields := strings.Fields(dep)
+		if len(fields) == 0 {
+			continue
+		}
+		metadata.Dependencies[fields[0]] = strings.Join(fields[1:], " ")
+	}
+	return metadata, nil
+}
+
+// validateMetadata returns all differences between the expected and actual
+// package metadata. Only the expected dependencies are required to exist,
+// whereas their constraints only get compared if they are expected.
+func validateMetadata(expected, actual *PackageMetadata) (mismatches []string) {
+	for _, field := range []struct{ name, expected, actual string }{
+		{"name", expected.Name, actual.Name},
+		{"version", expected.Version, actual.Version},
+		{"architecture", expected.Arch, actual.Arch},
+	} {
+		if field.expected != field.actual {
+			mismatches = append(mismatches, fmt.Sprintf(
+				"%s: expected %q, got %q", field.name, field.expected, field.actual,
+			))
+		}
+	}
+
+	if !strings.HasPrefix(actual.Maintainer, packageMaintainerName+" <") {
+		mismatches = append(mismatches, fmt.Sprintf(
+			"maintainer: expected %s, got %q", packageMaintainerName, actual.Maintainer,
+		))
+	}
+
+	deps := make([]string, 0, len(expected.Dependencies))
+	for dep := range expected.Dependencies {
+		deps = append(deps, dep)
+	}
+	sort.Strings(deps)
+	for _, dep := range deps {
+		constraint := expected.Dependencies[dep]
+		actualConstraint, ok := actual.Dependencies[dep]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf(
+				"dependencies: missing %s", dep,
+			))
+		case constraint != "" && constraint != actualConstraint:
+			mismatches = append(mismatches, fmt.Sprintf(
+				"dependencies: expected %s %s, got %q", dep, constraint, actualConstraint,
+			))
+		}
+	}
+	return mismatches
+}
+
+// mismatchesError returns an error listing all mismatches of the package at
+// path, which is nil if there are none.
+func mismatchesError(path string, mismatches []string) error {
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return errors.Errorf(
+		"package %s does not match the expected metadata:\n%s",
+		filepath.Base(path), strings.Join(mismatches, "\n"),
+	)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+const kubeadmDebMetadata = `kubeadm
+1.18.0-0
+amd64
+Kubernetes Authors <kubernetes-dev+release@googlegroups.com>
+kubelet (>= 1.13.0), kubectl (>= 1.13.0), kubernetes-cni (>= 0.8.6), cri-tools (>= 1.13.0)`
+
+func TestValidatePackages(t *testing.T) {
+	for _, tc := range []struct {
+		path, metadata string
+		expectedErr    []string
+	}{
+		{
+			path:     "kubeadm_1.18.0-0_amd64.deb",
+			metadata: kubeadmDebMetadata,
+		},
+		{
+			path: "kubeadm-1.18.0-0.x86_64.rpm",
+			metadata: "kubeadm\n1.18.0-0\nx86_64\nKubernetes Authors <kubernetes-dev+release@googlegroups.com>\n" +
+				"kubelet >= 1.13.0, kubectl >= 1.13.0, kubernetes-cni >= 0.8.6, cri-tools >= 1.13.0, rpmlib(CompressedFileNames) <= 3.0.4-1, ",
+		},
+		{
+			path:     "kubeadm_1.18.1-0_arm64.deb",
+			metadata: kubeadmDebMetadata,
+			expectedErr: []string{
+				"file name: expected kubeadm_1.18.0-0_amd64.deb, got kubeadm_1.18.1-0_arm64.deb",
+			},
+		},
+		{
+			path:     "kubeadm_1.18.0-0_amd64.deb",
+			metadata: "kubeadm\n1.18.0-0\namd64\nSomeone <someone@example.com>\nkubelet | kubelet-legacy, kubectl (>= 1.13.0)",
+			expectedErr: []string{
+				`maintainer: expected Kubernetes Authors, got "Someone <someone@example.com>"`,
+				"dependencies: missing cri-tools\ndependencies: missing kubernetes-cni",
+			},
+		},
+	} {
+		sut, mock := newSUT(nil)
+		mock.AvailableReturns(true)
+		mock.RunOutputWithWorkDirReturns(tc.metadata, nil)
+
+		results, err := sut.ValidatePackages(tc.path)
+		require.Len(t, results, 1)
+		require.Equal(t, tc.path, results[0].Path)
+		if len(tc.expectedErr) == 0 {
+			require.Nil(t, err)
+			require.Nil(t, results[0].Err)
+			continue
+		}
+		require.NotNil(t, err)
+		for _, expected := range tc.expectedErr {
+			require.Contains(t, results[0].Err.Error(), expected)
+		}
+	}
+}
+
+func TestValidatePackagesFailure(t *testing.T) {
+	sut, mock := newSUT(nil)
+	mock.AvailableReturns(false)
+	results, err := sut.ValidatePackages("kubeadm_1.18.0-0_amd64.deb")
+	require.NotNil(t, err)
+	require.Contains(t, results[0].Err.Error(), "requires dpkg-deb")
+
+	mock.AvailableReturns(true)
+	results, err = sut.ValidatePackages("kubeadm.snap")
+	require.NotNil(t, err)
+	require.Contains(t, results[0].Err.Error(), "not supported")
+
+	mock.RunOutputWithWorkDirReturns("", errors.New("dpkg-deb failed"))
+	results, err = sut.ValidatePackages("kubeadm_1.18.0-0_amd64.deb")
+	require.NotNil(t, err)
+	require.Contains(t, results[0].Err.Error(), "reading metadata")
+}
+
+func TestResultsJSON(t *testing.T) {
+	res, err := json.Marshal([]kubepkg.ValidateResult{
+		{Path: "kubeadm_1.18.0-0_amd64.deb"},
+		{Path: "kubelet_1.18.0-0_amd64.deb", Err: errors.New("wrong version")},
+	})
+	require.Nil(t, err)
+	require.JSONEq(t, `[
+		{"path": "kubeadm_1.18.0-0_amd64.deb"},
+		{"path": "kubelet_1.18.0-0_amd64.deb", "error": "wrong version"}
+	]`, string(res))
+
+	res, err = json.Marshal(kubepkg.VerifyResult{
+		Distro: "debian:bullseye", Arch: "amd64", Packages: []string{"kubeadm.deb"},
+		Err: errors.New("install failed"),
+	})
+	require.Nil(t, err)
+	require.JSONEq(t, `{
+		"distro": "debian:bullseye", "arch": "amd64",
+		"packages": ["kubeadm.deb"], "error": "install failed"
+	}`, string(res))
+}
+
+func TestWalkBuildsValidatePackages(t *testing.T) {
+	for _, tc := range []struct {
+		metadata    string
+		shouldError bool
+	}{
+		{metadata: kubeadmDebMetadata},
+		{
+			metadata:    strings.Replace(kubeadmDebMetadata, "cri-tools (>= 1.13.0)", "cri-tools (>= 1.12.0)", 1),
+			shouldError: true,
+		},
+	} {
+		opts := options.New().
+			WithPackages("kubeadm").
+			WithChannels("release").
+			WithArchitectures("amd64").
+			WithValidatePackages(true)
+		sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildDeb)
+		defer cleanup()
+		mock.AvailableReturns(true)
+		mock.RunOutputWithWorkDirReturns(tc.metadata, nil)
+
+		builds, err := sut.ConstructBuilds()
+		require.Nil(t, err)
+		err = sut.WalkBuilds(builds)
+		require.Equal(t, 1, mock.RunOutputWithWorkDirCallCount())
+		_, cmd, args := mock.RunOutputWithWorkDirArgsForCall(0)
+		require.Equal(t, "dpkg-deb", cmd)
+		require.Equal(t, "kubeadm_1.18.0-0_amd64.deb", filepath.Base(args[len(args)-1]))
+		if tc.shouldError {
+			require.Contains(t, err.Error(), "dependencies: expected cri-tools >= 1.13.0")
+			require.Zero(t, mock.WriteFileCallCount())
+			continue
+		}
+		require.Nil(t, err)
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// verifyPackageDir is the directory where the packages get mounted to inside
+// the verification containers.
+const verifyPackageDir = "/packages"
+
+var (
+	// verifyDistros are the container images of the distributions the
+	// packages get installed on per build type.
+	verifyDistros = map[options.BuildType][]string{
+		options.BuildDeb: {
+			"docker.io/library/debian:bullseye",
+			"docker.io/library/debian:buster",
+			"docker.io/library/ubuntu:20.04",
+			"docker.io/library/ubuntu:18.04",
+		},
+		options.BuildRpm: {
+			"docker.io/library/fedora:34",
+			"docker.io/library/rockylinux:8",
+			"docker.io/library/centos:7",
+		},
+	}
+
+	// verifyInstallCommands are the shell commands used for installing
+	// the packages, where %s gets replaced by the package files.
+	verifyInstallCommands = map[options.BuildType]string{
+		options.BuildDeb: "apt-get update && DEBIAN_FRONTEND=noninteractive apt-get install -y %s",
+		options.BuildRpm: "yum install -y %s",
+	}
+
+	// verifyCheckCommands are the smoke tests run per installed package.
+	verifyCheckCommands = map[string]string{
+		"kubeadm":        "kubeadm version",
+		"kubectl":        "kubectl version --client",
+		"kubelet":        "kubelet --version",
+		"cri-tools":      "crictl --version",
+		"kubernetes-cni": "test -x /opt/cni/bin/bridge",
+	}
+)
+
+// VerifyDistros returns the container images of the distributions used for
+// verifying packages of the provided build type.
+func VerifyDistros(buildType options.BuildType) []string {
+	return verifyDistros[buildType]
+}
+
+// VerifyResult is the result of installing the packages on a single
+// distribution.
+type VerifyResult struct {
+	// Distro is the container image of the distribution.
+	Distro string `json:"distro"`
+
+	// Arch is the architecture of the installed packages.
+	Arch string `json:"arch"`
+
+	// Packages are the package files which got installed.
+	Packages []string `json:"packages"`
+
+	// Err is the verification failure, which is nil on success.
+	Err error `json:"-"`
+}
+
+// MarshalJSON returns the JSON representation of the result, which contains
+// the message of the verification failure as error.
+func (r VerifyResult) MarshalJSON() ([]byte, error) {
+	type result VerifyResult
+	res := struct {
+		result
+		Error string `json:"error,omitempty"`
+	}{result: result(r)}
+	if r.Err != nil {
+		res.Error = r.Err.Error()
+	}
+	return json.Marshal(res)
+}
+
+// Verify installs the packages of the provided architectures found in
+// packageDir inside a container for every supported distribution and runs
+// basic smoke tests of the installed binaries. Only the packages of the host
+// architecture get verified if no architecture is provided, while foreign
+// architectures require QEMU user emulation. An error is returned if the
+// verification could not be started or if any distribution failed.
+func (c *Client) Verify(packageDir string, architectures ...string) ([]VerifyResult, error) {
+	buildType := c.options.BuildType()
+	distros, ok := verifyDistros[buildType]
+	if !ok {
+		return nil, errors.Errorf("verifying %s packages is not supported", buildType)
+	}
+
+	absPackageDir, err := filepath.Abs(packageDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting absolute path of %s", packageDir)
+	}
+
+	if len(architectures) == 0 {
+		architectures = []string{runtime.GOARCH}
+	}
+	type verifyTarget struct {
+		arch        string
+		files, pkgs []string
+	}
+	targets := []verifyTarget{}
+	for _, arch := range architectures {
+		files, pkgs, err := c.verifyPackages(absPackageDir, arch)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, verifyTarget{arch, files, pkgs})
+	}
+
+	containerRuntime, err := c.containerRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []VerifyResult{}
+	failed := []string{}
+	for _, target := range targets {
+		if err := c.ensureEmulation(containerRuntime, target.arch); err != nil {
+			return results, err
+		}
+
+		args := []string{"run", "--rm"}
+		if target.arch != runtime.GOARCH {
+			args = append(args, "--platform", "linux/"+target.arch)
+		}
+		args = append(args,
+			"--volume", fmt.Sprintf("%s:%s:ro,Z", absPackageDir, verifyPackageDir),
+		)
+		script := c.verifyScript(target.files, target.pkgs)
+
+		for _, distro := range distros {
+			logrus.Infof(
+				"Verifying %d %s packages on %s", len(target.files), target.arch, distro,
+			)
+			result := VerifyResult{
+				Distro: distro, Arch: target.arch, Packages: target.files,
+			}
+			runArgs := append([]string{}, args...)
+			runArgs = append(runArgs, distro, "sh", "-c", script)
+			if err := c.impl.RunSuccessWithWorkDir(
+				absPackageDir, containerRuntime, runArgs...,
+			); err != nil {
+				logrus.Errorf("Verification on %s (%s) failed: %v", distro, target.arch, err)
+				result.Err = err
+				failed = append(failed, fmt.Sprintf("%s (%s)", distro, target.arch))
+			} else {
+				logrus.Infof("Verification on %s (%s) succeeded", distro, target.arch)
+			}
+			results = append(results, result)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, WithCategory(errors.Errorf(
+			"verification failed on %d of %d distributions: %s",
+			len(failed), len(results), strings.Join(failed, ", "),
+		), ErrorCategoryBuild)
+	}
+	return results, nil
+}
+
+// verifyPackages returns the package files of the selected packages for the
+// provided architecture within packageDir as well as the package names.
+func (c *Client) verifyPackages(packageDir, arch string) (files, pkgs []string, err error) {
+	buildType := c.options.BuildType()
+	buildArch := getBuildArch(arch, buildType)
+	if buildArch == "" {
+		return nil, nil, errors.Errorf(
+			"architecture %s is not supported for %s packages",
+			arch, buildType,
+		)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(packageDir, "*."+string(buildType)))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "finding packages in %s", packageDir)
+	}
+
+	selected := map[string]bool{}
+	for _, pkg := range c.options.Packages() {
+		selected[pkg] = true
+	}
+
+	for _, match := range matches {
+		file := filepath.Base(match)
+		pkg, arch := parsePackageFileName(buildType, file)
+		if !selected[pkg] || arch != buildArch {
+			continue
+		}
+		files = append(files, file)
+		pkgs = append(pkgs, pkg)
+	}
+
+	if len(files) == 0 {
+		return nil, nil, errors.Errorf(
+			"no %s packages for %s found in %s",
+			buildType, buildArch, packageDir,
+		)
+	}
+	sort.Strings(pkgs)
+	return files, pkgs, nil
+}
+
+// verifyScript returns the shell script for installing and testing the
+// packages.
+func (c *Client) verifyScript(files, pkgs []string) string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, filepath.Join(verifyPackageDir, file))
+	}
+
+	commands := []string{
+		"set -ex",
+		fmt.Sprintf(
+			verifyInstallCommands[c.options.BuildType()],
+			strings.Join(paths, " "),
+		),
+	}
+	for _, pkg := range pkgs {
+		if check, ok := verifyCheckCommands[pkg]; ok {
+			commands = append(commands, check)
+		}
+	}
+	return strings.Join(commands, "\n")
+}
+
+// parsePackageFileName returns the package name and architecture from the
+// file name of a deb or rpm.
+func parsePackageFileName(buildType options.BuildType, file string) (pkg, arch string) {
+	name := strings.TrimSuffix(file, "."+string(buildType))
+	switch buildType {
+	case options.BuildDeb:
+		// <package>_<version>-<revision>_<arch>
+		parts := strings.Split(name, "_")
+		if len(parts) != 3 {
+			return "", ""
+		}
+		return parts[0], parts[2]
+	case options.BuildRpm:
+		// <package>-<version>-<revision>.<arch>
+		dot := strings.LastIndex(name, ".")
+		if dot < 0 {
+			return "", ""
+		}
+		parts := strings.Split(name[:dot], "-")
+		if len(parts) < 3 {
+			return "", ""
+		}
+		return strings.Join(parts[:len(parts)-2], "-"), name[dot+1:]
+	}
+	return "", ""
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg"
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestVerify(t *testing.T) {
+	hostArchs := map[string]map[options.BuildType]string{
+		"amd64": {options.BuildDeb: "amd64", options.BuildRpm: "x86_64"},
+		"arm64": {options.BuildDeb: "arm64", options.BuildRpm: "aarch64"},
+	}
+	if _, ok := hostArchs[runtime.GOARCH]; !ok {
+		t.Skipf("unsupported test architecture %s", runtime.GOARCH)
+	}
+
+	for _, tc := range []struct {
+		buildType       options.BuildType
+		files           []string
+		expectedInstall string
+	}{
+		{
+			buildType: options.BuildDeb,
+			files: []string{
+				"kubeadm_1.22.1-0_%s.deb",
+				"cri-tools_1.22.0-0_%s.deb",
+				"kubectl_1.22.1-0_%s.deb",
+				"kubeadm_1.22.1-0_s390x.deb",
+			},
+			expectedInstall: "apt-get install -y /packages/cri-tools_1.22.0-0_%[1]s.deb /packages/kubeadm_1.22.1-0_%[1]s.deb",
+		},
+		{
+			buildType: options.BuildRpm,
+			files: []string{
+				"kubeadm-1.22.1-0.%s.rpm",
+				"cri-tools-1.22.0-0.%s.rpm",
+				"kubectl-1.22.1-0.%s.rpm",
+				"kubeadm-1.22.1-0.s390x.rpm",
+			},
+			expectedInstall: "yum install -y /packages/cri-tools-1.22.0-0.%[1]s.rpm /packages/kubeadm-1.22.1-0.%[1]s.rpm",
+		},
+	} {
+		hostArch := hostArchs[runtime.GOARCH][tc.buildType]
+		dir, err := os.MkdirTemp("", "kubepkg-verify-")
+		require.Nil(t, err)
+		defer os.RemoveAll(dir)
+		for _, file := range tc.files {
+			require.Nil(t, os.WriteFile(
+				filepath.Join(dir, fmt.Sprintf(file, hostArch)), nil, 0o644,
+			))
+		}
+
+		opts := options.New().
+			WithBuildType(tc.buildType).
+			WithPackages("kubeadm", "cri-tools")
+		sut, mock := newSUT(opts)
+		mock.AvailableReturns(true)
+		mock.RunSuccessWithWorkDirReturnsOnCall(1, errors.New("install failed"))
+
+		results, err := sut.Verify(dir)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "verification failed on 1 of")
+		require.Len(t, results, len(kubepkg.VerifyDistros(tc.buildType)))
+		require.Nil(t, results[0].Err)
+		require.NotNil(t, results[1].Err)
+		require.Len(t, results[0].Packages, 2)
+
+		_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+		require.Equal(t, "docker", cmd)
+		require.Contains(t, args, kubepkg.VerifyDistros(tc.buildType)[0])
+		script := args[len(args)-1]
+		require.Contains(t, script, fmt.Sprintf(tc.expectedInstall, hostArch))
+		require.Contains(t, script, "kubeadm version")
+		require.Contains(t, script, "crictl --version")
+		require.NotContains(t, script, "kubectl version")
+	}
+}
+
+func TestVerifyForeignArchitecture(t *testing.T) {
+	if runtime.GOARCH == "s390x" {
+		t.Skip("test requires a foreign architecture")
+	}
+	dir, err := os.MkdirTemp("", "kubepkg-verify-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	require.Nil(t, os.WriteFile(
+		filepath.Join(dir, "kubeadm_1.22.1-0_s390x.deb"), nil, 0o644,
+	))
+	distros := len(kubepkg.VerifyDistros(options.BuildDeb))
+
+	for _, tc := range []struct {
+		setupQEMU   bool
+		registered  []bool
+		shouldErr   bool
+		expectedRun int
+	}{
+		{ // not registered
+			registered: []bool{false},
+			shouldErr:  true,
+		},
+		{ // already registered
+			registered:  []bool{true},
+			expectedRun: distros,
+		},
+		{ // registered by the setup
+			setupQEMU:   true,
+			registered:  []bool{false, true},
+			expectedRun: 1 + distros,
+		},
+		{ // setup failed to register
+			setupQEMU:   true,
+			registered:  []bool{false, false},
+			shouldErr:   true,
+			expectedRun: 1,
+		},
+	} {
+		opts := options.New().
+			WithBuildType(options.BuildDeb).
+			WithPackages("kubeadm").
+			WithSetupQEMU(tc.setupQEMU)
+		sut, mock := newSUT(opts)
+		mock.AvailableReturns(true)
+		for i, registered := range tc.registered {
+			if registered {
+				mock.ReadFileReturnsOnCall(i, []byte("enabled\ninterpreter /usr/bin/qemu-s390x\n"), nil)
+			} else {
+				mock.ReadFileReturnsOnCall(i, nil, os.ErrNotExist)
+			}
+		}
+
+		results, err := sut.Verify(dir, "s390x")
+		require.Equal(t, tc.expectedRun, mock.RunSuccessWithWorkDirCallCount())
+		file := mock.ReadFileArgsForCall(0)
+		require.Equal(t, "/proc/sys/fs/binfmt_misc/qemu-s390x", file)
+		if tc.shouldErr {
+			require.NotNil(t, err)
+			if !tc.setupQEMU {
+				require.Contains(t, err.Error(), "requires QEMU user emulation")
+				require.Contains(t, err.Error(), "--privileged "+kubepkg.BinfmtImage+" --install s390x")
+			}
+			continue
+		}
+
+		require.Nil(t, err)
+		require.Len(t, results, distros)
+		require.Equal(t, "s390x", results[0].Arch)
+		_, _, args := mock.RunSuccessWithWorkDirArgsForCall(tc.expectedRun - 1)
+		require.Contains(t, strings.Join(args, " "), "--platform linux/s390x")
+		if tc.setupQEMU {
+			_, _, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+			require.Contains(t, args, kubepkg.BinfmtImage)
+		}
+	}
+}
+
+func TestVerifyFailureNoPackages(t *testing.T) {
+	dir, err := os.MkdirTemp("", "kubepkg-verify-")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	sut, mock := newSUT(options.New().WithBuildType(options.BuildDeb))
+	mock.AvailableReturns(true)
+
+	_, err = sut.Verify(dir)
+	require.NotNil(t, err)
+	require.Zero(t, mock.RunSuccessWithWorkDirCallCount())
+}
+
+func TestVerifyFailureUnsupportedType(t *testing.T) {
+	sut, _ := newSUT(options.New().WithBuildType(options.BuildSnap))
+	_, err := sut.Verify("")
+	require.NotNil(t, err)
+}
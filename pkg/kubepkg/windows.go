@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+// windowsAllArch is the architecture used for Windows packages, which
+// contain the binaries of all selected architectures.
+const windowsAllArch = "all"
+
+// windowsArchMap maps the Go architectures to the Windows installer
+// architectures. Architectures which are not part of the map are skipped.
+var windowsArchMap = map[string]string{
+	"amd64": "x64",
+	"arm64": "arm64",
+}
+
+// WindowsInstaller is a single Windows binary referenced by the package
+// manifests.
+type WindowsInstaller struct {
+	// Architecture is the Windows architecture, like x64.
+	Architecture string
+
+	// URL is the download location of the binary.
+	URL string
+
+	// SHA256 is the hex encoded checksum of the binary.
+	SHA256 string
+}
+
+// windowsBinaryURL returns the download location of the Windows binary of
+// the package for the provided Go architecture.
+func windowsBinaryURL(bc *buildConfig, arch string) string {
+	return fmt.Sprintf(
+		"%s/bin/windows/%s/%s.exe", bc.DownloadLinkBase, arch, bc.Package,
+	)
+}
+
+func isWindowsType(buildType options.BuildType) bool {
+	return buildType == options.BuildChocolatey || buildType == options.BuildWinget
+}
+
+// windowsInstallers returns the installers for all selected architectures,
+// including their checksums retrieved from the published .sha256 files.
+func (c *Client) windowsInstallers(bc *buildConfig) ([]WindowsInstaller, error) {
+	installers := []WindowsInstaller{}
+	for _, arch := range c.options.Architectures() {
+		windowsArch, ok := windowsArchMap[arch]
+		if !ok {
+			bc.log.Infof("Skipping unsupported Windows architecture %s", arch)
+			continue
+		}
+
+		url := windowsBinaryURL(bc, arch)
+		sha256, err := c.publishedSHA256(url)
+		if err != nil {
+			return nil, err
+		}
+
+		installers = append(installers, WindowsInstaller{
+			Architecture: windowsArch,
+			URL:          url,
+			SHA256:       sha256,
+		})
+	}
+
+	if len(installers) == 0 {
+		return nil, errors.Errorf(
+			"no supported Windows architecture selected, supported are: %v",
+			windowsArchMap,
+		)
+	}
+	return installers, nil
+}
+
+// runChocolatey packs the nuspec rendered into specDirWithArch.
+func (c *Client) runChocolatey(bc *buildConfig, specDir, specDirWithArch string) error {
+	bc.log.Infof("Running choco pack for %s", bc.Package)
+
+	if err := c.runBuildCommand(
+		bc,
+		specDir,
+		specDirWithArch,
+		"choco",
+		"pack",
+		bc.Package+".nuspec",
+		"--outputdirectory", ".",
+	); err != nil {
+		return errors.Wrap(err, "running Chocolatey package build")
+	}
+
+	return c.copyPackage(bc, filepath.Join(specDirWithArch, packageFileName(bc)))
+}
+
+// copyWingetManifests copies the rendered winget manifests to their
+// destination. The manifests are not built any further, but have to be
+// submitted to the winget community repository.
+func (c *Client) copyWingetManifests(bc *buildConfig, specDirWithArch string) error {
+	manifests, err := filepath.Glob(filepath.Join(specDirWithArch, "*.yaml"))
+	if err != nil {
+		return errors.Wrap(err, "finding winget manifests")
+	}
+
+	for _, manifest := range manifests {
+		if err := c.copyPackage(bc, manifest); err != nil {
+			return errors.Wrapf(err, "copying %s", manifest)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubepkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubepkg/options"
+)
+
+func TestWalkBuildsSuccessWinget(t *testing.T) {
+	opts := options.New().
+		WithPackages("kubectl").
+		WithChannels("release").
+		WithArchitectures("amd64", "arm", "arm64")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildWinget)
+	defer cleanup()
+	mock.GetURLResponseReturns("1234  kubectl.exe", nil)
+	mock.ReadFileStub = os.ReadFile
+
+	templateDir := filepath.Join(opts.TemplateDir(), "winget", "kubectl")
+	require.Nil(t, os.WriteFile(
+		filepath.Join(templateDir, "Kubernetes.kubectl.installer.yaml"),
+		[]byte("{{ range .WindowsInstallers }}{{ .Architecture }} {{ .URL }} {{ .SHA256 }}\n{{ end }}"),
+		0o644,
+	))
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.Nil(t, err)
+
+	require.Equal(t, 2, mock.GetURLResponseCallCount())
+	// The manifest, SHA256SUMS and SHA512SUMS
+	require.Equal(t, 3, mock.WriteFileCallCount())
+	dst, content, _ := mock.WriteFileArgsForCall(0)
+	require.Equal(t, "Kubernetes.kubectl.installer.yaml", filepath.Base(dst))
+	require.Equal(t,
+		"x64 https://dl.k8s.io/v1.18.0/bin/windows/amd64/kubectl.exe 1234\n"+
+			"arm64 https://dl.k8s.io/v1.18.0/bin/windows/arm64/kubectl.exe 1234\n",
+		string(content),
+	)
+}
+
+func TestWalkBuildsSuccessChocolatey(t *testing.T) {
+	opts := options.New().WithChannels("release")
+	sut, cleanup, mock := sutWithTemplateDir(t, opts, options.BuildChocolatey)
+	defer cleanup()
+	mock.GetURLResponseReturns("1234", nil)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+	require.Len(t, builds, 2)
+
+	err = sut.WalkBuilds(builds)
+	require.Nil(t, err)
+
+	require.Equal(t, 2, mock.RunSuccessWithWorkDirCallCount())
+	_, cmd, args := mock.RunSuccessWithWorkDirArgsForCall(0)
+	require.Equal(t, "choco", cmd)
+	require.Contains(t, args, "kubectl.nuspec")
+	require.Equal(t,
+		"kubectl.1.18.0.nupkg",
+		filepath.Base(mock.ReadFileArgsForCall(0)),
+	)
+}
+
+func TestWalkBuildsFailureWindowsNoChecksum(t *testing.T) {
+	sut, cleanup, mock := sutWithTemplateDir(t, nil, options.BuildChocolatey)
+	defer cleanup()
+	mock.GetURLResponseReturns("", err)
+
+	builds, err := sut.ConstructBuilds()
+	require.Nil(t, err)
+
+	err = sut.WalkBuilds(builds)
+	require.NotNil(t, err)
+}
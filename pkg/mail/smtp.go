@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	netmail "net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sendgrid/rest"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+// SMTPSendClient is a SendClient which delivers mails via an SMTP server
+// instead of the SendGrid API.
+type SMTPSendClient struct {
+	addr     string
+	auth     smtp.Auth
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPSendClient creates a new SMTP client for the server at addr, which
+// has to be in the form host:port. PLAIN authentication is used if the
+// username is not empty, which requires the server to support TLS.
+func NewSMTPSendClient(addr, username, password string) (*SMTPSendClient, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing SMTP server address %s", addr)
+	}
+	client := &SMTPSendClient{addr: addr, sendMail: smtp.SendMail}
+	if username != "" {
+		client.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return client, nil
+}
+
+// SetSendMail can be used to set the function which delivers the mail. It
+// defaults to smtp.SendMail.
+func (c *SMTPSendClient) SetSendMail(
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error,
+) {
+	c.sendMail = sendMail
+}
+
+// Send delivers the mail to all recipients of its personalizations.
+func (c *SMTPSendClient) Send(msg *mail.SGMailV3) (*rest.Response, error) {
+	if msg.From == nil || msg.From.Address == "" {
+		return nil, errors.New("mail sender is not set")
+	}
+
+	to := []string{}
+	toHeader := []string{}
+	for _, p := range msg.Personalizations {
+		for _, recipient := range p.To {
+			to = append(to, recipient.Address)
+			toHeader = append(toHeader, address(recipient))
+		}
+	}
+	if len(to) == 0 {
+		return nil, errors.New("mail recipients are not set")
+	}
+
+	contentType := "text/plain"
+	if len(msg.Content) > 0 {
+		contentType = msg.Content[0].Type
+	}
+
+	var body bytes.Buffer
+	for _, header := range [][2]string{
+		{"From", address(msg.From)},
+		{"To", strings.Join(toHeader, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", msg.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", contentType + `; charset="utf-8"`},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	} {
+		fmt.Fprintf(&body, "%s: %s\r\n", header[0], header[1])
+	}
+	body.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&body)
+	for _, content := range msg.Content {
+		if _, err := w.Write([]byte(content.Value)); err != nil {
+			return nil, errors.Wrap(err, "encoding mail content")
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "encoding mail content")
+	}
+
+	if err := c.sendMail(
+		c.addr, c.auth, msg.From.Address, to, body.Bytes(),
+	); err != nil {
+		return nil, errors.Wrapf(err, "sending mail via %s", c.addr)
+	}
+	return &rest.Response{StatusCode: http.StatusAccepted}, nil
+}
+
+// address formats the mail address for a header.
+func address(email *mail.Email) string {
+	return (&netmail.Address{Name: email.Name, Address: email.Address}).String()
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mail_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/release/pkg/mail"
+)
+
+func TestSMTPSendClient(t *testing.T) {
+	// Given
+	client, err := mail.NewSMTPSendClient("smtp.example.com:587", "user", "pass")
+	require.Nil(t, err)
+
+	var (
+		gotAddr, gotFrom string
+		gotAuth          smtp.Auth
+		gotTo            []string
+		gotMsg           []byte
+	)
+	client.SetSendMail(func(
+		addr string, a smtp.Auth, from string, to []string, msg []byte,
+	) error {
+		gotAddr, gotAuth, gotFrom, gotTo, gotMsg = addr, a, from, to, msg
+		return nil
+	})
+
+	sender := mail.NewSender("")
+	sender.SetSendClient(client)
+	require.Nil(t, sender.SetSender("Release Manager", "rm@example.com"))
+	require.Nil(t, sender.SetGoogleGroupRecipients(
+		mail.KubernetesAnnounceTestGoogleGroup,
+	))
+	content := "<p>Kubernetes v1.21.1 is live! " + strings.Repeat("äöü", 100) + "</p>"
+
+	// When
+	err = sender.Send(content, "Kubernetes v1.21.1 is live!")
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "smtp.example.com:587", gotAddr)
+	require.NotNil(t, gotAuth)
+	require.Equal(t, "rm@example.com", gotFrom)
+	require.Equal(t, []string{"kubernetes-announce-test@googlegroups.com"}, gotTo)
+
+	msg, err := netmail.ReadMessage(bytes.NewReader(gotMsg))
+	require.Nil(t, err)
+	require.Equal(t, `"Release Manager" <rm@example.com>`, msg.Header.Get("From"))
+	require.Equal(t,
+		`"kubernetes-announce-test" <kubernetes-announce-test@googlegroups.com>`,
+		msg.Header.Get("To"),
+	)
+	require.Equal(t, "Kubernetes v1.21.1 is live!", msg.Header.Get("Subject"))
+	require.Equal(t, `text/html; charset="utf-8"`, msg.Header.Get("Content-Type"))
+	_, err = msg.Header.Date()
+	require.Nil(t, err)
+
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	require.Nil(t, err)
+	require.Equal(t, content, string(body))
+	for _, line := range strings.Split(string(gotMsg), "\r\n") {
+		require.LessOrEqual(t, len(line), 78)
+	}
+}
+
+func TestSMTPSendClientFailure(t *testing.T) {
+	// Given
+	_, err := mail.NewSMTPSendClient("smtp.example.com", "", "")
+	require.NotNil(t, err)
+
+	client, err := mail.NewSMTPSendClient("localhost:25", "", "")
+	require.Nil(t, err)
+	client.SetSendMail(func(string, smtp.Auth, string, []string, []byte) error {
+		return errors.New("connection refused")
+	})
+	sender := mail.NewSender("")
+	sender.SetSendClient(client)
+
+	// When
+	err = sender.Send("content", "subject")
+
+	// Then
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "sender is not set")
+
+	// Given
+	require.Nil(t, sender.SetSender("", "rm@example.com"))
+
+	// When
+	err = sender.Send("content", "subject")
+
+	// Then
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "recipients are not set")
+
+	// Given
+	require.Nil(t, sender.SetRecipients("", "dev@example.com"))
+
+	// When
+	err = sender.Send("content", "subject")
+
+	// Then
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "connection refused")
+}
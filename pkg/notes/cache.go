@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// commitCacheEntry contains the pull requests of a commit. An empty list
+// records that the commit has no pull request.
+type commitCacheEntry struct {
+	PullRequests []*gogithub.PullRequest `json:"pullRequests"`
+}
+
+// cachedPRsFromCommit returns the pull requests of a commit like
+// prsFromCommit, but serves them from the on-disk cache if enabled. Cache
+// entries do not expire, which means that edits of already cached pull
+// requests are only picked up after removing the cache directory.
+func (g *Gatherer) cachedPRsFromCommit(commit *gogithub.RepositoryCommit) (
+	[]*gogithub.PullRequest, error,
+) {
+	if g.options.CacheDir == "" {
+		return g.prsFromCommit(commit)
+	}
+
+	path := filepath.Join(
+		g.options.CacheDir, g.options.GithubOrg, g.options.GithubRepo,
+		commit.GetSHA()+".json",
+	)
+	entry, err := readCommitCacheEntry(path)
+	if err != nil {
+		logrus.Debugf("Ignoring cached pull requests of commit %s: %v", commit.GetSHA(), err)
+	}
+	if entry != nil {
+		logrus.Debugf("Using cached pull requests of commit %s", commit.GetSHA())
+		if len(entry.PullRequests) == 0 {
+			return nil, errNoPRFoundForCommitSHA
+		}
+		return entry.PullRequests, nil
+	}
+
+	prs, err := g.prsFromCommit(commit)
+	if err != nil && err != errNoPRIDFoundInCommitMessage && err != errNoPRFoundForCommitSHA {
+		return nil, err
+	}
+	if err := writeCommitCacheEntry(
+		path, &commitCacheEntry{PullRequests: prs},
+	); err != nil {
+		logrus.Warnf("Unable to cache pull requests of commit %s: %v", commit.GetSHA(), err)
+	}
+	return prs, err
+}
+
+// readCommitCacheEntry reads the cache entry at path, which is nil if
+// nothing has been cached yet.
+func readCommitCacheEntry(path string) (*commitCacheEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry := &commitCacheEntry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		return nil, errors.Wrap(err, "decoding cache entry")
+	}
+	return entry, nil
+}
+
+// writeCommitCacheEntry writes the entry to path. The entry gets renamed
+// into place, because commits are processed in parallel.
+func writeCommitCacheEntry(path string, entry *commitCacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "encoding cache entry")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)); err != nil {
+		return errors.Wrap(err, "creating cache directory")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "creating cache entry")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "writing cache entry")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "closing cache entry")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "renaming cache entry")
+}
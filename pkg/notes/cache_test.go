@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/github/githubfakes"
+)
+
+func TestCachedPRsFromCommit(t *testing.T) {
+	// Given
+	cacheDir, err := os.MkdirTemp("", "notes-cache-")
+	require.Nil(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	client := &githubfakes.FakeClient{}
+	client.GetPullRequestReturns(&github.PullRequest{
+		Number: github.Int(1),
+		Body:   github.String("```release-note\nA note\n```"),
+	}, nil, nil)
+	client.ListPullRequestsWithCommitReturns(nil, &github.Response{}, nil)
+
+	sut := NewGathererWithClient(context.Background(), client)
+	sut.options.CacheDir = cacheDir
+
+	withPR := &github.RepositoryCommit{
+		SHA:    github.String("1"),
+		Commit: &github.Commit{Message: github.String("Merge pull request #1 from user/branch")},
+	}
+	withoutPR := &github.RepositoryCommit{
+		SHA:    github.String("2"),
+		Commit: &github.Commit{Message: github.String("Update CHANGELOG")},
+	}
+
+	for i := 0; i < 2; i++ {
+		// When
+		prs, err := sut.cachedPRsFromCommit(withPR)
+
+		// Then
+		require.Nil(t, err)
+		require.Len(t, prs, 1)
+		require.Equal(t, 1, prs[0].GetNumber())
+		require.Equal(t, "```release-note\nA note\n```", prs[0].GetBody())
+
+		// When
+		_, err = sut.cachedPRsFromCommit(withoutPR)
+
+		// Then
+		require.Equal(t, errNoPRFoundForCommitSHA, err)
+	}
+	require.Equal(t, 1, client.GetPullRequestCallCount())
+	require.Equal(t, 1, client.ListPullRequestsWithCommitCallCount())
+	require.FileExists(t, filepath.Join(
+		cacheDir, sut.options.GithubOrg, sut.options.GithubRepo, "1.json",
+	))
+}
+
+func TestCachedPRsFromCommitDisabled(t *testing.T) {
+	// Given
+	client := &githubfakes.FakeClient{}
+	client.GetPullRequestReturns(&github.PullRequest{Number: github.Int(1)}, nil, nil)
+	sut := NewGathererWithClient(context.Background(), client)
+	commit := &github.RepositoryCommit{
+		SHA:    github.String("1"),
+		Commit: &github.Commit{Message: github.String("Merge pull request #1 from user/branch")},
+	}
+
+	for i := 0; i < 2; i++ {
+		// When
+		prs, err := sut.cachedPRsFromCommit(commit)
+
+		// Then
+		require.Nil(t, err)
+		require.Len(t, prs, 1)
+	}
+	require.Equal(t, 2, client.GetPullRequestCallCount())
+}
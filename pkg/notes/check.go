@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/nozzle/throttler"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// MissingNoteReason describes why a pull request has no usable release note.
+type MissingNoteReason string
+
+const (
+	// MissingNoteReasonNoBlock is used if the pull request description does
+	// not contain a release-note block at all.
+	MissingNoteReasonNoBlock MissingNoteReason = "No release-note block"
+
+	// MissingNoteReasonNoneUserFacing is used if the release note is "NONE",
+	// but the pull request has labels indicating a user facing change.
+	MissingNoteReasonNoneUserFacing MissingNoteReason = `Release note is "NONE" despite user facing labels`
+
+	// MissingNoteReasonMalformed is used if the release-note block exists,
+	// but no note text can be parsed from it. Empty blocks are treated like
+	// "NONE".
+	MissingNoteReasonMalformed MissingNoteReason = "Malformed release-note block"
+)
+
+// missingNoteReasons are all reasons in the order of the report.
+var missingNoteReasons = []MissingNoteReason{
+	MissingNoteReasonNoBlock,
+	MissingNoteReasonNoneUserFacing,
+	MissingNoteReasonMalformed,
+}
+
+// userFacingLabels are the pull request labels which require a release note.
+var userFacingLabels = []string{
+	"kind/api-change",
+	"kind/deprecation",
+	"kind/feature",
+	"kind/regression",
+	"release-note-action-required",
+}
+
+// releaseNoteBlockRegex matches the start of a release-note block.
+var releaseNoteBlockRegex = regexp.MustCompile("```(dev-)?release-note[s]?")
+
+// MissingNote is a merged pull request without a usable release note.
+type MissingNote struct {
+	PrNumber int               `json:"pr_number"`
+	PrURL    string            `json:"pr_url"`
+	Title    string            `json:"title"`
+	Author   string            `json:"author"`
+	Reason   MissingNoteReason `json:"reason"`
+
+	// Labels are the user facing labels of pull requests with a "NONE"
+	// release note.
+	Labels []string `json:"labels,omitempty"`
+}
+
+// ListMissingReleaseNotes returns the pull requests merged between the start
+// and end SHA, whose release note is missing, "NONE" despite user facing
+// labels or malformed. The result is sorted by the pull request number.
+func (g *Gatherer) ListMissingReleaseNotes() ([]*MissingNote, error) {
+	commits, err := g.listCommits(g.options.Branch, g.options.StartSHA, g.options.EndSHA)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing commits")
+	}
+	return g.missingNotesForCommits(commits)
+}
+
+// missingNotesForCommits checks the pull requests of all commits for missing
+// release notes.
+func (g *Gatherer) missingNotesForCommits(
+	commits []*gogithub.RepositoryCommit,
+) ([]*MissingNote, error) {
+	var (
+		mu  sync.Mutex
+		prs = map[int]*gogithub.PullRequest{}
+	)
+	t := throttler.New(maxParallelRequests, len(commits))
+
+	prsForCommit := func(commit *gogithub.RepositoryCommit) {
+		res, err := g.cachedPRsFromCommit(commit)
+		if err == errNoPRIDFoundInCommitMessage || err == errNoPRFoundForCommitSHA {
+			logrus.Debugf("No PR found for commit %s", commit.GetSHA())
+			err = nil
+		}
+		mu.Lock()
+		for _, pr := range res {
+			prs[pr.GetNumber()] = pr
+		}
+		mu.Unlock()
+		t.Done(err)
+	}
+
+	for i, commit := range commits {
+		logrus.Infof(
+			"Checking commit %d of %d (%0.2f%%): %s",
+			i+1, len(commits), (float64(i+1)/float64(len(commits)))*100.0,
+			commit.GetSHA(),
+		)
+		if g.options.ReplayDir == "" {
+			go prsForCommit(commit)
+		} else {
+			// Ensure the same order like recorded
+			prsForCommit(commit)
+		}
+		if t.Throttle() > 0 {
+			break
+		}
+	}
+	if err := t.Err(); err != nil {
+		return nil, err
+	}
+
+	missing := []*MissingNote{}
+	for _, pr := range prs {
+		if note := missingNoteForPR(pr); note != nil {
+			missing = append(missing, note)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].PrNumber < missing[j].PrNumber
+	})
+	return missing, nil
+}
+
+// missingNoteForPR returns nil if the pull request has a usable release
+// note or a "NONE" note without user facing labels.
+func missingNoteForPR(pr *gogithub.PullRequest) *MissingNote {
+	note := &MissingNote{
+		PrNumber: pr.GetNumber(),
+		PrURL:    pr.GetHTMLURL(),
+		Title:    pr.GetTitle(),
+		Author:   pr.GetUser().GetLogin(),
+	}
+
+	body := pr.GetBody()
+	switch {
+	case MatchesExcludeFilter(body) || labelExactMatch(pr, "release-note-none"):
+		for _, label := range userFacingLabels {
+			if labelExactMatch(pr, label) {
+				note.Labels = append(note.Labels, label)
+			}
+		}
+		if len(note.Labels) == 0 {
+			return nil
+		}
+		note.Reason = MissingNoteReasonNoneUserFacing
+
+	case !releaseNoteBlockRegex.MatchString(body):
+		note.Reason = MissingNoteReasonNoBlock
+
+	default:
+		if text, err := noteTextFromString(body); err == nil && text != "" {
+			return nil
+		}
+		note.Reason = MissingNoteReasonMalformed
+	}
+	return note
+}
+
+// MissingNotesReport renders the missing release notes as markdown checklist
+// grouped by their reason, which mentions the authors of the pull requests.
+func MissingNotesReport(missing []*MissingNote) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# Pull requests without a usable release note (%d)\n", len(missing))
+	for _, reason := range missingNoteReasons {
+		group := []*MissingNote{}
+		for _, note := range missing {
+			if note.Reason == reason {
+				group = append(group, note)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(b, "\n## %s (%d)\n\n", reason, len(group))
+		for _, note := range group {
+			fmt.Fprintf(b, "- [ ] [#%d](%s) %s (@%s)", note.PrNumber, note.PrURL, note.Title, note.Author)
+			if len(note.Labels) > 0 {
+				fmt.Fprintf(b, " `%s`", strings.Join(note.Labels, "`, `"))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/github/githubfakes"
+)
+
+func TestMissingNotesForCommits(t *testing.T) {
+	// Given
+	prs := map[int]*github.PullRequest{
+		// Valid release note
+		1: {Body: github.String("```release-note\nAdded a flag\n```")},
+		// NONE without user facing labels
+		2: {Body: github.String("```release-note\nNONE\n```")},
+		// NONE with user facing labels
+		3: {
+			Body: github.String("```release-note\nNONE\n```"),
+			Labels: []*github.Label{
+				{Name: github.String("kind/feature")},
+				{Name: github.String("sig/node")},
+				{Name: github.String("kind/api-change")},
+			},
+		},
+		// No release note block
+		4: {Body: github.String("Fixes a typo")},
+		// Unterminated release note block
+		5: {Body: github.String("```release-note\r\nAdded a flag")},
+	}
+	client := &githubfakes.FakeClient{}
+	client.GetPullRequestStub = func(
+		_ context.Context, _, _ string, number int,
+	) (*github.PullRequest, *github.Response, error) {
+		pr := prs[number]
+		pr.Number = github.Int(number)
+		pr.Title = github.String(fmt.Sprintf("PR %d", number))
+		pr.HTMLURL = github.String(fmt.Sprintf("https://github.com/kubernetes/kubernetes/pull/%d", number))
+		pr.User = &github.User{Login: github.String("author")}
+		return pr, nil, nil
+	}
+
+	commits := []*github.RepositoryCommit{}
+	for i := 5; i > 0; i-- {
+		commits = append(commits, &github.RepositoryCommit{
+			SHA: github.String(fmt.Sprint(i)),
+			Commit: &github.Commit{Message: github.String(
+				fmt.Sprintf("Merge pull request #%d from user/branch", i),
+			)},
+		})
+	}
+	sut := NewGathererWithClient(context.Background(), client)
+
+	// When
+	res, err := sut.missingNotesForCommits(commits)
+
+	// Then
+	require.Nil(t, err)
+	require.Len(t, res, 3)
+	require.Equal(t, 3, res[0].PrNumber)
+	require.Equal(t, MissingNoteReasonNoneUserFacing, res[0].Reason)
+	require.Equal(t, []string{"kind/api-change", "kind/feature"}, res[0].Labels)
+	require.Equal(t, 4, res[1].PrNumber)
+	require.Equal(t, MissingNoteReasonNoBlock, res[1].Reason)
+	require.Equal(t, 5, res[2].PrNumber)
+	require.Equal(t, MissingNoteReasonMalformed, res[2].Reason)
+
+	// When
+	report := MissingNotesReport(res)
+
+	// Then
+	require.Equal(t, "# Pull requests without a usable release note (3)\n"+
+		"\n## No release-note block (1)\n\n"+
+		"- [ ] [#4](https://github.com/kubernetes/kubernetes/pull/4) PR 4 (@author)\n"+
+		"\n## Release note is \"NONE\" despite user facing labels (1)\n\n"+
+		"- [ ] [#3](https://github.com/kubernetes/kubernetes/pull/3) PR 3 (@author) `kind/api-change`, `kind/feature`\n"+
+		"\n## Malformed release-note block (1)\n\n"+
+		"- [ ] [#5](https://github.com/kubernetes/kubernetes/pull/5) PR 5 (@author)\n",
+		report,
+	)
+}
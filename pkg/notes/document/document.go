@@ -39,6 +39,7 @@ import (
 type Document struct {
 	NotesWithActionRequired notes.Notes    `json:"action_required"`
 	Notes                   NoteCollection `json:"notes"`
+	NotesBySIG              SIGCollection  `json:"notes_by_sig"`
 	Downloads               *FileMetadata  `json:"downloads"`
 	CurrentRevision         string         `json:"release_tag"`
 	PreviousRevision        string
@@ -138,6 +139,28 @@ type NoteCategory struct {
 // NoteCollection is a collection of note categories.
 type NoteCollection []NoteCategory
 
+// SIGCategory groups the notes of a SIG, where an empty SIG groups the notes
+// without any SIG label.
+type SIGCategory struct {
+	SIG         string
+	NoteEntries *notes.Notes
+}
+
+// SIGCollection is a collection of SIG categories.
+type SIGCollection []SIGCategory
+
+// Sort sorts the collection by SIG name, followed by the notes without any
+// SIG label.
+func (s *SIGCollection) Sort() {
+	sigSlice := (*s)
+	sort.Slice(sigSlice, func(i, j int) bool {
+		if sigSlice[i].SIG == "" || sigSlice[j].SIG == "" {
+			return sigSlice[j].SIG == ""
+		}
+		return sigSlice[i].SIG < sigSlice[j].SIG
+	})
+}
+
 // Sort sorts the collection by priority order.
 func (n *NoteCollection) Sort(kindPriority []notes.Kind) {
 	indexOf := func(kind notes.Kind) int {
@@ -214,6 +237,22 @@ func New(
 	}
 
 	kindCategory := make(map[notes.Kind]NoteCategory)
+	addKind := func(kind notes.Kind, note string) {
+		if existing, ok := kindCategory[kind]; ok {
+			addNote(existing.NoteEntries, note)
+		} else {
+			kindCategory[kind] = NoteCategory{Kind: kind, NoteEntries: &notes.Notes{note}}
+		}
+	}
+	sigCategory := make(map[string]SIGCategory)
+	addSIG := func(sig, note string) {
+		if existing, ok := sigCategory[sig]; ok {
+			addNote(existing.NoteEntries, note)
+		} else {
+			sigCategory[sig] = SIGCategory{SIG: sig, NoteEntries: &notes.Notes{note}}
+		}
+	}
+
 	for _, pr := range releaseNotes.History() {
 		note := releaseNotes.Get(pr)
 
@@ -240,52 +279,64 @@ func New(
 			continue
 		}
 
+		markdown := processNote(note.Markdown)
+		if note.ActionRequired && !note.DuplicateKind {
+			doc.NotesWithActionRequired = append(doc.NotesWithActionRequired, markdown)
+			continue
+		}
+
 		// TODO: Refactor the logic here and add testing.
 		if note.DuplicateKind {
-			kind := mapKind(highestPriorityKind(note.Kinds))
-			if existing, ok := kindCategory[kind]; ok {
-				*existing.NoteEntries = append(*existing.NoteEntries, processNote(note.Markdown))
-			} else {
-				kindCategory[kind] = NoteCategory{Kind: kind, NoteEntries: &notes.Notes{processNote(note.Markdown)}}
-			}
-		} else if note.ActionRequired {
-			doc.NotesWithActionRequired = append(doc.NotesWithActionRequired, processNote(note.Markdown))
+			addKind(mapKind(highestPriorityKind(note.Kinds)), markdown)
 		} else {
 			for _, kind := range note.Kinds {
-				mappedKind := mapKind(notes.Kind(kind))
-
-				if existing, ok := kindCategory[mappedKind]; ok {
-					*existing.NoteEntries = append(*existing.NoteEntries, processNote(note.Markdown))
-				} else {
-					kindCategory[mappedKind] = NoteCategory{Kind: mappedKind, NoteEntries: &notes.Notes{processNote(note.Markdown)}}
-				}
+				addKind(mapKind(notes.Kind(kind)), markdown)
 			}
 
 			if len(note.Kinds) == 0 {
 				// the note has not been categorized so far
-				kind := notes.KindUncategorized
-				if existing, ok := kindCategory[kind]; ok {
-					*existing.NoteEntries = append(*existing.NoteEntries, processNote(note.Markdown))
-				} else {
-					kindCategory[kind] = NoteCategory{Kind: kind, NoteEntries: &notes.Notes{processNote(note.Markdown)}}
-				}
+				addKind(notes.KindUncategorized, markdown)
 			}
 		}
+
+		for _, sig := range note.SIGs {
+			addSIG(sig, markdown)
+		}
+		if len(note.SIGs) == 0 {
+			addSIG("", markdown)
+		}
 	}
 
 	for _, category := range kindCategory {
 		sort.Strings(*category.NoteEntries)
 		doc.Notes = append(doc.Notes, category)
 	}
+	for _, category := range sigCategory {
+		sort.Strings(*category.NoteEntries)
+		doc.NotesBySIG = append(doc.NotesBySIG, category)
+	}
 
 	doc.Notes.Sort(kindPriority)
+	doc.NotesBySIG.Sort()
 	sort.Strings(doc.NotesWithActionRequired)
 	return doc, nil
 }
 
+// addNote adds the note to the entries if they do not contain it already,
+// which happens for notes with multiple kinds mapping to the same kind.
+func addNote(entries *notes.Notes, note string) {
+	for _, entry := range *entries {
+		if entry == note {
+			return
+		}
+	}
+	*entries = append(*entries, note)
+}
+
 // RenderMarkdownTemplate renders a document using the golang template in
 // `templateSpec`. If `templateSpec` is set to `options.GoTemplateDefault`,
-// then it renders in the default template markdown format.
+// then it renders in the default template markdown format, whereas
+// `options.GoTemplateSIG` groups the changes by SIG instead of kind.
 func (d *Document) RenderMarkdownTemplate(bucket, fileDir, templateSpec string) (string, error) {
 	urlPrefix := release.URLPrefixForBucket(bucket)
 
@@ -300,7 +351,7 @@ func (d *Document) RenderMarkdownTemplate(bucket, fileDir, templateSpec string)
 		return "", errors.Wrap(err, "fetching template")
 	}
 	tmpl, err := template.New("markdown").
-		Funcs(template.FuncMap{"prettyKind": prettyKind}).
+		Funcs(template.FuncMap{"prettyKind": prettyKind, "prettySIG": prettySIG}).
 		Parse(goTemplate)
 	if err != nil {
 		return "", errors.Wrap(err, "parsing template")
@@ -313,19 +364,23 @@ func (d *Document) RenderMarkdownTemplate(bucket, fileDir, templateSpec string)
 	return strings.TrimSpace(s.String()), nil
 }
 
-// template returns either the default template, a template from file or an
+// template returns either a built-in template, a template from file or an
 // inline string template. The `templateSpec` must be in the format of
-// `go-template:{default|path/to/template.ext}` or
+// `go-template:{default|sig|path/to/template.ext}` or
 // `go-template:inline:string`
 func (d *Document) template(templateSpec string) (string, error) {
-	if templateSpec == options.GoTemplateDefault {
+	switch templateSpec {
+	case options.GoTemplateDefault:
 		return defaultReleaseNotesTemplate, nil
+	case options.GoTemplateSIG:
+		return sigReleaseNotesTemplate, nil
 	}
 
 	if !strings.HasPrefix(templateSpec, options.GoTemplatePrefix) {
 		return "", errors.Errorf(
-			"bad template format: expected %q, %q or %q. Got: %q",
+			"bad template format: expected %q, %q, %q or %q. Got: %q",
 			options.GoTemplateDefault,
+			options.GoTemplateSIG,
 			options.GoTemplatePrefix+"<file.template>",
 			options.GoTemplateInline+"<template>",
 			templateSpec,
@@ -438,3 +493,11 @@ func prettyKind(kind notes.Kind) string {
 	}
 	return strings.Title(string(kind))
 }
+
+// prettySIG returns the heading of the notes of a SIG.
+func prettySIG(sig string) string {
+	if sig == "" {
+		return prettyKind(notes.KindUncategorized)
+	}
+	return "SIG " + notes.PrettySIG(sig)
+}
@@ -277,6 +277,9 @@ func TestNew(t *testing.T) {
 						NoteEntries: &notes.Notes{"No one gave me a kind"},
 					},
 				},
+				NotesBySIG: SIGCollection{
+					SIGCategory{NoteEntries: &notes.Notes{"No one gave me a kind"}},
+				},
 			},
 		},
 		{
@@ -296,6 +299,9 @@ func TestNew(t *testing.T) {
 						NoteEntries: &notes.Notes{"A", "B", "C"},
 					},
 				},
+				NotesBySIG: SIGCollection{
+					SIGCategory{NoteEntries: &notes.Notes{"A", "B", "C"}},
+				},
 			},
 		},
 		{
@@ -323,6 +329,9 @@ func TestNew(t *testing.T) {
 						NoteEntries: &notes.Notes{"C"},
 					},
 				},
+				NotesBySIG: SIGCollection{
+					SIGCategory{NoteEntries: &notes.Notes{"A", "B", "C"}},
+				},
 			},
 		},
 		{
@@ -350,6 +359,17 @@ func TestNew(t *testing.T) {
 						},
 					},
 				},
+				NotesBySIG: SIGCollection{
+					SIGCategory{
+						NoteEntries: &notes.Notes{
+							"--someflag",
+							"double dash",
+							"double star",
+							"single dash",
+							"single star",
+						},
+					},
+				},
 			},
 		},
 		{
@@ -374,6 +394,9 @@ func TestNew(t *testing.T) {
 						NoteEntries: &notes.Notes{"A duplicate note gets the highest priority kind found"},
 					},
 				},
+				NotesBySIG: SIGCollection{
+					SIGCategory{NoteEntries: &notes.Notes{"A duplicate note gets the highest priority kind found"}},
+				},
 			},
 		},
 		{
@@ -398,6 +421,9 @@ func TestNew(t *testing.T) {
 						NoteEntries: &notes.Notes{"This note should not appear as a regular note."},
 					},
 				},
+				NotesBySIG: SIGCollection{
+					SIGCategory{NoteEntries: &notes.Notes{"This note should not appear as a regular note."}},
+				},
 			},
 		},
 		{
@@ -416,6 +442,64 @@ func TestNew(t *testing.T) {
 						NoteEntries: &notes.Notes{"PR#1", "PR#2"},
 					},
 				},
+				NotesBySIG: SIGCollection{
+					SIGCategory{NoteEntries: &notes.Notes{"PR#1", "PR#2"}},
+				},
+			},
+		},
+		{
+			"notes mapping to the same kind are not duplicated",
+			func() *notes.ReleaseNotes {
+				n := notes.NewReleaseNotes()
+				note := makeReleaseNote(notes.KindBug, "PR#1")
+				note.Kinds = append(note.Kinds, string(notes.KindRegression))
+				n.Set(0, note)
+				return n
+			},
+			&Document{
+				NotesWithActionRequired: notes.Notes{},
+				Notes: NoteCollection{
+					NoteCategory{
+						Kind:        notes.KindBug,
+						NoteEntries: &notes.Notes{"PR#1"},
+					},
+				},
+				NotesBySIG: SIGCollection{
+					SIGCategory{NoteEntries: &notes.Notes{"PR#1"}},
+				},
+			},
+		},
+		{
+			"notes are grouped by SIG",
+			func() *notes.ReleaseNotes {
+				n := notes.NewReleaseNotes()
+				node := makeReleaseNote(notes.KindBug, "B")
+				node.SIGs = []string{"node", "api-machinery"}
+				n.Set(0, node)
+				apps := makeReleaseNote(notes.KindFeature, "A")
+				apps.SIGs = []string{"apps"}
+				n.Set(1, apps)
+				n.Set(2, makeReleaseNote(notes.KindFeature, "C"))
+				return n
+			},
+			&Document{
+				NotesWithActionRequired: notes.Notes{},
+				Notes: NoteCollection{
+					NoteCategory{
+						Kind:        notes.KindFeature,
+						NoteEntries: &notes.Notes{"A", "C"},
+					},
+					NoteCategory{
+						Kind:        notes.KindBug,
+						NoteEntries: &notes.Notes{"B"},
+					},
+				},
+				NotesBySIG: SIGCollection{
+					SIGCategory{SIG: "api-machinery", NoteEntries: &notes.Notes{"B"}},
+					SIGCategory{SIG: "apps", NoteEntries: &notes.Notes{"A"}},
+					SIGCategory{SIG: "node", NoteEntries: &notes.Notes{"B"}},
+					SIGCategory{NoteEntries: &notes.Notes{"C"}},
+				},
 			},
 		},
 	}
@@ -457,18 +541,29 @@ func TestDocument_RenderMarkdownTemplate(t *testing.T) {
 			true,
 			"document.md.golden",
 		},
+		{
+			"render SIG template and no downloads",
+			options.GoTemplateSIG,
+			false,
+			false,
+			"document_by_sig.md.golden",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Given
 			testNotes := notes.NewReleaseNotes()
 			testNotes.Set(0, makeReleaseNote(notes.KindDeprecation, "Deprecation #1."))
-			testNotes.Set(1, makeReleaseNote(notes.KindBug, "Bugfix."))
+			bugfix := makeReleaseNote(notes.KindBug, "Bugfix.")
+			bugfix.SIGs = []string{"node", "cli"}
+			testNotes.Set(1, bugfix)
 			testNotes.Set(2, makeReleaseNote(notes.KindCleanup, "Clean up."))
 			testNotes.Set(3, makeReleaseNote(notes.KindDesign, "Design change."))
 			testNotes.Set(4, makeReleaseNote(notes.KindDocumentation, "Update docs."))
 			testNotes.Set(5, makeReleaseNote(notes.KindFailingTest, "Fix a failing test."))
-			testNotes.Set(6, makeReleaseNote(notes.KindFeature, "A feature."))
+			feature := makeReleaseNote(notes.KindFeature, "A feature.")
+			feature.SIGs = []string{"api-machinery"}
+			testNotes.Set(6, feature)
 			testNotes.Set(7, makeReleaseNote(notes.KindFlake, "Fix a flakey test."))
 			testNotes.Set(8, makeReleaseNote("", "Uncategorized note."))
 			testNotes.Set(9, makeReleaseNote(notes.KindBug, "- This note was prepended with a dash (-) initially."))
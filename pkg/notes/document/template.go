@@ -19,7 +19,33 @@ package document
 // defaultReleaseNotesTemplate is the text template for the default release notes.
 // k8s/release/cmd/release-notes uses text/template to render markdown
 // templates.
-const defaultReleaseNotesTemplate = `
+const defaultReleaseNotesTemplate = releaseNotesHeaderTemplate + `
+{{- if .Notes -}}
+## Changes by Kind
+{{ range .Notes}}
+### {{.Kind | prettyKind}}
+
+{{range $note := .NoteEntries }}{{println "-" $note}}{{end}}
+{{- end -}}
+{{- end -}}
+`
+
+// sigReleaseNotesTemplate is the text template for release notes which group
+// the changes by SIG instead of kind.
+const sigReleaseNotesTemplate = releaseNotesHeaderTemplate + `
+{{- if .NotesBySIG -}}
+## Changes by SIG
+{{ range .NotesBySIG}}
+### {{.SIG | prettySIG}}
+
+{{range $note := .NoteEntries }}{{println "-" $note}}{{end}}
+{{- end -}}
+{{- end -}}
+`
+
+// releaseNotesHeaderTemplate is the common part of the built-in templates,
+// containing the downloads, security information and urgent upgrade notes.
+const releaseNotesHeaderTemplate = `
 {{- $CurrentRevision := .CurrentRevision -}}
 {{- $PreviousRevision := .PreviousRevision -}}
 
@@ -86,12 +112,4 @@ This release contains changes that address the following vulnerabilities:
 {{range .}}{{println "-" .}} {{end}}
 {{end}}
 
-{{- if .Notes -}}
-## Changes by Kind
-{{ range .Notes}}
-### {{.Kind | prettyKind}}
-
-{{range $note := .NoteEntries }}{{println "-" $note}}{{end}}
-{{- end -}}
-{{- end -}}
 `
@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"strings"
+
+	"k8s.io/release/pkg/notes/options"
+)
+
+// Filter returns the release notes matching the filter, keeping the order of
+// the history. The label filter only matches gathered notes, because the
+// labels of a PR are not part of the serialized notes.
+func (r *ReleaseNotes) Filter(filter *options.NotesFilter) *ReleaseNotes {
+	res := NewReleaseNotes()
+	for _, pr := range r.history {
+		if note := r.byPR[pr]; note != nil && note.MatchesFilter(filter) {
+			res.Set(pr, note)
+		}
+	}
+	return res
+}
+
+// MatchesFilter returns true if the note matches all non-empty fields of the
+// filter.
+func (rn *ReleaseNote) MatchesFilter(filter *options.NotesFilter) bool {
+	return matchesAny(filter.SIGs, rn.SIGs, "sig/") &&
+		matchesAny(filter.Kinds, rn.Kinds, "kind/") &&
+		matchesAny(filter.Areas, rn.Areas, "area/") &&
+		matchesAny(filter.Authors, []string{rn.Author}, "@") &&
+		matchesAny(filter.Labels, rn.labels, "")
+}
+
+// matchesAny returns true if wanted is empty or if any of its values is part
+// of values. The prefix is optional for the wanted values.
+func matchesAny(wanted, values []string, prefix string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, w := range wanted {
+		w = strings.TrimPrefix(strings.ToLower(w), prefix)
+		for _, v := range values {
+			if w == strings.ToLower(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
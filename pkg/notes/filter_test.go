@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes/options"
+)
+
+func TestFilter(t *testing.T) {
+	// Given
+	releaseNotes := NewReleaseNotes()
+	releaseNotes.Set(3, &ReleaseNote{
+		PrNumber: 3, Author: "alice", SIGs: []string{"node"},
+		Kinds: []string{"bug"}, Areas: []string{"kubelet"},
+		labels: []string{"sig/node", "kind/bug", "area/kubelet"},
+	})
+	releaseNotes.Set(1, &ReleaseNote{
+		PrNumber: 1, Author: "bob", SIGs: []string{"api-machinery", "node"},
+		Kinds:  []string{"feature"},
+		labels: []string{"sig/api-machinery", "sig/node", "kind/feature", "release-note-action-required"},
+	})
+	releaseNotes.Set(2, &ReleaseNote{
+		PrNumber: 2, Author: "alice", SIGs: []string{"cli"},
+		Kinds: []string{"feature"},
+	})
+
+	for _, tc := range []struct {
+		filter   options.NotesFilter
+		expected ReleaseNotesHistory
+	}{
+		{
+			filter:   options.NotesFilter{},
+			expected: ReleaseNotesHistory{3, 1, 2},
+		},
+		{
+			filter:   options.NotesFilter{SIGs: []string{"sig/Node"}},
+			expected: ReleaseNotesHistory{3, 1},
+		},
+		{
+			filter:   options.NotesFilter{SIGs: []string{"node", "cli"}, Kinds: []string{"feature"}},
+			expected: ReleaseNotesHistory{1, 2},
+		},
+		{
+			filter:   options.NotesFilter{Areas: []string{"area/kubelet"}},
+			expected: ReleaseNotesHistory{3},
+		},
+		{
+			filter:   options.NotesFilter{Authors: []string{"@alice"}},
+			expected: ReleaseNotesHistory{3, 2},
+		},
+		{
+			filter:   options.NotesFilter{Labels: []string{"release-note-action-required"}},
+			expected: ReleaseNotesHistory{1},
+		},
+		{
+			filter:   options.NotesFilter{Authors: []string{"bob"}, Kinds: []string{"bug"}},
+			expected: nil,
+		},
+	} {
+		// When
+		res := releaseNotes.Filter(&tc.filter)
+
+		// Then
+		require.Equal(t, tc.expected, res.History())
+		require.Len(t, res.ByPR(), len(tc.expected))
+	}
+}
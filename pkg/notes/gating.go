@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/notes/options"
+)
+
+// releaseNoteLabels are the labels of which a pull request needs at least one
+// to be part of a release cut.
+var releaseNoteLabels = []string{
+	"release-note",
+	"release-note-action-required",
+	"release-note-none",
+}
+
+// GateReport is the result of checking the pull requests of a release range
+// for their milestone and labels.
+type GateReport struct {
+	// Milestone is the milestone all pull requests are expected to have.
+	Milestone string `json:"milestone"`
+
+	// PullRequests is the number of checked pull requests.
+	PullRequests int `json:"pullRequests"`
+
+	// Violations are the pull requests not passing the checks, ordered by
+	// their number.
+	Violations []*GateViolation `json:"violations"`
+}
+
+// GateViolation is a pull request which does not pass the gating checks.
+type GateViolation struct {
+	Number   int      `json:"number"`
+	Title    string   `json:"title"`
+	URL      string   `json:"url"`
+	Problems []string `json:"problems"`
+}
+
+// Blocking returns true if the report contains any violation, which means
+// that the release cut must not proceed.
+func (r *GateReport) Blocking() bool {
+	return len(r.Violations) > 0
+}
+
+// Err returns an error listing all violations if the report is blocking,
+// otherwise nil.
+func (r *GateReport) Err() error {
+	if !r.Blocking() {
+		return nil
+	}
+	return errors.Errorf(
+		"%d of %d pull requests do not pass the milestone and label checks:\n%s",
+		len(r.Violations), r.PullRequests, r.String(),
+	)
+}
+
+// String returns the violations as human readable list.
+func (r *GateReport) String() string {
+	b := &strings.Builder{}
+	for _, v := range r.Violations {
+		fmt.Fprintf(b, "- #%d %s: %s\n", v.Number, v.Title, strings.Join(v.Problems, ", "))
+	}
+	return b.String()
+}
+
+// GatePullRequests creates a new gatherer and checks the pull requests of the
+// release range afterwards.
+func GatePullRequests(opts *options.Options, milestone string) (*GateReport, error) {
+	logrus.Info("Checking pull requests for milestone and labels")
+	gatherer, err := NewGatherer(context.Background(), opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving notes gatherer")
+	}
+	return gatherer.GatePullRequests(milestone)
+}
+
+// GatePullRequests verifies that all pull requests merged between the start
+// and end SHA of the options carry the provided milestone as well as a kind,
+// sig and release note label. Commits not belonging to any pull request are
+// skipped.
+func (g *Gatherer) GatePullRequests(milestone string) (*GateReport, error) {
+	if milestone == "" {
+		return nil, errors.New("unable to check pull requests, milestone is empty")
+	}
+
+	commits, err := g.listCommits(g.options.Branch, g.options.StartSHA, g.options.EndSHA)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing commits")
+	}
+
+	report := &GateReport{Milestone: milestone, Violations: []*GateViolation{}}
+	checked := map[int]bool{}
+	for _, commit := range commits {
+		prs, err := g.prsFromCommit(commit)
+		if err != nil {
+			if err == errNoPRFoundForCommitSHA {
+				logrus.Debugf("Skipping commit %s without pull request", commit.GetSHA())
+				continue
+			}
+			return nil, errors.Wrapf(err, "getting pull requests of commit %s", commit.GetSHA())
+		}
+
+		for _, pr := range prs {
+			if checked[pr.GetNumber()] {
+				continue
+			}
+			checked[pr.GetNumber()] = true
+
+			if problems := gateProblems(pr, milestone); len(problems) > 0 {
+				report.Violations = append(report.Violations, &GateViolation{
+					Number:   pr.GetNumber(),
+					Title:    pr.GetTitle(),
+					URL:      pr.GetHTMLURL(),
+					Problems: problems,
+				})
+			}
+		}
+	}
+
+	report.PullRequests = len(checked)
+	sort.Slice(report.Violations, func(i, j int) bool {
+		return report.Violations[i].Number < report.Violations[j].Number
+	})
+	logrus.Infof(
+		"Checked %d pull requests, %d of them do not pass",
+		report.PullRequests, len(report.Violations),
+	)
+	return report, nil
+}
+
+// gateProblems returns the reasons why the pull request does not pass the
+// gating checks.
+func gateProblems(pr *gogithub.PullRequest, milestone string) []string {
+	problems := []string{}
+
+	switch actual := pr.GetMilestone().GetTitle(); actual {
+	case milestone:
+	case "":
+		problems = append(problems, fmt.Sprintf("missing milestone %s", milestone))
+	default:
+		problems = append(problems, fmt.Sprintf("milestone is %s instead of %s", actual, milestone))
+	}
+
+	for _, prefix := range []string{"kind", "sig"} {
+		if len(labelsWithPrefix(pr, prefix)) == 0 {
+			problems = append(problems, fmt.Sprintf("missing %s/* label", prefix))
+		}
+	}
+
+	hasReleaseNoteLabel := false
+	for _, label := range releaseNoteLabels {
+		if labelExactMatch(pr, label) {
+			hasReleaseNoteLabel = true
+			break
+		}
+	}
+	if !hasReleaseNoteLabel {
+		problems = append(problems, "missing release note label")
+	}
+
+	return problems
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/github/githubfakes"
+)
+
+func TestGatePullRequests(t *testing.T) {
+	gatePR := func(number int, milestone string, labels ...string) *github.PullRequest {
+		pr := &github.PullRequest{
+			Number: github.Int(number),
+			Title:  github.String("Some change"),
+		}
+		if milestone != "" {
+			pr.Milestone = &github.Milestone{Title: github.String(milestone)}
+		}
+		for _, label := range labels {
+			pr.Labels = append(pr.Labels, &github.Label{Name: github.String(label)})
+		}
+		return pr
+	}
+	prs := map[int]*github.PullRequest{
+		1: gatePR(1, "v1.22", "kind/bug", "sig/node", "release-note"),
+		2: gatePR(2, "v1.21", "kind/bug", "sig/node", "release-note-none"),
+		3: gatePR(3, "", "sig/node", "release-note-label-needed"),
+	}
+
+	client := &githubfakes.FakeClient{}
+	client.GetCommitReturns(&github.Commit{}, nil, nil)
+	client.ListCommitsReturns([]*github.RepositoryCommit{
+		repoCommit("1", "Merge pull request #1 from user/branch"),
+		repoCommit("2", "Merge pull request #2 from user/branch"),
+		repoCommit("3", "Merge pull request #3 from user/branch"),
+		repoCommit("4", "Update CHANGELOG"),
+		repoCommit("5", "automated-cherry-pick-of-#1 (#1)"),
+	}, &github.Response{}, nil)
+	client.ListPullRequestsWithCommitReturns(nil, &github.Response{}, nil)
+	client.GetPullRequestStub = func(
+		_ context.Context, _, _ string, number int,
+	) (*github.PullRequest, *github.Response, error) {
+		return prs[number], nil, nil
+	}
+
+	sut := NewGathererWithClient(context.Background(), client)
+	report, err := sut.GatePullRequests("v1.22")
+	require.Nil(t, err)
+
+	require.Equal(t, 3, report.PullRequests)
+	require.True(t, report.Blocking())
+	require.Len(t, report.Violations, 2)
+	require.Equal(t, 2, report.Violations[0].Number)
+	require.Equal(t, []string{"milestone is v1.21 instead of v1.22"}, report.Violations[0].Problems)
+	require.Equal(t, 3, report.Violations[1].Number)
+	require.Equal(t, []string{
+		"missing milestone v1.22",
+		"missing kind/* label",
+		"missing release note label",
+	}, report.Violations[1].Problems)
+	require.NotNil(t, report.Err())
+	require.Contains(t, report.Err().Error(), "2 of 3 pull requests")
+
+	_, err = sut.GatePullRequests("")
+	require.NotNil(t, err)
+}
+
+func TestGateReportNotBlocking(t *testing.T) {
+	report := &GateReport{Milestone: "v1.22", PullRequests: 3}
+	require.False(t, report.Blocking())
+	require.Nil(t, report.Err())
+}
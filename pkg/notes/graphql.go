@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"time"
+
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/github"
+)
+
+// graphQLCommitsPerPage is the number of commits retrieved per GraphQL
+// request, which is the maximum allowed by GitHub.
+const graphQLCommitsPerPage = 100
+
+// commitHistoryQuery retrieves a page of the commit history of a branch
+// together with the pull requests of every commit, including everything
+// required to create the release notes.
+const commitHistoryQuery = `query(
+  $owner: String!, $repo: String!, $branch: String!,
+  $since: GitTimestamp!, $until: GitTimestamp!, $first: Int!, $cursor: String
+) {
+  repository(owner: $owner, name: $repo) {
+    ref(qualifiedName: $branch) {
+      target {
+        ... on Commit {
+          history(since: $since, until: $until, first: $first, after: $cursor) {
+            pageInfo {
+              hasNextPage
+              endCursor
+            }
+            nodes {
+              oid
+              message
+              author {
+                user {
+                  login
+                }
+              }
+              associatedPullRequests(first: 5) {
+                nodes {
+` + github.GraphQLPullRequestFields + `
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// graphQLCommitHistory is the result of the commitHistoryQuery.
+type graphQLCommitHistory struct {
+	Repository struct {
+		Ref *struct {
+			Target struct {
+				History struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []*graphQLCommit `json:"nodes"`
+				} `json:"history"`
+			} `json:"target"`
+		} `json:"ref"`
+	} `json:"repository"`
+}
+
+type graphQLCommit struct {
+	OID     string `json:"oid"`
+	Message string `json:"message"`
+	Author  struct {
+		User *struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"author"`
+	AssociatedPullRequests struct {
+		Nodes []*github.GraphQLPullRequest `json:"nodes"`
+	} `json:"associatedPullRequests"`
+}
+
+// gatherNotesGraphQL lists the commits between the start and end SHA and
+// returns the ones having a pull request with a release note. It is the
+// GraphQL equivalent of listCommits and gatherNotes, which retrieves the pull
+// requests of a hundred commits per request instead of requesting them for
+// every single commit.
+func (g *Gatherer) gatherNotesGraphQL(branch, start, end string) ([]*Result, error) {
+	startCommit, _, err := g.client.GetCommit(g.context, g.options.GithubOrg, g.options.GithubRepo, start)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieve start commit")
+	}
+
+	endCommit, _, err := g.client.GetCommit(g.context, g.options.GithubOrg, g.options.GithubRepo, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieve end commit")
+	}
+
+	variables := map[string]interface{}{
+		"owner":  g.options.GithubOrg,
+		"repo":   g.options.GithubRepo,
+		"branch": branch,
+		"since":  startCommit.GetCommitter().GetDate().Format(time.RFC3339),
+		"until":  endCommit.GetCommitter().GetDate().Format(time.RFC3339),
+		"first":  graphQLCommitsPerPage,
+		"cursor": nil,
+	}
+
+	results := []*Result{}
+	for page := 1; ; page++ {
+		history := &graphQLCommitHistory{}
+		if err := g.client.GraphQL(g.context, commitHistoryQuery, variables, history); err != nil {
+			return nil, errors.Wrapf(err, "querying page %d of the commit history", page)
+		}
+		ref := history.Repository.Ref
+		if ref == nil {
+			return nil, errors.Errorf("branch %s does not exist", branch)
+		}
+
+		commits := ref.Target.History.Nodes
+		logrus.Infof("Processing %d commits of page %d", len(commits), page)
+		for _, commit := range commits {
+			if res := commit.result(); res != nil {
+				logrus.Infof("PR #%d seems to contain a release note", res.pullRequest.GetNumber())
+				results = append(results, res)
+			}
+		}
+
+		if !ref.Target.History.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = ref.Target.History.PageInfo.EndCursor
+	}
+	return results, nil
+}
+
+// result returns the result of the first merged pull request of the commit
+// which seems to contain a release note, or nil if there is none.
+func (c *graphQLCommit) result() *Result {
+	for _, pr := range c.AssociatedPullRequests.Nodes {
+		if pr.MergedAt == nil || !MatchesIncludeFilter(pr.Body) {
+			continue
+		}
+
+		commit := &gogithub.RepositoryCommit{
+			SHA: gogithub.String(c.OID),
+			Commit: &gogithub.Commit{
+				SHA:     gogithub.String(c.OID),
+				Message: gogithub.String(c.Message),
+			},
+		}
+		if c.Author.User != nil {
+			commit.Author = &gogithub.User{Login: gogithub.String(c.Author.User.Login)}
+		}
+		return &Result{commit: commit, pullRequest: pr.PullRequest()}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/github/githubfakes"
+)
+
+// commitHistoryPages are the GraphQL responses of two pages of commits.
+var commitHistoryPages = []string{`{
+  "repository": {"ref": {"target": {"history": {
+    "pageInfo": {"hasNextPage": true, "endCursor": "cursor"},
+    "nodes": [
+      {
+        "oid": "1",
+        "message": "Merge pull request #1 from user/branch",
+        "author": {"user": {"login": "k8s-ci-robot"}},
+        "associatedPullRequests": {"nodes": [{
+          "number": 1,
+          "title": "Add a feature",
+          "body": "` + "```release-note\\nAdded a feature\\n```" + `",
+          "url": "https://github.com/kubernetes/kubernetes/pull/1",
+          "mergedAt": "2021-08-01T00:00:00Z",
+          "author": {"login": "user", "url": "https://github.com/user"},
+          "labels": {"nodes": [{"name": "kind/feature"}, {"name": "sig/node"}]}
+        }]}
+      },
+      {
+        "oid": "2",
+        "message": "Update CHANGELOG",
+        "author": {"user": null},
+        "associatedPullRequests": {"nodes": []}
+      }
+    ]
+  }}}}
+}`, `{
+  "repository": {"ref": {"target": {"history": {
+    "pageInfo": {"hasNextPage": false, "endCursor": "end"},
+    "nodes": [
+      {
+        "oid": "3",
+        "message": "Merge pull request #3 from user/branch",
+        "author": {"user": {"login": "k8s-ci-robot"}},
+        "associatedPullRequests": {"nodes": [
+          {
+            "number": 2,
+            "body": "` + "```release-note\\nNot merged\\n```" + `",
+            "mergedAt": null
+          },
+          {
+            "number": 3,
+            "body": "` + "```release-note\\nNONE\\n```" + `",
+            "mergedAt": "2021-08-02T00:00:00Z"
+          }
+        ]}
+      }
+    ]
+  }}}}
+}`}
+
+func newGraphQLClient(pages ...string) *githubfakes.FakeClient {
+	client := &githubfakes.FakeClient{}
+	client.GetCommitReturns(&github.Commit{}, nil, nil)
+	client.GraphQLStub = func(
+		_ context.Context, _ string, _ map[string]interface{}, result interface{},
+	) error {
+		return json.Unmarshal([]byte(pages[client.GraphQLCallCount()-1]), result)
+	}
+	return client
+}
+
+func TestGatherNotesGraphQL(t *testing.T) {
+	client := newGraphQLClient(commitHistoryPages...)
+	gatherer := NewGathererWithClient(context.Background(), client)
+
+	results, err := gatherer.gatherNotesGraphQL("master", "start", "end")
+	require.Nil(t, err)
+	require.Equal(t, 2, client.GetCommitCallCount())
+	require.Equal(t, 2, client.GraphQLCallCount())
+	_, _, variables, _ := client.GraphQLArgsForCall(1)
+	require.Equal(t, "cursor", variables["cursor"])
+	require.Equal(t, "master", variables["branch"])
+
+	// Commits without merged pull requests or release notes are skipped
+	require.Len(t, results, 2)
+	require.Equal(t, "1", results[0].commit.GetSHA())
+	require.Equal(t, "k8s-ci-robot", results[0].commit.GetAuthor().GetLogin())
+	require.Equal(t, 1, results[0].pullRequest.GetNumber())
+	require.Equal(t, "user", results[0].pullRequest.GetUser().GetLogin())
+	require.Equal(t, []string{"feature"}, labelsWithPrefix(results[0].pullRequest, "kind"))
+	require.Equal(t, 3, results[1].pullRequest.GetNumber())
+}
+
+func TestListReleaseNotesGraphQL(t *testing.T) {
+	client := newGraphQLClient(commitHistoryPages...)
+	gatherer := NewGathererWithClient(context.Background(), client)
+	gatherer.options.GraphQL = true
+
+	releaseNotes, err := gatherer.ListReleaseNotes()
+	require.Nil(t, err)
+	require.Equal(t, 0, client.ListCommitsCallCount())
+	require.Equal(t, 0, client.ListPullRequestsWithCommitCallCount())
+
+	// Notes of "NONE" are excluded
+	require.Len(t, releaseNotes.History(), 1)
+	note := releaseNotes.Get(1)
+	require.Equal(t, "Added a feature", note.Text)
+	require.Equal(t, "user", note.Author)
+	require.Equal(t, "https://github.com/kubernetes/kubernetes/pull/1", note.PrURL)
+	require.Equal(t, []string{"node"}, note.SIGs)
+	require.True(t, note.Feature)
+}
+
+func TestGatherNotesGraphQLFailure(t *testing.T) {
+	client := &githubfakes.FakeClient{}
+	client.GetCommitReturns(&github.Commit{}, nil, nil)
+	client.GraphQLReturns(errors.New("error"))
+	gatherer := NewGathererWithClient(context.Background(), client)
+
+	_, err := gatherer.gatherNotesGraphQL("master", "start", "end")
+	require.NotNil(t, err)
+
+	// Missing branches result in no ref
+	client.GraphQLReturns(nil)
+	_, err = gatherer.gatherNotesGraphQL("master", "start", "end")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// JSONSchemaVersion is the version of the JSON release notes schema. It has
+// to be bumped on every incompatible change of ReleaseNotesJSON or the JSON
+// representation of ReleaseNote.
+const JSONSchemaVersion = "v1"
+
+// ReleaseNotesJSON is the versioned JSON representation of the release notes
+// between two revisions, meant to be consumed by external tools.
+type ReleaseNotesJSON struct {
+	// SchemaVersion is the JSON schema version, like v1.
+	SchemaVersion string `json:"schema_version"`
+
+	// PreviousRevision is the start revision of the release notes.
+	PreviousRevision string `json:"previous_revision,omitempty"`
+
+	// CurrentRevision is the end revision of the release notes.
+	CurrentRevision string `json:"current_revision,omitempty"`
+
+	// Notes are the release notes, sorted by their PR number.
+	Notes []*ReleaseNote `json:"notes"`
+}
+
+// JSON returns the versioned JSON representation of the release notes.
+func (r *ReleaseNotes) JSON(previousRevision, currentRevision string) ([]byte, error) {
+	doc := &ReleaseNotesJSON{
+		SchemaVersion:    JSONSchemaVersion,
+		PreviousRevision: previousRevision,
+		CurrentRevision:  currentRevision,
+		Notes:            []*ReleaseNote{},
+	}
+	for _, note := range r.byPR {
+		doc.Notes = append(doc.Notes, note)
+	}
+	sort.Slice(doc.Notes, func(i, j int) bool {
+		return doc.Notes[i].PrNumber < doc.Notes[j].PrNumber
+	})
+
+	res, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal release notes JSON")
+	}
+	return res, nil
+}
+
+// ParseReleaseNotesJSON parses the versioned JSON representation of release
+// notes and verifies that its schema version is supported.
+func ParseReleaseNotesJSON(data []byte) (*ReleaseNotesJSON, error) {
+	doc := &ReleaseNotesJSON{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, errors.Wrap(err, "unmarshal release notes JSON")
+	}
+	if doc.SchemaVersion != JSONSchemaVersion {
+		return nil, errors.Errorf(
+			"unsupported release notes JSON schema version %q, expected %q",
+			doc.SchemaVersion, JSONSchemaVersion,
+		)
+	}
+	return doc, nil
+}
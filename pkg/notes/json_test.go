@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseNotesJSON(t *testing.T) {
+	// Given
+	releaseNotes := NewReleaseNotes()
+	releaseNotes.Set(3, &ReleaseNote{PrNumber: 3, Text: "Third", SIGs: []string{"node"}})
+	releaseNotes.Set(1, &ReleaseNote{PrNumber: 1, Text: "First", ActionRequired: true})
+	releaseNotes.Set(2, &ReleaseNote{PrNumber: 2, Text: "Second"})
+
+	// When
+	res, err := releaseNotes.JSON("v1.21.0", "v1.21.1")
+
+	// Then
+	require.Nil(t, err)
+	require.Contains(t, string(res), `"schema_version": "v1"`)
+
+	doc, err := ParseReleaseNotesJSON(res)
+	require.Nil(t, err)
+	require.Equal(t, JSONSchemaVersion, doc.SchemaVersion)
+	require.Equal(t, "v1.21.0", doc.PreviousRevision)
+	require.Equal(t, "v1.21.1", doc.CurrentRevision)
+	require.Len(t, doc.Notes, 3)
+	for i, note := range doc.Notes {
+		require.Equal(t, i+1, note.PrNumber)
+		require.Equal(t, releaseNotes.Get(i+1), note)
+	}
+}
+
+func TestReleaseNotesJSONEmpty(t *testing.T) {
+	// Given
+	releaseNotes := NewReleaseNotes()
+
+	// When
+	res, err := releaseNotes.JSON("", "")
+
+	// Then
+	require.Nil(t, err)
+	require.Contains(t, string(res), `"notes": []`)
+}
+
+func TestParseReleaseNotesJSONFailure(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"{",
+		`{"notes": []}`,
+		`{"schema_version": "v0", "notes": []}`,
+		`{"1": {"pr_number": 1}}`,
+	} {
+		// When
+		_, err := ParseReleaseNotesJSON([]byte(data))
+
+		// Then
+		require.NotNil(t, err, data)
+	}
+}
@@ -129,6 +129,10 @@ type ReleaseNote struct {
 
 	// DataFields a key indexed map of data fields
 	DataFields map[string]ReleaseNotesDataField `json:"-"`
+
+	// labels are all labels of the PR, which are only available for
+	// gathered notes
+	labels []string
 }
 
 type Documentation struct {
@@ -249,6 +253,15 @@ func GatherReleaseNotes(opts *options.Options) (*ReleaseNotes, error) {
 	}
 	logrus.Infof("finished gathering release notes in %v", time.Since(startTime))
 
+	if !opts.Filter.IsEmpty() {
+		filtered := releaseNotes.Filter(&opts.Filter)
+		logrus.Infof(
+			"Using %d of %d release notes matching the filter",
+			len(filtered.History()), len(releaseNotes.History()),
+		)
+		releaseNotes = filtered
+	}
+
 	return releaseNotes, nil
 }
 
@@ -265,15 +278,10 @@ func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
 		mapProviders = append(mapProviders, provider)
 	}
 
-	commits, err := g.listCommits(g.options.Branch, g.options.StartSHA, g.options.EndSHA)
-	if err != nil {
-		return nil, errors.Wrap(err, "listing commits")
-	}
-
 	// Get the PRs into a temporary results set
-	resultsTemp, err := g.gatherNotes(commits)
+	resultsTemp, err := g.gatherResults()
 	if err != nil {
-		return nil, errors.Wrap(err, "gathering notes")
+		return nil, err
 	}
 
 	// Cycle the results and add the complete notes, as well as those that
@@ -353,6 +361,30 @@ func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
 	return notes, nil
 }
 
+// gatherResults returns the commits between the start and end SHA having a
+// pull request with a release note, either by using the GraphQL or the REST
+// API.
+func (g *Gatherer) gatherResults() ([]*Result, error) {
+	if g.options.GraphQL {
+		results, err := g.gatherNotesGraphQL(g.options.Branch, g.options.StartSHA, g.options.EndSHA)
+		if err != nil {
+			return nil, errors.Wrap(err, "gathering notes via GraphQL")
+		}
+		return results, nil
+	}
+
+	commits, err := g.listCommits(g.options.Branch, g.options.StartSHA, g.options.EndSHA)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing commits")
+	}
+
+	results, err := g.gatherNotes(commits)
+	if err != nil {
+		return nil, errors.Wrap(err, "gathering notes")
+	}
+	return results, nil
+}
+
 // noteTextFromString returns the text of the release note given a string which
 // may contain the commit message, the PR description, etc.
 // This is generally the content inside the ```release-note ``` stanza.
@@ -504,6 +536,7 @@ func (g *Gatherer) ReleaseNoteFromCommit(result *Result) (*ReleaseNote, error) {
 		DuplicateKind:  isDuplicateKind,
 		ActionRequired: labelExactMatch(pr, "release-note-action-required"),
 		DoNotPublish:   labelExactMatch(pr, "release-note-none"),
+		labels:         labelNames(pr),
 	}, nil
 }
 
@@ -695,7 +728,7 @@ func (g *Gatherer) gatherNotes(commits []*gogithub.RepositoryCommit) (filtered [
 }
 
 func (g *Gatherer) notesForCommit(commit *gogithub.RepositoryCommit) (*Result, error) {
-	prs, err := g.prsFromCommit(commit)
+	prs, err := g.cachedPRsFromCommit(commit)
 	if err != nil {
 		if err == errNoPRIDFoundInCommitMessage || err == errNoPRFoundForCommitSHA {
 			logrus.Debugf(
@@ -770,6 +803,15 @@ func labelsWithPrefix(pr *gogithub.PullRequest, prefix string) []string {
 	return labels
 }
 
+// labelNames returns the names of all labels on a PR
+func labelNames(pr *gogithub.PullRequest) []string {
+	labels := []string{}
+	for _, label := range pr.Labels {
+		labels = append(labels, label.GetName())
+	}
+	return labels
+}
+
 // labelExactMatch indicates whether or not a matching label was found on PR
 func labelExactMatch(pr *gogithub.PullRequest, labelToFind string) bool {
 	for _, label := range pr.Labels {
@@ -958,9 +1000,9 @@ func prForRegex(regex *regexp.Regexp, commitMessage string) int {
 	return pr
 }
 
-// prettySIG takes a sig name as parsed by the `sig-foo` label and returns a
+// PrettySIG takes a sig name as parsed by the `sig-foo` label and returns a
 // "pretty" version of it that can be printed in documents
-func prettySIG(sig string) string {
+func PrettySIG(sig string) string {
 	parts := strings.Split(sig, "-")
 	for i, part := range parts {
 		switch part {
@@ -988,11 +1030,11 @@ func prettifySIGList(sigs []string) string {
 
 	for i, sig := range sigs {
 		if i == 0 {
-			sigList = fmt.Sprintf("SIG %s", prettySIG(sig))
+			sigList = fmt.Sprintf("SIG %s", PrettySIG(sig))
 		} else if i == len(sigs)-1 {
-			sigList = fmt.Sprintf("%s and %s", sigList, prettySIG(sig))
+			sigList = fmt.Sprintf("%s and %s", sigList, PrettySIG(sig))
 		} else {
-			sigList = fmt.Sprintf("%s, %s", sigList, prettySIG(sig))
+			sigList = fmt.Sprintf("%s, %s", sigList, PrettySIG(sig))
 		}
 	}
 
@@ -1106,6 +1148,32 @@ func (rn *ReleaseNote) ContentHash() (string, error) {
 	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
 
+// UnresolvedReasons returns why the note cannot be sorted into the release
+// notes unambiguously, which is empty if the note needs no manual review.
+// Notes which do not get published are never unresolved.
+func (rn *ReleaseNote) UnresolvedReasons() []string {
+	reasons := []string{}
+	if rn.DoNotPublish {
+		return reasons
+	}
+	if strings.TrimSpace(rn.Text) == "" {
+		reasons = append(reasons, "the note has no text")
+	}
+	switch len(rn.Kinds) {
+	case 0:
+		reasons = append(reasons, "the note has no kind")
+	case 1:
+	default:
+		reasons = append(reasons, fmt.Sprintf(
+			"the note has multiple kinds: %s", strings.Join(rn.Kinds, ", "),
+		))
+	}
+	if len(rn.SIGs) == 0 {
+		reasons = append(reasons, "the note has no SIG")
+	}
+	return reasons
+}
+
 // capitalizeString returns a capitalized string of the input string
 func capitalizeString(s string) string {
 	r := []rune(s)
@@ -31,6 +31,7 @@ func TestNewProviderFromInitString(t *testing.T) {
 		{initString: "/this/shoud/not/really.exist/as/a/d33rect0ree", returnsError: true},
 		{initString: "gs://bucket-name/map/path/", returnsError: true},
 		{initString: "github://kubernetes/sig-release/maps", returnsError: true},
+		{initString: "map", returnsError: true},
 	}
 	for _, testCase := range testCases {
 		provider, err := NewProviderFromInitString(testCase.initString)
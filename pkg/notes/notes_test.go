@@ -238,7 +238,7 @@ func TestPrettySIG(t *testing.T) {
 	}
 
 	for input, expected := range cases {
-		require.Equal(t, expected, (prettySIG(input)))
+		require.Equal(t, expected, (PrettySIG(input)))
 	}
 }
 
@@ -496,3 +496,37 @@ func TestCapitalizeString(t *testing.T) {
 		require.Equal(t, tc.expected, result)
 	}
 }
+
+func TestUnresolvedReasons(t *testing.T) {
+	for _, tc := range []struct {
+		note     ReleaseNote
+		expected []string
+	}{
+		{
+			note: ReleaseNote{
+				Text: "Fixed a bug", Kinds: []string{"bug"}, SIGs: []string{"node"},
+			},
+			expected: []string{},
+		},
+		{
+			note:     ReleaseNote{DoNotPublish: true},
+			expected: []string{},
+		},
+		{
+			note: ReleaseNote{Text: " "},
+			expected: []string{
+				"the note has no text",
+				"the note has no kind",
+				"the note has no SIG",
+			},
+		},
+		{
+			note: ReleaseNote{
+				Text: "Added a flag", Kinds: []string{"feature", "bug"}, SIGs: []string{"cli"},
+			},
+			expected: []string{"the note has multiple kinds: feature, bug"},
+		},
+	} {
+		require.Equal(t, tc.expected, tc.note.UnresolvedReasons())
+	}
+}
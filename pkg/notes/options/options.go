@@ -69,12 +69,12 @@ type Options struct {
 	EndRev string
 
 	// Format specifies the format of the release notes. Can be either
-	// `json` or `markdown`.
+	// `json`, `json-v1` or `markdown`.
 	Format string
 
 	// If the `Format` is `markdown`, then this specifies the selected go
-	// template. Can be `go-template:default`, `go-template:<file.template>` or
-	// `go-template:inline:<template>`.
+	// template. Can be `go-template:default`, `go-template:sig`,
+	// `go-template:<file.template>` or `go-template:inline:<template>`.
 	GoTemplate string
 
 	// RequiredAuthor can be used to filter the release notes by the commit
@@ -108,6 +108,11 @@ type Options struct {
 	// EXPERIMENTAL: Feature flag for using v2 implementation to list commits
 	ListReleaseNotesV2 bool
 
+	// If true, the commits and their pull requests get retrieved in bulk via
+	// the GitHub GraphQL API, which needs far fewer requests than the REST
+	// API for big releases. Cannot be used together with ListReleaseNotesV2.
+	GraphQL bool
+
 	// RecordDir specifies the directory for API call recordings. Cannot be
 	// used together with ReplayDir.
 	RecordDir string
@@ -116,6 +121,15 @@ type Options struct {
 	// API. Cannot be used together with RecordDir.
 	ReplayDir string
 
+	// CacheDir specifies the directory for caching the pull requests of
+	// commits by their SHA, which lets subsequent runs only query GitHub for
+	// new commits. Not used by the GraphQL API. Caching is disabled if empty.
+	CacheDir string
+
+	// Filter restricts the gathered release notes to a subset of them, for
+	// example the notes of a single SIG. All notes are used if it is empty.
+	Filter NotesFilter
+
 	githubToken string
 	gitCloneFn  func(string, string, string, bool) (*git.Repo, error)
 
@@ -123,6 +137,32 @@ type Options struct {
 	MapProviderStrings []string
 }
 
+// NotesFilter selects release notes by their metadata. A note matches if it
+// matches at least one value of every non-empty field. Values are compared
+// case insensitive.
+type NotesFilter struct {
+	// SIGs are the SIGs of the notes, like `node` or `sig/node`.
+	SIGs []string
+
+	// Kinds are the kinds of the notes, like `feature` or `kind/feature`.
+	Kinds []string
+
+	// Areas are the areas of the notes, like `kubelet` or `area/kubelet`.
+	Areas []string
+
+	// Authors are the GitHub logins of the pull request authors.
+	Authors []string
+
+	// Labels are the pull request labels, like `release-note-action-required`.
+	Labels []string
+}
+
+// IsEmpty returns true if the filter does not restrict the notes.
+func (f *NotesFilter) IsEmpty() bool {
+	return len(f.SIGs) == 0 && len(f.Kinds) == 0 && len(f.Areas) == 0 &&
+		len(f.Authors) == 0 && len(f.Labels) == 0
+}
+
 type RevisionDiscoveryMode string
 
 const (
@@ -135,11 +175,13 @@ const (
 
 const (
 	FormatJSON     = "json"
+	FormatJSONV1   = "json-v1"
 	FormatMarkdown = "markdown"
 
 	GoTemplatePrefix       = "go-template:"
 	GoTemplatePrefixInline = "inline:"
 	GoTemplateDefault      = GoTemplatePrefix + "default"
+	GoTemplateSIG          = GoTemplatePrefix + "sig"
 	GoTemplateInline       = GoTemplatePrefix + GoTemplatePrefixInline
 )
 
@@ -170,6 +212,10 @@ func (o *Options) ValidateAndFinish() (err error) {
 		return errors.New("please do not use record and replay together")
 	}
 
+	if o.GraphQL && o.ListReleaseNotesV2 {
+		return errors.New("please do not use GraphQL and ListReleaseNotesV2 together")
+	}
+
 	// Recover for replay if needed
 	if o.ReplayDir != "" {
 		logrus.Info("Using replay mode")
@@ -251,7 +297,8 @@ func (o *Options) ValidateAndFinish() (err error) {
 func (o *Options) checkFormatOptions() error {
 	// Validate the output format and template
 	logrus.Infof("Using output format: %s", o.Format)
-	if o.Format == FormatMarkdown && o.GoTemplate != GoTemplateDefault {
+	if o.Format == FormatMarkdown && o.GoTemplate != GoTemplateDefault &&
+		o.GoTemplate != GoTemplateSIG {
 		if !strings.HasPrefix(o.GoTemplate, GoTemplatePrefix) {
 			return errors.Errorf("go template has to be prefixed with %q", GoTemplatePrefix)
 		}
@@ -268,10 +315,12 @@ func (o *Options) checkFormatOptions() error {
 			}
 		}
 	}
-	if o.Format == FormatJSON && o.GoTemplate != GoTemplateDefault {
+	if (o.Format == FormatJSON || o.Format == FormatJSONV1) &&
+		o.GoTemplate != GoTemplateDefault {
 		return errors.New("go-template cannot be defined when in JSON mode")
 	}
-	if o.Format != FormatJSON && o.Format != FormatMarkdown {
+	if o.Format != FormatJSON && o.Format != FormatJSONV1 &&
+		o.Format != FormatMarkdown {
 		return errors.Errorf("invalid format: %s", o.Format)
 	}
 	return nil
@@ -297,6 +346,14 @@ func (o *Options) resolveDiscoverMode() error {
 		return err
 	}
 
+	o.SetRange(&result)
+	return nil
+}
+
+// SetRange sets the start and end revisions of the release notes to the
+// range of a revision discovery, which allows gathering the release notes of
+// a range discovered by other tooling.
+func (o *Options) SetRange(result *git.DiscoverResult) {
 	o.StartSHA = result.StartSHA()
 	o.StartRev = result.StartRev()
 	o.EndSHA = result.EndSHA()
@@ -307,8 +364,6 @@ func (o *Options) resolveDiscoverMode() error {
 
 	logrus.Infof("Using start revision %s", o.StartRev)
 	logrus.Infof("Using end revision %s", o.EndRev)
-
-	return nil
 }
 
 func (o *Options) repo() (repo *git.Repo, err error) {
@@ -259,6 +259,15 @@ func TestValidateAndFinishFailureGithubTokenMissing(t *testing.T) {
 	require.NotNil(t, options.ValidateAndFinish())
 }
 
+func TestValidateAndFinishFailureGraphQLAndV2(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.GraphQL = true
+	options.ListReleaseNotesV2 = true
+	require.NotNil(t, options.ValidateAndFinish())
+}
+
 func TestValidateAndFinishFailureStartShaAndRevWrong(t *testing.T) {
 	options := newTestOptions(t)
 	defer options.testRepo.cleanup(t)
@@ -372,6 +381,29 @@ func TestValidateAndFinishSuccessDiscoveryModePatchToPatch(t *testing.T) {
 	require.Nil(t, options.ValidateAndFinish())
 }
 
+func TestSetRange(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	// Given
+	nextMinorTag := "v1.17.1"
+	require.Nil(t, command.NewWithWorkDir(
+		options.testRepo.sut.Dir(), "git", "tag", nextMinorTag,
+	).RunSuccess())
+
+	result, err := options.testRepo.sut.LatestPatchToPatch(options.testRepo.branchName)
+	require.Nil(t, err)
+
+	// When
+	options.SetRange(&result)
+
+	// Then
+	require.Equal(t, options.testRepo.firstCommit, options.StartSHA)
+	require.Equal(t, options.testRepo.firstTagName, options.StartRev)
+	require.Equal(t, options.testRepo.secondBranchCommit, options.EndSHA)
+	require.Equal(t, nextMinorTag, options.EndRev)
+}
+
 func TestValidateAndFinishFailureDiscoveryModePatchToPatchNoBranch(t *testing.T) {
 	options := newTestOptions(t)
 	defer options.testRepo.cleanup(t)
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/env"
+)
+
+const (
+	// MaxSizeEnvKey is the environment variable which can be used to
+	// override the default maximum size of captured command output in bytes.
+	MaxSizeEnvKey = "RELEASE_MAX_OUTPUT_SIZE"
+
+	// DefaultMaxSize is the default maximum amount of bytes kept in memory
+	// for captured command output.
+	DefaultMaxSize = 4 * 1024 * 1024
+
+	// TruncationMarker is inserted between the head and the tail of the
+	// captured output if the size limit has been exceeded.
+	TruncationMarker = "\n[... %d bytes truncated ...]\n"
+)
+
+// MaxSize returns the configured maximum size of captured output, which is
+// either the value of $RELEASE_MAX_OUTPUT_SIZE or DefaultMaxSize.
+func MaxSize() int {
+	value := env.Default(MaxSizeEnvKey, "")
+	if value == "" {
+		return DefaultMaxSize
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		logrus.Warnf(
+			"Invalid value %q for $%s, using default of %d bytes",
+			value, MaxSizeEnvKey, DefaultMaxSize,
+		)
+		return DefaultMaxSize
+	}
+	return size
+}
+
+// LimitedBuffer is an io.Writer which keeps at most a fixed amount of bytes
+// in memory. The first half of the limit is used for the beginning of the
+// output, while the second half always holds the most recent output. Every
+// write in between is dropped and accounted for in a truncation marker.
+// To avoid copying the recent output on every write, up to twice the second
+// half is kept in memory, which means that the buffer holds at most one and
+// a half times the limit.
+type LimitedBuffer struct {
+	mu      sync.Mutex
+	limit   int
+	head    []byte
+	tail    []byte
+	dropped int
+}
+
+// NewLimitedBuffer creates a new LimitedBuffer which keeps at most `limit`
+// bytes. A non positive limit results in using MaxSize().
+func NewLimitedBuffer(limit int) *LimitedBuffer {
+	if limit <= 0 {
+		limit = MaxSize()
+	}
+	return &LimitedBuffer{limit: limit}
+}
+
+// Write appends the provided data to the buffer. It never fails.
+func (b *LimitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data := p
+	headLimit := b.limit / 2
+	if free := headLimit - len(b.head); free > 0 {
+		if free > len(data) {
+			free = len(data)
+		}
+		b.head = append(b.head, data[:free]...)
+		data = data[free:]
+	}
+
+	// Only the last part of the data fits into the tail at all
+	tailLimit := b.limit - headLimit
+	if overflow := len(data) - tailLimit; overflow > 0 {
+		b.dropped += overflow
+		data = data[overflow:]
+	}
+
+	// Let the tail grow up to twice its limit before compacting it, which
+	// avoids copying the tail on every single write.
+	if len(b.tail)+len(data) > 2*tailLimit {
+		overflow := len(b.tail) + len(data) - tailLimit
+		b.dropped += overflow
+		b.tail = append(b.tail[:0], b.tail[overflow:]...)
+	}
+	b.tail = append(b.tail, data...)
+
+	return len(p), nil
+}
+
+// String returns the captured output including a truncation marker if
+// some bytes had to be dropped.
+func (b *LimitedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tail := b.tail
+	if overflow := len(tail) - (b.limit - b.limit/2); overflow > 0 {
+		tail = tail[overflow:]
+	}
+	truncated := b.truncatedUnlocked()
+	if truncated == 0 {
+		return string(b.head) + string(tail)
+	}
+	return string(b.head) + fmt.Sprintf(TruncationMarker, truncated) + string(tail)
+}
+
+// Truncated returns the amount of bytes which have been dropped.
+func (b *LimitedBuffer) Truncated() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.truncatedUnlocked()
+}
+
+func (b *LimitedBuffer) truncatedUnlocked() int {
+	truncated := b.dropped
+	if overflow := len(b.tail) - (b.limit - b.limit/2); overflow > 0 {
+		truncated += overflow
+	}
+	return truncated
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitedBufferWithinLimit(t *testing.T) {
+	sut := NewLimitedBuffer(10)
+	n, err := sut.Write([]byte("hello"))
+	require.Nil(t, err)
+	require.Equal(t, 5, n)
+	require.Equal(t, "hello", sut.String())
+	require.Zero(t, sut.Truncated())
+}
+
+func TestLimitedBufferTruncated(t *testing.T) {
+	sut := NewLimitedBuffer(10)
+	for i := 0; i < 100; i++ {
+		_, err := sut.Write([]byte(fmt.Sprintf("%d", i%10)))
+		require.Nil(t, err)
+	}
+	require.Equal(t, 90, sut.Truncated())
+	require.Equal(t, "01234"+fmt.Sprintf(TruncationMarker, 90)+"56789", sut.String())
+}
+
+func TestLimitedBufferLargeWrite(t *testing.T) {
+	sut := NewLimitedBuffer(4)
+	_, err := sut.Write([]byte(strings.Repeat("a", 10) + "bc"))
+	require.Nil(t, err)
+	require.Equal(t, 8, sut.Truncated())
+	require.True(t, strings.HasPrefix(sut.String(), "aa"))
+	require.True(t, strings.HasSuffix(sut.String(), "bc"))
+}
+
+func TestLimitedBufferBounded(t *testing.T) {
+	sut := NewLimitedBuffer(10)
+	for i := 0; i < 100; i++ {
+		_, err := sut.Write([]byte(strings.Repeat(fmt.Sprintf("%d", i%10), 7)))
+		require.Nil(t, err)
+		require.LessOrEqual(t, len(sut.head)+len(sut.tail), 15)
+	}
+	require.Equal(t, 690, sut.Truncated())
+	require.Equal(t, "00000"+fmt.Sprintf(TruncationMarker, 690)+"99999", sut.String())
+
+	_, err := sut.Write([]byte(strings.Repeat("a", 1000)))
+	require.Nil(t, err)
+	require.LessOrEqual(t, len(sut.tail), 10)
+	require.Equal(t, 1690, sut.Truncated())
+	require.Equal(t, "00000"+fmt.Sprintf(TruncationMarker, 1690)+"aaaaa", sut.String())
+}
+
+func TestMaxSize(t *testing.T) {
+	require.Nil(t, os.Unsetenv(MaxSizeEnvKey))
+	require.Equal(t, DefaultMaxSize, MaxSize())
+
+	require.Nil(t, os.Setenv(MaxSizeEnvKey, "1024"))
+	require.Equal(t, 1024, MaxSize())
+
+	require.Nil(t, os.Setenv(MaxSizeEnvKey, "wrong"))
+	require.Equal(t, DefaultMaxSize, MaxSize())
+	require.Nil(t, os.Unsetenv(MaxSizeEnvKey))
+}
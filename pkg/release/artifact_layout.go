@@ -0,0 +1,529 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/object"
+	rhash "sigs.k8s.io/release-utils/hash"
+)
+
+// The placeholders which can be used in the paths of an ArtifactGroup.
+const (
+	// ArtifactPlaceholderVersion is replaced by the release version.
+	ArtifactPlaceholderVersion = "{version}"
+
+	// ArtifactPlaceholderOS is replaced by the operating system of the
+	// platform.
+	ArtifactPlaceholderOS = "{os}"
+
+	// ArtifactPlaceholderArch is replaced by the architecture of the platform.
+	ArtifactPlaceholderArch = "{arch}"
+
+	// ArtifactPlaceholderExt is replaced by the executable file extension of
+	// the platform, which is ".exe" on Windows and empty otherwise.
+	ArtifactPlaceholderExt = "{ext}"
+)
+
+// Platform is an operating system and architecture combination.
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+// String returns the platform in the os/arch format.
+func (p Platform) String() string {
+	return p.OS + "/" + p.Arch
+}
+
+// ArtifactGroup is a set of artifacts which is expected for each platform of
+// the group.
+type ArtifactGroup struct {
+	// Name describes the artifacts of the group, like "client tarballs".
+	Name string
+
+	// Platforms are the platforms to expect the artifacts for. The paths are
+	// expected only once if no platforms are set.
+	Platforms []Platform
+
+	// Paths are the paths of the artifacts relative to the root of the
+	// release, which can contain the ArtifactPlaceholder* placeholders.
+	Paths []string
+
+	// Checksums indicates that each artifact is accompanied by a .sha256 and
+	// .sha512 file, like written by WriteChecksums.
+	Checksums bool
+}
+
+// Artifact is a single expected artifact of a release.
+type Artifact struct {
+	// Path is the path of the artifact relative to the root of the release.
+	Path string
+
+	// Group is the name of the group the artifact belongs to.
+	Group string
+
+	// Checksums indicates that the artifact is accompanied by checksum files.
+	Checksums bool
+}
+
+// ArtifactLayout describes the expected artifact tree of a release.
+type ArtifactLayout struct {
+	Groups []ArtifactGroup
+}
+
+var (
+	clientPlatforms = []Platform{
+		{"darwin", "amd64"},
+		{"darwin", "arm64"},
+		{"linux", "386"},
+		{"linux", "amd64"},
+		{"linux", "arm"},
+		{"linux", "arm64"},
+		{"linux", "ppc64le"},
+		{"linux", "s390x"},
+		{"windows", "386"},
+		{"windows", "amd64"},
+	}
+	serverPlatforms = []Platform{
+		{"linux", "amd64"},
+		{"linux", "arm"},
+		{"linux", "arm64"},
+		{"linux", "ppc64le"},
+		{"linux", "s390x"},
+	}
+	nodePlatforms = append(
+		append([]Platform{}, serverPlatforms...), Platform{"windows", "amd64"},
+	)
+)
+
+// DefaultArtifactLayout returns the artifact layout of a Kubernetes release,
+// relative to the stage path of a version within a staging bucket, which
+// contains the GCSStagePath and ImagesPath directories.
+func DefaultArtifactLayout() *ArtifactLayout {
+	layout := fileArtifactLayout(GCSStagePath + "/" + ArtifactPlaceholderVersion + "/")
+	layout.Groups = append(layout.Groups, ArtifactGroup{
+		Name:      "images",
+		Platforms: serverPlatforms,
+		Paths: []string{
+			ImagesPath + "/{arch}/kube-apiserver.tar",
+			ImagesPath + "/{arch}/kube-controller-manager.tar",
+			ImagesPath + "/{arch}/kube-proxy.tar",
+			ImagesPath + "/{arch}/kube-scheduler.tar",
+		},
+	})
+	return layout
+}
+
+// PublishedArtifactLayout returns the artifact layout of a published
+// Kubernetes release, relative to the release directory of a bucket, which
+// contains a directory per version. Container images are not part of it,
+// because they get published to a container registry.
+func PublishedArtifactLayout() *ArtifactLayout {
+	return fileArtifactLayout(ArtifactPlaceholderVersion + "/")
+}
+
+// fileArtifactLayout returns the layout of the release tarballs, binaries and
+// their checksums within the stage path.
+func fileArtifactLayout(stagePath string) *ArtifactLayout {
+	binPath := stagePath + "bin/" + ArtifactPlaceholderOS + "/" + ArtifactPlaceholderArch + "/"
+
+	return &ArtifactLayout{Groups: []ArtifactGroup{
+		{
+			Name: "release tarballs",
+			Paths: []string{
+				stagePath + KubernetesTar,
+				stagePath + "kubernetes-src.tar.gz",
+				stagePath + "kubernetes-manifests.tar.gz",
+			},
+			Checksums: true,
+		},
+		{
+			Name: "bill of materials",
+			Paths: []string{
+				stagePath + "kubernetes-source.spdx",
+				stagePath + "kubernetes-release.spdx",
+			},
+			Checksums: true,
+		},
+		{
+			Name:  "checksum files",
+			Paths: []string{stagePath + "SHA256SUMS", stagePath + "SHA512SUMS"},
+		},
+		{
+			Name:      "client tarballs",
+			Platforms: clientPlatforms,
+			Paths: []string{
+				stagePath + "kubernetes-client-{os}-{arch}.tar.gz",
+			},
+			Checksums: true,
+		},
+		{
+			Name:      "client binaries",
+			Platforms: clientPlatforms,
+			Paths:     []string{binPath + "kubectl{ext}"},
+			Checksums: true,
+		},
+		{
+			Name:      "server tarballs",
+			Platforms: serverPlatforms,
+			Paths: []string{
+				stagePath + "kubernetes-server-{os}-{arch}.tar.gz",
+			},
+			Checksums: true,
+		},
+		{
+			Name:      "server binaries",
+			Platforms: serverPlatforms,
+			Paths: []string{
+				binPath + "kube-apiserver",
+				binPath + "kube-controller-manager",
+				binPath + "kube-scheduler",
+			},
+			Checksums: true,
+		},
+		{
+			Name:      "node tarballs",
+			Platforms: nodePlatforms,
+			Paths: []string{
+				stagePath + "kubernetes-node-{os}-{arch}.tar.gz",
+			},
+			Checksums: true,
+		},
+		{
+			Name:      "node binaries",
+			Platforms: nodePlatforms,
+			Paths: []string{
+				binPath + "kubeadm{ext}",
+				binPath + "kubelet{ext}",
+				binPath + "kube-proxy{ext}",
+			},
+			Checksums: true,
+		},
+	}}
+}
+
+// Artifacts returns the expected artifacts for the version, in the order of
+// the groups and their platforms.
+func (l *ArtifactLayout) Artifacts(version string) []Artifact {
+	artifacts := []Artifact{}
+	for _, group := range l.Groups {
+		platforms := group.Platforms
+		if len(platforms) == 0 {
+			platforms = []Platform{{}}
+		}
+		for _, platform := range platforms {
+			ext := ""
+			if platform.OS == "windows" {
+				ext = ".exe"
+			}
+			replacer := strings.NewReplacer(
+				ArtifactPlaceholderVersion, version,
+				ArtifactPlaceholderOS, platform.OS,
+				ArtifactPlaceholderArch, platform.Arch,
+				ArtifactPlaceholderExt, ext,
+			)
+			for _, path := range group.Paths {
+				artifacts = append(artifacts, Artifact{
+					Path:      replacer.Replace(path),
+					Group:     group.Name,
+					Checksums: group.Checksums,
+				})
+			}
+		}
+	}
+	return artifacts
+}
+
+// ArtifactValidator checks staged releases against an artifact layout.
+type ArtifactValidator struct {
+	layout *ArtifactLayout
+	impl   artifactValidatorImpl
+}
+
+// NewArtifactValidator creates a new artifact validator for the layout.
+func NewArtifactValidator(layout *ArtifactLayout) *ArtifactValidator {
+	return &ArtifactValidator{layout, &defaultArtifactValidatorImpl{}}
+}
+
+// SetImpl can be used to set the internal ArtifactValidator implementation.
+func (a *ArtifactValidator) SetImpl(impl artifactValidatorImpl) {
+	a.impl = impl
+}
+
+//counterfeiter:generate . artifactValidatorImpl
+type artifactValidatorImpl interface {
+	RsyncRecursive(src, dst string) error
+	CopyToLocal(src, dst string) error
+}
+
+type defaultArtifactValidatorImpl struct{}
+
+func (*defaultArtifactValidatorImpl) CopyToLocal(src, dst string) error {
+	return object.NewGCS().CopyToLocal(src, dst)
+}
+
+func (*defaultArtifactValidatorImpl) RsyncRecursive(src, dst string) error {
+	return object.NewGCS().RsyncRecursive(src, dst)
+}
+
+// ValidateDir checks that the directory contains all artifacts of the layout
+// for the version and that their checksum files match. All violations are
+// reported in the returned error.
+func (a *ArtifactValidator) ValidateDir(dir, version string) error {
+	logrus.Infof("Validating artifacts of %s in %s", version, dir)
+
+	artifacts := a.layout.Artifacts(version)
+	if err := problemsError(
+		a.dirProblems(dir, artifacts), version, dir,
+	); err != nil {
+		return err
+	}
+	logrus.Infof("All %d artifacts of %s are valid", len(artifacts), version)
+	return nil
+}
+
+// dirProblems returns the violations of the artifacts in the directory.
+func (a *ArtifactValidator) dirProblems(dir string, artifacts []Artifact) []string {
+	problems := []string{}
+	for _, artifact := range artifacts {
+		path := filepath.Join(dir, artifact.Path)
+		info, err := os.Stat(path)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf(
+				"%s: missing %s", artifact.Group, artifact.Path,
+			))
+			continue
+		}
+		if info.IsDir() {
+			problems = append(problems, fmt.Sprintf(
+				"%s: %s is a directory", artifact.Group, artifact.Path,
+			))
+			continue
+		}
+		if !artifact.Checksums {
+			continue
+		}
+		for _, hasher := range []hash.Hash{sha256.New(), sha512.New()} {
+			if problem := checkChecksumFile(path, hasher); problem != "" {
+				problems = append(problems, fmt.Sprintf(
+					"%s: %s %s", artifact.Group, artifact.Path, problem,
+				))
+			}
+		}
+	}
+	return problems
+}
+
+// problemsError returns an error listing all problems, or nil if there are
+// none.
+func problemsError(problems []string, version, location string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.Errorf(
+		"%d problems with the artifacts of %s in %s:\n- %s",
+		len(problems), version, location, strings.Join(problems, "\n- "),
+	)
+}
+
+// ValidateBucket downloads the GCS path and checks it like ValidateDir.
+func (a *ArtifactValidator) ValidateBucket(gcsPath, version string) error {
+	src, err := object.NewGCS().NormalizePath(gcsPath)
+	if err != nil {
+		return errors.Wrap(err, "normalize GCS path")
+	}
+
+	dir, err := os.MkdirTemp("", "artifacts-")
+	if err != nil {
+		return errors.Wrap(err, "create temp dir")
+	}
+	defer os.RemoveAll(dir)
+
+	logrus.Infof("Downloading %s to %s", src, dir)
+	if err := a.impl.RsyncRecursive(src, dir); err != nil {
+		return errors.Wrapf(err, "download %s", src)
+	}
+	return a.ValidateDir(dir, version)
+}
+
+// ValidatePublished downloads the published release of the version from the
+// bucket and checks it like ValidateDir, where the layout has to be relative
+// to the release directory of the bucket, like PublishedArtifactLayout. The
+// checksums of all artifacts are additionally recomputed and compared with
+// the published SHA256SUMS and SHA512SUMS manifests, as well as with the
+// staged ones of the build version if not empty. All mismatches are reported
+// in the returned error.
+func (a *ArtifactValidator) ValidatePublished(bucket, version, buildVersion string) error {
+	gcs := object.NewGCS()
+	src, err := gcs.NormalizePath(bucket, "release", version)
+	if err != nil {
+		return errors.Wrap(err, "normalize GCS path")
+	}
+
+	dir, err := os.MkdirTemp("", "published-artifacts-")
+	if err != nil {
+		return errors.Wrap(err, "create temp dir")
+	}
+	defer os.RemoveAll(dir)
+
+	logrus.Infof("Downloading %s to %s", src, dir)
+	if err := a.impl.RsyncRecursive(src, filepath.Join(dir, version)); err != nil {
+		return errors.Wrapf(err, "download %s", src)
+	}
+
+	manifestDirs := map[string]string{"published": filepath.Join(dir, version)}
+	if buildVersion != "" {
+		stageDir := filepath.Join(dir, "stage")
+		for _, manifest := range []string{"SHA256SUMS", "SHA512SUMS"} {
+			stageSrc, err := gcs.NormalizePath(
+				bucket, StagePath, buildVersion, version,
+				GCSStagePath, version, manifest,
+			)
+			if err != nil {
+				return errors.Wrap(err, "normalize GCS stage path")
+			}
+			logrus.Infof("Downloading staged manifest %s", stageSrc)
+			if err := a.impl.CopyToLocal(
+				stageSrc, filepath.Join(stageDir, manifest),
+			); err != nil {
+				return errors.Wrapf(err, "download %s", stageSrc)
+			}
+		}
+		manifestDirs["staged"] = stageDir
+	}
+
+	logrus.Infof("Validating published artifacts of %s", version)
+	artifacts := a.layout.Artifacts(version)
+	problems := a.dirProblems(dir, artifacts)
+	for _, name := range []string{"published", "staged"} {
+		manifestDir, ok := manifestDirs[name]
+		if !ok {
+			continue
+		}
+		for _, hasher := range []func() hash.Hash{sha256.New, sha512.New} {
+			problems = append(problems, manifestProblems(
+				dir, manifestDir, name, version, artifacts, hasher,
+			)...)
+		}
+	}
+
+	if err := problemsError(problems, version, src); err != nil {
+		return err
+	}
+	logrus.Infof("All %d published artifacts of %s are valid", len(artifacts), version)
+	return nil
+}
+
+// manifestProblems recomputes the checksums of the artifacts in the directory
+// and compares them with the SHA256SUMS or SHA512SUMS manifest, depending on
+// the hasher, in the manifest directory.
+func manifestProblems(
+	dir, manifestDir, name, version string,
+	artifacts []Artifact,
+	newHasher func() hash.Hash,
+) []string {
+	manifest := fmt.Sprintf("SHA%dSUMS", newHasher().Size()*8)
+	content, err := os.ReadFile(filepath.Join(manifestDir, manifest))
+	if err != nil {
+		return []string{fmt.Sprintf("%s %s: cannot be read: %v", name, manifest, err)}
+	}
+	checksums := parseChecksumManifest(string(content), version)
+
+	problems := []string{}
+	for _, artifact := range artifacts {
+		if !artifact.Checksums {
+			continue
+		}
+		path := filepath.Join(dir, artifact.Path)
+		if _, err := os.Stat(path); err != nil {
+			// Already reported as missing
+			continue
+		}
+		expected, ok := checksums[strings.TrimPrefix(artifact.Path, version+"/")]
+		if !ok {
+			problems = append(problems, fmt.Sprintf(
+				"%s: %s is not part of the %s %s",
+				artifact.Group, artifact.Path, name, manifest,
+			))
+			continue
+		}
+		actual, err := rhash.ForFile(path, newHasher())
+		if err != nil {
+			problems = append(problems, fmt.Sprintf(
+				"%s: %s cannot be hashed: %v", artifact.Group, artifact.Path, err,
+			))
+			continue
+		}
+		if actual != expected {
+			problems = append(problems, fmt.Sprintf(
+				"%s: %s does not match the %s %s",
+				artifact.Group, artifact.Path, name, manifest,
+			))
+		}
+	}
+	return problems
+}
+
+// parseChecksumManifest parses a SHA256SUMS or SHA512SUMS file written by
+// WriteChecksums and returns the checksums indexed by the path relative to
+// the directory of the version. The manifests contain the paths of the
+// artifacts at the time of staging, like
+// /workspace/_output-v1.22.0/gcs-stage/v1.22.0/kubernetes.tar.gz.
+func parseChecksumManifest(content, version string) map[string]string {
+	checksums := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		path := "/" + filepath.ToSlash(fields[1])
+		if i := strings.LastIndex(path, "/"+version+"/"); i >= 0 {
+			path = path[i+len(version)+2:]
+		}
+		checksums[strings.TrimPrefix(path, "/")] = fields[0]
+	}
+	return checksums
+}
+
+// checkChecksumFile verifies the .sha256 or .sha512 file of the path and
+// returns a description of the problem, or an empty string if it matches.
+func checkChecksumFile(path string, hasher hash.Hash) string {
+	shaFile := fmt.Sprintf("%s.sha%d", path, hasher.Size()*8)
+	expected, err := os.ReadFile(shaFile)
+	if err != nil {
+		return fmt.Sprintf("has no %s file", filepath.Ext(shaFile))
+	}
+	actual, err := rhash.ForFile(path, hasher)
+	if err != nil {
+		return fmt.Sprintf("cannot be hashed: %v", err)
+	}
+	if strings.TrimSpace(string(expected)) != actual {
+		return fmt.Sprintf("does not match its %s file", filepath.Ext(shaFile))
+	}
+	return ""
+}
@@ -0,0 +1,350 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/release/releasefakes"
+)
+
+const testArtifactVersion = "v1.22.0"
+
+func testArtifactLayout() *release.ArtifactLayout {
+	return &release.ArtifactLayout{Groups: []release.ArtifactGroup{
+		{
+			Name:      "tarballs",
+			Paths:     []string{"{version}/kubernetes.tar.gz"},
+			Checksums: true,
+		},
+		{
+			Name: "binaries",
+			Platforms: []release.Platform{
+				{OS: "linux", Arch: "amd64"}, {OS: "windows", Arch: "amd64"},
+			},
+			Paths:     []string{"{version}/bin/{os}/{arch}/kubectl{ext}"},
+			Checksums: true,
+		},
+		{
+			Name:      "images",
+			Platforms: []release.Platform{{OS: "linux", Arch: "arm64"}},
+			Paths:     []string{"images/{arch}/kube-proxy.tar"},
+		},
+	}}
+}
+
+// writeTestArtifacts writes all artifacts of the test layout including their
+// checksum files into the directory.
+func writeTestArtifacts(t *testing.T, dir string) {
+	for _, artifact := range testArtifactLayout().Artifacts(testArtifactVersion) {
+		path := filepath.Join(dir, artifact.Path)
+		require.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.Nil(t, os.WriteFile(path, []byte("content"), 0o644))
+	}
+	require.Nil(t, release.WriteChecksums(filepath.Join(dir, testArtifactVersion)))
+}
+
+func TestArtifactLayoutArtifacts(t *testing.T) {
+	res := testArtifactLayout().Artifacts(testArtifactVersion)
+	require.Equal(t, []release.Artifact{
+		{Path: "v1.22.0/kubernetes.tar.gz", Group: "tarballs", Checksums: true},
+		{Path: "v1.22.0/bin/linux/amd64/kubectl", Group: "binaries", Checksums: true},
+		{Path: "v1.22.0/bin/windows/amd64/kubectl.exe", Group: "binaries", Checksums: true},
+		{Path: "images/arm64/kube-proxy.tar", Group: "images"},
+	}, res)
+}
+
+func TestDefaultArtifactLayout(t *testing.T) {
+	paths := map[string]bool{}
+	for _, artifact := range release.DefaultArtifactLayout().Artifacts(testArtifactVersion) {
+		require.False(t, paths[artifact.Path], artifact.Path)
+		paths[artifact.Path] = true
+	}
+	for _, path := range []string{
+		"gcs-stage/v1.22.0/kubernetes.tar.gz",
+		"gcs-stage/v1.22.0/SHA512SUMS",
+		"gcs-stage/v1.22.0/kubernetes-client-darwin-arm64.tar.gz",
+		"gcs-stage/v1.22.0/bin/windows/amd64/kubectl.exe",
+		"gcs-stage/v1.22.0/bin/linux/s390x/kube-apiserver",
+		"gcs-stage/v1.22.0/kubernetes-node-windows-amd64.tar.gz",
+		"release-images/arm64/kube-proxy.tar",
+	} {
+		require.True(t, paths[path], path)
+	}
+}
+
+func TestArtifactValidatorValidateDir(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		modify    func(dir string) error
+		shouldErr bool
+	}{
+		{
+			name:   "success",
+			modify: func(string) error { return nil },
+		},
+		{
+			name: "missing artifact",
+			modify: func(dir string) error {
+				return os.Remove(filepath.Join(dir, "images", "arm64", "kube-proxy.tar"))
+			},
+			shouldErr: true,
+		},
+		{
+			name: "artifact is a directory",
+			modify: func(dir string) error {
+				path := filepath.Join(dir, "images", "arm64", "kube-proxy.tar")
+				if err := os.Remove(path); err != nil {
+					return err
+				}
+				return os.Mkdir(path, 0o755)
+			},
+			shouldErr: true,
+		},
+		{
+			name: "missing checksum file",
+			modify: func(dir string) error {
+				return os.Remove(filepath.Join(
+					dir, testArtifactVersion, "kubernetes.tar.gz.sha512",
+				))
+			},
+			shouldErr: true,
+		},
+		{
+			name: "checksum mismatch",
+			modify: func(dir string) error {
+				return os.WriteFile(filepath.Join(
+					dir, testArtifactVersion, "bin", "windows", "amd64", "kubectl.exe",
+				), []byte("modified"), 0o644)
+			},
+			shouldErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			dir := t.TempDir()
+			writeTestArtifacts(t, dir)
+			require.Nil(t, tc.modify(dir))
+			sut := release.NewArtifactValidator(testArtifactLayout())
+
+			// When
+			err := sut.ValidateDir(dir, testArtifactVersion)
+
+			// Then
+			if tc.shouldErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestArtifactValidatorValidateBucket(t *testing.T) {
+	// Given
+	mock := &releasefakes.FakeArtifactValidatorImpl{}
+	mock.RsyncRecursiveCalls(func(src, dst string) error {
+		writeTestArtifacts(t, dst)
+		return nil
+	})
+	sut := release.NewArtifactValidator(testArtifactLayout())
+	sut.SetImpl(mock)
+
+	// When
+	err := sut.ValidateBucket("bucket/stage", testArtifactVersion)
+
+	// Then
+	require.Nil(t, err)
+	src, _ := mock.RsyncRecursiveArgsForCall(0)
+	require.Equal(t, "gs://bucket/stage", src)
+
+	// When the download fails
+	mock.RsyncRecursiveReturns(errors.New(""))
+	err = sut.ValidateBucket("gs://bucket/stage", testArtifactVersion)
+
+	// Then
+	require.NotNil(t, err)
+
+	// When the bucket misses artifacts
+	mock.RsyncRecursiveReturns(nil)
+	err = sut.ValidateBucket("gs://bucket/stage", testArtifactVersion)
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestPublishedArtifactLayout(t *testing.T) {
+	paths := map[string]bool{}
+	for _, artifact := range release.PublishedArtifactLayout().Artifacts(testArtifactVersion) {
+		require.NotEqual(t, "images", artifact.Group)
+		paths[artifact.Path] = true
+	}
+	for _, path := range []string{
+		"v1.22.0/kubernetes.tar.gz",
+		"v1.22.0/SHA256SUMS",
+		"v1.22.0/bin/linux/arm64/kubelet",
+	} {
+		require.True(t, paths[path], path)
+	}
+}
+
+func TestArtifactValidatorValidatePublished(t *testing.T) {
+	const buildVersion = "v1.22.0-rc.1.5+8b0d7ce6e6e1c6"
+
+	for _, tc := range []struct {
+		name         string
+		buildVersion string
+		prepare      func(mock *releasefakes.FakeArtifactValidatorImpl)
+		shouldErr    bool
+	}{
+		{
+			name:    "success",
+			prepare: func(*releasefakes.FakeArtifactValidatorImpl) {},
+		},
+		{
+			name:         "success with staged manifests",
+			buildVersion: buildVersion,
+			prepare:      func(*releasefakes.FakeArtifactValidatorImpl) {},
+		},
+		{
+			name: "download fails",
+			prepare: func(mock *releasefakes.FakeArtifactValidatorImpl) {
+				mock.RsyncRecursiveCalls(nil)
+				mock.RsyncRecursiveReturns(errors.New(""))
+			},
+			shouldErr: true,
+		},
+		{
+			name:         "download staged manifest fails",
+			buildVersion: buildVersion,
+			prepare: func(mock *releasefakes.FakeArtifactValidatorImpl) {
+				mock.CopyToLocalCalls(nil)
+				mock.CopyToLocalReturns(errors.New(""))
+			},
+			shouldErr: true,
+		},
+		{
+			name:         "staged manifest mismatch",
+			buildVersion: buildVersion,
+			prepare: func(mock *releasefakes.FakeArtifactValidatorImpl) {
+				mock.CopyToLocalCalls(func(src, dst string) error {
+					require.Nil(t, os.MkdirAll(filepath.Dir(dst), 0o755))
+					return os.WriteFile(dst, []byte(
+						"abc  /workspace/_output-v1.22.0/gcs-stage/v1.22.0/kubernetes.tar.gz",
+					), 0o644)
+				})
+			},
+			shouldErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			publishedDir := ""
+			mock := &releasefakes.FakeArtifactValidatorImpl{}
+			mock.RsyncRecursiveCalls(func(src, dst string) error {
+				publishedDir = dst
+				writeTestArtifacts(t, filepath.Dir(dst))
+				return nil
+			})
+			mock.CopyToLocalCalls(func(src, dst string) error {
+				require.Nil(t, os.MkdirAll(filepath.Dir(dst), 0o755))
+				content, err := os.ReadFile(filepath.Join(publishedDir, filepath.Base(src)))
+				require.Nil(t, err)
+				return os.WriteFile(dst, content, 0o644)
+			})
+			tc.prepare(mock)
+
+			layout := testArtifactLayout()
+			layout.Groups = layout.Groups[:2]
+			sut := release.NewArtifactValidator(layout)
+			sut.SetImpl(mock)
+
+			// When
+			err := sut.ValidatePublished("bucket", testArtifactVersion, tc.buildVersion)
+
+			// Then
+			if tc.shouldErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			src, _ := mock.RsyncRecursiveArgsForCall(0)
+			require.Equal(t, "gs://bucket/release/v1.22.0", src)
+			if tc.buildVersion != "" {
+				require.Equal(t, 2, mock.CopyToLocalCallCount())
+				src, _ := mock.CopyToLocalArgsForCall(0)
+				require.Equal(t,
+					"gs://bucket/stage/"+buildVersion+"/v1.22.0/gcs-stage/v1.22.0/SHA256SUMS",
+					src,
+				)
+			}
+		})
+	}
+}
+
+func TestArtifactValidatorValidatePublishedManifest(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		modify func(dir string) error
+	}{
+		{
+			name: "artifact missing in manifest",
+			modify: func(dir string) error {
+				return os.WriteFile(filepath.Join(dir, "SHA512SUMS"), []byte{}, 0o644)
+			},
+		},
+		{
+			name: "missing manifest",
+			modify: func(dir string) error {
+				return os.Remove(filepath.Join(dir, "SHA256SUMS"))
+			},
+		},
+		{
+			name: "artifact modified",
+			modify: func(dir string) error {
+				return os.WriteFile(
+					filepath.Join(dir, "kubernetes.tar.gz"), []byte("modified"), 0o644,
+				)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			mock := &releasefakes.FakeArtifactValidatorImpl{}
+			mock.RsyncRecursiveCalls(func(src, dst string) error {
+				writeTestArtifacts(t, filepath.Dir(dst))
+				return tc.modify(dst)
+			})
+			layout := testArtifactLayout()
+			layout.Groups = layout.Groups[:2]
+			sut := release.NewArtifactValidator(layout)
+			sut.SetImpl(mock)
+
+			// When
+			err := sut.ValidatePublished("bucket", testArtifactVersion, "")
+
+			// Then
+			require.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/git"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// buildVersionAbbrev is the length of the abbreviated commit within build
+// versions, which matches hack/lib/version.sh of kubernetes/kubernetes.
+const buildVersionAbbrev = 14
+
+// buildDescribeRegex matches the long git description of a commit, like
+// v1.29.0-beta.1-23-g0123456789abcd-dirty.
+var buildDescribeRegex = regexp.MustCompile(`^(.+)-(\d+)-g([0-9a-f]+)(-dirty)?$`)
+
+// BuildVersion is the version of a build from a git clone.
+type BuildVersion struct {
+	// Tag is the latest tag of the built commit, like v1.29.0-beta.1.
+	Tag string
+
+	// Commits is the number of commits since the tag.
+	Commits int
+
+	// Commit is the abbreviated built commit.
+	Commit string
+
+	// Dirty is true if the work tree contains uncommitted changes.
+	Dirty bool
+}
+
+// String returns the build version like Kubernetes stamps it into its
+// binaries, for example v1.29.0-beta.1.23+0123456789abcd or
+// v1.28.4-5+0123456789abcd. Builds of tagged commits use the tag.
+func (b *BuildVersion) String() string {
+	version := b.Tag
+	if b.Commits > 0 {
+		separator := "-"
+		if strings.Contains(b.Tag, "-") {
+			// The distance continues the numbering of the pre-release
+			separator = "."
+		}
+		version += fmt.Sprintf("%s%d+%s", separator, b.Commits, b.Commit)
+	}
+	if b.Dirty {
+		version += "-dirty"
+	}
+	return version
+}
+
+// Semver returns the build version as semver.
+func (b *BuildVersion) Semver() (semver.Version, error) {
+	return util.TagStringToSemver(b.String())
+}
+
+// BuildVersion computes the build version of the revision, which is HEAD
+// including uncommitted changes if empty.
+//
+// Embargoed builds only consider tags which have been published on the
+// default remote. Tags of releases under embargo, which only exist in the
+// clone, are skipped and therefore never end up in build versions of
+// artifacts, which get published before the embargo is lifted.
+func (r *Repo) BuildVersion(rev string, embargoed bool) (*BuildVersion, error) {
+	published := map[string]bool{}
+	if embargoed {
+		output, err := r.repo.LsRemote("--tags", git.DefaultRemote)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing published tags")
+		}
+		for _, field := range strings.Fields(output) {
+			if tag := strings.TrimPrefix(field, "refs/tags/"); tag != field {
+				published[strings.TrimSuffix(tag, "^{}")] = true
+			}
+		}
+	}
+
+	var unpublished []string
+	for {
+		opts := git.NewDescribeOptions().
+			WithTags().
+			WithLong().
+			WithAbbrev(buildVersionAbbrev).
+			WithMatch("v*").
+			WithExclude(unpublished...)
+		if rev == "" {
+			opts.WithDirty()
+		} else {
+			opts.WithRevision(rev)
+		}
+		description, err := r.repo.Describe(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "running git describe")
+		}
+
+		buildVersion, err := parseBuildDescription(description)
+		if err != nil {
+			return nil, err
+		}
+		if !embargoed || published[buildVersion.Tag] {
+			logrus.Infof("Computed build version %s from %s", buildVersion, description)
+			return buildVersion, nil
+		}
+		logrus.Infof("Skipping tag %s, which has not been published", buildVersion.Tag)
+		unpublished = append(unpublished, buildVersion.Tag)
+	}
+}
+
+// parseBuildDescription parses the long git description of a build.
+func parseBuildDescription(description string) (*BuildVersion, error) {
+	match := buildDescribeRegex.FindStringSubmatch(description)
+	if match == nil {
+		return nil, errors.Errorf("unable to parse git description %q", description)
+	}
+	if _, err := util.TagStringToSemver(match[1]); err != nil {
+		return nil, errors.Wrapf(err, "parsing tag %s", match[1])
+	}
+	commits, err := strconv.Atoi(match[2])
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing commit count %s", match[2])
+	}
+	return &BuildVersion{
+		Tag:     match[1],
+		Commits: commits,
+		Commit:  match[3],
+		Dirty:   match[4] != "",
+	}, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/release"
+)
+
+func TestBuildVersionString(t *testing.T) {
+	for _, tc := range []struct {
+		buildVersion release.BuildVersion
+		expected     string
+	}{
+		{
+			buildVersion: release.BuildVersion{Tag: "v1.29.0-beta.1", Commits: 23, Commit: "0123456789abcd"},
+			expected:     "v1.29.0-beta.1.23+0123456789abcd",
+		},
+		{
+			buildVersion: release.BuildVersion{Tag: "v1.28.4", Commits: 5, Commit: "0123456789abcd"},
+			expected:     "v1.28.4-5+0123456789abcd",
+		},
+		{
+			buildVersion: release.BuildVersion{Tag: "v1.28.4", Commit: "0123456789abcd"},
+			expected:     "v1.28.4",
+		},
+		{
+			buildVersion: release.BuildVersion{Tag: "v1.28.4", Commits: 5, Commit: "0123456789abcd", Dirty: true},
+			expected:     "v1.28.4-5+0123456789abcd-dirty",
+		},
+	} {
+		require.Equal(t, tc.expected, tc.buildVersion.String())
+		_, err := tc.buildVersion.Semver()
+		require.Nil(t, err)
+	}
+}
+
+func TestBuildVersionSuccess(t *testing.T) {
+	// Given
+	sut := newSUT(t)
+	defer sut.cleanup()
+	sut.mock.DescribeReturns("v1.29.0-beta.1-23-g0123456789abcd-dirty", nil)
+
+	// When
+	res, err := sut.repo.BuildVersion("", false)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, &release.BuildVersion{
+		Tag: "v1.29.0-beta.1", Commits: 23, Commit: "0123456789abcd", Dirty: true,
+	}, res)
+	require.Zero(t, sut.mock.LsRemoteCallCount())
+	require.Equal(t,
+		git.NewDescribeOptions().WithTags().WithLong().WithAbbrev(14).
+			WithMatch("v*").WithExclude().WithDirty(),
+		sut.mock.DescribeArgsForCall(0),
+	)
+}
+
+func TestBuildVersionSuccessRevision(t *testing.T) {
+	// Given
+	sut := newSUT(t)
+	defer sut.cleanup()
+	sut.mock.DescribeReturns("v1.28.4-0-g0123456789abcd", nil)
+
+	// When
+	res, err := sut.repo.BuildVersion("v1.28.4", false)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "v1.28.4", res.String())
+	require.Equal(t,
+		git.NewDescribeOptions().WithTags().WithLong().WithAbbrev(14).
+			WithMatch("v*").WithExclude().WithRevision("v1.28.4"),
+		sut.mock.DescribeArgsForCall(0),
+	)
+}
+
+func TestBuildVersionSuccessEmbargoed(t *testing.T) {
+	// Given
+	sut := newSUT(t)
+	defer sut.cleanup()
+	sut.mock.LsRemoteReturns(
+		"0123\trefs/tags/v1.28.3\n4567\trefs/tags/v1.28.3^{}\n", nil,
+	)
+	sut.mock.DescribeReturnsOnCall(0, "v1.28.4-2-g0123456789abcd", nil)
+	sut.mock.DescribeReturnsOnCall(1, "v1.28.3-12-g0123456789abcd", nil)
+
+	// When
+	res, err := sut.repo.BuildVersion("", true)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "v1.28.3-12+0123456789abcd", res.String())
+	require.Equal(t, []string{"--tags", git.DefaultRemote}, sut.mock.LsRemoteArgsForCall(0))
+	require.Equal(t, 2, sut.mock.DescribeCallCount())
+	require.Equal(t,
+		git.NewDescribeOptions().WithTags().WithLong().WithAbbrev(14).
+			WithMatch("v*").WithExclude("v1.28.4").WithDirty(),
+		sut.mock.DescribeArgsForCall(1),
+	)
+}
+
+func TestBuildVersionFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		prepare func(*sut)
+	}{
+		{
+			name: "describe fails",
+			prepare: func(s *sut) {
+				s.mock.DescribeReturns("", errors.New(""))
+			},
+		},
+		{
+			name: "unexpected description",
+			prepare: func(s *sut) {
+				s.mock.DescribeReturns("0123456789abcd", nil)
+			},
+		},
+		{
+			name: "tag is not semver",
+			prepare: func(s *sut) {
+				s.mock.DescribeReturns("v1-2-g0123456789abcd", nil)
+			},
+		},
+		{
+			name: "ls-remote fails",
+			prepare: func(s *sut) {
+				s.mock.LsRemoteReturns("", errors.New(""))
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			sut := newSUT(t)
+			defer sut.cleanup()
+			tc.prepare(sut)
+
+			// When
+			_, err := sut.repo.BuildVersion("", true)
+
+			// Then
+			require.NotNil(t, err)
+		})
+	}
+}
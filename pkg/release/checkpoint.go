@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Checkpoint records the completed steps of a multi-step run, like staging,
+// releasing or cutting a branch, in a state file, which allows resuming a
+// failed run after the last completed step. An empty state file path
+// disables the recording.
+type Checkpoint struct {
+	path  string
+	state checkpointState
+}
+
+// checkpointState is the content of the state file.
+type checkpointState struct {
+	// Options is the string representation of the options of the run, to
+	// avoid resuming a run for another version.
+	Options string `json:"options"`
+
+	// Completed are the names of the completed steps in execution order.
+	Completed []string `json:"completed"`
+}
+
+// NewCheckpoint loads the state file at the path, or starts a new one if it
+// does not exist yet. The options are the string representation of the
+// options of the run, and a state file of a run with different options is
+// rejected.
+func NewCheckpoint(path, options string) (*Checkpoint, error) {
+	c := &Checkpoint{path: path, state: checkpointState{Options: options}}
+	if path == "" {
+		return c, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		logrus.Infof("Recording completed steps in state file %s", path)
+		return c, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "read state file %s", path)
+	}
+
+	state := checkpointState{}
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal state file %s", path)
+	}
+	if state.Options != options {
+		return nil, errors.Errorf(
+			"state file %s belongs to a run with different options: %s",
+			path, state.Options,
+		)
+	}
+	logrus.Infof(
+		"Resuming from state file %s with %d completed steps",
+		path, len(state.Completed),
+	)
+	c.state = state
+	return c, nil
+}
+
+// Run executes the step if it has not been completed yet and records it as
+// completed on success.
+func (c *Checkpoint) Run(name string, step func() error) error {
+	for _, completed := range c.state.Completed {
+		if completed == name {
+			logrus.Infof("Skipping already completed step: %s", name)
+			return nil
+		}
+	}
+
+	if err := step(); err != nil {
+		return err
+	}
+
+	c.state.Completed = append(c.state.Completed, name)
+	return c.save()
+}
+
+// save writes the state file.
+func (c *Checkpoint) save() error {
+	if c.path == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(c.state, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal state")
+	}
+	if err := os.WriteFile(c.path, content, os.FileMode(0o644)); err != nil {
+		return errors.Wrapf(err, "write state file %s", c.path)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import "github.com/pkg/errors"
+
+// Mode determines whether the release process rehearses a release or writes
+// to the production locations. All locations which differ between the modes
+// are derived from it, to keep rehearsals away from production.
+type Mode string
+
+const (
+	// ModeMock rehearses a release by using the test bucket and the mock
+	// container registry, while git objects are only pushed in dry run mode.
+	ModeMock Mode = "mock"
+
+	// ModeNoMock releases to the production bucket, the staging container
+	// registry and the upstream git repository.
+	ModeNoMock Mode = "nomock"
+)
+
+// ModeFromNoMock returns the mode for the value of a --nomock flag.
+func ModeFromNoMock(nomock bool) Mode {
+	if nomock {
+		return ModeNoMock
+	}
+	return ModeMock
+}
+
+// String returns the name of the mode.
+func (m Mode) String() string {
+	return string(m)
+}
+
+// Validate returns an error if the mode is unknown.
+func (m Mode) Validate() error {
+	if m != ModeMock && m != ModeNoMock {
+		return errors.Errorf(
+			"invalid release mode %q, must be %q or %q", m, ModeMock, ModeNoMock,
+		)
+	}
+	return nil
+}
+
+// IsNoMock returns true if the mode writes to production locations. Unknown
+// modes are treated as mock.
+func (m Mode) IsNoMock() bool {
+	return m == ModeNoMock
+}
+
+// Bucket returns the Google Cloud bucket for the release artifacts.
+func (m Mode) Bucket() string {
+	if m.IsNoMock() {
+		return ProductionBucket
+	}
+	return TestBucket
+}
+
+// ContainerRegistry returns the container registry for the release images.
+func (m Mode) ContainerRegistry() string {
+	if m.IsNoMock() {
+		return GCRIOPathStaging
+	}
+	return GCRIOPathMock
+}
+
+// DryRun returns true if git objects must not be pushed to the remote.
+func (m Mode) DryRun() bool {
+	return !m.IsNoMock()
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+)
+
+func TestMode(t *testing.T) {
+	for _, tc := range []struct {
+		mode              release.Mode
+		shouldErr         bool
+		noMock            bool
+		bucket            string
+		containerRegistry string
+	}{
+		{
+			mode:              release.ModeMock,
+			bucket:            release.TestBucket,
+			containerRegistry: release.GCRIOPathMock,
+		},
+		{
+			mode:              release.ModeNoMock,
+			noMock:            true,
+			bucket:            release.ProductionBucket,
+			containerRegistry: release.GCRIOPathStaging,
+		},
+		{
+			mode:              "",
+			shouldErr:         true,
+			bucket:            release.TestBucket,
+			containerRegistry: release.GCRIOPathMock,
+		},
+		{
+			mode:              "production",
+			shouldErr:         true,
+			bucket:            release.TestBucket,
+			containerRegistry: release.GCRIOPathMock,
+		},
+	} {
+		err := tc.mode.Validate()
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.mode)
+		} else {
+			require.Nil(t, err, tc.mode)
+		}
+		require.Equal(t, tc.noMock, tc.mode.IsNoMock(), tc.mode)
+		require.Equal(t, !tc.noMock, tc.mode.DryRun(), tc.mode)
+		require.Equal(t, tc.bucket, tc.mode.Bucket(), tc.mode)
+		require.Equal(t, tc.containerRegistry, tc.mode.ContainerRegistry(), tc.mode)
+	}
+}
+
+func TestModeFromNoMock(t *testing.T) {
+	require.Equal(t, release.ModeNoMock, release.ModeFromNoMock(true))
+	require.Equal(t, release.ModeMock, release.ModeFromNoMock(false))
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"k8s.io/release/pkg/git"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// NextVersion returns the version of the next release of the release type on
+// the branch, based on the tags merged into the branch like the ones of
+// git.Repo.TagsForBranch. Tags which are not valid semver are ignored.
+//
+// The version is the prime version of GenerateReleaseVersion for the latest
+// tag of the branch. Alpha and beta releases are cut from the default branch,
+// for example v1.23.0-alpha.3 is followed by v1.23.0-alpha.4 or
+// v1.23.0-beta.0. Release branches consider only the tags of their minor
+// version and are used for release candidates and official releases, for
+// example v1.22.4-rc.0 is followed by v1.22.4-rc.1 or v1.22.4. A freshly cut
+// release branch without release candidate gets v1.23.0-rc.0 next.
+func NextVersion(releaseType, branch string, tags []string) (semver.Version, error) {
+	versions, err := nextVersions(releaseType, branch, tags)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	return util.TagStringToSemver(versions.Prime())
+}
+
+// NextTags returns the tags to be created for the next release of the release
+// type on the branch, based on the tags merged into the branch like
+// NextVersion. The first tag is the one of the release itself. Official
+// releases additionally tag the first release candidate of the next patch
+// version, for example v1.22.4 comes with v1.22.5-rc.0, which keeps `git
+// describe` on the branch pointing to the upcoming version.
+func NextTags(releaseType, branch string, tags []string) ([]string, error) {
+	versions, err := nextVersions(releaseType, branch, tags)
+	if err != nil {
+		return nil, err
+	}
+	return versions.Ordered(), nil
+}
+
+// nextVersions returns the versions of GenerateReleaseVersion for the latest
+// tag of the branch, after verifying that the release type can be cut from
+// the branch.
+func nextVersions(releaseType, branch string, tags []string) (*Versions, error) {
+	versions := []semver.Version{}
+	for _, tag := range tags {
+		if version, err := util.TagStringToSemver(tag); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	if err := CheckReleaseTypeForBranch(releaseType, branch); err != nil {
+		return nil, err
+	}
+
+	var latest semver.Version
+	if branch == git.DefaultBranch {
+		var ok bool
+		latest, ok = latestVersion(versions, func(semver.Version) bool { return true })
+		if !ok {
+			return nil, errors.Errorf("no version tags found on branch %s", branch)
+		}
+		// The branch cut tags the first alpha of the next minor version
+		if label, _ := preReleaseLabel(latest); label != ReleaseTypeAlpha && label != ReleaseTypeBeta {
+			return nil, errors.Errorf(
+				"latest tag %s of branch %s is neither an alpha nor a beta",
+				util.SemverToTagString(latest), branch,
+			)
+		}
+	} else {
+		var major, minor uint64
+		if _, err := fmt.Sscanf(branch, "release-%d.%d", &major, &minor); err != nil {
+			return nil, errors.Wrapf(err, "parsing release branch %s", branch)
+		}
+		var ok bool
+		latest, ok = latestVersion(versions, func(v semver.Version) bool {
+			return v.Major == major && v.Minor == minor
+		})
+		if !ok {
+			return nil, errors.Errorf(
+				"no tags of v%d.%d found on branch %s", major, minor, branch,
+			)
+		}
+		// A freshly cut branch only carries the pre-releases of the default
+		// branch, which makes the first release candidate the next version
+		label, _ := preReleaseLabel(latest)
+		if releaseType == ReleaseTypeRC && latest.Patch == 0 &&
+			(label == ReleaseTypeAlpha || label == ReleaseTypeBeta) {
+			rc := fmt.Sprintf("v%d.%d.0-rc.0", major, minor)
+			return NewReleaseVersions(rc, "", rc, "", ""), nil
+		}
+		// Every official release tags the first release candidate of the
+		// upcoming version
+		if label != ReleaseTypeRC {
+			return nil, errors.Errorf(
+				"latest tag %s of branch %s is not a release candidate",
+				util.SemverToTagString(latest), branch,
+			)
+		}
+	}
+
+	return GenerateReleaseVersion(
+		releaseType, util.SemverToTagString(latest), branch, false,
+	)
+}
+
+// latestVersion returns the highest of the versions matching the filter.
+func latestVersion(
+	versions []semver.Version, filter func(semver.Version) bool,
+) (latest semver.Version, found bool) {
+	for _, version := range versions {
+		if filter(version) && (!found || version.GT(latest)) {
+			latest, found = version, true
+		}
+	}
+	return latest, found
+}
+
+// preReleaseLabel returns the label and number of a pre-release version like
+// v1.23.0-beta.2, which are empty for official releases.
+func preReleaseLabel(version semver.Version) (label string, number uint64) {
+	if len(version.Pre) == 0 {
+		return "", 0
+	}
+	if len(version.Pre) > 1 && version.Pre[1].IsNum {
+		number = version.Pre[1].VersionNum
+	}
+	return version.Pre[0].VersionStr, number
+}
+
+// preRelease returns the pre-release part of a version like beta.2.
+func preRelease(label string, number uint64) []semver.PRVersion {
+	return []semver.PRVersion{
+		{VersionStr: label},
+		{VersionNum: number, IsNum: true},
+	}
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-utils/util"
+)
+
+func TestNextVersion(t *testing.T) {
+	mainTags := []string{
+		"v1.22.0-alpha.0", "v1.22.0-beta.1", "v1.23.0-alpha.0",
+		"v1.23.0-alpha.3", "v1.23.0-alpha.2", "not-semver",
+	}
+	branchTags := []string{
+		"v1.21.0", "v1.22.0-beta.1", "v1.22.0-rc.0",
+		"v1.22.0", "v1.22.1-rc.0", "v1.22.1", "v1.22.2-rc.0", "v1.23.0-alpha.0",
+	}
+
+	for _, tc := range []struct {
+		name        string
+		releaseType string
+		branch      string
+		tags        []string
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			name:        "alpha after alpha",
+			releaseType: release.ReleaseTypeAlpha,
+			branch:      git.DefaultBranch,
+			tags:        mainTags,
+			expected:    "v1.23.0-alpha.4",
+		},
+		{
+			name:        "beta after alpha",
+			releaseType: release.ReleaseTypeBeta,
+			branch:      git.DefaultBranch,
+			tags:        mainTags,
+			expected:    "v1.23.0-beta.0",
+		},
+		{
+			name:        "beta after beta",
+			releaseType: release.ReleaseTypeBeta,
+			branch:      git.DefaultBranch,
+			tags:        append([]string{"v1.23.0-beta.0"}, mainTags...),
+			expected:    "v1.23.0-beta.1",
+		},
+		{
+			name:        "alpha after beta",
+			releaseType: release.ReleaseTypeAlpha,
+			branch:      git.DefaultBranch,
+			tags:        append([]string{"v1.23.0-beta.0"}, mainTags...),
+			shouldErr:   true,
+		},
+		{
+			name:        "alpha without alpha tag of the next minor",
+			releaseType: release.ReleaseTypeAlpha,
+			branch:      git.DefaultBranch,
+			tags:        []string{"v1.22.0"},
+			shouldErr:   true,
+		},
+		{
+			name:        "rc on the default branch",
+			releaseType: release.ReleaseTypeRC,
+			branch:      git.DefaultBranch,
+			tags:        mainTags,
+			shouldErr:   true,
+		},
+		{
+			name:        "no tags on the default branch",
+			releaseType: release.ReleaseTypeAlpha,
+			branch:      git.DefaultBranch,
+			shouldErr:   true,
+		},
+		{
+			name:        "rc after official release",
+			releaseType: release.ReleaseTypeRC,
+			branch:      "release-1.22",
+			tags:        branchTags,
+			expected:    "v1.22.2-rc.1",
+		},
+		{
+			name:        "rc after rc",
+			releaseType: release.ReleaseTypeRC,
+			branch:      "release-1.22",
+			tags:        append([]string{"v1.22.2-rc.1"}, branchTags...),
+			expected:    "v1.22.2-rc.2",
+		},
+		{
+			name:        "rc on a freshly cut release branch",
+			releaseType: release.ReleaseTypeRC,
+			branch:      "release-1.23",
+			tags: []string{
+				"v1.22.0", "v1.23.0-alpha.4", "v1.23.0-beta.0", "v1.23.0-beta.1",
+			},
+			expected: "v1.23.0-rc.0",
+		},
+		{
+			name:        "official on a freshly cut release branch",
+			releaseType: release.ReleaseTypeOfficial,
+			branch:      "release-1.23",
+			tags:        []string{"v1.23.0-beta.1"},
+			shouldErr:   true,
+		},
+		{
+			name:        "rc after a patch pre-release",
+			releaseType: release.ReleaseTypeRC,
+			branch:      "release-1.23",
+			tags:        []string{"v1.23.0", "v1.23.1-beta.0"},
+			shouldErr:   true,
+		},
+		{
+			name:        "official after official release",
+			releaseType: release.ReleaseTypeOfficial,
+			branch:      "release-1.22",
+			tags:        branchTags,
+			expected:    "v1.22.2",
+		},
+		{
+			name:        "official after rc",
+			releaseType: release.ReleaseTypeOfficial,
+			branch:      "release-1.22",
+			tags:        append([]string{"v1.22.2-rc.1"}, branchTags...),
+			expected:    "v1.22.2",
+		},
+		{
+			name:        "beta on a release branch",
+			releaseType: release.ReleaseTypeBeta,
+			branch:      "release-1.22",
+			tags:        branchTags,
+			shouldErr:   true,
+		},
+		{
+			name:        "rc on a release branch without tags",
+			releaseType: release.ReleaseTypeRC,
+			branch:      "release-1.24",
+			tags:        branchTags,
+			shouldErr:   true,
+		},
+		{
+			name:        "alpha on a release branch",
+			releaseType: release.ReleaseTypeAlpha,
+			branch:      "release-1.22",
+			tags:        branchTags,
+			shouldErr:   true,
+		},
+		{
+			name:        "invalid branch",
+			releaseType: release.ReleaseTypeRC,
+			branch:      "feature",
+			tags:        branchTags,
+			shouldErr:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := release.NextVersion(tc.releaseType, tc.branch, tc.tags)
+			if tc.shouldErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, util.SemverToTagString(res))
+		})
+	}
+}
+
+func TestNextTags(t *testing.T) {
+	const (
+		alpha    = release.ReleaseTypeAlpha
+		beta     = release.ReleaseTypeBeta
+		rc       = release.ReleaseTypeRC
+		official = release.ReleaseTypeOfficial
+	)
+
+	// Every release type is tried for every state of a branch, where nil
+	// expected tags mean that the release type is not allowed.
+	for _, tc := range []struct {
+		name     string
+		branch   string
+		tags     []string
+		expected map[string][]string
+	}{
+		{
+			name:   "default branch after alpha",
+			branch: git.DefaultBranch,
+			tags:   []string{"v1.22.0", "v1.23.0-alpha.0", "v1.23.0-alpha.1"},
+			expected: map[string][]string{
+				alpha: {"v1.23.0-alpha.2"},
+				beta:  {"v1.23.0-beta.0"},
+			},
+		},
+		{
+			name:   "default branch after beta",
+			branch: git.DefaultBranch,
+			tags:   []string{"v1.23.0-alpha.4", "v1.23.0-beta.0"},
+			expected: map[string][]string{
+				beta: {"v1.23.0-beta.1"},
+			},
+		},
+		{
+			name:     "default branch without alpha of the next minor",
+			branch:   git.DefaultBranch,
+			tags:     []string{"v1.22.0-beta.2", "v1.22.0"},
+			expected: map[string][]string{},
+		},
+		{
+			name:     "default branch without tags",
+			branch:   git.DefaultBranch,
+			tags:     []string{"not-semver"},
+			expected: map[string][]string{},
+		},
+		{
+			name:   "freshly cut release branch",
+			branch: "release-1.23",
+			tags: []string{
+				"v1.23.0-beta.1", "v1.23.0-beta.0", "v1.23.0-alpha.4", "v1.22.0",
+			},
+			expected: map[string][]string{
+				rc: {"v1.23.0-rc.0"},
+			},
+		},
+		{
+			name:     "release branch with patch pre-release",
+			branch:   "release-1.23",
+			tags:     []string{"v1.23.0", "v1.23.1-beta.0"},
+			expected: map[string][]string{},
+		},
+		{
+			name:     "release branch without tags of its minor",
+			branch:   "release-1.23",
+			tags:     []string{"v1.22.0"},
+			expected: map[string][]string{},
+		},
+		{
+			name:   "release branch without release candidate",
+			branch: "release-1.23",
+			tags:   []string{"v1.23.0-alpha.4", "v1.23.0-beta.2"},
+			expected: map[string][]string{
+				rc: {"v1.23.0-rc.0"},
+			},
+		},
+		{
+			name:   "release branch after rc",
+			branch: "release-1.23",
+			tags:   []string{"v1.23.0-beta.2", "v1.23.0-rc.0", "v1.23.0-rc.1"},
+			expected: map[string][]string{
+				rc:       {"v1.23.0-rc.2"},
+				official: {"v1.23.0", "v1.23.1-rc.0"},
+			},
+		},
+		{
+			name:   "release branch after official release",
+			branch: "release-1.23",
+			tags:   []string{"v1.23.0-rc.1", "v1.23.0", "v1.23.1-rc.0"},
+			expected: map[string][]string{
+				rc:       {"v1.23.1-rc.1"},
+				official: {"v1.23.1", "v1.23.2-rc.0"},
+			},
+		},
+		{
+			name:     "release branch after official release without rc",
+			branch:   "release-1.23",
+			tags:     []string{"v1.23.0"},
+			expected: map[string][]string{},
+		},
+		{
+			name:     "invalid branch",
+			branch:   "feature",
+			tags:     []string{"v1.23.0"},
+			expected: map[string][]string{},
+		},
+	} {
+		for _, releaseType := range []string{alpha, beta, rc, official, "invalid"} {
+			t.Run(tc.name+"/"+releaseType, func(t *testing.T) {
+				res, err := release.NextTags(releaseType, tc.branch, tc.tags)
+				expected, ok := tc.expected[releaseType]
+				if !ok {
+					require.NotNil(t, err)
+					require.Nil(t, res)
+					return
+				}
+				require.Nil(t, err)
+				require.Equal(t, expected, res)
+			})
+		}
+	}
+}
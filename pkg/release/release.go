@@ -150,6 +150,13 @@ var (
 	FastArchitectures = []string{
 		"amd64",
 	}
+
+	// ReleaseBranchProtection is the expected protection of newly created
+	// release branches on GitHub.
+	ReleaseBranchProtection = &github.BranchProtectionPolicy{
+		RequiredChecks: []string{"cla/linuxfoundation"},
+		RestrictPushes: true,
+	}
 )
 
 // ImagePromoterImages abtracts the manifest used by the image promoter
@@ -551,6 +558,30 @@ func CreatePubBotBranchIssue(branchName string) error {
 	return nil
 }
 
+// VerifyReleaseBranchProtection checks that the protection of the release
+// branch in the Kubernetes repository matches ReleaseBranchProtection. The
+// differences get logged and result in an error.
+func VerifyReleaseBranchProtection(branchName string) error {
+	drift, err := github.New().BranchProtectionDrift(
+		git.DefaultGithubOrg, git.DefaultGithubRepo, branchName,
+		ReleaseBranchProtection,
+	)
+	if err != nil {
+		return errors.Wrap(err, "checking branch protection")
+	}
+	if len(drift) == 0 {
+		logrus.Infof("Branch %s is protected as expected", branchName)
+		return nil
+	}
+	for _, d := range drift {
+		logrus.Warnf("Branch protection drift of %s: %s", branchName, d)
+	}
+	return errors.Errorf(
+		"protection of branch %s differs from the expected policy: %s",
+		branchName, strings.Join(drift, ", "),
+	)
+}
+
 // Calls docker login to log into docker hub using a token from the environment
 func DockerHubLogin() error {
 	// Check the environment  variable is set
@@ -25,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/git"
 	"k8s.io/release/pkg/release/regex"
 	"sigs.k8s.io/release-utils/util"
 )
@@ -36,6 +37,37 @@ const (
 	ReleaseTypeAlpha    string = "alpha"
 )
 
+// ReleaseTypesForBranch returns the release types which can be cut from the
+// branch. Alpha and beta releases are cut from the default branch, release
+// candidates and official releases from release branches.
+func ReleaseTypesForBranch(branch string) ([]string, error) {
+	if branch == git.DefaultBranch {
+		return []string{ReleaseTypeAlpha, ReleaseTypeBeta}, nil
+	}
+	if !git.IsReleaseBranch(branch) {
+		return nil, errors.Errorf("%s is not a valid release branch", branch)
+	}
+	return []string{ReleaseTypeRC, ReleaseTypeOfficial}, nil
+}
+
+// CheckReleaseTypeForBranch returns an error if the release type cannot be
+// cut from the branch according to ReleaseTypesForBranch.
+func CheckReleaseTypeForBranch(releaseType, branch string) error {
+	allowed, err := ReleaseTypesForBranch(branch)
+	if err != nil {
+		return err
+	}
+	for _, allowedType := range allowed {
+		if releaseType == allowedType {
+			return nil
+		}
+	}
+	return errors.Errorf(
+		"%s releases cannot be cut from branch %s, only %s releases",
+		releaseType, branch, strings.Join(allowed, " and "),
+	)
+}
+
 // Versions specifies the collection of found release versions
 type Versions struct {
 	prime    string
@@ -126,6 +158,10 @@ func GenerateReleaseVersion(
 		version, branch, branchFromMaster,
 	)
 
+	if err := CheckReleaseTypeForBranch(releaseType, branch); err != nil {
+		return nil, err
+	}
+
 	// if branch == git.DefaultBranch, version is an alpha or beta
 	// if branch == release, version is a rc
 	// if branch == release+1, version is an alpha
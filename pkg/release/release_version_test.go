@@ -162,6 +162,28 @@ func TestGenerateReleaseVersion(t *testing.T) {
 				require.Nil(t, res)
 			},
 		},
+		{
+			// beta on a release branch
+			releaseType:      release.ReleaseTypeBeta,
+			version:          "v1.18.4-rc.0.3+3ff09514d162b0",
+			branch:           "release-1.18",
+			branchFromMaster: false,
+			expect: func(res *release.Versions, err error) {
+				require.NotNil(t, err)
+				require.Nil(t, res)
+			},
+		},
+		{
+			// rc on the default branch
+			releaseType:      release.ReleaseTypeRC,
+			version:          "v1.20.0-beta.1.2+3ff09514d162b0",
+			branch:           git.DefaultBranch,
+			branchFromMaster: false,
+			expect: func(res *release.Versions, err error) {
+				require.NotNil(t, err)
+				require.Nil(t, res)
+			},
+		},
 		{
 			// invalid branch
 			releaseType:      release.ReleaseTypeOfficial,
@@ -179,3 +201,29 @@ func TestGenerateReleaseVersion(t *testing.T) {
 		))
 	}
 }
+
+func TestCheckReleaseTypeForBranch(t *testing.T) {
+	for _, tc := range []struct {
+		releaseType string
+		branch      string
+		shouldErr   bool
+	}{
+		{releaseType: release.ReleaseTypeAlpha, branch: git.DefaultBranch},
+		{releaseType: release.ReleaseTypeBeta, branch: git.DefaultBranch},
+		{releaseType: release.ReleaseTypeRC, branch: git.DefaultBranch, shouldErr: true},
+		{releaseType: release.ReleaseTypeOfficial, branch: git.DefaultBranch, shouldErr: true},
+		{releaseType: release.ReleaseTypeAlpha, branch: "release-1.23", shouldErr: true},
+		{releaseType: release.ReleaseTypeBeta, branch: "release-1.23", shouldErr: true},
+		{releaseType: release.ReleaseTypeRC, branch: "release-1.23"},
+		{releaseType: release.ReleaseTypeOfficial, branch: "release-1.23"},
+		{releaseType: release.ReleaseTypeOfficial, branch: "feature", shouldErr: true},
+		{releaseType: "invalid", branch: "release-1.23", shouldErr: true},
+	} {
+		err := release.CheckReleaseTypeForBranch(tc.releaseType, tc.branch)
+		if tc.shouldErr {
+			require.NotNil(t, err, tc.releaseType+"/"+tc.branch)
+		} else {
+			require.Nil(t, err, tc.releaseType+"/"+tc.branch)
+		}
+	}
+}
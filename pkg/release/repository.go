@@ -87,7 +87,7 @@ func (r *Repo) GetTag() (string, error) {
 }
 
 // CheckState verifies that the repository is in the requested state
-func (r *Repo) CheckState(expOrg, expRepo, expRev string, nomock bool) error {
+func (r *Repo) CheckState(expOrg, expRepo, expRev string, mode Mode) error {
 	logrus.Info("Verifying repository state")
 
 	dirty, err := r.repo.IsDirty()
@@ -122,7 +122,7 @@ func (r *Repo) CheckState(expOrg, expRepo, expRev string, nomock bool) error {
 		return errors.Errorf("revision %q expected but got %q", head, rev)
 	}
 
-	if nomock && !(expOrg == DefaultToolOrg && expRepo == DefaultToolRepo && expRev == DefaultToolRef) {
+	if mode.IsNoMock() && !(expOrg == DefaultToolOrg && expRepo == DefaultToolRepo && expRev == DefaultToolRef) {
 		return errors.New("disallow using anything other than kubernetes/release:master with nomock flag")
 	}
 
@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/git"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// The names of the checks of the tag policy.
+const (
+	TagCheckSemver     = "semver"
+	TagCheckBranch     = "branch"
+	TagCheckMonotonic  = "monotonic"
+	TagCheckSequence   = "sequence"
+	TagCheckRemoteTag  = "remote-tag"
+	tagCheckPassedText = "PASS"
+	tagCheckFailedText = "FAIL"
+)
+
+// TagValidator checks proposed release tags against the tag policy.
+type TagValidator struct {
+	impl tagValidatorImpl
+}
+
+// NewTagValidator creates a new tag validator for the repository.
+func NewTagValidator(repo *git.Repo) *TagValidator {
+	return &TagValidator{repo}
+}
+
+// SetImpl can be used to set the internal TagValidator implementation.
+func (t *TagValidator) SetImpl(impl tagValidatorImpl) {
+	t.impl = impl
+}
+
+//counterfeiter:generate . tagValidatorImpl
+type tagValidatorImpl interface {
+	HasRemoteTag(tag string) (bool, error)
+	TagsForBranch(branch string) ([]string, error)
+}
+
+// TagPolicyReport is the result of validating a tag against the tag policy.
+type TagPolicyReport struct {
+	Tag    string           `json:"tag"`
+	Branch string           `json:"branch"`
+	Checks []TagPolicyCheck `json:"checks"`
+}
+
+// TagPolicyCheck is the result of a single check of the tag policy.
+type TagPolicyCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// Passed returns true if all checks of the report passed.
+func (r *TagPolicyReport) Passed() bool {
+	for _, check := range r.Checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns an error listing the failed checks, or nil if the tag complies
+// with the policy. It is meant to be used as a gate before tagging.
+func (r *TagPolicyReport) Err() error {
+	failed := []string{}
+	for _, check := range r.Checks {
+		if !check.Passed {
+			failed = append(failed, fmt.Sprintf("%s: %s", check.Name, check.Message))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return errors.Errorf(
+		"tag %s violates the tag policy for branch %s: %s",
+		r.Tag, r.Branch, strings.Join(failed, "; "),
+	)
+}
+
+// String returns a human readable summary of the report.
+func (r *TagPolicyReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Tag policy report for %s on branch %s:\n", r.Tag, r.Branch)
+	for _, check := range r.Checks {
+		result := tagCheckPassedText
+		if !check.Passed {
+			result = tagCheckFailedText
+		}
+		fmt.Fprintf(&sb, "  [%s] %s: %s\n", result, check.Name, check.Message)
+	}
+	return sb.String()
+}
+
+func (r *TagPolicyReport) add(name string, passed bool, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, TagPolicyCheck{
+		Name: name, Passed: passed, Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate checks the proposed tag for the branch against the tag policy:
+//
+//   - the tag has to be a valid semver version
+//   - the release type of the tag can be cut from the branch according to
+//     ReleaseTypesForBranch, and release branches only get the tags of their
+//     minor version
+//   - the tag has to be higher than all tags of its minor version on the branch
+//   - pre-releases have to continue the numbering of their label without gaps,
+//     for example v1.23.0-rc.2 requires v1.23.0-rc.1
+//   - the tag must not exist on the default remote yet
+//
+// Violations of the policy are recorded in the returned report, while the
+// error indicates that the validation itself was not possible.
+func (t *TagValidator) Validate(tag, branch string) (*TagPolicyReport, error) {
+	report := &TagPolicyReport{Tag: tag, Branch: branch}
+	logrus.Infof("Validating tag %s for branch %s", tag, branch)
+
+	version, err := util.TagStringToSemver(tag)
+	if err != nil {
+		report.add(TagCheckSemver, false, "%s is not a valid semver tag", tag)
+		return report, nil
+	}
+	report.add(TagCheckSemver, true, "%s is a valid semver tag", tag)
+
+	passed, message := checkTagBranch(version, branch)
+	report.add(TagCheckBranch, passed, "%s", message)
+
+	tags, err := t.impl.TagsForBranch(branch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving tags for branch %s", branch)
+	}
+	existing := map[string]bool{}
+	versions := []semver.Version{}
+	for _, existingTag := range tags {
+		if v, err := util.TagStringToSemver(existingTag); err == nil &&
+			v.Major == version.Major && v.Minor == version.Minor {
+			versions = append(versions, v)
+			existing[util.SemverToTagString(v)] = true
+		}
+	}
+
+	latest, found := latestVersion(versions, func(semver.Version) bool { return true })
+	if !found || version.GT(latest) {
+		report.add(TagCheckMonotonic, true, "%s is the highest version", tag)
+	} else {
+		report.add(TagCheckMonotonic, false,
+			"%s is not higher than the latest tag %s",
+			tag, util.SemverToTagString(latest),
+		)
+	}
+
+	label, number := preReleaseLabel(version)
+	if label == "" || number == 0 {
+		report.add(TagCheckSequence, true, "%s starts or has no pre-release sequence", tag)
+	} else {
+		previous := semver.Version{
+			Major: version.Major,
+			Minor: version.Minor,
+			Patch: version.Patch,
+			Pre:   preRelease(label, number-1),
+		}
+		previousTag := util.SemverToTagString(previous)
+		if existing[previousTag] {
+			report.add(TagCheckSequence, true, "%s follows %s", tag, previousTag)
+		} else {
+			report.add(TagCheckSequence, false,
+				"%s skips the pre-release %s", tag, previousTag,
+			)
+		}
+	}
+
+	hasRemoteTag, err := t.impl.HasRemoteTag(tag)
+	if err != nil {
+		return nil, errors.Wrapf(err, "checking if tag %s exists on remote", tag)
+	}
+	if hasRemoteTag {
+		report.add(TagCheckRemoteTag, false, "%s already exists on the remote", tag)
+	} else {
+		report.add(TagCheckRemoteTag, true, "%s does not exist on the remote", tag)
+	}
+
+	if report.Passed() {
+		logrus.Infof("Tag %s complies with the tag policy for branch %s", tag, branch)
+	} else {
+		logrus.Warnf("Tag %s violates the tag policy for branch %s", tag, branch)
+	}
+	return report, nil
+}
+
+// checkTagBranch verifies that the version is allowed to be tagged on the
+// branch and returns the result as well as its message.
+func checkTagBranch(version semver.Version, branch string) (passed bool, message string) {
+	tag := util.SemverToTagString(version)
+	releaseType, _ := preReleaseLabel(version)
+	if releaseType == "" {
+		releaseType = ReleaseTypeOfficial
+	}
+	if err := CheckReleaseTypeForBranch(releaseType, branch); err != nil {
+		return false, err.Error()
+	}
+
+	if branch != git.DefaultBranch {
+		if expected := fmt.Sprintf(
+			"release-%d.%d", version.Major, version.Minor,
+		); branch != expected {
+			return false, fmt.Sprintf("%s has to be tagged on branch %s", tag, expected)
+		}
+	}
+	return true, fmt.Sprintf("%s can be tagged on branch %s", tag, branch)
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/release/releasefakes"
+)
+
+func TestTagValidatorValidate(t *testing.T) {
+	mainTags := []string{
+		"v1.23.0-alpha.2", "v1.23.0-alpha.1", "v1.23.0-alpha.0", "v1.22.0-beta.0",
+	}
+	branchTags := []string{
+		"v1.22.1", "v1.22.1-rc.0", "v1.22.0", "v1.22.0-rc.1", "v1.22.0-rc.0",
+		"v1.22.0-beta.0", "v1.22.0-alpha.0", "not-semver",
+	}
+
+	for _, tc := range []struct {
+		name         string
+		tag          string
+		branch       string
+		tags         []string
+		hasRemoteTag bool
+		failedChecks []string
+	}{
+		{
+			name:   "next alpha",
+			tag:    "v1.23.0-alpha.3",
+			branch: git.DefaultBranch,
+			tags:   mainTags,
+		},
+		{
+			name:   "first beta",
+			tag:    "v1.23.0-beta.0",
+			branch: git.DefaultBranch,
+			tags:   mainTags,
+		},
+		{
+			name:   "next patch release",
+			tag:    "v1.22.2",
+			branch: "release-1.22",
+			tags:   branchTags,
+		},
+		{
+			name:   "next release candidate",
+			tag:    "v1.22.2-rc.0",
+			branch: "release-1.22",
+			tags:   branchTags,
+		},
+		{
+			name:         "not semver",
+			tag:          "v1.23",
+			branch:       git.DefaultBranch,
+			tags:         mainTags,
+			failedChecks: []string{release.TagCheckSemver},
+		},
+		{
+			name:         "rc on the default branch",
+			tag:          "v1.23.0-rc.0",
+			branch:       git.DefaultBranch,
+			tags:         mainTags,
+			failedChecks: []string{release.TagCheckBranch},
+		},
+		{
+			name:         "wrong release branch",
+			tag:          "v1.21.5",
+			branch:       "release-1.22",
+			tags:         branchTags,
+			failedChecks: []string{release.TagCheckBranch},
+		},
+		{
+			name:         "alpha on a release branch",
+			tag:          "v1.22.2-alpha.0",
+			branch:       "release-1.22",
+			tags:         branchTags,
+			failedChecks: []string{release.TagCheckBranch},
+		},
+		{
+			name:         "beta on a release branch",
+			tag:          "v1.22.2-beta.0",
+			branch:       "release-1.22",
+			tags:         branchTags,
+			failedChecks: []string{release.TagCheckBranch},
+		},
+		{
+			name:         "invalid branch",
+			tag:          "v1.22.2",
+			branch:       "feature",
+			tags:         branchTags,
+			failedChecks: []string{release.TagCheckBranch},
+		},
+		{
+			name:         "not increasing",
+			tag:          "v1.22.0-rc.2",
+			branch:       "release-1.22",
+			tags:         branchTags,
+			failedChecks: []string{release.TagCheckMonotonic},
+		},
+		{
+			name:         "skipped pre-release",
+			tag:          "v1.23.0-alpha.4",
+			branch:       git.DefaultBranch,
+			tags:         mainTags,
+			failedChecks: []string{release.TagCheckSequence},
+		},
+		{
+			name:         "already on the remote",
+			tag:          "v1.22.2",
+			branch:       "release-1.22",
+			tags:         branchTags,
+			hasRemoteTag: true,
+			failedChecks: []string{release.TagCheckRemoteTag},
+		},
+		{
+			name:   "multiple violations",
+			tag:    "v1.22.1-rc.2",
+			branch: "release-1.22",
+			tags:   branchTags,
+			failedChecks: []string{
+				release.TagCheckMonotonic, release.TagCheckSequence,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			mock := &releasefakes.FakeTagValidatorImpl{}
+			mock.TagsForBranchReturns(tc.tags, nil)
+			mock.HasRemoteTagReturns(tc.hasRemoteTag, nil)
+			sut := release.NewTagValidator(nil)
+			sut.SetImpl(mock)
+
+			// When
+			report, err := sut.Validate(tc.tag, tc.branch)
+
+			// Then
+			require.Nil(t, err)
+			require.Equal(t, tc.tag, report.Tag)
+			require.Equal(t, tc.branch, report.Branch)
+
+			failed := []string{}
+			for _, check := range report.Checks {
+				require.NotEmpty(t, check.Message)
+				if !check.Passed {
+					failed = append(failed, check.Name)
+				}
+			}
+			if len(tc.failedChecks) == 0 {
+				require.Empty(t, failed, report.String())
+				require.True(t, report.Passed())
+				require.Nil(t, report.Err())
+				require.Len(t, report.Checks, 5)
+				return
+			}
+			require.Equal(t, tc.failedChecks, failed, report.String())
+			require.False(t, report.Passed())
+			require.NotNil(t, report.Err())
+			require.Contains(t, report.String(), "[FAIL] "+tc.failedChecks[0])
+		})
+	}
+}
+
+func TestTagValidatorValidateFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		prepare func(*releasefakes.FakeTagValidatorImpl)
+	}{
+		{
+			name: "tags for branch fails",
+			prepare: func(mock *releasefakes.FakeTagValidatorImpl) {
+				mock.TagsForBranchReturns(nil, errors.New(""))
+			},
+		},
+		{
+			name: "has remote tag fails",
+			prepare: func(mock *releasefakes.FakeTagValidatorImpl) {
+				mock.HasRemoteTagReturns(false, errors.New(""))
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			mock := &releasefakes.FakeTagValidatorImpl{}
+			tc.prepare(mock)
+			sut := release.NewTagValidator(nil)
+			sut.SetImpl(mock)
+
+			// When
+			_, err := sut.Validate("v1.22.2", "release-1.22")
+
+			// Then
+			require.NotNil(t, err)
+		})
+	}
+}
@@ -17,31 +17,17 @@ limitations under the License.
 package release
 
 import (
-	"strings"
-
-	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/release/pkg/git"
-	"sigs.k8s.io/release-utils/http"
+	"k8s.io/release/pkg/release/version"
+	"sigs.k8s.io/release-utils/util"
 )
 
-// Version is a wrapper around version related functionality
+// Version is a wrapper around version related functionality, which resolves
+// the version markers using a version.Resolver.
 type Version struct {
-	client VersionClient
-}
-
-// VersionClient is a client for getting Kubernetes versions
-//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
-//counterfeiter:generate . VersionClient
-type VersionClient interface {
-	GetURLResponse(string) (string, error)
-}
-
-type versionClient struct{}
-
-func (*versionClient) GetURLResponse(url string) (string, error) {
-	return http.GetURLResponse(url, true)
+	resolver *version.Resolver
 }
 
 // VersionType is a simple wrapper around a Kubernetes release version
@@ -63,27 +49,24 @@ const (
 	// VersionTypeCILatestCross references the latest CI cross build Kubernetes
 	// version, for example `v1.19.0-alpha.0.721+f8ff8f44206ff4`
 	VersionTypeCILatestCross VersionType = "ci/k8s-" + git.DefaultBranch
-
-	// baseURL is the base URL for every release version retrieval
-	baseURL = "https://dl.k8s.io/"
 )
 
 // NewVersion creates a new Version
 func NewVersion() *Version {
-	return &Version{&versionClient{}}
+	return &Version{version.New()}
 }
 
-// SetClient can be used to manually set the internal Version client
-func (v *Version) SetClient(client VersionClient) {
-	v.client = client
+// SetClient can be used to manually set the internal client of the resolver
+func (v *Version) SetClient(client version.Client) {
+	v.resolver.SetClient(client)
 }
 
 // URL retrieves the full URL of the Kubernetes release version
-func (t VersionType) URL(version string) string {
-	url := baseURL + string(t)
+func (t VersionType) URL(minor string) string {
+	url := version.DefaultBaseURL + string(t)
 
-	if version != "" {
-		url += "-" + version
+	if minor != "" {
+		url += "-" + minor
 	}
 	url += ".txt"
 
@@ -93,7 +76,11 @@ func (t VersionType) URL(version string) string {
 // GetKubeVersion retrieves the version of the provided Kubernetes version type
 func (v *Version) GetKubeVersion(versionType VersionType) (string, error) {
 	logrus.Infof("Retrieving Kubernetes release version for %s", versionType)
-	return v.kubeVersionFromURL(versionType.URL(""))
+	kubeVersion, err := v.resolver.Resolve(string(versionType))
+	if err != nil {
+		return "", err
+	}
+	return util.SemverToTagString(kubeVersion), nil
 }
 
 // GetKubeVersionForBranch returns the remote Kubernetes release version for
@@ -103,28 +90,9 @@ func (v *Version) GetKubeVersionForBranch(versionType VersionType, branch string
 		"Retrieving Kubernetes release version for %s on branch %s",
 		versionType, branch,
 	)
-
-	version := ""
-	if branch != git.DefaultBranch {
-		if !git.IsReleaseBranch(branch) {
-			return "", errors.Errorf("%s is not a valid release branch", branch)
-		}
-		version = strings.TrimPrefix(branch, "release-")
+	kubeVersion, err := v.resolver.ResolveForBranch(string(versionType), branch)
+	if err != nil {
+		return "", err
 	}
-	url := versionType.URL(version)
-
-	return v.kubeVersionFromURL(url)
-}
-
-// kubeVersionFromURL retrieves the Kubernetes version from the provided URL
-// ans strips the tag prefix if `stripTagPrefix` is `true`
-func (v *Version) kubeVersionFromURL(url string) (string, error) {
-	logrus.Infof("Retrieving Kubernetes build version from %s...", url)
-	version, httpErr := v.client.GetURLResponse(url)
-	if httpErr != nil {
-		return "", errors.Wrap(httpErr, "retrieving kube version")
-	}
-
-	logrus.Infof("Retrieved Kubernetes version: %s", version)
-	return version, nil
+	return util.SemverToTagString(kubeVersion), nil
 }
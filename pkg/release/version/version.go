@@ -0,0 +1,223 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package version resolves the Kubernetes version markers published on
+// dl.k8s.io, like release/stable.txt or ci/latest-1.22.txt.
+package version
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/retry"
+	"sigs.k8s.io/release-utils/util"
+)
+
+const (
+	// DefaultBaseURL is the location of the version markers.
+	DefaultBaseURL = "https://dl.k8s.io/"
+
+	// defaultRetries is the number of times a failed marker retrieval gets
+	// retried.
+	defaultRetries = 3
+
+	// maxWait is the maximum time to wait between two attempts.
+	maxWait = 30 * time.Second
+
+	// timeout is the maximum time a single attempt may take.
+	timeout = 30 * time.Second
+)
+
+// Markers most commonly used, which can also be written without their
+// release/ bucket.
+const (
+	// MarkerStable is the latest stable release, for example v1.22.3.
+	MarkerStable = "release/stable"
+
+	// MarkerLatest is the latest release including pre-releases, for
+	// example v1.23.0-beta.1.
+	MarkerLatest = "release/latest"
+
+	// MarkerCILatest is the latest CI build, for example
+	// v1.23.0-alpha.0.721+f8ff8f44206ff4.
+	MarkerCILatest = "ci/latest"
+)
+
+// ErrMarkerNotFound is returned if a version marker does not exist, which
+// means that retrying will not help.
+var ErrMarkerNotFound = errors.New("version marker not found")
+
+// markerRegex matches the markers in the form [<bucket>/]<name>[.txt].
+var markerRegex = regexp.MustCompile(`^(?:(release|ci)/)?([a-z0-9][a-z0-9.-]*?)(?:\.txt)?$`)
+
+// Client retrieves the content of version markers.
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . Client
+type Client interface {
+	// GetMarker returns the content of the marker at url. It returns an
+	// error wrapping ErrMarkerNotFound if the marker does not exist.
+	GetMarker(url string) (string, error)
+}
+
+type defaultClient struct {
+	client *http.Client
+}
+
+func (c *defaultClient) GetMarker(url string) (string, error) {
+	resp, err := c.client.Get(url)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", errors.Wrapf(ErrMarkerNotFound, "getting %s", url)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("HTTP error %s for %s", resp.Status, url)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading %s", url)
+	}
+	return string(content), nil
+}
+
+// Resolver resolves version markers to the versions they point to. Resolved
+// markers are cached for the lifetime of the resolver, which makes it safe
+// to resolve the same marker for every build of a run.
+type Resolver struct {
+	client  Client
+	baseURL string
+	backoff *retry.Backoff
+
+	mu    sync.Mutex
+	cache map[string]semver.Version
+}
+
+// New creates a new Resolver for the markers of dl.k8s.io.
+func New() *Resolver {
+	return &Resolver{
+		client:  &defaultClient{client: &http.Client{Timeout: timeout}},
+		baseURL: DefaultBaseURL,
+		backoff: retry.New(defaultRetries, maxWait),
+		cache:   map[string]semver.Version{},
+	}
+}
+
+// SetClient can be used to manually set the internal client.
+func (r *Resolver) SetClient(client Client) {
+	r.client = client
+}
+
+// SetBaseURL sets the location of the markers, for example a mirror of
+// dl.k8s.io.
+func (r *Resolver) SetBaseURL(baseURL string) {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	r.baseURL = baseURL
+}
+
+// SetRetries sets the number of times a failed marker retrieval gets retried.
+func (r *Resolver) SetRetries(retries int) {
+	r.backoff.Retries = retries
+}
+
+// SetSleep sets the function used to wait between two attempts.
+func (r *Resolver) SetSleep(sleep func(time.Duration)) {
+	r.backoff.Sleep = sleep
+}
+
+// URL returns the location of the marker, which can be a name like stable,
+// stable-1.22 or latest for release markers, or prefixed with its bucket
+// like ci/latest-1.22. The .txt suffix is optional.
+func (r *Resolver) URL(marker string) (string, error) {
+	match := markerRegex.FindStringSubmatch(marker)
+	if match == nil {
+		return "", errors.Errorf("invalid version marker %q", marker)
+	}
+	bucket := match[1]
+	if bucket == "" {
+		bucket = "release"
+	}
+	return r.baseURL + bucket + "/" + match[2] + ".txt", nil
+}
+
+// Resolve returns the version the marker points to.
+func (r *Resolver) Resolve(marker string) (semver.Version, error) {
+	url, err := r.URL(marker)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if version, ok := r.cache[url]; ok {
+		return version, nil
+	}
+
+	content, err := r.getMarker(url)
+	if err != nil {
+		return semver.Version{}, errors.Wrapf(err, "resolving version marker %s", marker)
+	}
+	version, err := util.TagStringToSemver(strings.TrimSpace(content))
+	if err != nil {
+		return semver.Version{}, errors.Wrapf(
+			err, "parsing content %q of version marker %s", content, marker,
+		)
+	}
+
+	logrus.Infof("Resolved version marker %s to v%s", marker, version)
+	r.cache[url] = version
+	return version, nil
+}
+
+// ResolveForBranch returns the version of the marker for the release branch,
+// for example stable-1.22 for release-1.22. The marker itself gets used for
+// the default branch.
+func (r *Resolver) ResolveForBranch(marker, branch string) (semver.Version, error) {
+	if branch == git.DefaultBranch {
+		return r.Resolve(marker)
+	}
+	if !git.IsReleaseBranch(branch) {
+		return semver.Version{}, errors.Errorf("%s is not a valid release branch", branch)
+	}
+	minor := strings.TrimPrefix(branch, "release-")
+	return r.Resolve(strings.TrimSuffix(marker, ".txt") + "-" + minor)
+}
+
+// getMarker retrieves the content of the marker and retries failed attempts
+// using an exponential backoff. Missing markers are not retried.
+func (r *Resolver) getMarker(url string) (content string, err error) {
+	err = r.backoff.Run("getting "+url, func() error {
+		content, err = r.client.GetMarker(url)
+		if errors.Is(err, ErrMarkerNotFound) {
+			return retry.Permanent(err)
+		}
+		return err
+	})
+	return content, err
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/release/version"
+	"k8s.io/release/pkg/release/version/versionfakes"
+)
+
+func newSUT() (*version.Resolver, *versionfakes.FakeClient) {
+	client := &versionfakes.FakeClient{}
+	sut := version.New()
+	sut.SetClient(client)
+	sut.SetSleep(func(time.Duration) {})
+	return sut, client
+}
+
+func TestURL(t *testing.T) {
+	sut := version.New()
+	for marker, expected := range map[string]string{
+		"stable":                  "https://dl.k8s.io/release/stable.txt",
+		"stable.txt":              "https://dl.k8s.io/release/stable.txt",
+		"stable-1.22":             "https://dl.k8s.io/release/stable-1.22.txt",
+		"latest.txt":              "https://dl.k8s.io/release/latest.txt",
+		version.MarkerStable:      "https://dl.k8s.io/release/stable.txt",
+		version.MarkerCILatest:    "https://dl.k8s.io/ci/latest.txt",
+		"ci/latest-1.22.txt":      "https://dl.k8s.io/ci/latest-1.22.txt",
+		"ci/k8s-master":           "https://dl.k8s.io/ci/k8s-master.txt",
+		"release/stable-1.21.txt": "https://dl.k8s.io/release/stable-1.21.txt",
+	} {
+		url, err := sut.URL(marker)
+		require.Nil(t, err, marker)
+		require.Equal(t, expected, url, marker)
+	}
+
+	for _, marker := range []string{"", "Stable", "foo/stable", "../stable", "stable/"} {
+		_, err := sut.URL(marker)
+		require.NotNil(t, err, marker)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.GetMarkerReturns("v1.23.0-alpha.0.721+f8ff8f44206ff4\n", nil)
+
+	// When
+	res, err := sut.Resolve("ci/latest")
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "1.23.0-alpha.0.721+f8ff8f44206ff4", res.String())
+	require.Equal(t, "https://dl.k8s.io/ci/latest.txt", client.GetMarkerArgsForCall(0))
+
+	// When the marker gets resolved again
+	res, err = sut.Resolve("ci/latest.txt")
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "1.23.0-alpha.0.721+f8ff8f44206ff4", res.String())
+	require.Equal(t, 1, client.GetMarkerCallCount())
+}
+
+func TestResolveRetries(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.GetMarkerReturnsOnCall(0, "", errors.New("error"))
+	client.GetMarkerReturnsOnCall(1, "", errors.New("error"))
+	client.GetMarkerReturnsOnCall(2, "v1.22.3", nil)
+
+	// When
+	res, err := sut.Resolve(version.MarkerStable)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "1.22.3", res.String())
+	require.Equal(t, 3, client.GetMarkerCallCount())
+}
+
+func TestResolveFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		content       string
+		err           error
+		expectedCalls int
+	}{
+		{
+			name:          "retries exhausted",
+			err:           errors.New("error"),
+			expectedCalls: 4,
+		},
+		{
+			name:          "marker not found",
+			err:           errors.Wrap(version.ErrMarkerNotFound, "getting marker"),
+			expectedCalls: 1,
+		},
+		{
+			name:          "invalid content",
+			content:       "<html>",
+			expectedCalls: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			sut, client := newSUT()
+			client.GetMarkerReturns(tc.content, tc.err)
+
+			// When
+			_, err := sut.Resolve("stable")
+
+			// Then
+			require.NotNil(t, err)
+			require.Equal(t, tc.expectedCalls, client.GetMarkerCallCount())
+
+			// Failures are not cached
+			_, err = sut.Resolve("stable")
+			require.NotNil(t, err)
+			require.Equal(t, 2*tc.expectedCalls, client.GetMarkerCallCount())
+		})
+	}
+}
+
+func TestResolveForBranch(t *testing.T) {
+	// Given
+	sut, client := newSUT()
+	client.GetMarkerReturns("v1.22.3", nil)
+
+	// When
+	_, err := sut.ResolveForBranch("stable", "release-1.22")
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "https://dl.k8s.io/release/stable-1.22.txt", client.GetMarkerArgsForCall(0))
+
+	// When on the default branch
+	_, err = sut.ResolveForBranch("ci/latest", git.DefaultBranch)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "https://dl.k8s.io/ci/latest.txt", client.GetMarkerArgsForCall(1))
+
+	// When on an invalid branch
+	_, err = sut.ResolveForBranch("stable", "feature")
+
+	// Then
+	require.NotNil(t, err)
+	require.Equal(t, 2, client.GetMarkerCallCount())
+}
+
+func TestResolveDefaultClient(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/release/stable.txt" {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprintln(w, "v1.22.3")
+		},
+	))
+	defer server.Close()
+
+	sut := version.New()
+	sut.SetBaseURL(server.URL)
+
+	// When
+	res, err := sut.Resolve("stable")
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, "1.22.3", res.String())
+
+	// When the marker does not exist
+	_, err = sut.Resolve("stable-1.99")
+
+	// Then
+	require.True(t, errors.Is(err, version.ErrMarkerNotFound))
+}
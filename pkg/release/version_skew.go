@@ -0,0 +1,244 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+)
+
+// Components of the version skew policy.
+const (
+	SkewComponentAPIServer = "kube-apiserver"
+	SkewComponentKubelet   = "kubelet"
+	SkewComponentKubectl   = "kubectl"
+)
+
+// SkewPolicy is the range of minor versions of a component which is
+// supported with a kube-apiserver.
+type SkewPolicy struct {
+	// Component is the name of the component, like kubelet.
+	Component string `json:"component"`
+
+	// OlderMinors is the amount of minor versions the component may be older
+	// than the kube-apiserver.
+	OlderMinors uint64 `json:"olderMinors"`
+
+	// NewerMinors is the amount of minor versions the component may be newer
+	// than the kube-apiserver.
+	NewerMinors uint64 `json:"newerMinors"`
+}
+
+// DefaultSkewPolicies returns the policies of the Kubernetes version skew
+// policy: the kube-apiserver instances of a cluster may differ by one minor
+// version, kubelets must not be newer and may be up to two minor versions
+// older, and kubectl is supported within one minor version in both
+// directions.
+func DefaultSkewPolicies() []SkewPolicy {
+	return []SkewPolicy{
+		{Component: SkewComponentAPIServer, OlderMinors: 1},
+		{Component: SkewComponentKubelet, OlderMinors: 2},
+		{Component: SkewComponentKubectl, OlderMinors: 1, NewerMinors: 1},
+	}
+}
+
+// VersionSkewMatrix contains the supported minor versions of all components
+// per supported kube-apiserver minor version.
+type VersionSkewMatrix struct {
+	// Releases are the supported minor versions, like 1.22, newest first.
+	Releases []string `json:"releases"`
+
+	// Policies are the policies the matrix has been computed from.
+	Policies []SkewPolicy `json:"policies"`
+
+	// Entries are the supported versions per kube-apiserver version and
+	// component, in the order of the releases and policies.
+	Entries []VersionSkewEntry `json:"entries"`
+}
+
+// VersionSkewEntry contains the supported minor versions of a component for
+// a kube-apiserver minor version.
+type VersionSkewEntry struct {
+	APIServer string   `json:"apiServer"`
+	Component string   `json:"component"`
+	Versions  []string `json:"versions"`
+}
+
+// NewVersionSkewMatrix computes the version skew matrix of the default skew
+// policies for the supported release branches, like release-1.22.
+func NewVersionSkewMatrix(branches []string) (*VersionSkewMatrix, error) {
+	return NewVersionSkewMatrixWithPolicies(branches, DefaultSkewPolicies())
+}
+
+// NewVersionSkewMatrixWithPolicies computes the version skew matrix of the
+// policies for the supported release branches. Besides release branches,
+// minor versions like 1.22 or v1.22 are accepted. Component versions are
+// never newer than the newest supported release, because it is unreleased.
+func NewVersionSkewMatrixWithPolicies(
+	branches []string, policies []SkewPolicy,
+) (*VersionSkewMatrix, error) {
+	if len(branches) == 0 {
+		return nil, errors.New("no release branches provided")
+	}
+
+	seen := map[string]bool{}
+	releases := []semver.Version{}
+	for _, branch := range branches {
+		release, err := parseMinorVersion(branch)
+		if err != nil {
+			return nil, err
+		}
+		if seen[minorVersionString(release)] {
+			continue
+		}
+		seen[minorVersionString(release)] = true
+		releases = append(releases, release)
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].GT(releases[j])
+	})
+	newest := releases[0]
+
+	matrix := &VersionSkewMatrix{Policies: policies}
+	for _, release := range releases {
+		matrix.Releases = append(matrix.Releases, minorVersionString(release))
+		for _, policy := range policies {
+			entry := VersionSkewEntry{
+				APIServer: minorVersionString(release),
+				Component: policy.Component,
+				Versions:  []string{},
+			}
+			for _, version := range policy.versions(release) {
+				if version.Major == newest.Major && version.Minor > newest.Minor {
+					continue
+				}
+				entry.Versions = append(entry.Versions, minorVersionString(version))
+			}
+			matrix.Entries = append(matrix.Entries, entry)
+		}
+	}
+	return matrix, nil
+}
+
+// Supported returns true if the version of the component is supported with
+// the kube-apiserver version. Both versions can be minor or patch versions,
+// with or without a leading v.
+func (m *VersionSkewMatrix) Supported(component, apiServer, version string) (bool, error) {
+	apiServerVersion, err := parseMinorVersion(apiServer)
+	if err != nil {
+		return false, err
+	}
+	componentVersion, err := parseMinorVersion(version)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range m.Entries {
+		entry := &m.Entries[i]
+		if entry.Component != component ||
+			entry.APIServer != minorVersionString(apiServerVersion) {
+			continue
+		}
+		for _, supported := range entry.Versions {
+			if supported == minorVersionString(componentVersion) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, errors.Errorf(
+		"no %s skew found for kube-apiserver %s",
+		component, minorVersionString(apiServerVersion),
+	)
+}
+
+// JSON returns the indented JSON representation of the matrix.
+func (m *VersionSkewMatrix) JSON() ([]byte, error) {
+	res, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling version skew matrix")
+	}
+	return res, nil
+}
+
+// Markdown returns the matrix as markdown table with one row per supported
+// release of the kube-apiserver and one column per component.
+func (m *VersionSkewMatrix) Markdown() string {
+	b := &strings.Builder{}
+	header := []string{"Release"}
+	for _, policy := range m.Policies {
+		header = append(header, policy.Component)
+	}
+	fmt.Fprintf(b, "| %s |\n", strings.Join(header, " | "))
+	fmt.Fprintf(b, "|%s\n", strings.Repeat(" --- |", len(header)))
+
+	for _, release := range m.Releases {
+		row := []string{release}
+		for _, policy := range m.Policies {
+			versions := []string{}
+			for i := range m.Entries {
+				if m.Entries[i].APIServer == release &&
+					m.Entries[i].Component == policy.Component {
+					versions = m.Entries[i].Versions
+					break
+				}
+			}
+			row = append(row, strings.Join(versions, ", "))
+		}
+		fmt.Fprintf(b, "| %s |\n", strings.Join(row, " | "))
+	}
+	return b.String()
+}
+
+// versions returns the minor versions of the component supported with the
+// kube-apiserver release, newest first.
+func (p *SkewPolicy) versions(release semver.Version) []semver.Version {
+	res := []semver.Version{}
+	for minor := release.Minor + p.NewerMinors; ; minor-- {
+		res = append(res, semver.Version{Major: release.Major, Minor: minor})
+		if minor == 0 || minor+p.OlderMinors == release.Minor {
+			break
+		}
+	}
+	return res
+}
+
+// parseMinorVersion returns the minor version of a release branch, a minor
+// version or a patch version.
+func parseMinorVersion(input string) (semver.Version, error) {
+	version := strings.TrimSpace(input)
+	version = strings.TrimPrefix(version, "release-")
+	version = strings.TrimPrefix(version, "v")
+	if strings.Count(version, ".") == 1 {
+		version += ".0"
+	}
+	parsed, err := semver.Parse(version)
+	if err != nil {
+		return semver.Version{}, errors.Wrapf(err, "parsing version of %s", input)
+	}
+	return semver.Version{Major: parsed.Major, Minor: parsed.Minor}, nil
+}
+
+// minorVersionString returns the minor version string, like 1.22.
+func minorVersionString(version semver.Version) string {
+	return fmt.Sprintf("%d.%d", version.Major, version.Minor)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+)
+
+func TestNewVersionSkewMatrix(t *testing.T) {
+	// Given
+	branches := []string{"release-1.20", "release-1.22", "v1.21", "1.22"}
+
+	// When
+	res, err := release.NewVersionSkewMatrix(branches)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, []string{"1.22", "1.21", "1.20"}, res.Releases)
+	require.Equal(t, release.DefaultSkewPolicies(), res.Policies)
+	require.Len(t, res.Entries, 9)
+	require.Equal(t, []release.VersionSkewEntry{
+		{APIServer: "1.22", Component: release.SkewComponentAPIServer, Versions: []string{"1.22", "1.21"}},
+		{APIServer: "1.22", Component: release.SkewComponentKubelet, Versions: []string{"1.22", "1.21", "1.20"}},
+		{APIServer: "1.22", Component: release.SkewComponentKubectl, Versions: []string{"1.22", "1.21"}},
+	}, res.Entries[:3])
+	require.Equal(t, release.VersionSkewEntry{
+		APIServer: "1.20",
+		Component: release.SkewComponentKubectl,
+		Versions:  []string{"1.21", "1.20", "1.19"},
+	}, res.Entries[8])
+}
+
+func TestNewVersionSkewMatrixFailure(t *testing.T) {
+	for _, branches := range [][]string{
+		nil,
+		{"release-1.22", "main"},
+		{"release-1.x"},
+	} {
+		_, err := release.NewVersionSkewMatrix(branches)
+		require.NotNil(t, err, branches)
+	}
+}
+
+func TestNewVersionSkewMatrixWithPolicies(t *testing.T) {
+	// Given
+	policies := []release.SkewPolicy{{Component: "kube-proxy", OlderMinors: 5}}
+
+	// When
+	res, err := release.NewVersionSkewMatrixWithPolicies([]string{"1.2"}, policies)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, []release.VersionSkewEntry{
+		{APIServer: "1.2", Component: "kube-proxy", Versions: []string{"1.2", "1.1", "1.0"}},
+	}, res.Entries)
+}
+
+func TestVersionSkewMatrixSupported(t *testing.T) {
+	sut, err := release.NewVersionSkewMatrix(
+		[]string{"release-1.22", "release-1.21", "release-1.20"},
+	)
+	require.Nil(t, err)
+
+	for _, tc := range []struct {
+		component, apiServer, version string
+		expected                      bool
+		shouldErr                     bool
+	}{
+		{component: release.SkewComponentKubelet, apiServer: "v1.22.3", version: "v1.20.12", expected: true},
+		{component: release.SkewComponentKubelet, apiServer: "1.21", version: "1.22"},
+		{component: release.SkewComponentKubelet, apiServer: "1.22", version: "1.19"},
+		{component: release.SkewComponentKubectl, apiServer: "1.21", version: "1.22.0", expected: true},
+		{component: release.SkewComponentKubectl, apiServer: "1.22", version: "1.23"},
+		{component: release.SkewComponentAPIServer, apiServer: "1.21", version: "v1.20.1", expected: true},
+		{component: release.SkewComponentKubelet, apiServer: "1.19", version: "1.19", shouldErr: true},
+		{component: "kube-proxy", apiServer: "1.22", version: "1.22", shouldErr: true},
+		{component: release.SkewComponentKubelet, apiServer: "1.22", version: "wrong", shouldErr: true},
+	} {
+		res, err := sut.Supported(tc.component, tc.apiServer, tc.version)
+		if tc.shouldErr {
+			require.NotNil(t, err, tc)
+			continue
+		}
+		require.Nil(t, err, tc)
+		require.Equal(t, tc.expected, res, tc)
+	}
+}
+
+func TestVersionSkewMatrixOutput(t *testing.T) {
+	// Given
+	sut, err := release.NewVersionSkewMatrix([]string{"release-1.22", "release-1.21"})
+	require.Nil(t, err)
+
+	// When
+	markdown := sut.Markdown()
+	content, err := sut.JSON()
+
+	// Then
+	require.Equal(t, `| Release | kube-apiserver | kubelet | kubectl |
+| --- | --- | --- | --- |
+| 1.22 | 1.22, 1.21 | 1.22, 1.21, 1.20 | 1.22, 1.21 |
+| 1.21 | 1.21, 1.20 | 1.21, 1.20, 1.19 | 1.22, 1.21, 1.20 |
+`, markdown)
+
+	require.Nil(t, err)
+	decoded := &release.VersionSkewMatrix{}
+	require.Nil(t, json.Unmarshal(content, decoded))
+	require.Equal(t, sut, decoded)
+}
@@ -24,12 +24,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"k8s.io/release/pkg/release"
-	"k8s.io/release/pkg/release/releasefakes"
+	"k8s.io/release/pkg/release/version/versionfakes"
 	"sigs.k8s.io/release-utils/util"
 )
 
-func newVersionSUT() (*release.Version, *releasefakes.FakeVersionClient) {
-	client := &releasefakes.FakeVersionClient{}
+func newVersionSUT() (*release.Version, *versionfakes.FakeClient) {
+	client := &versionfakes.FakeClient{}
 	sut := release.NewVersion()
 	sut.SetClient(client)
 
@@ -38,27 +38,27 @@ func newVersionSUT() (*release.Version, *releasefakes.FakeVersionClient) {
 
 func TestGetKubeVersionSuccess(t *testing.T) {
 	testcases := []struct {
-		behavior    func(*releasefakes.FakeVersionClient)
+		behavior    func(*versionfakes.FakeClient)
 		versionType release.VersionType
 		assertion   func(semver.Version)
 	}{
 		{
-			behavior: func(mock *releasefakes.FakeVersionClient) {
-				mock.GetURLResponseReturns("v1.17.3", nil)
+			behavior: func(mock *versionfakes.FakeClient) {
+				mock.GetMarkerReturns("v1.17.3", nil)
 			},
 			versionType: release.VersionTypeStable,
 			assertion:   func(s semver.Version) { require.Empty(t, s.Pre) },
 		},
 		{
-			behavior: func(mock *releasefakes.FakeVersionClient) {
-				mock.GetURLResponseReturns("v1.19.0-alpha.0.721+f8ff8f44206ff4", nil)
+			behavior: func(mock *versionfakes.FakeClient) {
+				mock.GetMarkerReturns("v1.19.0-alpha.0.721+f8ff8f44206ff4", nil)
 			},
 			versionType: release.VersionTypeCILatest,
 			assertion:   func(s semver.Version) { require.Len(t, s.Pre, 3) },
 		},
 		{
-			behavior: func(mock *releasefakes.FakeVersionClient) {
-				mock.GetURLResponseReturns("v1.19.0-alpha.0", nil)
+			behavior: func(mock *versionfakes.FakeClient) {
+				mock.GetMarkerReturns("v1.19.0-alpha.0", nil)
 			},
 			versionType: release.VersionTypeStablePreRelease,
 			assertion:   func(s semver.Version) { require.Len(t, s.Pre, 2) },
@@ -105,7 +105,7 @@ func TestGetKubeVersionForBranchSuccess(t *testing.T) {
 
 	for _, tc := range testcases {
 		sut, client := newVersionSUT()
-		client.GetURLResponseReturns(tc.expected, nil)
+		client.GetMarkerReturns(tc.expected, nil)
 
 		actual, err := sut.GetKubeVersionForBranch(tc.versionType, tc.branch)
 
@@ -116,13 +116,13 @@ func TestGetKubeVersionForBranchSuccess(t *testing.T) {
 
 func TestGetKubeVersionForBranchFailure(t *testing.T) {
 	testcases := []struct {
-		behavior    func(*releasefakes.FakeVersionClient)
+		behavior    func(*versionfakes.FakeClient)
 		versionType release.VersionType
 		branch      string
 	}{
 		{
-			behavior: func(mock *releasefakes.FakeVersionClient) {
-				mock.GetURLResponseReturns("", errors.New(""))
+			behavior: func(mock *versionfakes.FakeClient) {
+				mock.GetMarkerReturns("", errors.New(""))
 			},
 			versionType: release.VersionTypeStable,
 			branch:      "wrong-branch",
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package retry runs operations which may fail temporarily, like network
+// requests, until they succeed.
+package retry
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// initialWait is the time to wait after the first failed attempt.
+const initialWait = time.Second
+
+// Backoff retries failed operations and doubles the wait time between two
+// attempts every time, up to a maximum.
+type Backoff struct {
+	// Retries is the number of times a failed operation gets retried.
+	Retries int
+
+	// MaxWait is the maximum time to wait between two attempts.
+	MaxWait time.Duration
+
+	// Sleep is used to wait between two attempts.
+	Sleep func(time.Duration)
+}
+
+// New creates a new Backoff which retries a failed operation the provided
+// amount of times.
+func New(retries int, maxWait time.Duration) *Backoff {
+	return &Backoff{
+		Retries: retries,
+		MaxWait: maxWait,
+		Sleep:   time.Sleep,
+	}
+}
+
+// permanentError is an error which cannot be fixed by retrying.
+type permanentError struct {
+	err error
+}
+
+func (p *permanentError) Error() string {
+	return p.err.Error()
+}
+
+// Permanent marks the error as not retryable, for example a missing file.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// Run runs fn until it succeeds, returns a permanent error or the retries
+// are exhausted. The action describes fn in log and error messages, like
+// "downloading https://dl.k8s.io/release/stable.txt".
+func (b *Backoff) Run(action string, fn func() error) error {
+	wait := initialWait
+	for try := 0; ; try++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if try >= b.Retries {
+			return errors.Wrapf(err, "%s failed after %d attempts", action, try+1)
+		}
+
+		if wait > b.MaxWait {
+			wait = b.MaxWait
+		}
+		logrus.Warnf(
+			"Retrying %s in %s (%d retries left): %v",
+			action, wait, b.Retries-try, err,
+		)
+		b.Sleep(wait)
+		wait *= 2
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/retry"
+)
+
+func newTestBackoff() (*retry.Backoff, *[]time.Duration) {
+	waits := []time.Duration{}
+	sut := retry.New(3, 3*time.Second)
+	sut.Sleep = func(wait time.Duration) { waits = append(waits, wait) }
+	return sut, &waits
+}
+
+func TestRunSuccessAfterRetries(t *testing.T) {
+	// Given
+	sut, waits := newTestBackoff()
+	tries := 0
+
+	// When
+	err := sut.Run("action", func() error {
+		tries++
+		if tries < 3 {
+			return errors.New("error")
+		}
+		return nil
+	})
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, 3, tries)
+	require.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *waits)
+}
+
+func TestRunRetriesExhausted(t *testing.T) {
+	// Given
+	sut, waits := newTestBackoff()
+	tries := 0
+
+	// When
+	err := sut.Run("action", func() error {
+		tries++
+		return errors.New("error")
+	})
+
+	// Then
+	require.NotNil(t, err)
+	require.Equal(t, "action failed after 4 attempts: error", err.Error())
+	require.Equal(t, 4, tries)
+	require.Equal(t,
+		[]time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, *waits,
+	)
+}
+
+func TestRunPermanentError(t *testing.T) {
+	// Given
+	sut, waits := newTestBackoff()
+	tries := 0
+	expected := errors.New("error")
+
+	// When
+	err := sut.Run("action", func() error {
+		tries++
+		return errors.Wrap(retry.Permanent(expected), "wrapped")
+	})
+
+	// Then
+	require.Equal(t, expected, err)
+	require.Equal(t, 1, tries)
+	require.Empty(t, *waits)
+}
+
+func TestPermanentNil(t *testing.T) {
+	require.Nil(t, retry.Permanent(nil))
+}
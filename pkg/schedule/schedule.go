@@ -0,0 +1,312 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DateFormat is the format of the dates within the patch release schedule.
+const DateFormat = "2006-01-02"
+
+// ErrNoUpcomingPatch is returned if the schedule of a release does not contain
+// any patch release in the future.
+var ErrNoUpcomingPatch = errors.New("no upcoming patch release scheduled")
+
+var (
+	releaseRegex = regexp.MustCompile(`^(\d+)\.(\d+)$`)
+	patchRegex   = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)$`)
+)
+
+// PatchSchedule main struct to hold the schedules
+type PatchSchedule struct {
+	Schedules []Schedule `yaml:"schedules"`
+}
+
+// PreviousPatches struct to define the old patch schedules
+type PreviousPatches struct {
+	Release            string `yaml:"release"`
+	CherryPickDeadline string `yaml:"cherryPickDeadline"`
+	TargetDate         string `yaml:"targetDate"`
+	Note               string `yaml:"note"`
+}
+
+// Schedule struct to define the release schedule for a specific version
+type Schedule struct {
+	Release            string            `yaml:"release"`
+	Next               string            `yaml:"next"`
+	CherryPickDeadline string            `yaml:"cherryPickDeadline"`
+	TargetDate         string            `yaml:"targetDate"`
+	EndOfLifeDate      string            `yaml:"endOfLifeDate"`
+	PreviousPatches    []PreviousPatches `yaml:"previousPatches"`
+}
+
+// PatchRelease is a single scheduled patch release with parsed dates.
+type PatchRelease struct {
+	// Release is the patch version, like 1.22.3.
+	Release string
+
+	// CherryPickDeadline is the last day for merging cherry picks.
+	CherryPickDeadline time.Time
+
+	// TargetDate is the planned day of the release.
+	TargetDate time.Time
+}
+
+// Parse parses the content of a patch release schedule file, which fails on
+// unknown fields.
+func Parse(data []byte) (*PatchSchedule, error) {
+	patchSchedule := &PatchSchedule{}
+	if err := yaml.UnmarshalStrict(data, patchSchedule); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the schedule")
+	}
+	return patchSchedule, nil
+}
+
+// ParseFile reads and parses the patch release schedule file at the path.
+func ParseFile(path string) (*PatchSchedule, error) {
+	logrus.Infof("Reading the schedule file %s", path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the file")
+	}
+	return Parse(data)
+}
+
+// ParseDate parses a date of the schedule.
+func ParseDate(date string) (time.Time, error) {
+	res, err := time.Parse(DateFormat, strings.TrimSpace(date))
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid date %q", date)
+	}
+	return res, nil
+}
+
+// ForRelease returns the schedule of the minor release, which can be
+// specified like 1.22, v1.22 or by any of its patch versions like v1.22.3.
+func (p *PatchSchedule) ForRelease(release string) (*Schedule, error) {
+	minor := strings.TrimPrefix(strings.TrimSpace(release), "v")
+	if match := patchRegex.FindStringSubmatch(minor); match != nil {
+		minor = match[1] + "." + match[2]
+	}
+	for i := range p.Schedules {
+		if strings.TrimSpace(p.Schedules[i].Release) == minor {
+			return &p.Schedules[i], nil
+		}
+	}
+	return nil, errors.Errorf("no schedule found for release %s", release)
+}
+
+// NextPatchRelease returns the first patch release of the minor release whose
+// target date is not before the day of now, which answers both the next
+// cherry pick deadline and the next target date.
+func (p *PatchSchedule) NextPatchRelease(release string, now time.Time) (*PatchRelease, error) {
+	schedule, err := p.ForRelease(release)
+	if err != nil {
+		return nil, err
+	}
+	patches, err := schedule.PatchReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	today := truncateToDay(now)
+	var next *PatchRelease
+	for i := range patches {
+		if patches[i].TargetDate.Before(today) {
+			continue
+		}
+		if next == nil || patches[i].TargetDate.Before(next.TargetDate) {
+			next = &patches[i]
+		}
+	}
+	if next == nil {
+		return nil, errors.Wrapf(ErrNoUpcomingPatch, "release %s", schedule.Release)
+	}
+	return next, nil
+}
+
+// ValidateCutDate verifies that the patch version can be cut at the date: it
+// has to be scheduled, the date must not be before its cherry pick deadline
+// and not after the end of life of the minor release. Deviations from the
+// target date are only logged.
+func (p *PatchSchedule) ValidateCutDate(version string, date time.Time) error {
+	schedule, err := p.ForRelease(version)
+	if err != nil {
+		return err
+	}
+	patches, err := schedule.PatchReleases()
+	if err != nil {
+		return err
+	}
+
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	day := truncateToDay(date)
+	for _, patch := range patches {
+		if patch.Release != version {
+			continue
+		}
+		if day.Before(patch.CherryPickDeadline) {
+			return errors.Errorf(
+				"cut date %s of %s is before its cherry pick deadline %s",
+				day.Format(DateFormat), version,
+				patch.CherryPickDeadline.Format(DateFormat),
+			)
+		}
+		if eol, err := ParseDate(schedule.EndOfLifeDate); err == nil && day.After(eol) {
+			return errors.Errorf(
+				"cut date %s of %s is after the end of life %s of %s",
+				day.Format(DateFormat), version, eol.Format(DateFormat),
+				schedule.Release,
+			)
+		}
+		if !day.Equal(patch.TargetDate) {
+			logrus.Warnf(
+				"Cut date %s of %s differs from its target date %s",
+				day.Format(DateFormat), version,
+				patch.TargetDate.Format(DateFormat),
+			)
+		}
+		return nil
+	}
+	return errors.Errorf("patch release %s is not scheduled", version)
+}
+
+// Validate verifies the consistency of all schedules and returns an error
+// listing all problems.
+func (p *PatchSchedule) Validate() error {
+	problems := []string{}
+	seen := map[string]bool{}
+	for i := range p.Schedules {
+		schedule := &p.Schedules[i]
+		if seen[schedule.Release] {
+			problems = append(problems, fmt.Sprintf(
+				"release %s is scheduled multiple times", schedule.Release,
+			))
+		}
+		seen[schedule.Release] = true
+		for _, problem := range schedule.problems() {
+			problems = append(problems, fmt.Sprintf(
+				"release %s: %s", schedule.Release, problem,
+			))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf(
+			"invalid schedule:\n- %s", strings.Join(problems, "\n- "),
+		)
+	}
+	return nil
+}
+
+// PatchReleases returns the next and the previous patch releases of the
+// schedule with parsed dates. The next patch release is not added twice if
+// it is also part of the previous patches.
+func (s *Schedule) PatchReleases() ([]PatchRelease, error) {
+	res := []PatchRelease{}
+	add := func(release, cherryPickDeadline, targetDate string) error {
+		release = strings.TrimSpace(release)
+		for _, patch := range res {
+			if patch.Release == release {
+				return nil
+			}
+		}
+		deadline, err := ParseDate(cherryPickDeadline)
+		if err != nil {
+			return errors.Wrapf(err, "parsing cherry pick deadline of %s", release)
+		}
+		target, err := ParseDate(targetDate)
+		if err != nil {
+			return errors.Wrapf(err, "parsing target date of %s", release)
+		}
+		res = append(res, PatchRelease{
+			Release:            release,
+			CherryPickDeadline: deadline,
+			TargetDate:         target,
+		})
+		return nil
+	}
+
+	if err := add(s.Next, s.CherryPickDeadline, s.TargetDate); err != nil {
+		return nil, err
+	}
+	for _, previous := range s.PreviousPatches {
+		if err := add(
+			previous.Release, previous.CherryPickDeadline, previous.TargetDate,
+		); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// problems returns all inconsistencies of the schedule.
+func (s *Schedule) problems() []string {
+	if !releaseRegex.MatchString(strings.TrimSpace(s.Release)) {
+		return []string{"release has to be in the format X.Y"}
+	}
+
+	problems := []string{}
+	check := func(release, cherryPickDeadline, targetDate string) {
+		release = strings.TrimSpace(release)
+		if !patchRegex.MatchString(release) ||
+			!strings.HasPrefix(release, strings.TrimSpace(s.Release)+".") {
+			problems = append(problems, fmt.Sprintf(
+				"patch release %q is not a patch of %s", release, s.Release,
+			))
+		}
+		deadline, err := ParseDate(cherryPickDeadline)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf(
+				"cherry pick deadline of %s: %v", release, err,
+			))
+		}
+		target, err := ParseDate(targetDate)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf(
+				"target date of %s: %v", release, err,
+			))
+		}
+		if !deadline.IsZero() && !target.IsZero() && target.Before(deadline) {
+			problems = append(problems, fmt.Sprintf(
+				"target date of %s is before its cherry pick deadline", release,
+			))
+		}
+	}
+
+	check(s.Next, s.CherryPickDeadline, s.TargetDate)
+	for _, previous := range s.PreviousPatches {
+		check(previous.Release, previous.CherryPickDeadline, previous.TargetDate)
+	}
+	return problems
+}
+
+// truncateToDay returns the calendar day of the time as UTC date, which is
+// comparable with the dates of the schedule.
+func truncateToDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/schedule"
+)
+
+const testSchedule = `schedules:
+- release: 1.22
+  next: 1.22.3
+  cherryPickDeadline: 2021-10-22
+  targetDate: 2021-10-27
+  endOfLifeDate: 2022-10-28
+  previousPatches:
+    - release: 1.22.2
+      cherryPickDeadline: 2021-09-10
+      targetDate: 2021-09-15
+    - release: 1.22.1
+      cherryPickDeadline: 2021-08-13
+      targetDate: 2021-08-19
+      note: Out of band
+- release: "1.20"
+  next: 1.20.12
+  cherryPickDeadline: 2021-10-22
+  targetDate: 2021-10-27
+  endOfLifeDate: 2021-12-28
+  previousPatches:
+    - release: 1.20.12
+      cherryPickDeadline: 2021-10-22
+      targetDate: 2021-10-27
+`
+
+func newSUT(t *testing.T) *schedule.PatchSchedule {
+	sut, err := schedule.Parse([]byte(testSchedule))
+	require.Nil(t, err)
+	return sut
+}
+
+func date(t *testing.T, value string) time.Time {
+	res, err := schedule.ParseDate(value)
+	require.Nil(t, err)
+	return res
+}
+
+func TestParse(t *testing.T) {
+	// Given
+	sut := newSUT(t)
+
+	// Then
+	require.Len(t, sut.Schedules, 2)
+	require.Equal(t, "1.22", sut.Schedules[0].Release)
+	require.Equal(t, "1.20", sut.Schedules[1].Release)
+	require.Equal(t, "Out of band", sut.Schedules[0].PreviousPatches[1].Note)
+	require.Nil(t, sut.Validate())
+
+	// When the schedule contains unknown fields
+	_, err := schedule.Parse([]byte("schedule:\n- bad: schedule\n"))
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestParseFile(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "schedule.yaml")
+	require.Nil(t, os.WriteFile(path, []byte(testSchedule), 0o644))
+
+	// When
+	res, err := schedule.ParseFile(path)
+
+	// Then
+	require.Nil(t, err)
+	require.Len(t, res.Schedules, 2)
+
+	// When the file does not exist
+	_, err = schedule.ParseFile(path + ".missing")
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestForRelease(t *testing.T) {
+	sut := newSUT(t)
+	for _, release := range []string{"1.22", "v1.22", "1.22.3", "v1.22.0"} {
+		res, err := sut.ForRelease(release)
+		require.Nil(t, err, release)
+		require.Equal(t, "1.22", res.Release, release)
+	}
+	for _, release := range []string{"1.21", "1", "v1.2"} {
+		_, err := sut.ForRelease(release)
+		require.NotNil(t, err, release)
+	}
+}
+
+func TestNextPatchRelease(t *testing.T) {
+	sut := newSUT(t)
+	for _, tc := range []struct {
+		release  string
+		now      time.Time
+		expected string
+		notFound bool
+	}{
+		{
+			release:  "1.22",
+			now:      time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC),
+			expected: "1.22.3",
+		},
+		{
+			release:  "v1.22",
+			now:      time.Date(2021, 9, 15, 23, 0, 0, 0, time.UTC),
+			expected: "1.22.2",
+		},
+		{
+			release:  "1.22",
+			now:      time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC),
+			expected: "1.22.1",
+		},
+		{
+			release:  "1.20",
+			now:      time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC),
+			expected: "1.20.12",
+		},
+		{
+			release:  "1.22",
+			now:      time.Date(2021, 10, 28, 0, 0, 0, 0, time.UTC),
+			notFound: true,
+		},
+	} {
+		res, err := sut.NextPatchRelease(tc.release, tc.now)
+		if tc.notFound {
+			require.True(t, errors.Is(err, schedule.ErrNoUpcomingPatch))
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, res.Release)
+	}
+
+	// When the release is not scheduled
+	_, err := sut.NextPatchRelease("1.21", time.Now())
+
+	// Then
+	require.NotNil(t, err)
+}
+
+func TestPatchReleases(t *testing.T) {
+	// Given
+	sut := newSUT(t)
+
+	// When
+	res, err := sut.Schedules[1].PatchReleases()
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, []schedule.PatchRelease{{
+		Release:            "1.20.12",
+		CherryPickDeadline: date(t, "2021-10-22"),
+		TargetDate:         date(t, "2021-10-27"),
+	}}, res)
+}
+
+func TestValidateCutDate(t *testing.T) {
+	sut := newSUT(t)
+	for _, tc := range []struct {
+		version   string
+		date      string
+		shouldErr bool
+	}{
+		{version: "1.22.3", date: "2021-10-27"},
+		{version: "v1.22.3", date: "2021-10-25"},
+		{version: "1.22.3", date: "2021-10-22"},
+		{version: "1.22.3", date: "2021-10-21", shouldErr: true},
+		{version: "1.22.4", date: "2021-11-17", shouldErr: true},
+		{version: "1.21.6", date: "2021-10-27", shouldErr: true},
+		{version: "1.20.12", date: "2021-12-29", shouldErr: true},
+	} {
+		err := sut.ValidateCutDate(tc.version, date(t, tc.date))
+		if tc.shouldErr {
+			require.NotNil(t, err, tc)
+		} else {
+			require.Nil(t, err, tc)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schedule schedule.Schedule
+	}{
+		{
+			name:     "invalid release",
+			schedule: schedule.Schedule{Release: "1.22.0"},
+		},
+		{
+			name: "next is not a patch of the release",
+			schedule: schedule.Schedule{
+				Release:            "1.22",
+				Next:               "1.21.3",
+				CherryPickDeadline: "2021-10-22",
+				TargetDate:         "2021-10-27",
+			},
+		},
+		{
+			name: "invalid date",
+			schedule: schedule.Schedule{
+				Release:            "1.22",
+				Next:               "1.22.3",
+				CherryPickDeadline: "TBD",
+				TargetDate:         "2021-10-27",
+			},
+		},
+		{
+			name: "target date before cherry pick deadline",
+			schedule: schedule.Schedule{
+				Release:            "1.22",
+				Next:               "1.22.3",
+				CherryPickDeadline: "2021-10-22",
+				TargetDate:         "2021-10-21",
+			},
+		},
+		{
+			name: "invalid previous patch",
+			schedule: schedule.Schedule{
+				Release:            "1.22",
+				Next:               "1.22.3",
+				CherryPickDeadline: "2021-10-22",
+				TargetDate:         "2021-10-27",
+				PreviousPatches: []schedule.PreviousPatches{
+					{Release: "1.22.2", TargetDate: "2021-09-15"},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sut := &schedule.PatchSchedule{
+				Schedules: []schedule.Schedule{tc.schedule},
+			}
+			require.NotNil(t, sut.Validate())
+		})
+	}
+
+	// When a release is scheduled multiple times
+	sut := newSUT(t)
+	sut.Schedules = append(sut.Schedules, sut.Schedules[0])
+
+	// Then
+	require.NotNil(t, sut.Validate())
+}
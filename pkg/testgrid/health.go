@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const testgridSummaryURL = "https://testgrid.k8s.io/%s/summary"
+
+// Overall statuses of a dashboard tab as reported by the testgrid summary.
+const (
+	StatusPassing    = "PASSING"
+	StatusAcceptable = "ACCEPTABLE"
+	StatusFlaky      = "FLAKY"
+	StatusFailing    = "FAILING"
+	StatusBroken     = "BROKEN"
+	StatusStale      = "STALE"
+	StatusPending    = "PENDING"
+)
+
+// TabSummary is the summary of a single dashboard tab, which corresponds to
+// a Prow job.
+type TabSummary struct {
+	OverallStatus    string `json:"overall_status"`
+	Status           string `json:"status"`
+	Alert            string `json:"alert"`
+	LatestGreen      string `json:"latest_green"`
+	LastRunTimestamp int64  `json:"last_run_timestamp"`
+}
+
+// HealthOptions configure which CI signal blocks a release.
+type HealthOptions struct {
+	// AllowFlaky does not block the release on flaky jobs.
+	AllowFlaky bool
+
+	// IgnoredTabs are the names of the tabs which never block the release,
+	// for example because of a known issue accepted by the release team.
+	IgnoredTabs []string
+}
+
+// HealthReport is the go/no-go verdict for releasing a branch, based on the
+// jobs of its release blocking dashboard.
+type HealthReport struct {
+	Branch    string      `json:"branch"`
+	Dashboard string      `json:"dashboard"`
+	Tabs      []TabHealth `json:"tabs"`
+}
+
+// TabHealth is the verdict for a single dashboard tab.
+type TabHealth struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Message  string `json:"message,omitempty"`
+	Blocking bool   `json:"blocking"`
+	Ignored  bool   `json:"ignored,omitempty"`
+}
+
+// BlockingDashboard returns the name of the release blocking dashboard of the
+// branch, like sig-release-1.22-blocking.
+func BlockingDashboard(branch string) string {
+	return fmt.Sprintf("sig-release-%s-blocking", strings.TrimPrefix(branch, "release-"))
+}
+
+// DashboardSummary returns the summaries of all tabs of the dashboard, indexed
+// by their name.
+func (t *TestGrid) DashboardSummary(dashboard string) (map[string]*TabSummary, error) {
+	logrus.Infof("Retrieving testgrid summary of dashboard %s", dashboard)
+	response, err := t.client.GetURLResponse(
+		fmt.Sprintf(testgridSummaryURL, dashboard), false,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving summary of dashboard %s", dashboard)
+	}
+
+	summary := map[string]*TabSummary{}
+	if err := json.Unmarshal([]byte(response), &summary); err != nil {
+		return nil, errors.Wrapf(err, "decoding summary of dashboard %s", dashboard)
+	}
+	return summary, nil
+}
+
+// ReleaseHealth returns the health report of the release blocking dashboard
+// of the branch. Failing, broken, stale and pending jobs block the release,
+// as well as flaky jobs if not allowed by the options. An error is only
+// returned if the report cannot be created.
+func (t *TestGrid) ReleaseHealth(branch string, opts *HealthOptions) (*HealthReport, error) {
+	if opts == nil {
+		opts = &HealthOptions{}
+	}
+	dashboard := BlockingDashboard(branch)
+	summary, err := t.DashboardSummary(dashboard)
+	if err != nil {
+		return nil, err
+	}
+	if len(summary) == 0 {
+		return nil, errors.Errorf("dashboard %s does not contain any tabs", dashboard)
+	}
+
+	ignored := map[string]bool{}
+	for _, tab := range opts.IgnoredTabs {
+		ignored[tab] = true
+	}
+
+	report := &HealthReport{Branch: branch, Dashboard: dashboard}
+	for name, tab := range summary {
+		health := TabHealth{
+			Name:    name,
+			Status:  tab.OverallStatus,
+			Message: tab.Alert,
+			Ignored: ignored[name],
+		}
+		if health.Message == "" {
+			health.Message = tab.Status
+		}
+		switch tab.OverallStatus {
+		case StatusPassing, StatusAcceptable:
+		case StatusFlaky:
+			health.Blocking = !opts.AllowFlaky
+		default:
+			health.Blocking = true
+		}
+		if health.Ignored {
+			health.Blocking = false
+		}
+		report.Tabs = append(report.Tabs, health)
+	}
+	sort.Slice(report.Tabs, func(i, j int) bool {
+		return report.Tabs[i].Name < report.Tabs[j].Name
+	})
+	return report, nil
+}
+
+// Go returns true if no tab blocks the release.
+func (r *HealthReport) Go() bool {
+	for _, tab := range r.Tabs {
+		if tab.Blocking {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns nil for a go verdict, otherwise an error naming every tab
+// which blocks the release together with its status.
+func (r *HealthReport) Err() error {
+	blocking := []string{}
+	for _, tab := range r.Tabs {
+		if tab.Blocking {
+			blocking = append(blocking, fmt.Sprintf("%s (%s)", tab.Name, tab.Status))
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+	return errors.Errorf(
+		"%d jobs of dashboard %s block the release of %s: %s",
+		len(blocking), r.Dashboard, r.Branch, strings.Join(blocking, ", "),
+	)
+}
+
+// String returns a human readable summary of the report.
+func (r *HealthReport) String() string {
+	var sb strings.Builder
+	verdict := "GO"
+	if !r.Go() {
+		verdict = "NO-GO"
+	}
+	fmt.Fprintf(&sb, "Release health of %s (%s): %s\n", r.Branch, r.Dashboard, verdict)
+	for _, tab := range r.Tabs {
+		result := "ok"
+		if tab.Blocking {
+			result = "blocking"
+		} else if tab.Ignored {
+			result = "ignored"
+		}
+		fmt.Fprintf(&sb, "  [%s] %s: %s", result, tab.Name, tab.Status)
+		if tab.Message != "" {
+			fmt.Fprintf(&sb, " - %s", tab.Message)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testgrid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/git"
+	"k8s.io/release/pkg/testgrid"
+)
+
+const testSummary = `{
+	"ci-kubernetes-e2e-gce": {"overall_status": "PASSING", "status": "9 of 9 (100.0%) recent columns passed"},
+	"ci-kubernetes-unit": {"overall_status": "FLAKY", "status": "8 of 9 (88.9%) recent columns passed"},
+	"ci-kubernetes-build": {"overall_status": "FAILING", "alert": "1 test failed"}
+}`
+
+func TestBlockingDashboard(t *testing.T) {
+	require.Equal(t, "sig-release-master-blocking", testgrid.BlockingDashboard(git.DefaultBranch))
+	require.Equal(t, "sig-release-1.22-blocking", testgrid.BlockingDashboard("release-1.22"))
+}
+
+func TestDashboardSummarySuccess(t *testing.T) {
+	// Given
+	sut, client := newSut()
+	client.GetURLResponseReturns(testSummary, nil)
+
+	// When
+	res, err := sut.DashboardSummary("sig-release-1.22-blocking")
+
+	// Then
+	require.Nil(t, err)
+	require.Len(t, res, 3)
+	require.Equal(t, testgrid.StatusFailing, res["ci-kubernetes-build"].OverallStatus)
+	require.Equal(t, "1 test failed", res["ci-kubernetes-build"].Alert)
+	url, _ := client.GetURLResponseArgsForCall(0)
+	require.Equal(t, "https://testgrid.k8s.io/sig-release-1.22-blocking/summary", url)
+}
+
+func TestDashboardSummaryFailure(t *testing.T) {
+	for _, tc := range []struct {
+		response string
+		err      error
+	}{
+		{response: "", err: errors.New("")},
+		{response: "invalid", err: nil},
+	} {
+		// Given
+		sut, client := newSut()
+		client.GetURLResponseReturns(tc.response, tc.err)
+
+		// When
+		res, err := sut.DashboardSummary("")
+
+		// Then
+		require.NotNil(t, err)
+		require.Nil(t, res)
+	}
+}
+
+func TestReleaseHealthSuccess(t *testing.T) {
+	for _, tc := range []struct {
+		opts     *testgrid.HealthOptions
+		blocking []string
+	}{
+		{
+			opts:     nil,
+			blocking: []string{"ci-kubernetes-build", "ci-kubernetes-unit"},
+		},
+		{
+			opts:     &testgrid.HealthOptions{AllowFlaky: true},
+			blocking: []string{"ci-kubernetes-build"},
+		},
+		{
+			opts: &testgrid.HealthOptions{
+				AllowFlaky:  true,
+				IgnoredTabs: []string{"ci-kubernetes-build"},
+			},
+			blocking: []string{},
+		},
+	} {
+		// Given
+		sut, client := newSut()
+		client.GetURLResponseReturns(testSummary, nil)
+
+		// When
+		res, err := sut.ReleaseHealth("release-1.22", tc.opts)
+
+		// Then
+		require.Nil(t, err)
+		require.Equal(t, "sig-release-1.22-blocking", res.Dashboard)
+		require.Len(t, res.Tabs, 3)
+		require.Equal(t, "ci-kubernetes-build", res.Tabs[0].Name)
+
+		blocking := []string{}
+		for _, tab := range res.Tabs {
+			if tab.Blocking {
+				blocking = append(blocking, tab.Name)
+			}
+		}
+		require.Equal(t, tc.blocking, blocking)
+		require.Equal(t, len(tc.blocking) == 0, res.Go())
+		if res.Go() {
+			require.Nil(t, res.Err())
+			require.Contains(t, res.String(), ": GO")
+		} else {
+			require.NotNil(t, res.Err())
+			require.Contains(t, res.String(), ": NO-GO")
+		}
+	}
+}
+
+func TestReleaseHealthFailure(t *testing.T) {
+	for _, tc := range []struct {
+		response string
+		err      error
+	}{
+		{response: "", err: errors.New("")},
+		{response: "{}", err: nil},
+	} {
+		// Given
+		sut, client := newSut()
+		client.GetURLResponseReturns(tc.response, tc.err)
+
+		// When
+		res, err := sut.ReleaseHealth(git.DefaultBranch, nil)
+
+		// Then
+		require.NotNil(t, err)
+		require.Nil(t, res)
+	}
+}